@@ -212,6 +212,7 @@ const (
 	flagEdit           = "edit"
 	flagAutoApprove    = "auto-approve"
 	flagBaseline       = "baseline"
+	flagBaselineFile   = "baseline-file"
 	flagConfig         = "config"
 	flagDevURL         = "dev-url"
 	flagDirURL         = "dir"
@@ -219,15 +220,21 @@ const (
 	flagDryRun         = "dry-run"
 	flagEnv            = "env"
 	flagExclude        = "exclude"
+	flagExpandContract = "expand-contract"
 	flagFile           = "file"
 	flagFrom           = "from"
 	flagFromShort      = "f"
 	flagFormat         = "format"
 	flagGitBase        = "git-base"
 	flagGitDir         = "git-dir"
+	flagHoldContract   = "hold-contract"
+	flagIncludeRoles   = "include-roles"
 	flagLatest         = "latest"
+	flagLint           = "lint"
 	flagLockTimeout    = "lock-timeout"
 	flagLog            = "log"
+	flagPlan           = "plan"
+	flagPlanOut        = "plan-out"
 	flagRevisionSchema = "revisions-schema"
 	flagSchema         = "schema"
 	flagSchemaShort    = "s"
@@ -236,6 +243,9 @@ const (
 	flagURL            = "url"
 	flagURLShort       = "u"
 	flagVar            = "var"
+	flagToVersion      = "to-version"
+	flagWithDown       = "with-down"
+	flagWriteBaseline  = "write-baseline"
 	flagQualifier      = "qualifier"
 )
 
@@ -295,6 +305,10 @@ func addFlagExclude(set *pflag.FlagSet, target *[]string) {
 	)
 }
 
+func addFlagIncludeRoles(set *pflag.FlagSet, target *bool) {
+	set.BoolVar(target, flagIncludeRoles, false, "inspect roles, users and their privileges")
+}
+
 func addFlagLog(set *pflag.FlagSet, target *string) {
 	set.StringVar(target, flagLog, "", "Go template to use to format the output")
 	// Use MarkHidden instead of MarkDeprecated to avoid
@@ -410,6 +424,7 @@ type stateReaderConfig struct {
 	client, dev *sqlclient.Client // database connections, while dev is considered a dev database, client is not
 	schemas     []string          // schemas to work on
 	exclude     []string          // exclude flag values
+	roles       bool              // inspect roles of database connections
 	vars        Vars
 }
 
@@ -480,15 +495,22 @@ func stateReader(ctx context.Context, config *stateReaderConfig) (*cmdext.StateR
 		if err != nil {
 			return nil, err
 		}
-		var sr migrate.StateReader
+		var (
+			sr   migrate.StateReader
+			mode schema.InspectMode
+		)
+		if config.roles {
+			mode = inspectModeRoles
+		}
 		switch c.URL.Schema {
 		case "":
 			sr = migrate.RealmConn(c.Driver, &schema.InspectRealmOption{
+				Mode:    mode,
 				Schemas: config.schemas,
 				Exclude: config.exclude,
 			})
 		default:
-			sr = migrate.SchemaConn(c.Driver, c.URL.Schema, &schema.InspectOptions{Exclude: config.exclude})
+			sr = migrate.SchemaConn(c.Driver, c.URL.Schema, &schema.InspectOptions{Mode: mode, Exclude: config.exclude})
 		}
 		return &cmdext.StateReadCloser{
 			StateReader: sr,
@@ -498,6 +520,13 @@ func stateReader(ctx context.Context, config *stateReaderConfig) (*cmdext.StateR
 	}
 }
 
+// inspectModeRoles is the inspection mode of database connections in case the --include-roles
+// flag was set. Roles are not inspected by default, and they are enabled in addition to all
+// other resources.
+const inspectModeRoles = schema.InspectSchemas | schema.InspectTables | schema.InspectViews |
+	schema.InspectTriggers | schema.InspectFuncs | schema.InspectSequences | schema.InspectExtensions |
+	schema.InspectTypes | schema.InspectPolicies | schema.InspectEvents | schema.InspectRoles
+
 const (
 	extHCL = ".hcl"
 	extSQL = ".sql"
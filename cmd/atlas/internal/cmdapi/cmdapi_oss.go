@@ -14,6 +14,7 @@ func init() {
 		schemaDiffCmd(),
 		schemaFmtCmd(),
 		schemaInspectCmd(),
+		schemaLintCmd(),
 	)
 	Root.AddCommand(schemaCmd)
 }
@@ -709,6 +709,32 @@ func TestMigrate_ApplyTxModeDirective(t *testing.T) {
 	require.Equal(t, s, `unknown txmode "unknown" found in file directive "20220925094021_second.sql"`)
 }
 
+func TestMigrate_ApplyHoldContract(t *testing.T) {
+	p := t.TempDir()
+	dir, err := migrate.NewLocalDir(p)
+	require.NoError(t, err)
+	require.NoError(t, dir.WriteFile("1_users.sql", []byte("CREATE TABLE users (name text);\n")))
+	require.NoError(t, dir.WriteFile("2_rename.sql", []byte("-- atlas:phase expand\n\nALTER TABLE users ADD COLUMN full_name text;\n")))
+	require.NoError(t, dir.WriteFile("3_rename_contract.sql", []byte("-- atlas:phase contract\n\nALTER TABLE users DROP COLUMN name;\n")))
+	sum, err := dir.Checksum()
+	require.NoError(t, err)
+	require.NoError(t, migrate.WriteSumFile(dir, sum))
+	u := fmt.Sprintf("sqlite://file:%s?cache=shared&_fk=1", filepath.Join(t.TempDir(), "test.db"))
+
+	// Contract files are held back.
+	s, err := runCmd(migrateApplyCmd(), "--dir", "file://"+p, "--url", u, "--hold-contract")
+	require.NoError(t, err)
+	require.Contains(t, s, "Migrating to version 2 (2 migrations in total)")
+	s, err = runCmd(migrateApplyCmd(), "--dir", "file://"+p, "--url", u, "--hold-contract")
+	require.NoError(t, err)
+	require.Contains(t, s, "No migration files to execute")
+
+	// And applied once the flag is not set.
+	s, err = runCmd(migrateApplyCmd(), "--dir", "file://"+p, "--url", u)
+	require.NoError(t, err)
+	require.Contains(t, s, "Migrating to version 3 from 2 (1 migrations in total)")
+}
+
 func TestMigrate_ApplyBaseline(t *testing.T) {
 	t.Run("FromFlags", func(t *testing.T) {
 		p := t.TempDir()
@@ -1591,6 +1617,76 @@ func TestMigrate_Set(t *testing.T) {
 `, s)
 }
 
+func TestMigrate_Down(t *testing.T) {
+	p := t.TempDir()
+	dir, err := migrate.NewLocalDir(p)
+	require.NoError(t, err)
+	require.NoError(t, dir.WriteFile("1_t1.sql", []byte("CREATE TABLE t1 (c int);\n\n-- atlas:down\nDROP TABLE t1;\n")))
+	require.NoError(t, dir.WriteFile("2_t2.sql", []byte("CREATE TABLE t2 (c int);\n")))
+	sum, err := dir.Checksum()
+	require.NoError(t, err)
+	require.NoError(t, migrate.WriteSumFile(dir, sum))
+	ctx := context.Background()
+	u := fmt.Sprintf("sqlite://file:%s?cache=shared&_fk=1", filepath.Join(t.TempDir(), "test.db"))
+	_, err = runCmd(migrateApplyCmd(), "--dir", "file://"+p, "--url", u)
+	require.NoError(t, err)
+	c, err := sqlclient.Open(ctx, u)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, c.Close())
+	})
+	rrw, err := migrate2.NewEntRevisions(ctx, c)
+	require.NoError(t, err)
+
+	// Reverse statements are not stored, and no dev-database was given.
+	_, err = runCmd(migrateDownCmd(), "--dir", "file://"+p, "--url", u)
+	require.ErrorContains(t, err, `file "2_t2.sql" does not store down statements`)
+	_, err = runCmd(migrateDownCmd(), "--dir", "file://"+p, "--url", u, "--to-version", "1", "1")
+	require.EqualError(t, err, `the amount argument and the "to-version" flag are mutually exclusive`)
+
+	// Reverse statements are computed on the dev-database.
+	s, err := runCmd(migrateDownCmd(), "--dir", "file://"+p, "--url", u, "--dev-url", openSQLite(t, ""))
+	require.NoError(t, err)
+	require.Contains(t, s, "reverting version")
+	require.Contains(t, s, "DROP TABLE `t2`")
+	revs, err := rrw.ReadRevisions(ctx)
+	require.NoError(t, err)
+	require.Len(t, revs, 1)
+	require.Equal(t, "1", revs[0].Version)
+
+	// Reverse statements are read from the atlas:down section.
+	s, err = runCmd(migrateDownCmd(), "--dir", "file://"+p, "--url", u, "--to-version", "1")
+	require.NoError(t, err)
+	require.Contains(t, s, "No migration files to revert")
+	s, err = runCmd(migrateDownCmd(), "--dir", "file://"+p, "--url", u)
+	require.NoError(t, err)
+	require.Contains(t, s, "DROP TABLE t1;")
+	revs, err = rrw.ReadRevisions(ctx)
+	require.NoError(t, err)
+	require.Empty(t, revs)
+
+	// A failed revert is rolled back, and the revision is kept.
+	require.NoError(t, dir.WriteFile("3_t3.sql", []byte("CREATE TABLE t3 (c int);\n\n-- atlas:down\nDROP TABLE t3;\nDROP TABLE unknown;\n")))
+	sum, err = dir.Checksum()
+	require.NoError(t, err)
+	require.NoError(t, migrate.WriteSumFile(dir, sum))
+	_, err = runCmd(migrateApplyCmd(), "--dir", "file://"+p, "--url", u)
+	require.NoError(t, err)
+	_, err = runCmd(migrateDownCmd(), "--dir", "file://"+p, "--url", u)
+	require.ErrorContains(t, err, "no such table: unknown")
+	revs, err = rrw.ReadRevisions(ctx)
+	require.NoError(t, err)
+	require.Len(t, revs, 3)
+	_, err = c.ExecContext(ctx, "SELECT * FROM t3")
+	require.NoError(t, err, "table t3 was restored by the rollback")
+
+	// Without a transaction, statements are not rolled back.
+	_, err = runCmd(migrateDownCmd(), "--dir", "file://"+p, "--url", u, "--tx-mode", "none")
+	require.ErrorContains(t, err, "no such table: unknown")
+	_, err = c.ExecContext(ctx, "SELECT * FROM t3")
+	require.Error(t, err)
+}
+
 func TestMigrate_New(t *testing.T) {
 	var (
 		p = t.TempDir()
@@ -1850,6 +1946,99 @@ lint {
 	require.Equal(t, "2.up.sql: executing statement: near \"BORING\": syntax error\n", s)
 }
 
+func TestMigrate_LintBaseline(t *testing.T) {
+	var (
+		p        = t.TempDir()
+		baseline = filepath.Join(t.TempDir(), "baseline.json")
+	)
+	err := os.WriteFile(filepath.Join(p, "1.sql"), []byte("CREATE TABLE t(c int);\nCREATE TABLE t2(c int);"), 0600)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(p, "2.sql"), []byte("DROP TABLE t;"), 0600)
+	require.NoError(t, err)
+	_, err = runCmd(
+		migrateLintCmd(),
+		"--dir", "file://"+p,
+		"--dev-url", openSQLite(t, ""),
+		"--latest", "1",
+		"--write-baseline",
+	)
+	require.EqualError(t, err, "--baseline-file is required when --write-baseline is set")
+
+	// Analyzer errors do not fail recording a baseline.
+	s, err := runCmd(
+		migrateLintCmd(),
+		"--dir", "file://"+p,
+		"--dev-url", openSQLite(t, ""),
+		"--latest", "1",
+		"--baseline-file", baseline,
+		"--write-baseline",
+	)
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("1 diagnostics were recorded in baseline file %q\n", baseline), s)
+	s, err = runCmd(
+		migrateLintCmd(),
+		"--dir", "file://"+p,
+		"--dev-url", openSQLite(t, ""),
+		"--latest", "1",
+		"--baseline-file", baseline,
+	)
+	require.NoError(t, err)
+	require.Empty(t, s)
+
+	// Only new diagnostics are reported.
+	err = os.WriteFile(filepath.Join(p, "3.sql"), []byte("DROP TABLE t2;"), 0600)
+	require.NoError(t, err)
+	s, err = runCmd(
+		migrateLintCmd(),
+		"--dir", "file://"+p,
+		"--dev-url", openSQLite(t, ""),
+		"--latest", "2",
+		"--baseline-file", baseline,
+	)
+	require.Error(t, err)
+	require.Equal(t, "3.sql: destructive changes detected:\n\n\tL1: Dropping table \"t2\"\n\n", s)
+
+	t.Run("FromConfig", func(t *testing.T) {
+		cfg := filepath.Join(p, "atlas.hcl")
+		err := os.WriteFile(cfg, []byte(fmt.Sprintf(`
+variable "severity" {
+  type = string
+}
+
+lint {
+  latest   = 2
+  baseline = %q
+  severity {
+    DS102 = var.severity
+  }
+}
+`, baseline)), 0600)
+		require.NoError(t, err)
+		cmd := migrateCmd()
+		cmd.AddCommand(migrateLintCmd())
+		s, err := runCmd(
+			cmd, "lint",
+			"--dir", "file://"+p,
+			"--dev-url", openSQLite(t, ""),
+			"-c", "file://"+cfg,
+			"--var", "severity=warning",
+		)
+		require.NoError(t, err)
+		require.Equal(t, "3.sql: destructive changes detected:\n\n\tL1: Dropping table \"t2\"\n\n", s)
+
+		cmd = migrateCmd()
+		cmd.AddCommand(migrateLintCmd())
+		_, err = runCmd(
+			cmd, "lint",
+			"--dir", "file://"+p,
+			"--dev-url", openSQLite(t, ""),
+			"-c", "file://"+cfg,
+			"--var", "severity=fatal",
+		)
+		require.EqualError(t, err, `invalid severity "fatal" for code "DS102", expect one of: error, warning, info`)
+	})
+}
+
 const testSchema = `
 schema "main" {
 }
@@ -12,6 +12,7 @@ import (
 	"reflect"
 
 	"ariga.io/atlas/cmd/atlas/internal/cmdext"
+	"ariga.io/atlas/cmd/atlas/internal/lint"
 	cmdmigrate "ariga.io/atlas/cmd/atlas/internal/migrate"
 	"ariga.io/atlas/schemahcl"
 	"ariga.io/atlas/sql/schema"
@@ -102,6 +103,10 @@ type (
 			// Base configures the --git-base option.
 			Base string `spec:"base"`
 		} `spec:"git"`
+		// Baseline configures the --baseline-file option.
+		Baseline string `spec:"baseline"`
+		// Apply configures the --lint option of 'schema apply'.
+		Apply bool `spec:"apply"`
 		schemahcl.DefaultExtension
 	}
 
@@ -194,6 +199,12 @@ func (l *Lint) Extend(global *Lint) *Lint {
 	if l.Format == "" {
 		l.Format = global.Format
 	}
+	if l.Baseline == "" {
+		l.Baseline = global.Baseline
+	}
+	if !l.Apply {
+		l.Apply = global.Apply
+	}
 	l.Extra = global.Extra
 	switch {
 	// Changes detector was configured on the env.
@@ -230,6 +241,35 @@ func (l *Lint) remainedLog() error {
 	return nil
 }
 
+// policy returns the lint policy with the severity levels
+// configured for the diagnostic codes. For example:
+//
+//	lint {
+//	  severity {
+//	    DS102 = "warning"
+//	    MF103 = "error"
+//	  }
+//	}
+func (l *Lint) policy() (*lint.Policy, error) {
+	p := &lint.Policy{}
+	r, ok := l.Remain().Resource("severity")
+	if !ok {
+		return p, nil
+	}
+	p.Severity = make(map[string]string, len(r.Attrs))
+	for _, at := range r.Attrs {
+		s, err := at.String()
+		if err != nil {
+			return nil, fmt.Errorf("severity of code %q: %w", at.K, err)
+		}
+		if !lint.ValidSeverity(s) {
+			return nil, fmt.Errorf("invalid severity %q for code %q, expect one of: error, warning, info", s, at.K)
+		}
+		p.Severity[at.K] = s
+	}
+	return p, nil
+}
+
 // Extend allows extending environment blocks with
 // a global one. For example:
 //
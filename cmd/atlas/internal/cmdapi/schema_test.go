@@ -525,6 +525,131 @@ func TestSchema_ApplyLog(t *testing.T) {
 	})
 }
 
+func TestSchema_ApplyPlan(t *testing.T) {
+	var (
+		db   = openSQLite(t, "")
+		path = filepath.Join(t.TempDir(), "plan.json")
+		run  = func(args ...string) (string, error) {
+			cmd := schemaCmd()
+			cmd.AddCommand(schemaApplyCmd())
+			return runCmd(cmd, append([]string{"apply", "-u", db, "--format", "{{ json .Changes }}"}, args...)...)
+		}
+	)
+	s, err := run("--to", openSQLite(t, "create table t1 (id int);"), "--plan-out", path)
+	require.NoError(t, err)
+	require.Equal(t, "{\"Pending\":[\"CREATE TABLE `t1` (`id` int NULL)\"]}", s)
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(b), `"Fingerprint": "h1:`)
+	require.Contains(t, string(b), `"Cmd": "CREATE TABLE `+"`t1` (`id` int NULL)"+`"`)
+
+	// The plan was not applied.
+	s, err = run("--to", openSQLite(t, ""), "--dry-run")
+	require.NoError(t, err)
+	require.Equal(t, "{}", s)
+
+	_, err = run("--plan", path, "--to", openSQLite(t, ""))
+	require.Error(t, err)
+	s, err = run("--plan", path, "--auto-approve")
+	require.NoError(t, err)
+	require.Equal(t, "{\"Applied\":[\"CREATE TABLE `t1` (`id` int NULL)\"]}", s)
+
+	// The database state was changed since the plan was created.
+	_, err = run("--plan", path, "--auto-approve")
+	require.EqualError(t, err, fmt.Sprintf("the database state was changed since the plan %q was created. Plan the changes again", path))
+}
+
+func TestSchema_ApplyLint(t *testing.T) {
+	var (
+		p   = t.TempDir()
+		cfg = filepath.Join(p, "atlas.hcl")
+		db  = openSQLite(t, "create table t (a int, b int);")
+		run = func(args ...string) (string, error) {
+			cmd := schemaCmd()
+			cmd.AddCommand(schemaApplyCmd())
+			return runCmd(cmd, append([]string{
+				"apply",
+				"-u", db,
+				"--to", openSQLite(t, "create table t (a int);"),
+				"-c", "file://" + cfg,
+				"--env", "local",
+			}, args...)...)
+		}
+	)
+	err := os.WriteFile(cfg, []byte(`
+variable "error" {
+  type    = bool
+  default = false
+}
+
+lint {
+  apply = true
+  destructive {
+    error = var.error
+  }
+}
+
+env "local" {}
+`), 0600)
+	require.NoError(t, err)
+	s, err := run("--dry-run")
+	require.NoError(t, err)
+	require.Contains(t, s, "\n-- Analyzing planned changes:\n  -- destructive changes detected:\n    -- Dropping non-virtual column \"b\" (DS103)\n")
+
+	s, err = run("--dry-run", "--format", "{{ json .Lint.Reports }}")
+	require.NoError(t, err)
+	require.Contains(t, s, `"Text":"destructive changes detected"`)
+	require.Contains(t, s, `"Code":"DS103"`)
+
+	// The lint policy blocks the apply.
+	s, err = run("--auto-approve", "--var", "error=true")
+	require.EqualError(t, err, "planned changes were rejected by the lint policy: destructive changes detected")
+	require.Contains(t, s, `-- Dropping non-virtual column "b" (DS103)`)
+	s, err = run("--auto-approve", "--var", "error=true", "--format", "{{ json . }}")
+	require.Error(t, err)
+	require.Contains(t, s, `"Error":"planned changes were rejected by the lint policy: destructive changes detected"`)
+	s, err = run("--dry-run")
+	require.NoError(t, err)
+	require.Contains(t, s, "DS103")
+
+	// Diagnostics do not block the apply without a policy.
+	_, err = run("--auto-approve")
+	require.NoError(t, err)
+	s, err = run("--dry-run")
+	require.NoError(t, err)
+	require.Equal(t, "Schema is synced, no changes to be made\n", s)
+}
+
+func TestSchema_ApplyLintNoConfig(t *testing.T) {
+	run := func(db, to string, args ...string) (string, error) {
+		cmd := schemaCmd()
+		cmd.AddCommand(schemaApplyCmd())
+		// No lint configuration and no dev database.
+		return runCmd(cmd, append([]string{"apply", "-u", db, "--to", openSQLite(t, to), "--auto-approve"}, args...)...)
+	}
+	// Planned changes are not analyzed by default.
+	db := openSQLite(t, "create table t (a int, b int);")
+	s, err := run(db, "create table t (a int);", "--dry-run")
+	require.NoError(t, err)
+	require.NotContains(t, s, "Analyzing planned changes")
+
+	// Destructive changes are reported, but not blocked by default.
+	s, err = run(db, "create table t (a int);", "--lint")
+	require.NoError(t, err)
+	require.Contains(t, s, "\n-- Analyzing planned changes:\n  -- destructive changes detected:\n    -- Dropping non-virtual column \"b\" (DS103)\n")
+	s, err = run(db, "create table t (a int);")
+	require.NoError(t, err)
+	require.Equal(t, "Schema is synced, no changes to be made\n", s)
+
+	// Checks that require the dev database are skipped without it.
+	db = openSQLite(t, "create table t (a int, b int);")
+	_, err = run(db, "create table t (a int, b text not null default '');", "--lint")
+	require.NoError(t, err)
+	s, err = run(db, "create table t (a int, b text not null default '');")
+	require.NoError(t, err)
+	require.Equal(t, "Schema is synced, no changes to be made\n", s)
+}
+
 func TestSchema_ApplySchemaMismatch(t *testing.T) {
 	var (
 		p   = t.TempDir()
@@ -893,6 +1018,37 @@ table "users" {
 	require.Equal(t, "-- Create \"users\" table\nCREATE TABLE `users` (`id` int NOT NULL);\n", s)
 }
 
+func TestSchema_Lint(t *testing.T) {
+	var (
+		p   = t.TempDir()
+		cfg = filepath.Join(p, "atlas.hcl")
+		db  = openSQLite(t, "create table `order` (id int primary key); create table Users (id int);")
+	)
+	s, err := runCmd(schemaLintCmd(), "-u", db)
+	require.NoError(t, err)
+	require.Equal(t, "schema design issues detected:\n\n\tTable name \"order\" is a reserved word (DE104)\n\tTable \"Users\" has no primary key (DE101)\n\n", s)
+
+	s, err = runCmd(schemaLintCmd(), "-u", db, "--format", "{{ range .Reports }}{{ range .Diagnostics }}{{ .Code }} {{ end }}{{ end }}")
+	require.NoError(t, err)
+	require.Equal(t, "DE104 DE101 ", s)
+
+	// The naming analyzer is configured by the lint policy.
+	err = os.WriteFile(cfg, []byte(`
+lint {
+  naming {
+    match = "^[a-z]+$"
+    error = true
+  }
+}
+`), 0600)
+	require.NoError(t, err)
+	cmd := schemaCmd()
+	cmd.AddCommand(schemaLintCmd())
+	s, err = runCmd(cmd, "lint", "-u", db, "-c", "file://"+cfg)
+	require.EqualError(t, err, "naming violations detected")
+	require.Contains(t, s, "naming violations detected:\n\n\tTable named \"Users\" violates the naming policy (NM102)\n")
+}
+
 func TestFmt(t *testing.T) {
 	for _, tt := range []struct {
 		name          string
@@ -498,3 +498,46 @@ dir = data.remote_dir.hello.url
 	_, err = md.Open(migrate.HashFileName)
 	require.NoError(t, err)
 }
+
+func TestStateReaderHCL_Hints(t *testing.T) {
+	var (
+		ctx = context.Background()
+		dir = t.TempDir()
+	)
+	err := os.WriteFile(filepath.Join(dir, "schema.hcl"), []byte(`
+schema "main" {}
+
+table "users" {
+  schema       = schema.main
+  renamed_from = "accounts"
+  column "full_name" {
+    type         = text
+    renamed_from = "name"
+  }
+  index "users_full_name" {
+    columns      = [column.full_name]
+    renamed_from = "users_name"
+  }
+}
+`), 0644)
+	require.NoError(t, err)
+	drv, err := sqlclient.Open(ctx, "sqlite://test?mode=memory&_fk=1")
+	require.NoError(t, err)
+	sr, err := cmdext.StateReaderHCL(ctx, &cmdext.StateReaderConfig{
+		Dev:  drv,
+		URLs: []*url.URL{{Scheme: "file", Path: dir}},
+	})
+	require.NoError(t, err)
+	realm, err := sr.ReadState(ctx)
+	require.NoError(t, err)
+	// Hints are kept after normalizing the desired state on the dev database.
+	tb, ok := realm.Schemas[0].Table("users")
+	require.True(t, ok)
+	require.Contains(t, tb.Attrs, &schema.RenamedFrom{Name: "accounts"})
+	c, ok := tb.Column("full_name")
+	require.True(t, ok)
+	require.Contains(t, c.Attrs, &schema.RenamedFrom{Name: "name"})
+	idx, ok := tb.Index("users_full_name")
+	require.True(t, ok)
+	require.Contains(t, idx.Attrs, &schema.RenamedFrom{Name: "users_name"})
+}
@@ -169,6 +169,7 @@ func stateReaderHCL(ctx context.Context, config *StateReaderConfig, paths []stri
 		StateReader: migrate.StateReaderFunc(func(ctx context.Context) (*schema.Realm, error) {
 			// Normalize once, only on dev database connection.
 			if nr, ok := client.Driver.(schema.Normalizer); ok && !normalized && config.Dev != nil {
+				desired := append([]*schema.Schema(nil), realm.Schemas...)
 				switch {
 				// Empty schema file.
 				case len(realm.Schemas) == 0:
@@ -180,12 +181,65 @@ func stateReaderHCL(ctx context.Context, config *StateReaderConfig, paths []stri
 				if err != nil {
 					return nil, err
 				}
+				copyHints(desired, realm.Schemas)
 			}
 			return realm, nil
 		}),
 	}, nil
 }
 
+// copyHints copies the hints defined on the desired schemas (e.g., renamed_from)
+// to their normalized form, as hints are not stored in the dev database, and
+// therefore, are lost on normalization.
+func copyHints(desired, normalized []*schema.Schema) {
+	for _, s1 := range desired {
+		var s2 *schema.Schema
+		switch {
+		// Schema normalization might rename the desired schema
+		// to the dev schema, and therefore, it is matched as is.
+		case len(desired) == 1 && len(normalized) == 1:
+			s2 = normalized[0]
+		default:
+			for _, s := range normalized {
+				if s.Name == s1.Name {
+					s2 = s
+					break
+				}
+			}
+		}
+		if s2 == nil {
+			continue
+		}
+		for _, t1 := range s1.Tables {
+			t2, ok := s2.Table(t1.Name)
+			if !ok {
+				continue
+			}
+			t2.Attrs = appendHints(t2.Attrs, t1.Attrs)
+			for _, c1 := range t1.Columns {
+				if c2, ok := t2.Column(c1.Name); ok {
+					c2.Attrs = appendHints(c2.Attrs, c1.Attrs)
+				}
+			}
+			for _, idx1 := range t1.Indexes {
+				if idx2, ok := t2.Index(idx1.Name); ok {
+					idx2.Attrs = appendHints(idx2.Attrs, idx1.Attrs)
+				}
+			}
+		}
+	}
+}
+
+// appendHints appends the hint attributes found in src to dst.
+func appendHints(dst, src []schema.Attr) []schema.Attr {
+	for _, a := range src {
+		if h, ok := a.(*schema.RenamedFrom); ok {
+			dst = append(dst, h)
+		}
+	}
+	return dst
+}
+
 // parseHCLPaths parses the HCL files in the given paths. If a path represents a directory,
 // its direct descendants will be considered, skipping any subdirectories. If a project file
 // is present in the input paths, an error is returned.
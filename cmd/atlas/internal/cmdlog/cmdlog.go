@@ -14,8 +14,10 @@ import (
 	"text/template"
 	"time"
 
+	"ariga.io/atlas/cmd/atlas/internal/lint"
 	"ariga.io/atlas/sql/migrate"
 	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlcheck"
 	"ariga.io/atlas/sql/sqlclient"
 
 	"github.com/fatih/color"
@@ -321,6 +323,29 @@ Migrating to version {{ cyan .Target }}{{ with .Current }} from {{ cyan . }}{{ e
   {{ yellow "--" }} {{ .CountStmts  }} sql statements
 {{- end }}
 {{- end }}
+`))
+	// MigrateDownTemplate holds the default template of the 'migrate down' command.
+	MigrateDownTemplate = template.Must(template.
+				New("report").
+				Funcs(ApplyTemplateFuncs).
+				Parse(`{{- if not .Pending -}}
+No migration files to revert
+{{- else -}}
+Reverting to version {{ with .Target }}{{ cyan . }}{{ else }}{{ cyan "none" }}{{ end }} from {{ cyan .Current }} ({{ len .Pending }} migrations in total):
+{{ range $i, $f := .Applied }}
+  {{ yellow "--" }} reverting version {{ cyan $f.File.Version }}{{ range $f.Applied }}
+    {{ cyan "->" }} {{ . }}{{ end }}
+  {{- with .Error }}
+    {{ redBgWhiteFg .Text }}
+  {{- else }}
+  {{ yellow "--" }} ok ({{ yellow (.End.Sub .Start).String }})
+  {{- end }}
+{{ end }}
+  {{ cyan "-------------------------" }}
+  {{ yellow "--" }} {{ .End.Sub .Start }}
+  {{ yellow "--" }} {{ len .Applied }} migrations reverted
+  {{ yellow "--" }} {{ .CountStmts }} sql statements
+{{- end }}
 `))
 )
 
@@ -464,6 +489,21 @@ var SchemaPlanTemplate = template.Must(template.
 {{- end -}}
 {{- printf "%s;\n" .Cmd -}}
 {{- end -}}
+{{- with $.Changes.Warnings }}
+-- Warnings:
+{{ range . -}}
+{{- printf "  -- %s\n" . -}}
+{{- end -}}
+{{- end -}}
+{{- with $.Lint }}{{ with .Reports }}
+-- Analyzing planned changes:
+{{ range $r := . -}}
+{{- printf "  -- %s:\n" $r.Text -}}
+{{- range $d := $r.Diagnostics -}}
+{{- printf "    -- %s" $d.Text -}}{{ with $d.Code }}{{ printf " (%s)" . }}{{ end }}{{ print "\n" -}}
+{{- end -}}
+{{- end -}}
+{{- end }}{{ end -}}
 {{- else -}}
 Schema is synced, no changes to be made
 {{ end -}}
@@ -474,6 +514,8 @@ type (
 	SchemaApply struct {
 		Env
 		Changes Changes `json:"Changes,omitempty"`
+		// Lint holds the analysis reports of the planned changes.
+		Lint *lint.FileReport `json:"Lint,omitempty"`
 		// General error that occurred during execution.
 		// e.g., when committing or rolling back a transaction.
 		Error string `json:"Error,omitempty"`
@@ -503,12 +545,26 @@ func NewSchemaPlan(env Env, pending []*migrate.Change, err *StmtError) *SchemaAp
 	return NewSchemaApply(env, nil, pending, err)
 }
 
+// Warnings returns the warnings reported by the planned changes, if any.
+func (c Changes) Warnings() []string {
+	var ws []string
+	for _, cs := range [][]*migrate.Change{c.Applied, c.Pending} {
+		for i := range cs {
+			if cs[i].Warning != "" {
+				ws = append(ws, cs[i].Warning)
+			}
+		}
+	}
+	return ws
+}
+
 // MarshalJSON implements json.Marshaler.
 func (c Changes) MarshalJSON() ([]byte, error) {
 	var v struct {
-		Applied []string   `json:"Applied,omitempty"`
-		Pending []string   `json:"Pending,omitempty"`
-		Error   *StmtError `json:"Error,omitempty"`
+		Applied  []string   `json:"Applied,omitempty"`
+		Pending  []string   `json:"Pending,omitempty"`
+		Warnings []string   `json:"Warnings,omitempty"`
+		Error    *StmtError `json:"Error,omitempty"`
 	}
 	for i := range c.Applied {
 		v.Applied = append(v.Applied, c.Applied[i].Cmd)
@@ -516,10 +572,32 @@ func (c Changes) MarshalJSON() ([]byte, error) {
 	for i := range c.Pending {
 		v.Pending = append(v.Pending, c.Pending[i].Cmd)
 	}
+	v.Warnings = c.Warnings()
 	v.Error = c.Error
 	return json.Marshal(v)
 }
 
+// SchemaLint contains a summary of the 'schema lint' command.
+type SchemaLint struct {
+	Env
+	Reports []sqlcheck.Report `json:"Reports,omitempty"` // Analysis reports.
+	Error   string            `json:"Error,omitempty"`   // Errors returned by the analyzers.
+}
+
+// SchemaLintTemplate holds the default template of the 'schema lint' command.
+var SchemaLintTemplate = template.Must(template.
+	New("lint").
+	Funcs(ApplyTemplateFuncs).
+	Parse(`
+{{- range $r := .Reports }}
+	{{- printf "%s:\n\n" $r.Text }}
+	{{- range $d := $r.Diagnostics }}
+		{{- printf "\t%s" $d.Text }}{{ with $d.Code }}{{ printf " (%s)" . }}{{ end }}{{ print "\n" }}
+	{{- end }}
+	{{- print "\n" }}
+{{- end -}}
+`))
+
 // SchemaInspect contains a summary of the 'schema inspect' command.
 type SchemaInspect struct {
 	*sqlclient.Client `json:"-"`
@@ -665,6 +743,16 @@ func sqlInspect(report *SchemaInspect, indent ...string) (string, error) {
 		for _, v := range s.Views {
 			changes = append(changes, &schema.AddView{V: v})
 		}
+		for _, t := range s.Tables {
+			for _, tr := range t.Triggers {
+				changes = append(changes, &schema.AddTrigger{T: tr})
+			}
+		}
+		for _, v := range s.Views {
+			for _, tr := range v.Triggers {
+				changes = append(changes, &schema.AddTrigger{T: tr})
+			}
+		}
 		for _, o := range s.Objects {
 			changes = append(changes, &schema.AddObject{O: o})
 		}
@@ -211,3 +211,34 @@ func TestMigrateSet(t *testing.T) {
 
 `, b.String())
 }
+
+func TestSchemaPlan_Warnings(t *testing.T) {
+	var (
+		b   bytes.Buffer
+		log = cmdlog.NewSchemaPlan(cmdlog.Env{}, []*migrate.Change{
+			{
+				Cmd:     "ALTER TABLE `users` ADD COLUMN `age` int NULL, ALGORITHM=INSTANT",
+				Comment: `modify "users" table`,
+			},
+			{
+				Cmd:     "ALTER TABLE `pets` ADD CONSTRAINT `name_len` CHECK (length(name) > 0)",
+				Comment: `modify "pets" table (adding check constraint "name_len" cannot be applied online)`,
+				Warning: `alter table "pets": adding check constraint "name_len" cannot be applied online`,
+			},
+		}, nil)
+	)
+	require.NoError(t, cmdlog.SchemaPlanTemplate.Execute(&b, log))
+	require.Equal(t, `-- Planned Changes:
+-- Modify "users" table
+ALTER TABLE `+"`users`"+` ADD COLUMN `+"`age`"+` int NULL, ALGORITHM=INSTANT;
+-- Modify "pets" table (adding check constraint "name_len" cannot be applied online)
+ALTER TABLE `+"`pets`"+` ADD CONSTRAINT `+"`name_len`"+` CHECK (length(name) > 0);
+
+-- Warnings:
+  -- alter table "pets": adding check constraint "name_len" cannot be applied online
+`, b.String())
+
+	buf, err := json.Marshal(log)
+	require.NoError(t, err)
+	require.Contains(t, string(buf), `"Warnings":["alter table \"pets\": adding check constraint \"name_len\" cannot be applied online"]`)
+}
@@ -0,0 +1,310 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package lint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"ariga.io/atlas/sql/sqlcheck"
+)
+
+// Built-in formats for writing summary reports in a machine-readable form.
+const (
+	FormatSARIF       = "sarif"       // Static Analysis Results Interchange Format.
+	FormatJUnit       = "junit"       // JUnit XML.
+	FormatCodeClimate = "codeclimate" // GitLab Code Quality (Code Climate subset).
+)
+
+// WriterFor returns the built-in ReportWriter for the given format name.
+// The second return value reports if such a writer exists.
+func WriterFor(format string, w io.Writer) (ReportWriter, bool) {
+	switch format {
+	case FormatSARIF:
+		return &SARIFWriter{W: w}, true
+	case FormatJUnit:
+		return &JUnitWriter{W: w}, true
+	case FormatCodeClimate:
+		return &CodeClimateWriter{W: w}, true
+	default:
+		return nil, false
+	}
+}
+
+type (
+	// SARIFWriter writes the summary report in the SARIF 2.1.0 format.
+	// For example, to be uploaded to GitHub code scanning.
+	SARIFWriter struct {
+		W io.Writer
+	}
+
+	// JUnitWriter writes the summary report in the JUnit XML format. Each
+	// analyzed file is reported as a test suite, and each diagnostic as a
+	// failed test case.
+	JUnitWriter struct {
+		W io.Writer
+	}
+
+	// CodeClimateWriter writes the summary report in the format that
+	// is accepted by GitLab Code Quality reports.
+	CodeClimateWriter struct {
+		W io.Writer
+	}
+)
+
+// finding is a diagnostic or an error found in a file.
+type finding struct {
+	path   string // File path, relative to the working directory.
+	line   int    // Line number of the diagnostic.
+	code   string // Diagnostic code. Empty for errors.
+	text   string // Diagnostic text or error message.
+	report string // Report text the diagnostic belongs to.
+	level  string // Severity level of the diagnostic, if configured.
+	err    bool   // Finding is a file error.
+}
+
+// findings returns the findings of the given file, in the same
+// way they are printed by the DefaultTemplate: file errors are
+// reported only in case the file has no diagnostics.
+func (r *SummaryReport) findings(f *FileReport) []finding {
+	var (
+		fs   []finding
+		path = r.path(f)
+	)
+	if f.Error != "" && len(f.Reports) == 0 {
+		return append(fs, finding{path: path, line: 1, text: f.Error, err: true})
+	}
+	for _, rp := range f.Reports {
+		for _, d := range rp.Diagnostics {
+			fs = append(fs, finding{path: path, line: f.Line(d.Pos), code: d.Code, text: d.Text, report: rp.Text, level: d.Severity})
+		}
+	}
+	return fs
+}
+
+// path returns the path of the file, joined with the migration directory.
+func (r *SummaryReport) path(f *FileReport) string {
+	return filepath.ToSlash(filepath.Join(r.Env.Dir, f.Name))
+}
+
+type (
+	sarifLog struct {
+		Schema  string     `json:"$schema"`
+		Version string     `json:"version"`
+		Runs    []sarifRun `json:"runs"`
+	}
+	sarifRun struct {
+		Tool struct {
+			Driver struct {
+				Name           string      `json:"name"`
+				InformationURI string      `json:"informationUri"`
+				Rules          []sarifRule `json:"rules"`
+			} `json:"driver"`
+		} `json:"tool"`
+		Results []sarifResult `json:"results"`
+	}
+	sarifRule struct {
+		ID               string       `json:"id"`
+		ShortDescription sarifMessage `json:"shortDescription"`
+		HelpURI          string       `json:"helpUri"`
+	}
+	sarifResult struct {
+		RuleID    string          `json:"ruleId,omitempty"`
+		Level     string          `json:"level"`
+		Message   sarifMessage    `json:"message"`
+		Locations []sarifLocation `json:"locations"`
+	}
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+	sarifLocation struct {
+		PhysicalLocation struct {
+			ArtifactLocation struct {
+				URI string `json:"uri"`
+			} `json:"artifactLocation"`
+			Region struct {
+				StartLine int `json:"startLine"`
+			} `json:"region"`
+		} `json:"physicalLocation"`
+	}
+)
+
+// WriteReport implements ReportWriter.
+func (w *SARIFWriter) WriteReport(r *SummaryReport) error {
+	run := sarifRun{Results: make([]sarifResult, 0)}
+	run.Tool.Driver.Name = "atlas"
+	run.Tool.Driver.InformationURI = "https://atlasgo.io"
+	run.Tool.Driver.Rules = make([]sarifRule, 0)
+	for _, c := range sqlcheck.Codes() {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			ID:               c.Code,
+			ShortDescription: sarifMessage{Text: c.Text},
+			HelpURI:          "https://atlasgo.io/lint/analyzers#" + c.Code,
+		})
+	}
+	for _, f := range r.Files {
+		for _, fd := range r.findings(f) {
+			var loc sarifLocation
+			loc.PhysicalLocation.ArtifactLocation.URI = fd.path
+			loc.PhysicalLocation.Region.StartLine = fd.line
+			res := sarifResult{
+				RuleID:    fd.code,
+				Level:     "warning",
+				Message:   sarifMessage{Text: fd.text},
+				Locations: []sarifLocation{loc},
+			}
+			switch {
+			case fd.err, fd.level == sqlcheck.SeverityError:
+				res.Level = "error"
+			case fd.level == sqlcheck.SeverityInfo:
+				res.Level = "note"
+			}
+			run.Results = append(run.Results, res)
+		}
+	}
+	return writeJSON(w.W, sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}
+
+type (
+	junitTestSuites struct {
+		XMLName  xml.Name         `xml:"testsuites"`
+		Name     string           `xml:"name,attr"`
+		Tests    int              `xml:"tests,attr"`
+		Failures int              `xml:"failures,attr"`
+		Errors   int              `xml:"errors,attr"`
+		Suites   []junitTestSuite `xml:"testsuite"`
+	}
+	junitTestSuite struct {
+		Name     string          `xml:"name,attr"`
+		Tests    int             `xml:"tests,attr"`
+		Failures int             `xml:"failures,attr"`
+		Errors   int             `xml:"errors,attr"`
+		Cases    []junitTestCase `xml:"testcase"`
+	}
+	junitTestCase struct {
+		Name      string        `xml:"name,attr"`
+		Classname string        `xml:"classname,attr"`
+		Failure   *junitFailure `xml:"failure,omitempty"`
+		Error     *junitFailure `xml:"error,omitempty"`
+	}
+	junitFailure struct {
+		Message string `xml:"message,attr"`
+		Type    string `xml:"type,attr,omitempty"`
+		Text    string `xml:",chardata"`
+	}
+)
+
+// WriteReport implements ReportWriter.
+func (w *JUnitWriter) WriteReport(r *SummaryReport) error {
+	suites := junitTestSuites{Name: "atlas migrate lint"}
+	for _, f := range r.Files {
+		var (
+			fs    = r.findings(f)
+			suite = junitTestSuite{Name: r.path(f)}
+		)
+		// Files without findings are reported as passed test cases.
+		if len(fs) == 0 {
+			suite.Cases = append(suite.Cases, junitTestCase{Name: "lint", Classname: suite.Name})
+		}
+		for _, fd := range fs {
+			tc := junitTestCase{Classname: suite.Name}
+			switch {
+			case fd.err:
+				tc.Name = "lint"
+				tc.Error = &junitFailure{Message: fd.text, Text: fd.text}
+				suite.Errors++
+			default:
+				tc.Name = fmt.Sprintf("L%d", fd.line)
+				if fd.code != "" {
+					tc.Name = fmt.Sprintf("%s: %s", tc.Name, fd.code)
+				}
+				// Informational diagnostics do not fail the test case.
+				if fd.level == sqlcheck.SeverityInfo {
+					break
+				}
+				tc.Failure = &junitFailure{
+					Message: fd.text,
+					Type:    fd.code,
+					Text:    fmt.Sprintf("%s:%d: %s: %s", fd.path, fd.line, fd.report, fd.text),
+				}
+				suite.Failures++
+			}
+			suite.Cases = append(suite.Cases, tc)
+		}
+		suite.Tests = len(suite.Cases)
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Errors += suite.Errors
+		suites.Suites = append(suites.Suites, suite)
+	}
+	b, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w.W, "%s%s\n", xml.Header, b)
+	return err
+}
+
+type codeClimateIssue struct {
+	Type        string `json:"type"`
+	CheckName   string `json:"check_name"`
+	Description string `json:"description"`
+	Severity    string `json:"severity"`
+	Fingerprint string `json:"fingerprint"`
+	Location    struct {
+		Path  string `json:"path"`
+		Lines struct {
+			Begin int `json:"begin"`
+		} `json:"lines"`
+	} `json:"location"`
+}
+
+// WriteReport implements ReportWriter.
+func (w *CodeClimateWriter) WriteReport(r *SummaryReport) error {
+	issues := make([]codeClimateIssue, 0)
+	for _, f := range r.Files {
+		for _, fd := range r.findings(f) {
+			is := codeClimateIssue{
+				Type:        "issue",
+				CheckName:   fd.code,
+				Description: fd.text,
+				Severity:    "minor",
+			}
+			if fd.code == "" {
+				is.CheckName = "atlas"
+			}
+			switch {
+			case fd.err:
+				is.Severity = "critical"
+			case fd.level == sqlcheck.SeverityError:
+				is.Severity = "major"
+			case fd.level == sqlcheck.SeverityInfo:
+				is.Severity = "info"
+			}
+			is.Location.Path = fd.path
+			is.Location.Lines.Begin = fd.line
+			h := sha256.Sum256([]byte(strings.Join([]string{fd.path, fd.code, fd.text, fmt.Sprint(fd.line)}, "\x00")))
+			is.Fingerprint = hex.EncodeToString(h[:])
+			issues = append(issues, is)
+		}
+	}
+	return writeJSON(w.W, issues)
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,155 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package lint_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"ariga.io/atlas/cmd/atlas/internal/lint"
+	"ariga.io/atlas/sql/sqlcheck"
+	_ "ariga.io/atlas/sql/sqlcheck/destructive"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriterFor(t *testing.T) {
+	var (
+		b   bytes.Buffer
+		sum = &lint.SummaryReport{
+			Files: []*lint.FileReport{
+				{
+					Name: "1.sql",
+					Text: "CREATE TABLE t (id int);",
+				},
+				{
+					Name: "2.sql",
+					Text: "CREATE TABLE t2 (id int);\nDROP TABLE t;",
+					Reports: []sqlcheck.Report{
+						{
+							Text: "destructive changes detected",
+							Diagnostics: []sqlcheck.Diagnostic{
+								{Pos: 26, Code: "DS102", Text: `Dropping table "t"`},
+							},
+						},
+					},
+				},
+				{
+					Name:  "3.sql",
+					Text:  "DROP TABLE t2;",
+					Error: "executing statement: table not found",
+				},
+			},
+		}
+	)
+	sum.Env.Dir = "migrations"
+	_, ok := lint.WriterFor("{{ json .Files }}", &b)
+	require.False(t, ok)
+
+	// SARIF.
+	w, ok := lint.WriterFor(lint.FormatSARIF, &b)
+	require.True(t, ok)
+	require.NoError(t, w.WriteReport(sum))
+	var sarif struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Rules []struct {
+						ID               string `json:"id"`
+						ShortDescription struct {
+							Text string `json:"text"`
+						} `json:"shortDescription"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []json.RawMessage `json:"results"`
+		} `json:"runs"`
+	}
+	require.NoError(t, json.Unmarshal(b.Bytes(), &sarif))
+	require.Equal(t, "2.1.0", sarif.Version)
+	require.Len(t, sarif.Runs, 1)
+	rules := make(map[string]string)
+	for _, r := range sarif.Runs[0].Tool.Driver.Rules {
+		rules[r.ID] = r.ShortDescription.Text
+	}
+	require.Equal(t, "Table was dropped", rules["DS102"])
+	require.Len(t, sarif.Runs[0].Results, 2)
+	require.JSONEq(t, `{"ruleId":"DS102","level":"warning","message":{"text":"Dropping table \"t\""},"locations":[{"physicalLocation":{"artifactLocation":{"uri":"migrations/2.sql"},"region":{"startLine":2}}}]}`, string(sarif.Runs[0].Results[0]))
+	require.JSONEq(t, `{"level":"error","message":{"text":"executing statement: table not found"},"locations":[{"physicalLocation":{"artifactLocation":{"uri":"migrations/3.sql"},"region":{"startLine":1}}}]}`, string(sarif.Runs[0].Results[1]))
+
+	// JUnit.
+	b.Reset()
+	w, ok = lint.WriterFor(lint.FormatJUnit, &b)
+	require.True(t, ok)
+	require.NoError(t, w.WriteReport(sum))
+	require.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="atlas migrate lint" tests="3" failures="1" errors="1">
+  <testsuite name="migrations/1.sql" tests="1" failures="0" errors="0">
+    <testcase name="lint" classname="migrations/1.sql"></testcase>
+  </testsuite>
+  <testsuite name="migrations/2.sql" tests="1" failures="1" errors="0">
+    <testcase name="L2: DS102" classname="migrations/2.sql">
+      <failure message="Dropping table &#34;t&#34;" type="DS102">migrations/2.sql:2: destructive changes detected: Dropping table &#34;t&#34;</failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="migrations/3.sql" tests="1" failures="0" errors="1">
+    <testcase name="lint" classname="migrations/3.sql">
+      <error message="executing statement: table not found">executing statement: table not found</error>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, b.String())
+
+	// GitLab Code Quality.
+	b.Reset()
+	w, ok = lint.WriterFor(lint.FormatCodeClimate, &b)
+	require.True(t, ok)
+	require.NoError(t, w.WriteReport(sum))
+	var issues []struct {
+		CheckName   string `json:"check_name"`
+		Description string `json:"description"`
+		Severity    string `json:"severity"`
+		Fingerprint string `json:"fingerprint"`
+		Location    struct {
+			Path  string `json:"path"`
+			Lines struct {
+				Begin int `json:"begin"`
+			} `json:"lines"`
+		} `json:"location"`
+	}
+	require.NoError(t, json.Unmarshal(b.Bytes(), &issues))
+	require.Len(t, issues, 2)
+	require.Equal(t, "DS102", issues[0].CheckName)
+	require.Equal(t, `Dropping table "t"`, issues[0].Description)
+	require.Equal(t, "minor", issues[0].Severity)
+	require.Equal(t, "migrations/2.sql", issues[0].Location.Path)
+	require.Equal(t, 2, issues[0].Location.Lines.Begin)
+	require.Equal(t, "critical", issues[1].Severity)
+	require.Equal(t, "migrations/3.sql", issues[1].Location.Path)
+	require.NotEqual(t, issues[0].Fingerprint, issues[1].Fingerprint)
+
+	// Severity levels are mapped to the levels of each format.
+	sum.Files[1].Reports[0].Diagnostics[0].Severity = sqlcheck.SeverityInfo
+	b.Reset()
+	w, _ = lint.WriterFor(lint.FormatSARIF, &b)
+	require.NoError(t, w.WriteReport(sum))
+	require.Contains(t, b.String(), `"level": "note"`)
+	b.Reset()
+	w, _ = lint.WriterFor(lint.FormatJUnit, &b)
+	require.NoError(t, w.WriteReport(sum))
+	require.Contains(t, b.String(), `<testcase name="L2: DS102" classname="migrations/2.sql"></testcase>`)
+	b.Reset()
+	w, _ = lint.WriterFor(lint.FormatCodeClimate, &b)
+	require.NoError(t, w.WriteReport(sum))
+	require.Contains(t, b.String(), `"severity": "info"`)
+
+	// Empty reports are valid documents.
+	b.Reset()
+	w, _ = lint.WriterFor(lint.FormatCodeClimate, &b)
+	require.NoError(t, w.WriteReport(&lint.SummaryReport{}))
+	require.Equal(t, "[]\n", b.String())
+}
@@ -245,6 +245,33 @@ func (d *DevLoader) LoadChanges(ctx context.Context, base, files []migrate.File)
 	return diff, nil
 }
 
+// PlanFile returns a synthetic file for analyzing the changes of a plan that is not
+// part of a migration directory (e.g., 'schema apply'). Each planned statement is
+// mapped to the schema change it was created from, and sum holds all changes.
+func PlanFile(c *sqlclient.Client, name string, plan *migrate.Plan, sum schema.Changes) *sqlcheck.File {
+	var (
+		b    strings.Builder
+		seen = make(map[schema.Change]bool)
+		f    = &sqlcheck.File{Sum: sum, Parser: sqlparse.ParserFor(c.Name)}
+	)
+	for _, c := range plan.Changes {
+		sc := &sqlcheck.Change{
+			Stmt: &migrate.Stmt{Pos: b.Len(), Text: c.Cmd + ";"},
+		}
+		// A schema change can be planned as multiple statements.
+		// Attach it only to the first one to avoid duplicate reports.
+		if c.Source != nil && !seen[c.Source] {
+			seen[c.Source] = true
+			sc.Changes = schema.Changes{c.Source}
+		}
+		f.Changes = append(f.Changes, sc)
+		b.WriteString(sc.Stmt.Text)
+		b.WriteString("\n")
+	}
+	f.File = migrate.NewLocalFile(name, []byte(b.String()))
+	return f
+}
+
 // mayFix uses the sqlparse package for fixing or attaching more info to the changes.
 func (d *DevLoader) mayFix(stmt string, changes schema.Changes) schema.Changes {
 	p := sqlparse.ParserFor(d.Dev.Name)
@@ -209,6 +209,31 @@ func (t testDir) Files() ([]migrate.File, error) {
 	return t.files, nil
 }
 
+func TestPlanFile(t *testing.T) {
+	var (
+		users = schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "int"))
+		drop  = &schema.DropTable{T: users}
+		plan  = &migrate.Plan{
+			Changes: []*migrate.Change{
+				{Cmd: "PRAGMA foreign_keys = off"},
+				{Cmd: "DROP TABLE `users`", Source: drop},
+				{Cmd: "PRAGMA foreign_keys = on", Source: drop},
+			},
+		}
+	)
+	f := lint.PlanFile(&sqlclient.Client{Name: "sqlite3"}, "schema_apply.sql", plan, schema.Changes{drop})
+	require.Equal(t, "schema_apply.sql", f.Name())
+	require.Equal(t, "PRAGMA foreign_keys = off;\nDROP TABLE `users`;\nPRAGMA foreign_keys = on;\n", string(f.Bytes()))
+	require.Equal(t, schema.Changes{drop}, f.Sum)
+	require.Len(t, f.Changes, 3)
+	require.Equal(t, 27, f.Changes[1].Stmt.Pos)
+	require.Equal(t, "DROP TABLE `users`;", f.Changes[1].Stmt.Text)
+	require.Empty(t, f.Changes[0].Changes)
+	require.Equal(t, schema.Changes{drop}, f.Changes[1].Changes)
+	// The source change is attached only to its first statement.
+	require.Empty(t, f.Changes[2].Changes)
+}
+
 type testFile struct {
 	migrate.File
 	name, content string
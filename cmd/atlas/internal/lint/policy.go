@@ -0,0 +1,185 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package lint
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"ariga.io/atlas/sql/sqlcheck"
+)
+
+type (
+	// Policy configures how diagnostics reported by the analyzers
+	// are treated by the runner. A nil Policy is a valid policy that
+	// keeps the reports and errors of the analyzers as is.
+	Policy struct {
+		// Severity maps diagnostic codes to severity levels. For example, DS102 => "warning".
+		// Codes with "error" severity fail the analysis, and codes with "warning" or "info"
+		// severity never fail it, regardless of the analyzer configuration.
+		Severity map[string]string
+
+		// Baseline holds diagnostics that were recorded before
+		// and should not be reported in the analysis.
+		Baseline *Baseline
+	}
+
+	// Baseline holds a list of recorded diagnostics. It allows adopting lint
+	// in existing migration directories by reporting only new diagnostics.
+	Baseline struct {
+		Diagnostics []BaselineDiagnostic `json:"Diagnostics"`
+	}
+
+	// BaselineDiagnostic is a diagnostic recorded in a baseline. Diagnostics are
+	// matched by their file, code and text, and not by their position, in order
+	// to keep the baseline valid in case a file was edited.
+	BaselineDiagnostic struct {
+		File string `json:"File"`           // Name of the file.
+		Code string `json:"Code,omitempty"` // Code of the diagnostic.
+		Text string `json:"Text"`           // Text of the diagnostic.
+	}
+
+	// A BaselineWriter is a type of writer that records all
+	// diagnostics of the summary report in a baseline file.
+	BaselineWriter struct {
+		Path string    // Path to the baseline file.
+		W    io.Writer // Writer for the command output.
+	}
+)
+
+// ValidSeverity reports if the given severity level is valid.
+func ValidSeverity(s string) bool {
+	switch s {
+	case sqlcheck.SeverityError, sqlcheck.SeverityWarning, sqlcheck.SeverityInfo:
+		return true
+	default:
+		return false
+	}
+}
+
+// NewBaseline returns a new Baseline from the diagnostics of the given summary.
+func NewBaseline(sum *SummaryReport) *Baseline {
+	b := &Baseline{Diagnostics: make([]BaselineDiagnostic, 0)}
+	for _, f := range sum.Files {
+		for _, r := range f.Reports {
+			for _, d := range r.Diagnostics {
+				if bd := (BaselineDiagnostic{File: f.Name, Code: d.Code, Text: d.Text}); !b.Has(bd) {
+					b.Diagnostics = append(b.Diagnostics, bd)
+				}
+			}
+		}
+	}
+	return b
+}
+
+// ReadBaseline reads a Baseline from the given path.
+func ReadBaseline(path string) (*Baseline, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading baseline file: %w", err)
+	}
+	var b Baseline
+	if err := json.Unmarshal(buf, &b); err != nil {
+		return nil, fmt.Errorf("parsing baseline file %q: %w", path, err)
+	}
+	return &b, nil
+}
+
+// WriteFile writes the baseline to the given path.
+func (b *Baseline) WriteFile(path string) error {
+	buf, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(buf, '\n'), 0644)
+}
+
+// Has reports if the given diagnostic was recorded in the baseline.
+func (b *Baseline) Has(d BaselineDiagnostic) bool {
+	if b == nil {
+		return false
+	}
+	for _, bd := range b.Diagnostics {
+		if bd == d {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteReport implements ReportWriter.
+func (w *BaselineWriter) WriteReport(r *SummaryReport) error {
+	b := NewBaseline(r)
+	if err := b.WriteFile(w.Path); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w.W, "%d diagnostics were recorded in baseline file %q\n", len(b.Diagnostics), w.Path)
+	return err
+}
+
+// policyReporter applies the policy on the reports
+// written by an analyzer and tracks their results.
+type policyReporter struct {
+	p    *Policy
+	rw   sqlcheck.ReportWriter
+	file string
+	// Set by WriteReport.
+	called   bool     // reports were written by the analyzer
+	reported bool     // diagnostics were kept after applying the policy
+	demoted  bool     // all kept diagnostics have a non-error severity
+	raised   []string // texts of reports with diagnostics raised to errors
+}
+
+func (p *Policy) reporterFor(rw sqlcheck.ReportWriter, file string) *policyReporter {
+	return &policyReporter{p: p, rw: rw, file: file, demoted: true}
+}
+
+// WriteReport implements sqlcheck.ReportWriter.
+func (r *policyReporter) WriteReport(rp sqlcheck.Report) {
+	r.called = true
+	ds := make([]sqlcheck.Diagnostic, 0, len(rp.Diagnostics))
+	for _, d := range rp.Diagnostics {
+		if r.p != nil && r.p.Baseline.Has(BaselineDiagnostic{File: r.file, Code: d.Code, Text: d.Text}) {
+			continue
+		}
+		if r.p != nil && r.p.Severity[d.Code] != "" {
+			d.Severity = r.p.Severity[d.Code]
+		}
+		switch d.Severity {
+		case sqlcheck.SeverityError:
+			if len(r.raised) == 0 || r.raised[len(r.raised)-1] != rp.Text {
+				r.raised = append(r.raised, rp.Text)
+			}
+			r.demoted = false
+		case "":
+			r.demoted = false
+		}
+		ds = append(ds, d)
+	}
+	if len(ds) > 0 {
+		r.reported = true
+		r.rw.WriteReport(sqlcheck.Report{Text: rp.Text, Diagnostics: ds})
+	}
+}
+
+// err returns the error of the analysis after applying the policy
+// on the error returned by the analyzer.
+func (r *policyReporter) err(err error) error {
+	switch {
+	// All reported diagnostics were ignored or demoted.
+	case err != nil && r.called && (!r.reported || r.demoted):
+		return nil
+	case err != nil:
+		return err
+	case len(r.raised) > 0:
+		return errors.New(strings.Join(r.raised, "; "))
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package lint_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"ariga.io/atlas/cmd/atlas/internal/lint"
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/sqlcheck"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyze_Policy(t *testing.T) {
+	var (
+		ctx = context.Background()
+		f   = &sqlcheck.File{
+			File: migrate.NewLocalFile("1.sql", []byte("DROP TABLE users;\nDROP TABLE pets;")),
+			Changes: []*sqlcheck.Change{
+				{Stmt: &migrate.Stmt{Pos: 0, Text: "DROP TABLE users;"}},
+				{Stmt: &migrate.Stmt{Pos: 18, Text: "DROP TABLE pets;"}},
+			},
+		}
+		destructive = &policyAnalyzer{
+			err: errors.New("destructive changes detected"),
+			report: sqlcheck.Report{
+				Text: "destructive changes detected",
+				Diagnostics: []sqlcheck.Diagnostic{
+					{Pos: 0, Code: "DS102", Text: `Dropping table "users"`},
+					{Pos: 18, Code: "DS102", Text: `Dropping table "pets"`},
+				},
+			},
+		}
+		naming = &policyAnalyzer{
+			report: sqlcheck.Report{
+				Text: "naming violations detected",
+				Diagnostics: []sqlcheck.Diagnostic{
+					{Pos: 0, Code: "NM102", Text: `Table named "users" violates the naming policy`},
+				},
+			},
+		}
+		azs = []sqlcheck.Analyzer{destructive, naming}
+	)
+
+	// No policy.
+	r := lint.Analyze(ctx, nil, f, azs, nil)
+	require.Equal(t, "destructive changes detected", r.Error)
+	require.Len(t, r.Reports, 2)
+	require.Empty(t, r.Reports[0].Diagnostics[0].Severity)
+
+	// Demote and raise diagnostics.
+	r = lint.Analyze(ctx, nil, f, azs, &lint.Policy{
+		Severity: map[string]string{"DS102": sqlcheck.SeverityWarning, "NM102": sqlcheck.SeverityError},
+	})
+	require.Equal(t, "naming violations detected", r.Error)
+	require.Len(t, r.Reports, 2)
+	require.Equal(t, sqlcheck.SeverityWarning, r.Reports[0].Diagnostics[0].Severity)
+	require.Equal(t, sqlcheck.SeverityError, r.Reports[1].Diagnostics[0].Severity)
+
+	// Informational diagnostics never fail.
+	r = lint.Analyze(ctx, nil, f, azs, &lint.Policy{
+		Severity: map[string]string{"DS102": sqlcheck.SeverityInfo},
+	})
+	require.Empty(t, r.Error)
+
+	// Baseline diagnostics are not reported.
+	b := &lint.Baseline{
+		Diagnostics: []lint.BaselineDiagnostic{
+			{File: "1.sql", Code: "DS102", Text: `Dropping table "users"`},
+		},
+	}
+	r = lint.Analyze(ctx, nil, f, azs, &lint.Policy{Baseline: b})
+	require.Equal(t, "destructive changes detected", r.Error, "new diagnostics fail the analysis")
+	require.Equal(t, []sqlcheck.Diagnostic{{Pos: 18, Code: "DS102", Text: `Dropping table "pets"`}}, r.Reports[0].Diagnostics)
+	b.Diagnostics = append(b.Diagnostics, lint.BaselineDiagnostic{File: "1.sql", Code: "DS102", Text: `Dropping table "pets"`})
+	r = lint.Analyze(ctx, nil, f, azs, &lint.Policy{Baseline: b})
+	require.Empty(t, r.Error)
+	require.Len(t, r.Reports, 1)
+	require.Equal(t, "naming violations detected", r.Reports[0].Text)
+
+	// Diagnostics of other files are not matched.
+	b.Diagnostics = []lint.BaselineDiagnostic{
+		{File: "2.sql", Code: "DS102", Text: `Dropping table "users"`},
+		{File: "2.sql", Code: "DS102", Text: `Dropping table "pets"`},
+	}
+	r = lint.Analyze(ctx, nil, f, azs, &lint.Policy{Baseline: b})
+	require.Equal(t, "destructive changes detected", r.Error)
+}
+
+func TestBaselineWriter(t *testing.T) {
+	var (
+		out  bytes.Buffer
+		path = filepath.Join(t.TempDir(), "baseline.json")
+		w    = &lint.BaselineWriter{Path: path, W: &out}
+	)
+	err := w.WriteReport(&lint.SummaryReport{
+		Files: []*lint.FileReport{
+			{
+				Name: "1.sql",
+				Reports: []sqlcheck.Report{
+					{
+						Text: "destructive changes detected",
+						Diagnostics: []sqlcheck.Diagnostic{
+							{Pos: 0, Code: "DS102", Text: `Dropping table "users"`},
+							{Pos: 18, Code: "DS102", Text: `Dropping table "users"`},
+						},
+					},
+				},
+			},
+			{Name: "2.sql"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "1 diagnostics were recorded in baseline file \""+path+"\"\n", out.String())
+	b, err := lint.ReadBaseline(path)
+	require.NoError(t, err)
+	require.Equal(t, []lint.BaselineDiagnostic{{File: "1.sql", Code: "DS102", Text: `Dropping table "users"`}}, b.Diagnostics)
+
+	_, err = lint.ReadBaseline(filepath.Join(t.TempDir(), "unknown.json"))
+	require.Error(t, err)
+}
+
+type policyAnalyzer struct {
+	report sqlcheck.Report
+	err    error
+}
+
+func (a *policyAnalyzer) Analyze(_ context.Context, p *sqlcheck.Pass) error {
+	p.Reporter.WriteReport(a.report)
+	return a.err
+}
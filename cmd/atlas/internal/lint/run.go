@@ -40,6 +40,10 @@ type Runner struct {
 	// ReportWriter writes the summary report.
 	ReportWriter ReportWriter
 
+	// Policy configures the severity levels and the
+	// baseline of the diagnostics. Optional.
+	Policy *Policy
+
 	// summary report. reset on each run.
 	sum *SummaryReport
 }
@@ -51,6 +55,11 @@ func (r *Runner) Run(ctx context.Context) error {
 		if err := r.ReportWriter.WriteReport(r.sum); err != nil {
 			return err
 		}
+		// Recording a baseline accepts all
+		// diagnostics found in the analysis.
+		if _, ok := r.ReportWriter.(*BaselineWriter); ok {
+			return nil
+		}
 		// If any of the analyzers returns
 		// an error, fail silently.
 		for _, f := range r.sum.Files {
@@ -60,6 +69,10 @@ func (r *Runner) Run(ctx context.Context) error {
 		}
 		return nil
 	case *FileError:
+		// Avoid recording a partial baseline.
+		if _, ok := r.ReportWriter.(*BaselineWriter); ok {
+			return err
+		}
 		if err := r.ReportWriter.WriteReport(r.sum); err != nil {
 			return err
 		}
@@ -114,27 +127,10 @@ func (r *Runner) summary(ctx context.Context) error {
 
 	// Analyze files.
 	for _, f := range diff.Files {
-		var (
-			es []string
-			nl = nolintRules(f)
-			fr = NewFileReport(f)
-		)
-		if nl.ignored {
+		fr := Analyze(ctx, r.Dev, f, r.Analyzers, r.Policy)
+		if fr == nil {
 			continue
 		}
-		for _, az := range r.Analyzers {
-			err := az.Analyze(ctx, &sqlcheck.Pass{
-				File:     f,
-				Dev:      r.Dev,
-				Reporter: nl.reporterFor(fr, az),
-			})
-			// If the last report was skipped,
-			// skip emitting its error.
-			if err != nil && !nl.skipped {
-				es = append(es, err.Error())
-			}
-		}
-		fr.Error = strings.Join(es, "; ")
 		r.sum.Files = append(r.sum.Files, fr)
 		r.sum.StepResult(
 			fmt.Sprintf(stepAnalyzeFile, f.Name()),
@@ -145,6 +141,36 @@ func (r *Runner) summary(ctx context.Context) error {
 	return nil
 }
 
+// Analyze runs the analyzers on the given file and returns their reports. Errors
+// returned by the analyzers are joined into the Error field of the report. A nil
+// report is returned in case the file is ignored by an atlas:nolint directive.
+// The given policy, if not nil, is applied on the diagnostics and the errors.
+func Analyze(ctx context.Context, dev *sqlclient.Client, f *sqlcheck.File, azs []sqlcheck.Analyzer, p *Policy) *FileReport {
+	var (
+		es []string
+		nl = nolintRules(f)
+		fr = NewFileReport(f)
+	)
+	if nl.ignored {
+		return nil
+	}
+	for _, az := range azs {
+		pr := p.reporterFor(fr, f.Name())
+		err := az.Analyze(ctx, &sqlcheck.Pass{
+			File:     f,
+			Dev:      dev,
+			Reporter: nl.reporterFor(pr, az),
+		})
+		// If the last report was skipped,
+		// skip emitting its error.
+		if err = pr.err(err); err != nil && !nl.skipped {
+			es = append(es, err.Error())
+		}
+	}
+	fr.Error = strings.Join(es, "; ")
+	return fr
+}
+
 var (
 	// TemplateFuncs are global functions available in templates.
 	TemplateFuncs = template.FuncMap{
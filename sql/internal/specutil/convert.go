@@ -5,6 +5,7 @@
 package specutil
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -23,10 +24,13 @@ type (
 	ConvertTableColumnFunc func(*sqlspec.Column, *schema.Table) (*schema.Column, error)
 	ConvertViewFunc        func(*sqlspec.View, *schema.Schema) (*schema.View, error)
 	ConvertViewColumnFunc  func(*sqlspec.Column, *schema.View) (*schema.Column, error)
+	ConvertTriggerFunc     func(*sqlspec.Trigger, *schema.Realm) (*schema.Trigger, error)
 	ConvertTypeFunc        func(*sqlspec.Column) (schema.Type, error)
+	ConvertFuncTypeFunc    func(*schemahcl.Type) (schema.Type, error)
 	ConvertPrimaryKeyFunc  func(*sqlspec.PrimaryKey, *schema.Table) (*schema.Index, error)
 	ConvertIndexFunc       func(*sqlspec.Index, *schema.Table) (*schema.Index, error)
 	ConvertCheckFunc       func(*sqlspec.Check) (*schema.Check, error)
+	ConvertForeignKeyFunc  func(*sqlspec.ForeignKey, *schema.ForeignKey) error
 	ColumnTypeSpecFunc     func(schema.Type) (*sqlspec.Column, error)
 	TableSpecFunc          func(*schema.Table) (*sqlspec.Table, error)
 	TableColumnSpecFunc    func(*schema.Column, *schema.Table) (*sqlspec.Column, error)
@@ -36,20 +40,29 @@ type (
 	IndexSpecFunc          func(*schema.Index) (*sqlspec.Index, error)
 	ForeignKeySpecFunc     func(*schema.ForeignKey) (*sqlspec.ForeignKey, error)
 	CheckSpecFunc          func(*schema.Check) *sqlspec.Check
+	FuncTypeSpecFunc       func(schema.Type) (*schemahcl.Type, error)
 )
 
 type (
 	// ScanDoc represents a scanned HCL document.
 	ScanDoc struct {
-		Schemas []*sqlspec.Schema
-		Tables  []*sqlspec.Table
-		Views   []*sqlspec.View
+		Schemas  []*sqlspec.Schema
+		Tables   []*sqlspec.Table
+		Views    []*sqlspec.View
+		Triggers []*sqlspec.Trigger
+		// Materialized views are supported only by some drivers.
+		Materialized []*sqlspec.View
 	}
 	// ScanFuncs represents a set of scan functions
 	// used to convert the HCL document to the Realm.
 	ScanFuncs struct {
-		Table ConvertTableFunc
-		View  ConvertViewFunc
+		Table        ConvertTableFunc
+		View         ConvertViewFunc
+		Trigger      ConvertTriggerFunc
+		Materialized ConvertViewFunc
+		// ForeignKey is an optional function for converting the
+		// driver-specific attributes of the linked foreign keys.
+		ForeignKey ConvertForeignKeyFunc
 	}
 )
 
@@ -83,31 +96,43 @@ func Scan(r *schema.Realm, doc *ScanDoc, funcs *ScanFuncs) error {
 	}
 	// Link the foreign keys.
 	for t, fks := range tableFKs {
-		if err := linkForeignKeys(t, fks); err != nil {
+		if err := linkForeignKeys(t, fks, funcs.ForeignKey); err != nil {
 			return err
 		}
 	}
-	viewDeps := make(map[*schema.View][]*schemahcl.Ref, len(doc.Views))
-	for _, sv := range doc.Views {
-		name, err := SchemaName(sv.Schema)
-		if err != nil {
-			return fmt.Errorf("specutil: cannot extract schema name for view %q: %w", sv.Name, err)
+	viewDeps := make(map[*schema.View][]*schemahcl.Ref, len(doc.Views)+len(doc.Materialized))
+	for _, vs := range []struct {
+		typ     string
+		specs   []*sqlspec.View
+		convert ConvertViewFunc
+	}{
+		{typ: "view", specs: doc.Views, convert: funcs.View},
+		{typ: "materialized", specs: doc.Materialized, convert: funcs.Materialized},
+	} {
+		if len(vs.specs) > 0 && vs.convert == nil {
+			return fmt.Errorf("specutil: %s blocks are not supported", vs.typ)
 		}
-		s, ok := byName[name]
-		if !ok {
-			return fmt.Errorf("specutil: schema %q not found for view %q", name, sv.Name)
-		}
-		v, err := funcs.View(sv, s)
-		if err != nil {
-			return fmt.Errorf("specutil: cannot convert view %q: %w", sv.Name, err)
-		}
-		s.AddViews(v)
-		if deps, ok := sv.Attr("depends_on"); ok {
-			refs, err := deps.Refs()
+		for _, sv := range vs.specs {
+			name, err := SchemaName(sv.Schema)
 			if err != nil {
-				return fmt.Errorf("specutil: expect list of references for attribute view.%s.depends_on: %w", sv.Name, err)
+				return fmt.Errorf("specutil: cannot extract schema name for %s %q: %w", vs.typ, sv.Name, err)
+			}
+			s, ok := byName[name]
+			if !ok {
+				return fmt.Errorf("specutil: schema %q not found for %s %q", name, vs.typ, sv.Name)
+			}
+			v, err := vs.convert(sv, s)
+			if err != nil {
+				return fmt.Errorf("specutil: cannot convert %s %q: %w", vs.typ, sv.Name, err)
+			}
+			s.AddViews(v)
+			if deps, ok := sv.Attr("depends_on"); ok {
+				refs, err := deps.Refs()
+				if err != nil {
+					return fmt.Errorf("specutil: expect list of references for attribute %s.%s.depends_on: %w", vs.typ, sv.Name, err)
+				}
+				viewDeps[v] = refs
 			}
-			viewDeps[v] = refs
 		}
 	}
 	// Link views' dependencies.
@@ -118,8 +143,8 @@ func Scan(r *schema.Realm, doc *ScanDoc, funcs *ScanFuncs) error {
 				return fmt.Errorf("specutil: extract reference for view.%s: %w", v.Name, err)
 			case len(p) == 0:
 				return fmt.Errorf("specutil: empty reference for view.%s", v.Name)
-			case p[0].T == "view":
-				q, n, err := viewName(r)
+			case p[0].T == "view" || p[0].T == "materialized":
+				q, n, err := refName(r, p[0].T)
 				if err != nil {
 					return fmt.Errorf("specutil: extract view name from view.%s.depends_on[%d]: %w", v.Name, i, err)
 				}
@@ -145,6 +170,19 @@ func Scan(r *schema.Realm, doc *ScanDoc, funcs *ScanFuncs) error {
 			}
 		}
 	}
+	// Triggers are converted after all tables and views were created.
+	for _, st := range doc.Triggers {
+		t, err := funcs.Trigger(st, r)
+		if err != nil {
+			return fmt.Errorf("specutil: cannot convert trigger %q: %w", st.Name, err)
+		}
+		switch {
+		case t.Table != nil:
+			t.Table.AddTriggers(t)
+		case t.View != nil:
+			t.View.AddTriggers(t)
+		}
+	}
 	return nil
 }
 
@@ -188,6 +226,9 @@ func Table(spec *sqlspec.Table, parent *schema.Schema, convertColumn ConvertTabl
 	if err := convertCommentFromSpec(spec, &tbl.Attrs); err != nil {
 		return nil, err
 	}
+	if err := convertRenamedFromSpec(spec, &tbl.Attrs); err != nil {
+		return nil, err
+	}
 	return tbl, nil
 }
 
@@ -222,6 +263,354 @@ func View(spec *sqlspec.View, parent *schema.Schema, convertColumn ConvertViewCo
 	return v, nil
 }
 
+// Trigger converts a sqlspec.Trigger to a schema.Trigger. The trigger is linked to the
+// table or view it is defined on, but it is not added to its triggers list.
+func Trigger(spec *sqlspec.Trigger, r *schema.Realm) (*schema.Trigger, error) {
+	if spec.On == nil {
+		return nil, fmt.Errorf("specutil: missing 'on' definition for trigger %q", spec.Name)
+	}
+	if len(r.Schemas) == 0 {
+		return nil, fmt.Errorf("specutil: missing schema for trigger %q", spec.Name)
+	}
+	t := schema.NewTrigger(spec.Name)
+	switch p, err := spec.On.Path(); {
+	case err != nil:
+		return nil, fmt.Errorf("specutil: extract reference for trigger.%s.on: %w", spec.Name, err)
+	case len(p) == 0:
+		return nil, fmt.Errorf("specutil: empty reference for trigger.%s.on", spec.Name)
+	case p[0].T == "table":
+		q, n, err := tableName(spec.On)
+		if err != nil {
+			return nil, fmt.Errorf("specutil: extract table name from trigger.%s.on: %w", spec.Name, err)
+		}
+		if t.Table, err = findT(r.Schemas[0], q, n, func(s *schema.Schema, name string) (*schema.Table, bool) {
+			return s.Table(name)
+		}); err != nil {
+			return nil, fmt.Errorf("specutil: find table reference for trigger.%s.on: %w", spec.Name, err)
+		}
+	case p[0].T == "view":
+		q, n, err := viewName(spec.On)
+		if err != nil {
+			return nil, fmt.Errorf("specutil: extract view name from trigger.%s.on: %w", spec.Name, err)
+		}
+		if t.View, err = findT(r.Schemas[0], q, n, func(s *schema.Schema, name string) (*schema.View, bool) {
+			return s.View(name)
+		}); err != nil {
+			return nil, fmt.Errorf("specutil: find view reference for trigger.%s.on: %w", spec.Name, err)
+		}
+	default:
+		return nil, fmt.Errorf("specutil: expect reference to a table or a view for trigger.%s.on, got %q", spec.Name, p[0].T)
+	}
+	var timing *schemahcl.Resource
+	for _, at := range []schema.TriggerTime{schema.TriggerTimeBefore, schema.TriggerTimeAfter, schema.TriggerTimeInstead} {
+		b, ok := spec.Extra.Resource(triggerTimeBlock(at))
+		if !ok {
+			continue
+		}
+		if timing != nil {
+			return nil, fmt.Errorf("specutil: multiple action times were defined for trigger %q", spec.Name)
+		}
+		timing, t.ActionTime = b, at
+	}
+	if timing == nil {
+		return nil, fmt.Errorf("specutil: missing action time block (before, after or instead_of) for trigger %q", spec.Name)
+	}
+	for _, e := range []schema.TriggerEvent{schema.TriggerEventInsert, schema.TriggerEventUpdate, schema.TriggerEventDelete, schema.TriggerEventTruncate} {
+		k := strings.ToLower(e.Name)
+		if a, ok := timing.Attr(k); ok {
+			b, err := a.Bool()
+			if err != nil {
+				return nil, fmt.Errorf("specutil: expect bool value for attribute trigger.%s.%s.%s: %w", spec.Name, timing.Type, k, err)
+			}
+			if b {
+				t.AddEvents(e)
+			}
+		}
+		if e.Name != schema.TriggerEventUpdate.Name {
+			continue
+		}
+		a, ok := timing.Attr("update_of")
+		if !ok {
+			continue
+		}
+		if len(t.Events) > 0 && t.Events[len(t.Events)-1].Name == e.Name {
+			return nil, fmt.Errorf("specutil: update and update_of cannot be both defined for trigger %q", spec.Name)
+		}
+		if t.Table == nil {
+			return nil, fmt.Errorf("specutil: update_of is supported only for table triggers: %q", spec.Name)
+		}
+		refs, err := a.Refs()
+		if err != nil {
+			return nil, fmt.Errorf("specutil: expect list of references for attribute trigger.%s.%s.update_of: %w", spec.Name, timing.Type, err)
+		}
+		columns := make([]*schema.Column, 0, len(refs))
+		for _, ref := range refs {
+			c, err := ColumnByRef(t.Table, ref)
+			if err != nil {
+				return nil, fmt.Errorf("specutil: find column reference for trigger.%s.%s.update_of: %w", spec.Name, timing.Type, err)
+			}
+			columns = append(columns, c)
+		}
+		t.AddEvents(schema.TriggerEventUpdateOf(columns...))
+	}
+	if len(t.Events) == 0 {
+		return nil, fmt.Errorf("specutil: missing events for trigger %q", spec.Name)
+	}
+	if a, ok := spec.Extra.Attr("for"); ok {
+		f, err := a.String()
+		if err != nil {
+			return nil, fmt.Errorf("specutil: expect string definition for attribute trigger.%s.for: %w", spec.Name, err)
+		}
+		t.SetFor(schema.TriggerFor(FromVar(f)))
+	}
+	if a, ok := spec.Extra.Attr("when"); ok {
+		w, err := a.String()
+		if err != nil {
+			return nil, fmt.Errorf("specutil: expect string definition for attribute trigger.%s.when: %w", spec.Name, err)
+		}
+		t.SetWhen(w)
+	}
+	as, ok := spec.Extra.Attr("as")
+	if !ok {
+		return nil, fmt.Errorf("specutil: missing 'as' definition for trigger %q", spec.Name)
+	}
+	body, err := as.String()
+	if err != nil {
+		return nil, fmt.Errorf("specutil: expect string definition for attribute trigger.%s.as: %w", spec.Name, err)
+	}
+	// Heredoc definitions end with a newline.
+	t.SetBody(strings.TrimSpace(body))
+	if err := convertCommentFromSpec(spec, &t.Attrs); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Roles converts the role and user specs to schema.Roles and adds them to the realm. Grants
+// on objects that are not schemas, tables or views (e.g. sequences) are resolved using the
+// given function, if it is not nil. Roles are converted after all other realm objects, as
+// their grants reference them.
+func Roles(r *schema.Realm, roles, users []*sqlspec.Role, objectRef func(*schemahcl.Ref) (schema.Object, error)) error {
+	specs := make([]*sqlspec.Role, 0, len(roles)+len(users))
+	specs = append(append(specs, roles...), users...)
+	added := make([]*schema.Role, 0, len(specs))
+	for i, spec := range specs {
+		if _, ok := r.Role(spec.Name); ok {
+			return fmt.Errorf("specutil: duplicate role %q", spec.Name)
+		}
+		ro := schema.NewRole(spec.Name)
+		if i >= len(roles) {
+			ro = schema.NewUser(spec.Name)
+		}
+		r.AddRoles(ro)
+		added = append(added, ro)
+	}
+	for i, spec := range specs {
+		ro := added[i]
+		for _, ref := range spec.MemberOf {
+			m, err := roleByRef(r, ref)
+			if err != nil {
+				return fmt.Errorf("specutil: find member_of reference for role %q: %w", spec.Name, err)
+			}
+			ro.AddMemberOf(m)
+		}
+		for _, gs := range spec.Grants {
+			g, err := grant(r, gs, objectRef)
+			if err != nil {
+				return fmt.Errorf("specutil: convert grant of role %q: %w", spec.Name, err)
+			}
+			ro.AddGrants(g)
+		}
+	}
+	return nil
+}
+
+// roleByRef returns the role or the user referenced by ref.
+func roleByRef(r *schema.Realm, ref *schemahcl.Ref) (*schema.Role, error) {
+	p, err := ref.Path()
+	if err != nil {
+		return nil, err
+	}
+	if len(p) != 1 || p[0].T != "role" && p[0].T != "user" || len(p[0].V) != 1 {
+		return nil, fmt.Errorf("expect reference to a role or a user, got %q", ref.V)
+	}
+	ro, ok := r.Role(p[0].V[0])
+	if !ok {
+		return nil, fmt.Errorf("role %q was not found", p[0].V[0])
+	}
+	return ro, nil
+}
+
+// grant converts a sqlspec.Grant to a schema.Grant.
+func grant(r *schema.Realm, spec *sqlspec.Grant, objectRef func(*schemahcl.Ref) (schema.Object, error)) (*schema.Grant, error) {
+	if spec.On == nil {
+		return nil, errors.New("missing 'on' definition")
+	}
+	if len(spec.Privileges) == 0 {
+		return nil, errors.New("missing privileges")
+	}
+	g := schema.NewGrant(nil, spec.Privileges...).SetGrantOption(spec.GrantOption)
+	switch p, err := spec.On.Path(); {
+	case err != nil:
+		return nil, fmt.Errorf("extract reference for grant.on: %w", err)
+	case len(p) == 0:
+		return nil, errors.New("empty reference for grant.on")
+	case p[0].T == "schema":
+		name, err := SchemaName(spec.On)
+		if err != nil {
+			return nil, err
+		}
+		s, ok := r.Schema(name)
+		if !ok {
+			return nil, fmt.Errorf("schema %q was not found", name)
+		}
+		g.On = s
+	case (p[0].T == "table" || p[0].T == "view") && len(r.Schemas) == 0:
+		return nil, fmt.Errorf("missing schema for %s reference %q", p[0].T, spec.On.V)
+	case p[0].T == "table":
+		q, n, err := tableName(spec.On)
+		if err != nil {
+			return nil, err
+		}
+		if g.On, err = findT(r.Schemas[0], q, n, func(s *schema.Schema, name string) (*schema.Table, bool) {
+			return s.Table(name)
+		}); err != nil {
+			return nil, err
+		}
+	case p[0].T == "view":
+		q, n, err := viewName(spec.On)
+		if err != nil {
+			return nil, err
+		}
+		if g.On, err = findT(r.Schemas[0], q, n, func(s *schema.Schema, name string) (*schema.View, bool) {
+			return s.View(name)
+		}); err != nil {
+			return nil, err
+		}
+	case objectRef != nil:
+		if g.On, err = objectRef(spec.On); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported reference %q for grant.on", spec.On.V)
+	}
+	if len(spec.Columns) > 0 {
+		t, ok := g.On.(*schema.Table)
+		if !ok {
+			return nil, fmt.Errorf("column privileges are supported only on tables, got reference %q", spec.On.V)
+		}
+		columns := make([]*schema.Column, 0, len(spec.Columns))
+		for _, ref := range spec.Columns {
+			c, err := ColumnByRef(t, ref)
+			if err != nil {
+				return nil, err
+			}
+			columns = append(columns, c)
+		}
+		g.SetColumns(columns...)
+	}
+	return g, nil
+}
+
+// Func converts a sqlspec.Func to a schema.Func. The function is
+// not added to the schema objects, as its types may reference
+// objects that were not converted yet.
+func Func(spec *sqlspec.Func, conv ConvertFuncTypeFunc) (*schema.Func, error) {
+	if spec.Return == nil {
+		return nil, fmt.Errorf("specutil: missing return type for function %q", spec.Name)
+	}
+	args, err := funcArgs("function", spec.Name, spec.Args, conv)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := conv(spec.Return)
+	if err != nil {
+		return nil, fmt.Errorf("specutil: convert return type of function %q: %w", spec.Name, err)
+	}
+	lang, body, err := funcDef("function", spec.Name, spec)
+	if err != nil {
+		return nil, err
+	}
+	f := schema.NewFunc(spec.Name).AddArgs(args...).SetReturn(ret).SetLang(lang).SetBody(body)
+	if err := convertCommentFromSpec(spec, &f.Attrs); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Proc converts a sqlspec.Proc to a schema.Proc. Similar to functions,
+// the procedure is not added to the schema objects.
+func Proc(spec *sqlspec.Proc, conv ConvertFuncTypeFunc) (*schema.Proc, error) {
+	args, err := funcArgs("procedure", spec.Name, spec.Args, conv)
+	if err != nil {
+		return nil, err
+	}
+	lang, body, err := funcDef("procedure", spec.Name, spec)
+	if err != nil {
+		return nil, err
+	}
+	p := schema.NewProc(spec.Name).AddArgs(args...).SetLang(lang).SetBody(body)
+	if err := convertCommentFromSpec(spec, &p.Attrs); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// funcArgs converts the arguments of a function or a procedure.
+func funcArgs(kind, name string, specs []*sqlspec.FuncArg, conv ConvertFuncTypeFunc) ([]*schema.FuncArg, error) {
+	args := make([]*schema.FuncArg, 0, len(specs))
+	for _, spec := range specs {
+		if spec.Type == nil {
+			return nil, fmt.Errorf("specutil: missing type for argument %q of %s %q", spec.Name, kind, name)
+		}
+		t, err := conv(spec.Type)
+		if err != nil {
+			return nil, fmt.Errorf("specutil: convert type of argument %q of %s %q: %w", spec.Name, kind, name, err)
+		}
+		a := schema.NewFuncArg(spec.Name, t)
+		if d := spec.Default; !d.IsNull() {
+			x, err := DefaultExpr(d)
+			if err != nil {
+				return nil, fmt.Errorf("specutil: convert default value of argument %q of %s %q: %w", spec.Name, kind, name, err)
+			}
+			a.SetDefault(x)
+		}
+		if m, ok := spec.Attr("mode"); ok {
+			v, err := m.String()
+			if err != nil {
+				return nil, fmt.Errorf("specutil: expect string value for attribute %s.%s.arg.%s.mode: %w", kind, name, spec.Name, err)
+			}
+			a.SetMode(schema.FuncArgMode(strings.ToUpper(v)))
+		}
+		args = append(args, a)
+	}
+	return args, nil
+}
+
+// funcDef returns the language and the definition of a function or a procedure.
+// The language is optional, as some databases (e.g. MySQL) support only SQL routines.
+func funcDef(kind, name string, spec Attrer) (lang, body string, err error) {
+	if l, ok := spec.Attr("lang"); ok {
+		if lang, err = l.String(); err != nil {
+			return "", "", fmt.Errorf("specutil: expect string value for attribute %s.%s.lang: %w", kind, name, err)
+		}
+	}
+	as, ok := spec.Attr("as")
+	if !ok {
+		return "", "", fmt.Errorf("specutil: missing 'as' definition for %s %q", kind, name)
+	}
+	if body, err = as.String(); err != nil {
+		return "", "", fmt.Errorf("specutil: expect string definition for attribute %s.%s.as: %w", kind, name, err)
+	}
+	// Heredoc definitions end with a newline.
+	return lang, strings.TrimSpace(body), nil
+}
+
+// triggerTimeBlock returns the block name of the given trigger action time.
+func triggerTimeBlock(at schema.TriggerTime) string {
+	return strings.ToLower(Var(string(at)))
+}
+
 // Column converts a sqlspec.Column into a schema.Column.
 func Column(spec *sqlspec.Column, conv ConvertTypeFunc) (*schema.Column, error) {
 	out := &schema.Column{
@@ -231,22 +620,11 @@ func Column(spec *sqlspec.Column, conv ConvertTypeFunc) (*schema.Column, error)
 		},
 	}
 	if d := spec.Default; !d.IsNull() {
-		switch {
-		case d.Type() == cty.String:
-			out.Default = &schema.Literal{V: d.AsString()}
-		case d.Type() == cty.Number:
-			out.Default = &schema.Literal{V: d.AsBigFloat().String()}
-		case d.Type() == cty.Bool:
-			out.Default = &schema.Literal{V: strconv.FormatBool(d.True())}
-		case d.Type().IsCapsuleType():
-			x, ok := d.EncapsulatedValue().(*schemahcl.RawExpr)
-			if !ok {
-				return nil, fmt.Errorf("invalid default value %q", d.Type().FriendlyName())
-			}
-			out.Default = &schema.RawExpr{X: x.X}
-		default:
-			return nil, fmt.Errorf("unsupported value type for default: %T", d)
+		x, err := DefaultExpr(d)
+		if err != nil {
+			return nil, err
 		}
+		out.Default = x
 	}
 	ct, err := conv(spec)
 	if err != nil {
@@ -256,9 +634,32 @@ func Column(spec *sqlspec.Column, conv ConvertTypeFunc) (*schema.Column, error)
 	if err := convertCommentFromSpec(spec, &out.Attrs); err != nil {
 		return nil, err
 	}
+	if err := convertRenamedFromSpec(spec, &out.Attrs); err != nil {
+		return nil, err
+	}
 	return out, err
 }
 
+// DefaultExpr converts a spec default value to a schema.Expr.
+func DefaultExpr(d cty.Value) (schema.Expr, error) {
+	switch {
+	case d.Type() == cty.String:
+		return &schema.Literal{V: d.AsString()}, nil
+	case d.Type() == cty.Number:
+		return &schema.Literal{V: d.AsBigFloat().String()}, nil
+	case d.Type() == cty.Bool:
+		return &schema.Literal{V: strconv.FormatBool(d.True())}, nil
+	case d.Type().IsCapsuleType():
+		x, ok := d.EncapsulatedValue().(*schemahcl.RawExpr)
+		if !ok {
+			return nil, fmt.Errorf("invalid default value %q", d.Type().FriendlyName())
+		}
+		return &schema.RawExpr{X: x.X}, nil
+	default:
+		return nil, fmt.Errorf("unsupported value type for default: %T", d)
+	}
+}
+
 // Index converts a sqlspec.Index to a schema.Index. The optional arguments allow
 // passing functions for mutating the created index-part (e.g. add attributes).
 func Index(spec *sqlspec.Index, parent *schema.Table, partFns ...func(*sqlspec.IndexPart, *schema.IndexPart) error) (*schema.Index, error) {
@@ -313,6 +714,9 @@ func Index(spec *sqlspec.Index, parent *schema.Table, partFns ...func(*sqlspec.I
 	if err := convertCommentFromSpec(spec, &i.Attrs); err != nil {
 		return nil, err
 	}
+	if err := convertRenamedFromSpec(spec, &i.Attrs); err != nil {
+		return nil, err
+	}
 	return i, nil
 }
 
@@ -346,7 +750,7 @@ func PrimaryKey(spec *sqlspec.PrimaryKey, parent *schema.Table) (*schema.Index,
 // linkForeignKeys creates the foreign keys defined in the Table's spec by creating references
 // to column in the provided Schema. It is assumed that all tables referenced FK definitions in the spec
 // are reachable from the provided schema or its connected realm.
-func linkForeignKeys(tbl *schema.Table, fks []*sqlspec.ForeignKey) error {
+func linkForeignKeys(tbl *schema.Table, fks []*sqlspec.ForeignKey, convert ConvertForeignKeyFunc) error {
 	for _, spec := range fks {
 		fk := &schema.ForeignKey{Symbol: spec.Symbol, Table: tbl}
 		if spec.OnUpdate != nil {
@@ -380,6 +784,11 @@ func linkForeignKeys(tbl *schema.Table, fks []*sqlspec.ForeignKey) error {
 			fk.RefTable = t
 			fk.RefColumns = append(fk.RefColumns, c)
 		}
+		if convert != nil {
+			if err := convert(spec, fk); err != nil {
+				return fmt.Errorf("sqlspec: converting foreign-key %q: %w", fk.Symbol, err)
+			}
+		}
 		tbl.ForeignKeys = append(tbl.ForeignKeys, fk)
 	}
 	return nil
@@ -391,8 +800,10 @@ func FromSchema(s *schema.Schema, specT TableSpecFunc, specV ViewSpecFunc) (*Sch
 		spec = &sqlspec.Schema{
 			Name: s.Name,
 		}
-		views  = make([]*sqlspec.View, 0, len(s.Views))
-		tables = make([]*sqlspec.Table, 0, len(s.Tables))
+		views        = make([]*sqlspec.View, 0, len(s.Views))
+		tables       = make([]*sqlspec.Table, 0, len(s.Tables))
+		triggers     []*sqlspec.Trigger
+		materialized []*sqlspec.View
 	)
 	for _, t := range s.Tables {
 		table, err := specT(t)
@@ -412,13 +823,37 @@ func FromSchema(s *schema.Schema, specT TableSpecFunc, specV ViewSpecFunc) (*Sch
 		if s.Name != "" {
 			view.Schema = SchemaRef(s.Name)
 		}
-		views = append(views, view)
+		if v.Materialized() {
+			materialized = append(materialized, view)
+		} else {
+			views = append(views, view)
+		}
+	}
+	for _, t := range s.Tables {
+		for _, tr := range t.Triggers {
+			trigger, err := FromTrigger(tr)
+			if err != nil {
+				return nil, err
+			}
+			triggers = append(triggers, trigger)
+		}
+	}
+	for _, v := range s.Views {
+		for _, tr := range v.Triggers {
+			trigger, err := FromTrigger(tr)
+			if err != nil {
+				return nil, err
+			}
+			triggers = append(triggers, trigger)
+		}
 	}
 	convertCommentFromSchema(s.Attrs, &spec.Extra.Attrs)
 	return &SchemaSpec{
-		Schema: spec,
-		Tables: tables,
-		Views:  views,
+		Schema:       spec,
+		Tables:       tables,
+		Views:        views,
+		Triggers:     triggers,
+		Materialized: materialized,
 	}, nil
 }
 
@@ -477,15 +912,9 @@ func FromView(v *schema.View, colFn ViewColumnSpecFunc) (*sqlspec.View, error) {
 		}
 		spec.Columns = append(spec.Columns, cs)
 	}
-	as := v.Def
-	// In case the view definition is multi-line,
-	// format it as indented heredoc with two spaces.
-	if lines := strings.Split(v.Def, "\n"); len(lines) > 1 {
-		as = fmt.Sprintf("<<-SQL\n  %s\n  SQL", strings.Join(lines, "\n  "))
-	}
 	embed := &schemahcl.Resource{
 		Attrs: []*schemahcl.Attr{
-			schemahcl.StringAttr("as", as),
+			SQLAttr("as", v.Def),
 		},
 	}
 	if c := (schema.ViewCheckOption{}); sqlx.Has(v.Attrs, &c) {
@@ -527,8 +956,12 @@ func FromView(v *schema.View, colFn ViewColumnSpecFunc) (*sqlspec.View, error) {
 			if nameV[d.Name] > 1 {
 				path = append(path, d.Schema.Name)
 			}
+			typ := "view"
+			if d.Materialized() {
+				typ = "materialized"
+			}
 			deps = append(deps, schemahcl.BuildRef([]schemahcl.PathIndex{
-				{T: "view", V: append(path, d.Name)},
+				{T: typ, V: append(path, d.Name)},
 			}))
 		}
 	}
@@ -540,6 +973,211 @@ func FromView(v *schema.View, colFn ViewColumnSpecFunc) (*sqlspec.View, error) {
 	return spec, nil
 }
 
+// FromTrigger converts a schema.Trigger to a sqlspec.Trigger.
+func FromTrigger(t *schema.Trigger) (*sqlspec.Trigger, error) {
+	var (
+		s     *schema.Schema
+		path  []string
+		names = make(map[string]int)
+		spec  = &sqlspec.Trigger{Name: t.Name}
+	)
+	switch {
+	case t.Table != nil:
+		s = t.Table.Schema
+	case t.View != nil:
+		s = t.View.Schema
+	default:
+		return nil, fmt.Errorf("specutil: missing table or view for trigger %q", t.Name)
+	}
+	// Qualify the table/view name if there
+	// are multiple tables/views with this name.
+	if s != nil && s.Realm != nil {
+		for _, s := range s.Realm.Schemas {
+			for _, t := range s.Tables {
+				names["table."+t.Name]++
+			}
+			for _, v := range s.Views {
+				names["view."+v.Name]++
+			}
+		}
+	}
+	timing := &schemahcl.Resource{Type: triggerTimeBlock(t.ActionTime)}
+	switch {
+	case t.Table != nil:
+		if names["table."+t.Table.Name] > 1 {
+			path = append(path, s.Name)
+		}
+		spec.On = schemahcl.BuildRef([]schemahcl.PathIndex{{T: "table", V: append(path, t.Table.Name)}})
+	case t.View != nil:
+		if names["view."+t.View.Name] > 1 {
+			path = append(path, s.Name)
+		}
+		spec.On = schemahcl.BuildRef([]schemahcl.PathIndex{{T: "view", V: append(path, t.View.Name)}})
+	}
+	for _, e := range t.Events {
+		if len(e.Columns) == 0 {
+			timing.Attrs = append(timing.Attrs, schemahcl.BoolAttr(strings.ToLower(e.Name), true))
+			continue
+		}
+		refs := make([]*schemahcl.Ref, 0, len(e.Columns))
+		for _, c := range e.Columns {
+			refs = append(refs, schemahcl.BuildRef([]schemahcl.PathIndex{
+				{T: "table", V: append(path, t.Table.Name)},
+				{T: "column", V: []string{c.Name}},
+			}))
+		}
+		timing.Attrs = append(timing.Attrs, schemahcl.RefsAttr("update_of", refs...))
+	}
+	embed := &schemahcl.Resource{}
+	if t.For != "" {
+		embed.Attrs = append(embed.Attrs, VarAttr("for", Var(string(t.For))))
+	}
+	if t.When != "" {
+		embed.Attrs = append(embed.Attrs, schemahcl.StringAttr("when", t.When))
+	}
+	embed.Attrs = append(embed.Attrs, SQLAttr("as", t.Body))
+	convertCommentFromSchema(t.Attrs, &embed.Attrs)
+	spec.Extra.Children = append(spec.Extra.Children, timing, embed)
+	return spec, nil
+}
+
+// FromRoles converts the roles of the realm to role and user specs. References to
+// objects that are not schemas, tables or views (e.g. sequences) are built using the
+// given function, if it is not nil.
+func FromRoles(r *schema.Realm, objectRef func(schema.Object) (*schemahcl.Ref, error)) (roles, users []*sqlspec.Role, err error) {
+	// Qualify the table/view references if there
+	// are multiple tables/views with the same name.
+	names := make(map[string]int)
+	for _, s := range r.Schemas {
+		for _, t := range s.Tables {
+			names["table."+t.Name]++
+		}
+		for _, v := range s.Views {
+			names["view."+v.Name]++
+		}
+	}
+	path := func(s *schema.Schema, kind, name string) []string {
+		if names[kind+"."+name] > 1 && s != nil {
+			return []string{s.Name, name}
+		}
+		return []string{name}
+	}
+	for _, ro := range r.Roles {
+		spec := &sqlspec.Role{Name: ro.Name}
+		for _, m := range ro.MemberOf {
+			spec.MemberOf = append(spec.MemberOf, schemahcl.BuildRef([]schemahcl.PathIndex{{T: roleBlock(m), V: []string{m.Name}}}))
+		}
+		for _, g := range ro.Grants {
+			gs := &sqlspec.Grant{Privileges: g.Privileges, GrantOption: g.GrantOption}
+			switch o := g.On.(type) {
+			case *schema.Schema:
+				gs.On = SchemaRef(o.Name)
+			case *schema.Table:
+				p := path(o.Schema, "table", o.Name)
+				gs.On = schemahcl.BuildRef([]schemahcl.PathIndex{{T: "table", V: p}})
+				for _, c := range g.Columns {
+					gs.Columns = append(gs.Columns, schemahcl.BuildRef([]schemahcl.PathIndex{
+						{T: "table", V: p},
+						{T: "column", V: []string{c.Name}},
+					}))
+				}
+			case *schema.View:
+				gs.On = schemahcl.BuildRef([]schemahcl.PathIndex{{T: "view", V: path(o.Schema, "view", o.Name)}})
+			default:
+				if objectRef == nil {
+					return nil, nil, fmt.Errorf("specutil: unsupported object %T for grant of role %q", g.On, ro.Name)
+				}
+				if gs.On, err = objectRef(g.On); err != nil {
+					return nil, nil, fmt.Errorf("specutil: convert grant of role %q: %w", ro.Name, err)
+				}
+			}
+			spec.Grants = append(spec.Grants, gs)
+		}
+		if ro.User {
+			users = append(users, spec)
+		} else {
+			roles = append(roles, spec)
+		}
+	}
+	return roles, users, nil
+}
+
+// roleBlock returns the block type of the given role.
+func roleBlock(r *schema.Role) string {
+	if r.User {
+		return "user"
+	}
+	return "role"
+}
+
+// FromFunc converts a schema.Func to a sqlspec.Func. The given attributes
+// (e.g. language) are written after the return type and before the definition.
+func FromFunc(f *schema.Func, typeFn FuncTypeSpecFunc, attrs ...*schemahcl.Attr) (*sqlspec.Func, error) {
+	args, err := fromFuncArgs(f.Args, typeFn)
+	if err != nil {
+		return nil, fmt.Errorf("specutil: convert arguments of function %q: %w", f.Name, err)
+	}
+	ret, err := typeFn(f.Ret)
+	if err != nil {
+		return nil, fmt.Errorf("specutil: convert return type of function %q: %w", f.Name, err)
+	}
+	spec := &sqlspec.Func{Name: f.Name, Args: args, Return: ret}
+	spec.Extra.Children = append(spec.Extra.Children, fromFuncDef(f.Body, f.Attrs, attrs))
+	return spec, nil
+}
+
+// FromProc converts a schema.Proc to a sqlspec.Proc. The given
+// attributes are written before the procedure definition.
+func FromProc(p *schema.Proc, typeFn FuncTypeSpecFunc, attrs ...*schemahcl.Attr) (*sqlspec.Proc, error) {
+	args, err := fromFuncArgs(p.Args, typeFn)
+	if err != nil {
+		return nil, fmt.Errorf("specutil: convert arguments of procedure %q: %w", p.Name, err)
+	}
+	spec := &sqlspec.Proc{Name: p.Name, Args: args}
+	spec.Extra.Children = append(spec.Extra.Children, fromFuncDef(p.Body, p.Attrs, attrs))
+	return spec, nil
+}
+
+// fromFuncArgs converts the arguments of a function or a procedure to their spec.
+func fromFuncArgs(args []*schema.FuncArg, typeFn FuncTypeSpecFunc) ([]*sqlspec.FuncArg, error) {
+	specs := make([]*sqlspec.FuncArg, 0, len(args))
+	for _, a := range args {
+		t, err := typeFn(a.Type)
+		if err != nil {
+			return nil, err
+		}
+		spec := &sqlspec.FuncArg{Name: a.Name, Type: t}
+		if a.Default != nil {
+			if spec.Default, err = ExprValue(a.Default); err != nil {
+				return nil, err
+			}
+		}
+		if a.Mode != "" && a.Mode != schema.FuncArgModeIn {
+			spec.Extra.Attrs = append(spec.Extra.Attrs, VarAttr("mode", strings.ToUpper(string(a.Mode))))
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// fromFuncDef returns the resource that holds the attributes
+// and the definition of a function or a procedure.
+func fromFuncDef(body string, src []schema.Attr, attrs []*schemahcl.Attr) *schemahcl.Resource {
+	embed := &schemahcl.Resource{Attrs: attrs}
+	embed.Attrs = append(embed.Attrs, SQLAttr("as", body))
+	convertCommentFromSchema(src, &embed.Attrs)
+	return embed
+}
+
+// SQLAttr returns a string attribute for the given SQL statement or expression.
+// In case the statement is multi-line, it is formatted as an indented heredoc.
+func SQLAttr(k, v string) *schemahcl.Attr {
+	if lines := strings.Split(v, "\n"); len(lines) > 1 {
+		v = fmt.Sprintf("<<-SQL\n  %s\n  SQL", strings.Join(lines, "\n  "))
+	}
+	return schemahcl.StringAttr(k, v)
+}
+
 // FromPrimaryKey converts schema.Index to a sqlspec.PrimaryKey.
 func FromPrimaryKey(s *schema.Index) (*sqlspec.PrimaryKey, error) {
 	c := make([]*schemahcl.Ref, 0, len(s.Parts))
@@ -769,6 +1407,12 @@ func ColumnByRef(t *schema.Table, ref *schemahcl.Ref) (*schema.Column, error) {
 	return c, nil
 }
 
+// ExternalColumn returns the table and the column referenced by ref. The table is looked
+// up in the given schema, or in the other schemas of its realm if it is not qualified.
+func ExternalColumn(ref *schemahcl.Ref, sch *schema.Schema) (*schema.Table, *schema.Column, error) {
+	return externalRef(ref, sch)
+}
+
 func externalRef(ref *schemahcl.Ref, sch *schema.Schema) (*schema.Table, *schema.Column, error) {
 	qualifier, name, err := tableName(ref)
 	if err != nil {
@@ -839,7 +1483,12 @@ func tableName(ref *schemahcl.Ref) (qualifier, name string, err error) {
 }
 
 func viewName(ref *schemahcl.Ref) (qualifier, name string, err error) {
-	vs, err := ref.ByType("view")
+	return refName(ref, "view")
+}
+
+// refName returns the qualifier and the name of the referenced object of the given type.
+func refName(ref *schemahcl.Ref, typ string) (qualifier, name string, err error) {
+	vs, err := ref.ByType(typ)
 	if err != nil {
 		return "", "", err
 	}
@@ -903,6 +1552,18 @@ func convertCommentFromSpec(spec Attrer, attrs *[]schema.Attr) error {
 	return nil
 }
 
+// convertRenamedFromSpec converts a spec "renamed_from" hint attribute to a schema element attribute.
+func convertRenamedFromSpec(spec Attrer, attrs *[]schema.Attr) error {
+	if r, ok := spec.Attr("renamed_from"); ok {
+		s, err := r.String()
+		if err != nil {
+			return err
+		}
+		*attrs = append(*attrs, &schema.RenamedFrom{Name: s})
+	}
+	return nil
+}
+
 // convertCommentFromSchema converts a schema element comment attribute to a spec comment attribute.
 func convertCommentFromSchema(src []schema.Attr, target *[]*schemahcl.Attr) {
 	var c schema.Comment
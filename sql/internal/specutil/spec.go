@@ -24,14 +24,20 @@ type (
 	// SchemaSpec is returned by driver convert functions to
 	// marshal a *schema.Schema into top-level spec objects.
 	SchemaSpec struct {
-		Schema *sqlspec.Schema
-		Tables []*sqlspec.Table
-		Views  []*sqlspec.View
+		Schema   *sqlspec.Schema
+		Tables   []*sqlspec.Table
+		Views    []*sqlspec.View
+		Triggers []*sqlspec.Trigger
+		// Materialized views are supported only by some drivers.
+		Materialized []*sqlspec.View
 	}
 	doc struct {
-		Tables  []*sqlspec.Table  `spec:"table"`
-		Views   []*sqlspec.View   `spec:"view"`
-		Schemas []*sqlspec.Schema `spec:"schema"`
+		Tables   []*sqlspec.Table   `spec:"table"`
+		Views    []*sqlspec.View    `spec:"view"`
+		Triggers []*sqlspec.Trigger `spec:"trigger"`
+		Schemas  []*sqlspec.Schema  `spec:"schema"`
+		Roles    []*sqlspec.Role    `spec:"role"`
+		Users    []*sqlspec.Role    `spec:"user"`
 	}
 )
 
@@ -47,6 +53,7 @@ func Marshal(v any, marshaler schemahcl.Marshaler, convertFunc func(*schema.Sche
 		}
 		d.Tables = spec.Tables
 		d.Views = spec.Views
+		d.Triggers = spec.Triggers
 		d.Schemas = []*sqlspec.Schema{spec.Schema}
 	case *schema.Realm:
 		for _, s := range s.Schemas {
@@ -56,6 +63,7 @@ func Marshal(v any, marshaler schemahcl.Marshaler, convertFunc func(*schema.Sche
 			}
 			d.Tables = append(d.Tables, spec.Tables...)
 			d.Views = append(d.Views, spec.Views...)
+			d.Triggers = append(d.Triggers, spec.Triggers...)
 			d.Schemas = append(d.Schemas, spec.Schema)
 		}
 		if err := QualifyTables(d.Tables); err != nil {
@@ -67,6 +75,10 @@ func Marshal(v any, marshaler schemahcl.Marshaler, convertFunc func(*schema.Sche
 		if err := QualifyReferences(d.Tables, s); err != nil {
 			return nil, err
 		}
+		var err error
+		if d.Roles, d.Users, err = FromRoles(s, nil); err != nil {
+			return nil, err
+		}
 	default:
 		return nil, fmt.Errorf("specutil: failed marshaling spec. %T is not supported", v)
 	}
@@ -69,6 +69,12 @@ func (d *DevDriver) NormalizeRealm(ctx context.Context, r *schema.Realm) (nr *sc
 		for _, v := range s.Views {
 			changes = append(changes, &schema.AddView{V: v})
 		}
+		for _, t := range s.Tables {
+			changes = append(changes, addTriggers(t.Triggers)...)
+		}
+		for _, v := range s.Views {
+			changes = append(changes, addTriggers(v.Triggers)...)
+		}
 		for _, o := range s.Objects {
 			changes = append(changes, &schema.AddObject{O: o})
 		}
@@ -76,8 +82,68 @@ func (d *DevDriver) NormalizeRealm(ctx context.Context, r *schema.Realm) (nr *sc
 	if err := d.Driver.ApplyChanges(ctx, changes); err != nil {
 		return nil, err
 	}
-	nr, err = d.Driver.InspectRealm(ctx, opts)
-	return
+	if nr, err = d.Driver.InspectRealm(ctx, opts); err != nil {
+		return nil, err
+	}
+	// Roles are defined at the server level, and therefore, they are not created
+	// on the dev database, as they outlive its snapshots. Instead, they are copied
+	// to the normalized realm, and their grants are linked to its objects.
+	copyRoles(r, nr)
+	return nr, nil
+}
+
+// copyRoles copies the roles of the realm "from" to the realm "to", and links
+// the objects their privileges were granted on to the objects of "to".
+func copyRoles(from, to *schema.Realm) {
+	roles := make(map[*schema.Role]*schema.Role, len(from.Roles))
+	for _, r := range from.Roles {
+		nr := &schema.Role{Name: r.Name, Realm: to, User: r.User, Attrs: r.Attrs}
+		roles[r] = nr
+		to.Roles = append(to.Roles, nr)
+	}
+	for _, r := range from.Roles {
+		nr := roles[r]
+		for _, m := range r.MemberOf {
+			if nm, ok := roles[m]; ok {
+				m = nm
+			}
+			nr.MemberOf = append(nr.MemberOf, m)
+		}
+		for _, g := range r.Grants {
+			ng := &schema.Grant{To: nr, On: g.On, Columns: g.Columns, Privileges: g.Privileges, GrantOption: g.GrantOption, Attrs: g.Attrs}
+			switch o := g.On.(type) {
+			case *schema.Schema:
+				if s, ok := to.Schema(o.Name); ok {
+					ng.On = s
+				}
+			case *schema.Table:
+				if o.Schema == nil {
+					break
+				}
+				if s, ok := to.Schema(o.Schema.Name); ok {
+					if t, ok := s.Table(o.Name); ok {
+						ng.On, ng.Columns = t, nil
+						for _, c := range g.Columns {
+							if nc, ok := t.Column(c.Name); ok {
+								c = nc
+							}
+							ng.Columns = append(ng.Columns, c)
+						}
+					}
+				}
+			case *schema.View:
+				if o.Schema == nil {
+					break
+				}
+				if s, ok := to.Schema(o.Schema.Name); ok {
+					if v, ok := s.View(o.Name); ok {
+						ng.On = v
+					}
+				}
+			}
+			nr.Grants = append(nr.Grants, ng)
+		}
+	}
 }
 
 // NormalizeSchema returns the normal representation of the given database. See NormalizeRealm for more info.
@@ -132,6 +198,12 @@ func (d *DevDriver) NormalizeSchema(ctx context.Context, s *schema.Schema) (*sch
 		}
 		changes = append(changes, &schema.AddView{V: v})
 	}
+	for _, t := range s.Tables {
+		changes = append(changes, addTriggers(t.Triggers)...)
+	}
+	for _, v := range s.Views {
+		changes = append(changes, addTriggers(v.Triggers)...)
+	}
 	for _, o := range s.Objects {
 		if d.PatchObject != nil {
 			d.PatchObject(s, o)
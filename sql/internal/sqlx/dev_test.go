@@ -36,6 +36,34 @@ func TestDriver_NormalizeRealm(t *testing.T) {
 		// operation noop for schema like "public" in Postgres.
 		Extra: []schema.Clause{&schema.IfNotExists{}},
 	}, drv.changes[0])
+
+	// Roles are not created on the dev database, but
+	// copied to the normalized realm with their grants.
+	var (
+		t1    = schema.NewTable("t1").AddColumns(schema.NewIntColumn("id", "int"))
+		admin = &schema.Role{Name: "admin"}
+		app   = &schema.Role{Name: "app", User: true, MemberOf: []*schema.Role{admin}}
+	)
+	r = schema.NewRealm(schema.New("test").AddTables(t1))
+	admin.Grants = []*schema.Grant{{To: admin, On: r.Schemas[0], Privileges: []string{"ALL PRIVILEGES"}}}
+	app.Grants = []*schema.Grant{{To: app, On: t1, Columns: t1.Columns, Privileges: []string{"SELECT"}}}
+	r.Roles = []*schema.Role{admin, app}
+	nt1 := schema.NewTable("t1").AddColumns(schema.NewIntColumn("id", "int"))
+	drv.realm, drv.changes = schema.NewRealm(schema.New("test").AddTables(nt1)), nil
+	normal, err = dev.NormalizeRealm(context.Background(), r)
+	require.NoError(t, err)
+	require.Len(t, drv.changes, 2, "expect 2 calls for creating the schema and the table")
+	require.Len(t, normal.Roles, 2)
+	require.Equal(t, "admin", normal.Roles[0].Name)
+	require.Equal(t, []*schema.Role{normal.Roles[0]}, normal.Roles[1].MemberOf)
+	require.True(t, normal.Roles[1].User)
+	require.Same(t, normal, normal.Roles[1].Realm)
+	require.Same(t, normal.Schemas[0], normal.Roles[0].Grants[0].On)
+	require.Same(t, nt1, normal.Roles[1].Grants[0].On)
+	require.Same(t, nt1.Columns[0], normal.Roles[1].Grants[0].Columns[0])
+	require.Same(t, normal.Roles[1], normal.Roles[1].Grants[0].To)
+	require.Same(t, app, r.Roles[1], "desired state is not modified")
+	require.Same(t, t1, app.Grants[0].On)
 }
 
 type mockDriver struct {
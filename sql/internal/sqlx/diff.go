@@ -81,11 +81,29 @@ type (
 		FindTable(*schema.Schema, string) (*schema.Table, error)
 	}
 
+	// ForeignKeyAttrDiffer is an optional interface that allows DiffDriver to
+	// report changes in driver-specific attributes of foreign keys.
+	ForeignKeyAttrDiffer interface {
+		ForeignKeyAttrChanged(from, to []schema.Attr) bool
+	}
+
 	// ChangesAnnotator is an optional interface allows DiffDriver to annotate
 	// changes with additional driver-specific attributes before they are returned.
 	ChangesAnnotator interface {
 		AnnotateChanges([]schema.Change, *schema.DiffOptions) error
 	}
+
+	// GrantDiffer is an optional interface that allows DiffDriver to support grants
+	// on driver-specific objects (e.g. sequences), and to expand the ALL PRIVILEGES
+	// keyword into the privileges it stands for, so grants are compared by their
+	// actual privileges.
+	GrantDiffer interface {
+		// ObjectName returns a unique name in the realm for the driver-specific object.
+		ObjectName(schema.Object) (string, bool)
+		// AllPrivileges returns the privileges that ALL PRIVILEGES stands for
+		// on the given object, or on its columns if column is true.
+		AllPrivileges(o schema.Object, column bool) []string
+	}
 )
 
 // RealmDiff implements the schema.Differ for Realm objects and returns a list of changes
@@ -114,12 +132,30 @@ func (d *Diff) RealmDiff(from, to *schema.Realm, options ...schema.DiffOption) (
 			continue
 		}
 		changes = opts.AddOrSkip(changes, &schema.AddSchema{S: s1})
+		for _, o := range s1.Objects {
+			changes = opts.AddOrSkip(changes, &schema.AddObject{O: o})
+		}
 		for _, t := range s1.Tables {
 			changes = opts.AddOrSkip(changes, &schema.AddTable{T: t})
 		}
 		for _, v := range s1.Views {
 			changes = opts.AddOrSkip(changes, &schema.AddView{V: v})
 		}
+		for _, t := range s1.Tables {
+			changes = opts.AddOrSkip(changes, addTriggers(t.Triggers)...)
+		}
+		for _, v := range s1.Views {
+			changes = opts.AddOrSkip(changes, addTriggers(v.Triggers)...)
+		}
+	}
+	// Add, drop or modify roles and their privileges,
+	// in case they were inspected in the current state.
+	if opts.Roles {
+		change, err := d.roleDiff(from, to)
+		if err != nil {
+			return nil, err
+		}
+		changes = opts.AddOrSkip(changes, change...)
 	}
 	return d.mayAnnotate(changes, opts)
 }
@@ -154,15 +190,28 @@ func (d *Diff) schemaDiff(from, to *schema.Schema, opts *schema.DiffOptions) ([]
 	}
 	changes = opts.AddOrSkip(changes, change...)
 
+	// Renamed tables are diffed against their desired state, and
+	// are compared under their new names using the renames mapping.
+	renamed, err := d.renamedTables(from, to, opts)
+	if err != nil {
+		return nil, err
+	}
+	rn := &renames{tables: renamed}
 	// Drop or modify tables.
 	for _, t1 := range from.Tables {
-		switch t2, err := d.findTable(to, t1.Name); {
+		t2, ok := renamed[t1]
+		if ok {
+			changes = opts.AddOrSkip(changes, &schema.RenameTable{From: t1, To: t2})
+		} else {
+			t2, err = d.findTable(to, t1.Name)
+		}
+		switch {
 		case schema.IsNotExistError(err):
 			changes = opts.AddOrSkip(changes, &schema.DropTable{T: t1})
 		case err != nil:
 			return nil, err
 		default:
-			change, err := d.tableDiff(t1, t2, opts)
+			change, err := d.tableDiff(t1, t2, opts, rn)
 			if err != nil {
 				return nil, err
 			}
@@ -176,6 +225,9 @@ func (d *Diff) schemaDiff(from, to *schema.Schema, opts *schema.DiffOptions) ([]
 	}
 	// Add tables.
 	for _, t1 := range to.Tables {
+		if rn.renamed(t1) {
+			continue
+		}
 		switch _, err := d.findTable(from, t1.Name); {
 		case schema.IsNotExistError(err):
 			changes = opts.AddOrSkip(changes, &schema.AddTable{T: t1})
@@ -190,8 +242,9 @@ func (d *Diff) schemaDiff(from, to *schema.Schema, opts *schema.DiffOptions) ([]
 			changes = opts.AddOrSkip(changes, &schema.DropView{V: v1})
 			continue
 		}
-		if TrimViewExtra(v1.Def) != TrimViewExtra(v2.Def) || d.ViewAttrChanged(v1, v2) {
-			changes = opts.AddOrSkip(changes, &schema.ModifyView{From: v1, To: v2})
+		change := d.viewIndexDiff(v1, v2, opts)
+		if TrimViewExtra(v1.Def) != TrimViewExtra(v2.Def) || d.ViewAttrChanged(v1, v2) || len(change) > 0 {
+			changes = opts.AddOrSkip(changes, &schema.ModifyView{From: v1, To: v2, Changes: change})
 		}
 	}
 	// Add views.
@@ -200,9 +253,367 @@ func (d *Diff) schemaDiff(from, to *schema.Schema, opts *schema.DiffOptions) ([]
 			changes = opts.AddOrSkip(changes, &schema.AddView{V: v1})
 		}
 	}
+	// Add, drop or modify triggers.
+	change, err = d.triggerDiff(from, to, renamed)
+	if err != nil {
+		return nil, err
+	}
+	return opts.AddOrSkip(changes, change...), nil
+}
+
+// triggerDiff returns the schema changes (if any) for migrating the triggers of
+// the schema tables and views. Triggers of dropped tables and views are dropped
+// implicitly by the database, and therefore, are not returned.
+func (d *Diff) triggerDiff(from, to *schema.Schema, renamed map[*schema.Table]*schema.Table) ([]schema.Change, error) {
+	var (
+		changes []schema.Change
+		prev    = make(map[*schema.Table]*schema.Table, len(renamed))
+	)
+	for t1, t2 := range renamed {
+		prev[t2] = t1
+	}
+	for _, t2 := range to.Tables {
+		t1, err := prev[t2], error(nil)
+		if t1 == nil {
+			t1, err = d.findTable(from, t2.Name)
+		}
+		switch {
+		case schema.IsNotExistError(err):
+			changes = append(changes, addTriggers(t2.Triggers)...)
+		case err != nil:
+			return nil, err
+		default:
+			changes = append(changes, triggersDiff(t1.Triggers, t2.Triggers)...)
+		}
+	}
+	for _, v2 := range to.Views {
+		if v1, ok := from.View(v2.Name); ok {
+			changes = append(changes, triggersDiff(v1.Triggers, v2.Triggers)...)
+		} else {
+			changes = append(changes, addTriggers(v2.Triggers)...)
+		}
+	}
 	return changes, nil
 }
 
+// triggersDiff returns the changes for migrating the triggers
+// of a table or a view from the current state to the desired.
+func triggersDiff(from, to []*schema.Trigger) []schema.Change {
+	var changes []schema.Change
+	for _, t1 := range from {
+		t2, ok := triggerByName(to, t1.Name)
+		switch {
+		case !ok:
+			changes = append(changes, &schema.DropTrigger{T: t1})
+		case TriggerChanged(t1, t2) || CommentChange(t1.Attrs, t2.Attrs) != schema.NoChange:
+			changes = append(changes, &schema.ModifyTrigger{From: t1, To: t2})
+		}
+	}
+	for _, t2 := range to {
+		if _, ok := triggerByName(from, t2.Name); !ok {
+			changes = append(changes, &schema.AddTrigger{T: t2})
+		}
+	}
+	return changes
+}
+
+// TriggerChanged reports if the trigger definition was changed.
+// Note that the order of the trigger events is not significant.
+func TriggerChanged(from, to *schema.Trigger) bool {
+	if !strings.EqualFold(string(from.ActionTime), string(to.ActionTime)) ||
+		!strings.EqualFold(string(from.For), string(to.For)) ||
+		trimTriggerExpr(from.When) != trimTriggerExpr(to.When) ||
+		trimTriggerExpr(from.Body) != trimTriggerExpr(to.Body) ||
+		len(from.Events) != len(to.Events) {
+		return true
+	}
+	events := make(map[string][]*schema.Column, len(from.Events))
+	for _, e := range from.Events {
+		events[strings.ToUpper(e.Name)] = e.Columns
+	}
+	for _, e := range to.Events {
+		columns, ok := events[strings.ToUpper(e.Name)]
+		if !ok || len(columns) != len(e.Columns) {
+			return true
+		}
+		for i := range columns {
+			if columns[i].Name != e.Columns[i].Name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// trimTriggerExpr trims the spaces, the trailing semicolons
+// and the wrapping parentheses of a trigger expression.
+func trimTriggerExpr(x string) string {
+	x = strings.TrimRight(strings.TrimSpace(x), "; \t\n")
+	for n := len(x) - 1; n > 0 && x[0] == '(' && x[n] == ')' && balanced(x[1:n]); n = len(x) - 1 {
+		x = strings.TrimSpace(x[1:n])
+	}
+	return x
+}
+
+func triggerByName(triggers []*schema.Trigger, name string) (*schema.Trigger, bool) {
+	for _, t := range triggers {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+// addTriggers returns the changes for creating the given triggers.
+func addTriggers(triggers []*schema.Trigger) []schema.Change {
+	changes := make([]schema.Change, 0, len(triggers))
+	for _, t := range triggers {
+		changes = append(changes, &schema.AddTrigger{T: t})
+	}
+	return changes
+}
+
+// roleDiff returns the changes for migrating the roles of the realm and the privileges
+// that were granted to them. Privileges of dropped roles are revoked before they are dropped.
+func (d *Diff) roleDiff(from, to *schema.Realm) ([]schema.Change, error) {
+	var changes []schema.Change
+	for _, r1 := range from.Roles {
+		r2, ok := to.Role(r1.Name)
+		if !ok {
+			revoke, err := d.grantsDiff(r1.Grants, nil)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, revoke...)
+			changes = append(changes, &schema.DropRole{R: r1})
+			continue
+		}
+		if RoleChanged(r1, r2) {
+			changes = append(changes, &schema.ModifyRole{From: r1, To: r2})
+		}
+		change, err := d.grantsDiff(r1.Grants, r2.Grants)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change...)
+	}
+	var added []*schema.Role
+	for _, r2 := range to.Roles {
+		if _, ok := from.Role(r2.Name); !ok {
+			added = append(added, r2)
+		}
+	}
+	// Roles are created after the roles they are member of.
+	for _, r2 := range rolesByMembership(added) {
+		changes = append(changes, &schema.AddRole{R: r2})
+		grant, err := d.grantsDiff(nil, r2.Grants)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, grant...)
+	}
+	return changes, nil
+}
+
+// rolesByMembership returns the given roles sorted such that each role comes after
+// the roles it is member of. Roles without memberships between them retain their order.
+func rolesByMembership(roles []*schema.Role) []*schema.Role {
+	var (
+		visit  func(*schema.Role)
+		sorted = make([]*schema.Role, 0, len(roles))
+		seen   = make(map[string]bool, len(roles))
+		byName = make(map[string]*schema.Role, len(roles))
+	)
+	for _, r := range roles {
+		byName[r.Name] = r
+	}
+	visit = func(r *schema.Role) {
+		if seen[r.Name] {
+			return
+		}
+		seen[r.Name] = true
+		for _, m := range r.MemberOf {
+			if m, ok := byName[m.Name]; ok {
+				visit(m)
+			}
+		}
+		sorted = append(sorted, r)
+	}
+	for _, r := range roles {
+		visit(r)
+	}
+	return sorted
+}
+
+// RoleChanged reports if the role definition was changed.
+// Note that the order of the role memberships is not significant.
+func RoleChanged(from, to *schema.Role) bool {
+	if from.User != to.User || len(from.MemberOf) != len(to.MemberOf) {
+		return true
+	}
+	names := make(map[string]bool, len(from.MemberOf))
+	for _, r := range from.MemberOf {
+		names[r.Name] = true
+	}
+	for _, r := range to.MemberOf {
+		if !names[r.Name] {
+			return true
+		}
+	}
+	return false
+}
+
+// privilege describes a single privilege that was granted on an object (or one of its columns).
+type privilege struct {
+	on, column, name string
+}
+
+// grantsDiff returns the changes for migrating the grants of a role. Grants are compared by
+// their single privileges, and therefore, the way they are grouped is not significant.
+func (d *Diff) grantsDiff(from, to []*schema.Grant) ([]schema.Change, error) {
+	privs1, order1, err := d.privileges(from)
+	if err != nil {
+		return nil, err
+	}
+	privs2, order2, err := d.privileges(to)
+	if err != nil {
+		return nil, err
+	}
+	var revoke, grant []*schema.Grant
+	for _, p := range order1 {
+		if g, ok := privs2[p]; !ok || g.GrantOption != privs1[p].GrantOption {
+			revoke = appendPrivilege(revoke, privs1[p], p)
+		}
+	}
+	for _, p := range order2 {
+		if g, ok := privs1[p]; !ok || g.GrantOption != privs2[p].GrantOption {
+			grant = appendPrivilege(grant, privs2[p], p)
+		}
+	}
+	changes := make([]schema.Change, 0, len(revoke)+len(grant))
+	for _, g := range revoke {
+		changes = append(changes, &schema.DropGrant{G: g})
+	}
+	for _, g := range grant {
+		changes = append(changes, &schema.AddGrant{G: g})
+	}
+	return changes, nil
+}
+
+// privileges splits the given grants into their single privileges.
+func (d *Diff) privileges(grants []*schema.Grant) (map[privilege]*schema.Grant, []privilege, error) {
+	var (
+		order []privilege
+		privs = make(map[privilege]*schema.Grant)
+	)
+	for _, g := range grants {
+		on, err := d.objectName(g.On)
+		if err != nil {
+			return nil, nil, err
+		}
+		columns := []string{""}
+		if len(g.Columns) > 0 {
+			columns = make([]string, len(g.Columns))
+			for i, c := range g.Columns {
+				columns[i] = c.Name
+			}
+		}
+		names := make([]string, 0, len(g.Privileges))
+		for _, name := range g.Privileges {
+			if name = PrivilegeName(name); name == PrivilegeAll {
+				if gd, ok := d.DiffDriver.(GrantDiffer); ok {
+					names = append(names, gd.AllPrivileges(g.On, len(g.Columns) > 0)...)
+					continue
+				}
+			}
+			names = append(names, name)
+		}
+		for _, c := range columns {
+			for _, name := range names {
+				p := privilege{on: on, column: c, name: name}
+				if _, ok := privs[p]; !ok {
+					order = append(order, p)
+				}
+				privs[p] = g
+			}
+		}
+	}
+	return privs, order, nil
+}
+
+// appendPrivilege appends the privilege to the grant of the same object, columns and grant option,
+// or creates a new one. Column-level privileges are merged into the grants of the same privileges.
+func appendPrivilege(grants []*schema.Grant, src *schema.Grant, p privilege) []*schema.Grant {
+	var column *schema.Column
+	if p.column != "" {
+		for _, c := range src.Columns {
+			if c.Name == p.column {
+				column = c
+			}
+		}
+	}
+	for _, g := range grants {
+		if g.To != src.To || g.On != src.On || g.GrantOption != src.GrantOption {
+			continue
+		}
+		switch {
+		case column == nil && len(g.Columns) == 0:
+			g.Privileges = append(g.Privileges, p.name)
+			return grants
+		case column != nil && len(g.Privileges) == 1 && g.Privileges[0] == p.name:
+			g.Columns = append(g.Columns, column)
+			return grants
+		}
+	}
+	g := &schema.Grant{To: src.To, On: src.On, Privileges: []string{p.name}, GrantOption: src.GrantOption}
+	if column != nil {
+		g.Columns = []*schema.Column{column}
+	}
+	return append(grants, g)
+}
+
+// objectName returns a unique name for the object that privileges were granted on.
+func (d *Diff) objectName(o schema.Object) (string, error) {
+	switch o := o.(type) {
+	case *schema.Schema:
+		return "schema:" + o.Name, nil
+	case *schema.Table:
+		return "table:" + qualifiedName(o.Schema, o.Name), nil
+	case *schema.View:
+		return "view:" + qualifiedName(o.Schema, o.Name), nil
+	case *schema.Func:
+		return "function:" + qualifiedName(o.Schema, o.Name), nil
+	case *schema.Proc:
+		return "procedure:" + qualifiedName(o.Schema, o.Name), nil
+	}
+	if gd, ok := d.DiffDriver.(GrantDiffer); ok {
+		if name, ok := gd.ObjectName(o); ok {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported object %T for grant", o)
+}
+
+func qualifiedName(s *schema.Schema, name string) string {
+	if s == nil {
+		return name
+	}
+	return s.Name + "." + name
+}
+
+// PrivilegeAll is the keyword for granting all privileges on an object.
+const PrivilegeAll = "ALL PRIVILEGES"
+
+// PrivilegeName returns the normalized name of a privilege. For example,
+// the ALL keyword is normalized to its long form, ALL PRIVILEGES.
+func PrivilegeName(name string) string {
+	name = strings.Join(strings.Fields(strings.ToUpper(name)), " ")
+	if name == "ALL" {
+		return PrivilegeAll
+	}
+	return name
+}
+
 // TableDiff implements the schema.TableDiffer interface and returns a list of
 // changes that need to be applied in order to move from one state to the other.
 func (d *Diff) TableDiff(from, to *schema.Table, options ...schema.DiffOption) ([]schema.Change, error) {
@@ -210,7 +621,7 @@ func (d *Diff) TableDiff(from, to *schema.Table, options ...schema.DiffOption) (
 	if from.Name != to.Name {
 		return nil, fmt.Errorf("mismatched table names: %q != %q", from.Name, to.Name)
 	}
-	changes, err := d.tableDiff(from, to, opts)
+	changes, err := d.tableDiff(from, to, opts, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -218,7 +629,7 @@ func (d *Diff) TableDiff(from, to *schema.Table, options ...schema.DiffOption) (
 }
 
 // tableDiff implements the table diffing but skips the table name check.
-func (d *Diff) tableDiff(from, to *schema.Table, opts *schema.DiffOptions) ([]schema.Change, error) {
+func (d *Diff) tableDiff(from, to *schema.Table, opts *schema.DiffOptions, rn *renames) ([]schema.Change, error) {
 	// tableDiff can be called with non-identical
 	// names without affecting the diff process.
 	if name := from.Name; name != to.Name {
@@ -239,9 +650,22 @@ func (d *Diff) tableDiff(from, to *schema.Table, opts *schema.DiffOptions) ([]sc
 	}
 	changes = append(changes, change...)
 
+	// Renamed columns are diffed against their desired state. Note,
+	// indexes and foreign-keys are compared under the new column names,
+	// and therefore, are not changed in case they point to these columns.
+	renamed, err := d.renamedColumns(from, to, opts)
+	if err != nil {
+		return nil, err
+	}
+	rn = rn.withColumns(renamed)
 	// Drop or modify columns.
 	for _, c1 := range from.Columns {
-		c2, ok := to.Column(c1.Name)
+		c2, ok := renamed[c1]
+		if ok {
+			changes = opts.AddOrSkip(changes, &schema.RenameColumn{From: c1, To: c2})
+		} else {
+			c2, ok = to.Column(c1.Name)
+		}
 		if !ok {
 			changes = opts.AddOrSkip(changes, &schema.DropColumn{C: c1})
 			continue
@@ -260,7 +684,7 @@ func (d *Diff) tableDiff(from, to *schema.Table, opts *schema.DiffOptions) ([]sc
 	}
 	// Add columns.
 	for _, c1 := range to.Columns {
-		if _, ok := from.Column(c1.Name); !ok {
+		if _, ok := from.Column(c1.Name); !ok && !rn.renamed(c1) {
 			changes = opts.AddOrSkip(changes, &schema.AddColumn{
 				C: c1,
 			})
@@ -268,8 +692,12 @@ func (d *Diff) tableDiff(from, to *schema.Table, opts *schema.DiffOptions) ([]sc
 	}
 
 	// Primary-key and index changes.
-	changes = append(changes, d.pkDiff(from, to, opts)...)
-	changes = append(changes, d.indexDiff(from, to, opts)...)
+	changes = append(changes, d.pkDiff(from, to, opts, rn)...)
+	change, err = d.indexDiff(from, to, opts, rn)
+	if err != nil {
+		return nil, err
+	}
+	changes = append(changes, change...)
 
 	// Drop or modify foreign-keys.
 	for _, fk1 := range from.ForeignKeys {
@@ -278,7 +706,7 @@ func (d *Diff) tableDiff(from, to *schema.Table, opts *schema.DiffOptions) ([]sc
 			changes = opts.AddOrSkip(changes, &schema.DropForeignKey{F: fk1})
 			continue
 		}
-		if change := d.fkChange(fk1, fk2); change != schema.NoChange {
+		if change := d.fkChange(fk1, fk2, rn); change != schema.NoChange {
 			changes = opts.AddOrSkip(changes, &schema.ModifyForeignKey{
 				From:   fk1,
 				To:     fk2,
@@ -307,14 +735,14 @@ func (d *Diff) mayAnnotate(changes []schema.Change, opts *schema.DiffOptions) ([
 
 // pkDiff returns the schema changes (if any) for migrating table
 // primary-key from current state to the desired state.
-func (d *Diff) pkDiff(from, to *schema.Table, opts *schema.DiffOptions) (changes []schema.Change) {
+func (d *Diff) pkDiff(from, to *schema.Table, opts *schema.DiffOptions, rn *renames) (changes []schema.Change) {
 	switch pk1, pk2 := from.PrimaryKey, to.PrimaryKey; {
 	case pk1 == nil && pk2 != nil:
 		changes = opts.AddOrSkip(changes, &schema.AddPrimaryKey{P: pk2})
 	case pk1 != nil && pk2 == nil:
 		changes = opts.AddOrSkip(changes, &schema.DropPrimaryKey{P: pk1})
 	case pk1 != nil && pk2 != nil:
-		change := d.indexChange(pk1, pk2)
+		change := d.indexChange(pk1, pk2, rn)
 		change &= ^schema.ChangeUnique
 		if change != schema.NoChange {
 			changes = opts.AddOrSkip(changes, &schema.ModifyPrimaryKey{
@@ -329,17 +757,26 @@ func (d *Diff) pkDiff(from, to *schema.Table, opts *schema.DiffOptions) (changes
 
 // indexDiff returns the schema changes (if any) for migrating table
 // indexes from current state to the desired state.
-func (d *Diff) indexDiff(from, to *schema.Table, opts *schema.DiffOptions) []schema.Change {
+func (d *Diff) indexDiff(from, to *schema.Table, opts *schema.DiffOptions, rn *renames) ([]schema.Change, error) {
 	var (
 		changes []schema.Change
 		exists  = make(map[*schema.Index]bool)
 	)
+	renamed, err := d.renamedIndexes(from, to, opts, rn)
+	if err != nil {
+		return nil, err
+	}
 	// Drop or modify indexes.
 	for _, idx1 := range from.Indexes {
-		idx2, ok := to.Index(idx1.Name)
+		idx2, ok := renamed[idx1]
+		if ok {
+			changes = opts.AddOrSkip(changes, &schema.RenameIndex{From: idx1, To: idx2})
+		} else {
+			idx2, ok = to.Index(idx1.Name)
+		}
 		// Found directly.
 		if ok {
-			if change := d.indexChange(idx1, idx2); change != schema.NoChange {
+			if change := d.indexChange(idx1, idx2, rn); change != schema.NoChange {
 				changes = opts.AddOrSkip(changes, &schema.ModifyIndex{
 					From:   idx1,
 					To:     idx2,
@@ -351,7 +788,7 @@ func (d *Diff) indexDiff(from, to *schema.Table, opts *schema.DiffOptions) []sch
 		}
 		// Found indirectly.
 		if d.IsGeneratedIndexName(from, idx1) {
-			if idx2, ok := d.similarUnnamedIndex(to, idx1); ok {
+			if idx2, ok := d.similarUnnamedIndex(to, idx1, rn); ok {
 				exists[idx2] = true
 				continue
 			}
@@ -368,11 +805,265 @@ func (d *Diff) indexDiff(from, to *schema.Table, opts *schema.DiffOptions) []sch
 			changes = opts.AddOrSkip(changes, &schema.AddIndex{I: idx})
 		}
 	}
+	return changes, nil
+}
+
+// viewIndexDiff returns the schema changes (if any) for migrating
+// the indexes of materialized views from one state to the other.
+func (d *Diff) viewIndexDiff(from, to *schema.View, opts *schema.DiffOptions) []schema.Change {
+	var changes []schema.Change
+	for _, idx1 := range from.Indexes {
+		idx2, ok := to.Index(idx1.Name)
+		if !ok {
+			changes = opts.AddOrSkip(changes, &schema.DropIndex{I: idx1})
+			continue
+		}
+		if change := d.indexChange(idx1, idx2, nil); change != schema.NoChange {
+			changes = opts.AddOrSkip(changes, &schema.ModifyIndex{
+				From:   idx1,
+				To:     idx2,
+				Change: change,
+			})
+		}
+	}
+	for _, idx2 := range to.Indexes {
+		if _, ok := from.Index(idx2.Name); !ok {
+			changes = opts.AddOrSkip(changes, &schema.AddIndex{I: idx2})
+		}
+	}
 	return changes
 }
 
+// renamedTables returns the renamed tables between the two schemas, mapped
+// from their current state to their desired state.
+func (d *Diff) renamedTables(from, to *schema.Schema, opts *schema.DiffOptions) (map[*schema.Table]*schema.Table, error) {
+	var drop, add []*schema.Table
+	for _, t1 := range from.Tables {
+		switch _, err := d.findTable(to, t1.Name); {
+		case schema.IsNotExistError(err):
+			drop = append(drop, t1)
+		case err != nil:
+			return nil, err
+		}
+	}
+	for _, t2 := range to.Tables {
+		switch _, err := d.findTable(from, t2.Name); {
+		case schema.IsNotExistError(err):
+			add = append(add, t2)
+		case err != nil:
+			return nil, err
+		}
+	}
+	return renamer[*schema.Table]{
+		name:  func(t *schema.Table) string { return t.Name },
+		attrs: func(t *schema.Table) []schema.Attr { return t.Attrs },
+		change: func(from, to *schema.Table) schema.Change {
+			return &schema.RenameTable{From: from, To: to}
+		},
+		similar: func(t1, t2 *schema.Table) (bool, error) {
+			if len(t1.Columns) != len(t2.Columns) {
+				return false, nil
+			}
+			for _, c1 := range t1.Columns {
+				c2, ok := t2.Column(c1.Name)
+				if !ok {
+					return false, nil
+				}
+				if change, err := d.ColumnChange(t1, c1, c2); err != nil || change != schema.NoChange {
+					return false, err
+				}
+			}
+			return true, nil
+		},
+	}.pairs(drop, add, opts)
+}
+
+// renamedColumns returns the renamed columns between the two tables, mapped
+// from their current state to their desired state.
+func (d *Diff) renamedColumns(from, to *schema.Table, opts *schema.DiffOptions) (map[*schema.Column]*schema.Column, error) {
+	var drop, add []*schema.Column
+	for _, c1 := range from.Columns {
+		if _, ok := to.Column(c1.Name); !ok {
+			drop = append(drop, c1)
+		}
+	}
+	for _, c2 := range to.Columns {
+		if _, ok := from.Column(c2.Name); !ok {
+			add = append(add, c2)
+		}
+	}
+	return renamer[*schema.Column]{
+		name:  func(c *schema.Column) string { return c.Name },
+		attrs: func(c *schema.Column) []schema.Attr { return c.Attrs },
+		change: func(c1, c2 *schema.Column) schema.Change {
+			return &schema.RenameColumn{From: c1, To: c2}
+		},
+		similar: func(c1, c2 *schema.Column) (bool, error) {
+			change, err := d.ColumnChange(from, c1, c2)
+			return change == schema.NoChange, err
+		},
+	}.pairs(drop, add, opts)
+}
+
+// renamedIndexes returns the renamed indexes between the two tables, mapped
+// from their current state to their desired state. Unlike tables and columns,
+// modified indexes are rebuilt, and therefore, renames are detected only for
+// indexes that were not changed.
+func (d *Diff) renamedIndexes(from, to *schema.Table, opts *schema.DiffOptions, rn *renames) (map[*schema.Index]*schema.Index, error) {
+	var drop, add []*schema.Index
+	for _, idx1 := range from.Indexes {
+		if _, ok := to.Index(idx1.Name); !ok && !d.IsGeneratedIndexName(from, idx1) {
+			drop = append(drop, idx1)
+		}
+	}
+	for _, idx2 := range to.Indexes {
+		if _, ok := from.Index(idx2.Name); !ok {
+			add = append(add, idx2)
+		}
+	}
+	return renamer[*schema.Index]{
+		strict: true,
+		name:   func(idx *schema.Index) string { return idx.Name },
+		attrs:  func(idx *schema.Index) []schema.Attr { return idx.Attrs },
+		change: func(idx1, idx2 *schema.Index) schema.Change {
+			return &schema.RenameIndex{From: idx1, To: idx2}
+		},
+		similar: func(idx1, idx2 *schema.Index) (bool, error) {
+			return d.indexChange(idx1, idx2, rn) == schema.NoChange, nil
+		},
+	}.pairs(drop, add, opts)
+}
+
+// renames holds the renamed tables and columns of the current state, mapped to
+// their desired state. It allows comparing elements under their new names without
+// changing the current state. A nil renames is valid and holds no renames.
+type renames struct {
+	tables  map[*schema.Table]*schema.Table
+	columns map[*schema.Column]*schema.Column
+}
+
+// withColumns returns a copy of the renames with the given renamed columns.
+func (r *renames) withColumns(columns map[*schema.Column]*schema.Column) *renames {
+	nr := &renames{columns: columns}
+	if r != nil {
+		nr.tables = r.tables
+	}
+	return nr
+}
+
+// table returns the name of the table in the desired state.
+func (r *renames) table(t *schema.Table) string {
+	if r != nil {
+		if t2, ok := r.tables[t]; ok {
+			return t2.Name
+		}
+	}
+	return t.Name
+}
+
+// column returns the name of the column in the desired state.
+func (r *renames) column(c *schema.Column) string {
+	if r != nil {
+		if c2, ok := r.columns[c]; ok {
+			return c2.Name
+		}
+	}
+	return c.Name
+}
+
+// renamed reports if the given table or column of the
+// desired state was renamed from the current state.
+func (r *renames) renamed(v any) bool {
+	if r == nil {
+		return false
+	}
+	switch v := v.(type) {
+	case *schema.Table:
+		for _, t2 := range r.tables {
+			if t2 == v {
+				return true
+			}
+		}
+	case *schema.Column:
+		for _, c2 := range r.columns {
+			if c2 == v {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// renamer pairs dropped and added elements of the same kind into renames.
+type renamer[T comparable] struct {
+	strict  bool                           // Hinted pairs must be similar as well.
+	name    func(T) string                 // Element name.
+	attrs   func(T) []schema.Attr          // Element attributes.
+	change  func(from, to T) schema.Change // Rename change.
+	similar func(from, to T) (bool, error) // Structural similarity.
+}
+
+// pairs returns the renamed elements, mapped from their current state to
+// their desired state. An added element is paired with a dropped element
+// if it holds a RenamedFrom hint with its name. Otherwise, if exactly one
+// element was dropped and one was added, and both are structurally similar,
+// the rename candidate is resolved using the DiffOptions.AskRename callback.
+func (r renamer[T]) pairs(drop, add []T, opts *schema.DiffOptions) (map[T]T, error) {
+	var zero T
+	pairs := make(map[T]T)
+	if len(drop) == 0 || len(add) == 0 || opts.Skipped(r.change(zero, zero)) {
+		return pairs, nil
+	}
+	paired := make(map[T]bool)
+	for _, e2 := range add {
+		h := &schema.RenamedFrom{}
+		if !Has(r.attrs(e2), h) || h.Name == "" {
+			continue
+		}
+		for _, e1 := range drop {
+			if paired[e1] || r.name(e1) != h.Name {
+				continue
+			}
+			if r.strict {
+				if ok, err := r.similar(e1, e2); err != nil || !ok {
+					break
+				}
+			}
+			pairs[e1], paired[e1], paired[e2] = e2, true, true
+			break
+		}
+	}
+	if opts.AskRename == nil || len(drop)-len(pairs) != 1 || len(add)-len(pairs) != 1 {
+		return pairs, nil
+	}
+	var e1, e2 T
+	for _, e := range drop {
+		if !paired[e] {
+			e1 = e
+		}
+	}
+	for _, e := range add {
+		if !paired[e] {
+			e2 = e
+		}
+	}
+	switch ok, err := r.similar(e1, e2); {
+	case err != nil:
+		return nil, err
+	case !ok:
+		return pairs, nil
+	}
+	switch ok, err := opts.AskRename(r.change(e1, e2)); {
+	case err != nil:
+		return nil, err
+	case ok:
+		pairs[e1] = e2
+	}
+	return pairs, nil
+}
+
 // indexChange returns the schema changes (if any) for migrating one index to the other.
-func (d *Diff) indexChange(from, to *schema.Index) schema.ChangeKind {
+func (d *Diff) indexChange(from, to *schema.Index, rn *renames) schema.ChangeKind {
 	var change schema.ChangeKind
 	if from.Unique != to.Unique {
 		change |= schema.ChangeUnique
@@ -380,12 +1071,12 @@ func (d *Diff) indexChange(from, to *schema.Index) schema.ChangeKind {
 	if d.IndexAttrChanged(from.Attrs, to.Attrs) {
 		change |= schema.ChangeAttr
 	}
-	change |= d.partsChange(from, to)
+	change |= d.partsChange(from, to, rn)
 	change |= CommentChange(from.Attrs, to.Attrs)
 	return change
 }
 
-func (d *Diff) partsChange(fromI, toI *schema.Index) schema.ChangeKind {
+func (d *Diff) partsChange(fromI, toI *schema.Index, rn *renames) schema.ChangeKind {
 	from, to := fromI.Parts, toI.Parts
 	if len(from) != len(to) {
 		return schema.ChangeParts
@@ -397,7 +1088,7 @@ func (d *Diff) partsChange(fromI, toI *schema.Index) schema.ChangeKind {
 		case from[i].Desc != to[i].Desc || d.IndexPartAttrChanged(fromI, toI, i):
 			return schema.ChangeParts
 		case from[i].C != nil && to[i].C != nil:
-			if from[i].C.Name != to[i].C.Name {
+			if rn.column(from[i].C) != to[i].C.Name {
 				return schema.ChangeParts
 			}
 		case from[i].X != nil && to[i].X != nil:
@@ -413,16 +1104,16 @@ func (d *Diff) partsChange(fromI, toI *schema.Index) schema.ChangeKind {
 }
 
 // fkChange returns the schema changes (if any) for migrating one index to the other.
-func (d *Diff) fkChange(from, to *schema.ForeignKey) schema.ChangeKind {
+func (d *Diff) fkChange(from, to *schema.ForeignKey, rn *renames) schema.ChangeKind {
 	var change schema.ChangeKind
 	switch {
-	case from.Table.Name != to.Table.Name:
+	case rn.table(from.Table) != to.Table.Name:
 		change |= schema.ChangeRefTable | schema.ChangeRefColumn
 	case len(from.RefColumns) != len(to.RefColumns):
 		change |= schema.ChangeRefColumn
 	default:
 		for i := range from.RefColumns {
-			if from.RefColumns[i].Name != to.RefColumns[i].Name {
+			if rn.column(from.RefColumns[i]) != to.RefColumns[i].Name {
 				change |= schema.ChangeRefColumn
 			}
 		}
@@ -432,7 +1123,7 @@ func (d *Diff) fkChange(from, to *schema.ForeignKey) schema.ChangeKind {
 		change |= schema.ChangeColumn
 	default:
 		for i := range from.Columns {
-			if from.Columns[i].Name != to.Columns[i].Name {
+			if rn.column(from.Columns[i]) != to.Columns[i].Name {
 				change |= schema.ChangeColumn
 			}
 		}
@@ -443,16 +1134,19 @@ func (d *Diff) fkChange(from, to *schema.ForeignKey) schema.ChangeKind {
 	if d.ReferenceChanged(from.OnDelete, to.OnDelete) {
 		change |= schema.ChangeDeleteAction
 	}
+	if fd, ok := d.DiffDriver.(ForeignKeyAttrDiffer); ok && fd.ForeignKeyAttrChanged(from.Attrs, to.Attrs) {
+		change |= schema.ChangeAttr
+	}
 	return change
 }
 
 // similarUnnamedIndex searches for an unnamed index with the same index-parts in the table.
-func (d *Diff) similarUnnamedIndex(t *schema.Table, idx1 *schema.Index) (*schema.Index, bool) {
+func (d *Diff) similarUnnamedIndex(t *schema.Table, idx1 *schema.Index, rn *renames) (*schema.Index, bool) {
 	for _, idx2 := range t.Indexes {
 		if idx2.Name != "" || len(idx2.Parts) != len(idx1.Parts) || idx2.Unique != idx1.Unique {
 			continue
 		}
-		if d.partsChange(idx1, idx2) == schema.NoChange {
+		if d.partsChange(idx1, idx2, rn) == schema.NoChange {
 			return idx2, true
 		}
 	}
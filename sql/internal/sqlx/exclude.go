@@ -109,6 +109,20 @@ func excludeS(s *schema.Schema, glob []string) error {
 		tables = append(tables, t)
 	}
 	s.Tables = tables
+	// Views are excluded only by their names.
+	if len(glob) == 1 {
+		var views []*schema.View
+		for _, v := range s.Views {
+			match, err := filepath.Match(glob[0], v.Name)
+			if err != nil {
+				return err
+			}
+			if !match {
+				views = append(views, v)
+			}
+		}
+		s.Views = views
+	}
 	return nil
 }
 
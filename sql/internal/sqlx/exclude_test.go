@@ -94,6 +94,33 @@ func TestExcludeRealm_Tables(t *testing.T) {
 	require.Empty(t, r.Schemas[2].Tables)
 }
 
+func TestExcludeRealm_Views(t *testing.T) {
+	r := schema.NewRealm(
+		schema.New("s1").AddViews(
+			schema.NewView("v1", "SELECT 1"),
+			schema.NewView("v2", "SELECT 2"),
+		),
+		schema.New("s2").AddViews(
+			schema.NewView("v1", "SELECT 1"),
+		),
+	)
+	r, err := ExcludeRealm(r, []string{"*.v1.*"})
+	require.NoError(t, err)
+	require.Len(t, r.Schemas[0].Views, 2)
+	require.Len(t, r.Schemas[1].Views, 1)
+
+	r, err = ExcludeRealm(r, []string{"s1.v1"})
+	require.NoError(t, err)
+	require.Len(t, r.Schemas[0].Views, 1)
+	require.Equal(t, "v2", r.Schemas[0].Views[0].Name)
+	require.Len(t, r.Schemas[1].Views, 1)
+
+	r, err = ExcludeRealm(r, []string{"*.*"})
+	require.NoError(t, err)
+	require.Empty(t, r.Schemas[0].Views)
+	require.Empty(t, r.Schemas[1].Views)
+}
+
 func TestExcludeRealm_Checks(t *testing.T) {
 	r := schema.NewRealm(
 		schema.New("s1").AddTables(
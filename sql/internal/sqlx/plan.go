@@ -82,7 +82,8 @@ func SetReversible(p *migrate.Plan) error {
 		if err != nil {
 			return err
 		}
-		if len(stmts) == 0 {
+		// A non-nil empty list describes a no-op reverse.
+		if r, ok := c.Reverse.([]string); len(stmts) == 0 && (!ok || r == nil) {
 			reversible = false
 		}
 	}
@@ -104,7 +105,7 @@ func DetachCycles(changes []schema.Change) ([]schema.Change, error) {
 	}
 	planned := make([]schema.Change, len(changes))
 	copy(planned, changes)
-	sort.Slice(planned, func(i, j int) bool {
+	sort.SliceStable(planned, func(i, j int) bool {
 		return sorted[table(planned[i])] < sorted[table(planned[j])]
 	})
 	return planned, nil
@@ -309,6 +310,142 @@ func isDropped(changes []schema.Change, t *schema.Table) bool {
 	return false
 }
 
+// RoleChanges splits the role and privilege changes from the other changes. The "before" changes
+// (created and modified roles, and revoked privileges) should be planned before the other changes,
+// and the "after" changes (granted privileges and dropped roles) should be planned after them, as
+// privileges may be granted on objects that are created by the other changes.
+func RoleChanges(changes []schema.Change) (others, before, after []schema.Change) {
+	var (
+		addR, modifyR, dropR []schema.Change
+		addG, dropG          []schema.Change
+	)
+	for _, c := range changes {
+		switch c.(type) {
+		case *schema.AddRole:
+			addR = append(addR, c)
+		case *schema.ModifyRole:
+			modifyR = append(modifyR, c)
+		case *schema.DropRole:
+			dropR = append(dropR, c)
+		case *schema.AddGrant:
+			addG = append(addG, c)
+		case *schema.DropGrant:
+			dropG = append(dropG, c)
+		default:
+			others = append(others, c)
+		}
+	}
+	before = append(append(addR, modifyR...), dropG...)
+	after = append(addG, dropR...)
+	return others, before, after
+}
+
+// MembershipsDiff returns the roles that were added to, and removed from, the memberships of a role.
+func MembershipsDiff(from, to []*schema.Role) (add, drop []*schema.Role) {
+	has := func(rs []*schema.Role, r *schema.Role) bool {
+		for i := range rs {
+			if rs[i].Name == r.Name {
+				return true
+			}
+		}
+		return false
+	}
+	for _, r := range to {
+		if !has(from, r) {
+			add = append(add, r)
+		}
+	}
+	for _, r := range from {
+		if !has(to, r) {
+			drop = append(drop, r)
+		}
+	}
+	return add, drop
+}
+
+// A PhasedColumn describes a breaking column change, a rename or a type change, that is
+// planned in expand and contract phases. In the expand phase, the Shadow column is added
+// to the table, kept in sync with the From column by triggers, and backfilled with its
+// values. In the contract phase, the triggers and the From column are dropped, and the
+// Shadow column is renamed to the name of the To column, if they differ.
+type PhasedColumn struct {
+	T        *schema.Table
+	From, To *schema.Column
+	Shadow   *schema.Column
+}
+
+// Expanded returns the Shadow column as it is added in the expand phase. That is,
+// nullable, as the existing rows are backfilled only after the column was added.
+func (c *PhasedColumn) Expanded() *schema.Column {
+	x, t := *c.Shadow, *c.Shadow.Type
+	t.Null = true
+	x.Type = &t
+	return &x
+}
+
+// PhasedColumns splits the breaking column changes of the given table modification, that
+// should be planned in expand and contract phases, from the other changes. Generated
+// columns, and columns that are used by keys or indexes, cannot be changed in phases.
+func PhasedColumns(m *schema.ModifyTable) (phased []*PhasedColumn, others []schema.Change, err error) {
+	for _, c := range m.Changes {
+		var p *PhasedColumn
+		switch c := c.(type) {
+		case *schema.RenameColumn:
+			p = &PhasedColumn{T: m.T, From: c.From, To: c.To, Shadow: c.To}
+		case *schema.ModifyColumn:
+			if !c.Change.Is(schema.ChangeType) || c.Change.Is(schema.ChangeGenerated) {
+				others = append(others, c)
+				continue
+			}
+			shadow := *c.To
+			shadow.Name += "_new"
+			if _, ok := m.T.Column(shadow.Name); ok {
+				return nil, nil, fmt.Errorf("cannot change the type of column %q in phases: column %q already exists", c.To.Name, shadow.Name)
+			}
+			p = &PhasedColumn{T: m.T, From: c.From, To: c.To, Shadow: &shadow}
+		default:
+			others = append(others, c)
+			continue
+		}
+		if err := checkPhased(m.T, p.To); err != nil {
+			return nil, nil, err
+		}
+		phased = append(phased, p)
+	}
+	return phased, others, nil
+}
+
+// checkPhased checks that the column is not generated, and not used by keys or indexes.
+func checkPhased(t *schema.Table, c *schema.Column) error {
+	uses := func(parts []*schema.IndexPart) bool {
+		for _, p := range parts {
+			if p.C != nil && p.C.Name == c.Name {
+				return true
+			}
+		}
+		return false
+	}
+	if t.PrimaryKey != nil && uses(t.PrimaryKey.Parts) {
+		return fmt.Errorf("cannot change column %q in phases: column is part of the primary key", c.Name)
+	}
+	for _, idx := range t.Indexes {
+		if uses(idx.Parts) {
+			return fmt.Errorf("cannot change column %q in phases: column is used by index %q", c.Name, idx.Name)
+		}
+	}
+	for _, fk := range t.ForeignKeys {
+		for _, fc := range fk.Columns {
+			if fc.Name == c.Name {
+				return fmt.Errorf("cannot change column %q in phases: column is used by foreign key %q", c.Name, fk.Symbol)
+			}
+		}
+	}
+	if x := (schema.GeneratedExpr{}); Has(c.Attrs, &x) {
+		return fmt.Errorf("cannot change column %q in phases: column is generated", c.Name)
+	}
+	return nil
+}
+
 // CheckChangesScope checks that changes can be applied
 // on a schema scope (connection).
 func CheckChangesScope(opts migrate.PlanOptions, changes []schema.Change) error {
@@ -328,7 +465,7 @@ func CheckChangesScope(opts migrate.PlanOptions, changes []schema.Change) error
 				names[c.S.Name] = struct{}{}
 				continue
 			}
-		case *schema.AddSchema, *schema.DropSchema:
+		case *schema.AddSchema, *schema.DropSchema, *schema.AddRole, *schema.DropRole, *schema.ModifyRole:
 			return fmt.Errorf("%T is not allowed when migration plan is scoped to one schema", c)
 		case *schema.AddTable:
 			t = c.T
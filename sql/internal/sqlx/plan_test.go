@@ -108,6 +108,52 @@ func TestDetachCycles(t *testing.T) {
 	require.Equal(t, []schema.Change{changes[1], changes[0]}, planned)
 }
 
+func TestPhasedColumns(t *testing.T) {
+	var (
+		id    = schema.NewIntColumn("id", "int")
+		name  = schema.NewStringColumn("full_name", "text")
+		age   = schema.NewIntColumn("age", "bigint")
+		users = schema.NewTable("users").AddColumns(id, name, age).SetPrimaryKey(schema.NewPrimaryKey(id))
+		add   = &schema.AddIndex{I: schema.NewIndex("idx").AddColumns(id)}
+	)
+	phased, others, err := PhasedColumns(&schema.ModifyTable{
+		T: users,
+		Changes: []schema.Change{
+			&schema.RenameColumn{From: schema.NewStringColumn("name", "text"), To: name},
+			&schema.ModifyColumn{From: schema.NewIntColumn("age", "int"), To: age, Change: schema.ChangeType},
+			add,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []schema.Change{add}, others)
+	require.Len(t, phased, 2)
+	require.Equal(t, name, phased[0].Shadow)
+	require.Equal(t, "age_new", phased[1].Shadow.Name)
+	require.Equal(t, age, phased[1].To)
+	require.False(t, phased[1].Shadow.Type.Null)
+	require.True(t, phased[1].Expanded().Type.Null)
+
+	// Columns that are used by keys cannot be changed in phases.
+	_, _, err = PhasedColumns(&schema.ModifyTable{
+		T: users,
+		Changes: []schema.Change{
+			&schema.ModifyColumn{From: schema.NewIntColumn("id", "int"), To: id, Change: schema.ChangeType},
+		},
+	})
+	require.EqualError(t, err, `cannot change column "id" in phases: column is part of the primary key`)
+
+	// Non-breaking changes are not phased.
+	phased, others, err = PhasedColumns(&schema.ModifyTable{
+		T: users,
+		Changes: []schema.Change{
+			&schema.ModifyColumn{From: schema.NewNullIntColumn("age", "bigint"), To: age, Change: schema.ChangeNull},
+		},
+	})
+	require.NoError(t, err)
+	require.Empty(t, phased)
+	require.Len(t, others, 1)
+}
+
 func TestCheckChangesScope(t *testing.T) {
 	err := CheckChangesScope(migrate.PlanOptions{}, []schema.Change{
 		&schema.AddSchema{},
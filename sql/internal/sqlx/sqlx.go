@@ -149,6 +149,23 @@ func SchemaFKs(s *schema.Schema, rows *sql.Rows) error {
 	return nil
 }
 
+// AddPrivilege adds an inspected privilege to the role grant of the same object,
+// column and grant option, or creates a new grant if there is no such grant.
+func AddPrivilege(ro *schema.Role, on schema.Object, c *schema.Column, privilege string, grantable bool) {
+	for _, g := range ro.Grants {
+		if g.On != on || g.GrantOption != grantable || (c == nil) != (len(g.Columns) == 0) || c != nil && g.Columns[0] != c {
+			continue
+		}
+		g.Privileges = append(g.Privileges, privilege)
+		return
+	}
+	g := schema.NewGrant(on, privilege).SetGrantOption(grantable)
+	if c != nil {
+		g.SetColumns(c)
+	}
+	ro.AddGrants(g)
+}
+
 // LinkSchemaTables links foreign-key stub tables/columns to actual elements.
 func LinkSchemaTables(schemas []*schema.Schema) {
 	byName := make(map[string]map[string]*schema.Table)
@@ -198,7 +215,7 @@ func ValuesEqual(v1, v2 []string) bool {
 // ModeInspectSchema returns the InspectMode or its default.
 func ModeInspectSchema(o *schema.InspectOptions) schema.InspectMode {
 	if o == nil || o.Mode == 0 {
-		return schema.InspectSchemas | schema.InspectTables | schema.InspectViews
+		return schema.InspectSchemas | schema.InspectTables | schema.InspectViews | schema.InspectTriggers | schema.InspectFuncs | schema.InspectSequences | schema.InspectExtensions | schema.InspectTypes | schema.InspectPolicies | schema.InspectEvents
 	}
 	return o.Mode
 }
@@ -206,7 +223,7 @@ func ModeInspectSchema(o *schema.InspectOptions) schema.InspectMode {
 // ModeInspectRealm returns the InspectMode or its default.
 func ModeInspectRealm(o *schema.InspectRealmOption) schema.InspectMode {
 	if o == nil || o.Mode == 0 {
-		return schema.InspectSchemas | schema.InspectTables | schema.InspectViews
+		return schema.InspectSchemas | schema.InspectTables | schema.InspectViews | schema.InspectTriggers | schema.InspectFuncs | schema.InspectSequences | schema.InspectExtensions | schema.InspectTypes | schema.InspectPolicies | schema.InspectEvents
 	}
 	return o.Mode
 }
@@ -262,6 +279,37 @@ func (b *Builder) Table(t *schema.Table) *Builder {
 	return b.mayQualify(t.Schema, t.Name)
 }
 
+// Trigger writes the trigger identifier to the builder, prefixed
+// with the schema name of its table or view if exists.
+func (b *Builder) Trigger(t *schema.Trigger) *Builder {
+	var s *schema.Schema
+	switch {
+	case t.Table != nil:
+		s = t.Table.Schema
+	case t.View != nil:
+		s = t.View.Schema
+	}
+	return b.mayQualify(s, t.Name)
+}
+
+// Func writes the function identifier to the builder, prefixed
+// with the schema name if exists.
+func (b *Builder) Func(f *schema.Func) *Builder {
+	return b.mayQualify(f.Schema, f.Name)
+}
+
+// Proc writes the procedure identifier to the builder, prefixed
+// with the schema name if exists.
+func (b *Builder) Proc(p *schema.Proc) *Builder {
+	return b.mayQualify(p.Schema, p.Name)
+}
+
+// SchemaResource writes the identifier of a schema-level resource (e.g. a scheduled
+// event) to the builder, prefixed with the schema name if exists.
+func (b *Builder) SchemaResource(s *schema.Schema, name string) *Builder {
+	return b.mayQualify(s, name)
+}
+
 // TableResource writes the table's resource identifier to the builder, prefixed
 // with the schema name if exists.
 func (b *Builder) TableResource(t *schema.Table, r any) *Builder {
@@ -7,11 +7,105 @@
 package sqlx
 
 import (
+	"fmt"
+
 	"ariga.io/atlas/sql/schema"
 )
 
-// PlanViewChanges (should) plan view changes in the current order they should be applied.
-// It is unimplemented for community version as views are not supported there.
+// PlanViewChanges plans view changes in the order they should be applied. Views are
+// dropped before the views they depend on, and are created (or modified) after them.
+// Dropping changes are planned first, as views with the same name may be recreated.
 func PlanViewChanges(changes []schema.Change) ([]schema.Change, error) {
-	return changes, nil // unimplemented.
+	var drops, others []schema.Change
+	for _, c := range changes {
+		switch c.(type) {
+		case *schema.DropView:
+			drops = append(drops, c)
+		case *schema.AddView, *schema.ModifyView, *schema.RenameView:
+			others = append(others, c)
+		default:
+			return nil, fmt.Errorf("unexpected view change: %T", c)
+		}
+	}
+	drops, err := sortViewChanges(drops)
+	if err != nil {
+		return nil, err
+	}
+	// Views are dropped before their dependencies.
+	ReverseChanges(drops)
+	if others, err = sortViewChanges(others); err != nil {
+		return nil, err
+	}
+	return append(drops, others...), nil
+}
+
+// sortViewChanges sorts the view changes topologically based on their
+// dependencies. i.e., a view comes after the views it depends on. Views
+// without dependencies between them retain their original order.
+func sortViewChanges(changes []schema.Change) ([]schema.Change, error) {
+	var (
+		visit    func(int) error
+		sorted   = make([]schema.Change, 0, len(changes))
+		done     = make(map[int]bool, len(changes))
+		progress = make(map[int]bool, len(changes))
+		byKey    = make(map[string]int, len(changes))
+	)
+	for i, c := range changes {
+		byKey[viewKey(changedView(c))] = i
+	}
+	visit = func(i int) error {
+		if done[i] {
+			return nil
+		}
+		v := changedView(changes[i])
+		if progress[i] {
+			return fmt.Errorf("cyclic view dependency detected for view %q", v.Name)
+		}
+		progress[i] = true
+		for _, d := range v.Deps {
+			dv, ok := d.(*schema.View)
+			if !ok {
+				continue
+			}
+			if j, ok := byKey[viewKey(dv)]; ok && j != i {
+				if err := visit(j); err != nil {
+					return err
+				}
+			}
+		}
+		delete(progress, i)
+		done[i] = true
+		sorted = append(sorted, changes[i])
+		return nil
+	}
+	for i := range changes {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
+
+// changedView returns the view that is the result of the change.
+func changedView(c schema.Change) *schema.View {
+	switch c := c.(type) {
+	case *schema.AddView:
+		return c.V
+	case *schema.DropView:
+		return c.V
+	case *schema.ModifyView:
+		return c.To
+	case *schema.RenameView:
+		return c.To
+	default:
+		panic(fmt.Sprintf("unexpected view change: %T", c))
+	}
+}
+
+// viewKey returns a unique key for the view in its realm.
+func viewKey(v *schema.View) string {
+	if v.Schema != nil {
+		return fmt.Sprintf("%q.%q", v.Schema.Name, v.Name)
+	}
+	return fmt.Sprintf("%q", v.Name)
 }
@@ -0,0 +1,74 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+//go:build !ent
+
+package sqlx
+
+import (
+	"testing"
+
+	"ariga.io/atlas/sql/schema"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanViewChanges(t *testing.T) {
+	var (
+		s  = schema.New("public")
+		t1 = schema.NewTable("t1")
+		v1 = schema.NewView("v1", "SELECT * FROM t1").AddDeps(t1)
+		v2 = schema.NewView("v2", "SELECT * FROM v1").AddDeps(v1)
+		v3 = schema.NewView("v3", "SELECT * FROM v2 JOIN v1 USING (id)").AddDeps(v2, v1)
+	)
+	s.AddTables(t1).AddViews(v1, v2, v3)
+	planned, err := PlanViewChanges([]schema.Change{
+		&schema.AddView{V: v3},
+		&schema.AddView{V: v2},
+		&schema.AddView{V: v1},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []schema.Change{
+		&schema.AddView{V: v1},
+		&schema.AddView{V: v2},
+		&schema.AddView{V: v3},
+	}, planned)
+
+	planned, err = PlanViewChanges([]schema.Change{
+		&schema.AddView{V: v3},
+		&schema.DropView{V: v1},
+		&schema.DropView{V: v2},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []schema.Change{
+		&schema.DropView{V: v2},
+		&schema.DropView{V: v1},
+		&schema.AddView{V: v3},
+	}, planned)
+
+	// Independent views retain their order.
+	v4 := schema.NewView("v4", "SELECT 1").SetSchema(s)
+	planned, err = PlanViewChanges([]schema.Change{
+		&schema.ModifyView{From: v4, To: v4},
+		&schema.AddView{V: v2},
+		&schema.AddView{V: v1},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []schema.Change{
+		&schema.ModifyView{From: v4, To: v4},
+		&schema.AddView{V: v1},
+		&schema.AddView{V: v2},
+	}, planned)
+
+	v1.AddDeps(v3)
+	_, err = PlanViewChanges([]schema.Change{
+		&schema.AddView{V: v1},
+		&schema.AddView{V: v2},
+		&schema.AddView{V: v3},
+	})
+	require.EqualError(t, err, `cyclic view dependency detected for view "v1"`)
+
+	_, err = PlanViewChanges([]schema.Change{&schema.AddTable{T: t1}})
+	require.Error(t, err)
+}
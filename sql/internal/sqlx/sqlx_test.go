@@ -25,6 +25,16 @@ func TestModeInspectRealm(t *testing.T) {
 	m = ModeInspectRealm(&schema.InspectRealmOption{Mode: schema.InspectSchemas})
 	require.True(t, m.Is(schema.InspectSchemas))
 	require.False(t, m.Is(schema.InspectTables))
+
+	// Negated modes do not enable the modes that were added later.
+	m = ModeInspectRealm(&schema.InspectRealmOption{Mode: ^schema.InspectViews})
+	require.True(t, m.Is(schema.InspectSchemas))
+	require.True(t, m.Is(schema.InspectTables))
+	require.False(t, m.Is(schema.InspectViews))
+	require.False(t, m.Is(schema.InspectTriggers))
+	require.False(t, m.Is(schema.InspectRoles))
+	m = ModeInspectRealm(&schema.InspectRealmOption{Mode: schema.InspectTables | schema.InspectTriggers})
+	require.True(t, m.Is(schema.InspectTriggers))
 }
 
 func TestModeInspectSchema(t *testing.T) {
@@ -59,8 +59,20 @@ type (
 		// StmtDecls returns the set of SQL statements this file holds alongside its preceding comments.
 		StmtDecls() ([]*Stmt, error)
 	}
+
+	// DownFile is an optional interface implemented by migration files
+	// that store the statements for reverting their changes.
+	DownFile interface {
+		File
+		// DownStmts returns the statements that revert the changes of the file.
+		// ErrNoDownStmts is returned if the file does not store such statements.
+		DownStmts() ([]string, error)
+	}
 )
 
+// ErrNoDownStmts is returned by DownFile.DownStmts if the file does not store reverse statements.
+var ErrNoDownStmts = errors.New("sql/migrate: file does not store down statements")
+
 // LocalDir implements Dir for a local migration
 // directory with default Atlas formatting.
 type LocalDir struct {
@@ -164,7 +176,43 @@ func (f LocalFile) Version() string {
 
 // Stmts returns the SQL statement exists in the local file.
 func (f LocalFile) Stmts() ([]string, error) {
-	s, err := Stmts(string(f.b))
+	up, _, _ := f.sections()
+	return stmtsText(up)
+}
+
+// StmtDecls returns the all statement declarations exist in the local file.
+// Statements of the atlas:down section are not included.
+func (f LocalFile) StmtDecls() ([]*Stmt, error) {
+	up, _, _ := f.sections()
+	return Stmts(up)
+}
+
+// DownStmts implements DownFile.DownStmts. It returns the statements
+// written after the atlas:down directive of the local file.
+func (f LocalFile) DownStmts() ([]string, error) {
+	up, down, ok := f.sections()
+	if !ok {
+		return nil, ErrNoDownStmts
+	}
+	// The down section is split by the delimiter of the file.
+	if d, ok := directive(up, directiveDelimiter, directivePrefixSQL); ok {
+		down = fmt.Sprintf("%satlas:%s %s\n%s", directivePrefixSQL, directiveDelimiter, d, down)
+	}
+	return stmtsText(down)
+}
+
+// sections splits the file content into its "up" and "down" parts.
+// The down part starts after the atlas:down directive line, if exists.
+func (f LocalFile) sections() (up, down string, ok bool) {
+	loc := reDownSection.FindIndex(f.b)
+	if loc == nil {
+		return string(f.b), "", false
+	}
+	return string(f.b[:loc[0]]), string(f.b[loc[1]:]), true
+}
+
+func stmtsText(input string) ([]string, error) {
+	s, err := Stmts(input)
 	if err != nil {
 		return nil, err
 	}
@@ -175,11 +223,6 @@ func (f LocalFile) Stmts() ([]string, error) {
 	return stmts, nil
 }
 
-// StmtDecls returns the all statement declarations exist in the local file.
-func (f LocalFile) StmtDecls() ([]*Stmt, error) {
-	return Stmts(string(f.b))
-}
-
 // Bytes returns local file data.
 func (f LocalFile) Bytes() []byte {
 	return f.b
@@ -216,6 +259,15 @@ func (f LocalFile) Directive(name string) (ds []string) {
 	return ds
 }
 
+// Phase returns the expand/contract phase of the migration file that is recorded
+// by its atlas:phase directive, or an empty string if the file is not phased.
+func (f LocalFile) Phase() string {
+	if ds := f.Directive(directivePhase); len(ds) > 0 {
+		return ds[0]
+	}
+	return ""
+}
+
 type (
 	// MemDir provides an in-memory Dir implementation.
 	MemDir struct {
@@ -337,20 +389,46 @@ var (
 	templateFuncs = template.FuncMap{
 		"upper": strings.ToUpper,
 		"now":   func() string { return time.Now().UTC().Format("20060102150405") },
-	}
+		"rev":   reverseChanges,
+	}
+	// defaultName and defaultContent are the templates used by the DefaultFormatter.
+	defaultName = "{{ with .Version }}{{ . }}{{ else }}{{ now }}{{ end }}{{ with .Name }}_{{ . }}{{ end }}.sql"
+	// File directives are written in a single header block, as they are
+	// separated from the file content by the first double newline.
+	defaultContent = `{{ with .Delimiter }}{{ printf "-- atlas:delimiter %s\n" . }}{{ end }}` +
+		`{{ with .Phase }}{{ printf "-- atlas:phase %s\n" . }}{{ end }}` +
+		`{{ if or .Delimiter .Phase }}{{ printf "\n" }}{{ end }}` +
+		`{{ range .Changes }}{{ with .Comment }}{{ printf "-- %s%s\n" (slice . 0 1 | upper ) (slice . 1) }}{{ end }}{{ printf "%s%s\n" .Cmd (or $.Delimiter ";") }}{{ end }}`
 	// DefaultFormatter is a default implementation for Formatter.
 	DefaultFormatter = TemplateFormatter{
 		{
-			N: template.Must(template.New("").Funcs(templateFuncs).Parse(
-				"{{ with .Version }}{{ . }}{{ else }}{{ now }}{{ end }}{{ with .Name }}_{{ . }}{{ end }}.sql",
-			)),
-			C: template.Must(template.New("").Funcs(templateFuncs).Parse(
-				`{{ range .Changes }}{{ with .Comment }}{{ printf "-- %s%s\n" (slice . 0 1 | upper ) (slice . 1) }}{{ end }}{{ printf "%s;\n" .Cmd }}{{ end }}`,
+			N: template.Must(template.New("").Funcs(templateFuncs).Parse(defaultName)),
+			C: template.Must(template.New("").Funcs(templateFuncs).Parse(defaultContent)),
+		},
+	}
+	// DownFormatter is like the DefaultFormatter, but it also writes the reverse statements
+	// of reversible plans to an atlas:down section at the end of the migration file.
+	DownFormatter = TemplateFormatter{
+		{
+			N: template.Must(template.New("").Funcs(templateFuncs).Parse(defaultName)),
+			C: template.Must(template.New("").Funcs(templateFuncs).Parse(defaultContent +
+				`{{ if .Reversible }}{{ printf "\n-- atlas:down\n" }}` +
+				`{{ range $c := rev .Changes }}{{ with $stmts := .ReverseStmts }}{{ with $c.Comment }}{{ printf "-- Reverse: %s\n" . }}{{ end }}` +
+				`{{ range $stmts }}{{ printf "%s%s\n" . (or $.Delimiter ";") }}{{ end }}{{ end }}{{ end }}{{ end }}`,
 			)),
 		},
 	}
 )
 
+// reverseChanges returns the changes in reverse order.
+func reverseChanges(changes []*Change) []*Change {
+	rev := make([]*Change, len(changes))
+	for i, c := range changes {
+		rev[len(changes)-1-i] = c
+	}
+	return rev
+}
+
 // TemplateFormatter implements Formatter by using templates.
 type TemplateFormatter []struct{ N, C *template.Template }
 
@@ -528,9 +606,16 @@ const (
 	// atlas:delimiter directive.
 	directiveDelimiter = "delimiter"
 	directivePrefixSQL = "-- "
+	// atlas:phase directive.
+	directivePhase = "phase"
+	// atlas:down directive.
+	directiveDown = "down"
 )
 
-var reDirective = regexp.MustCompile(`^([ -~]*)atlas:(\w+)(?: +([ -~]*))*`)
+var (
+	reDirective   = regexp.MustCompile(`^([ -~]*)atlas:(\w+)(?: +([ -~]*))*`)
+	reDownSection = regexp.MustCompile(`(?m)^` + directivePrefixSQL + `atlas:` + directiveDown + `[ \t]*\r?$`)
+)
 
 // directive searches in the content a line that matches a directive
 // with the given prefix and name. For example:
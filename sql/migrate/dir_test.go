@@ -315,6 +315,46 @@ alter table pets drop column id;
 	require.Equal(t, []string{"ignore"}, f.Directive("lint"), "double newline as directive separator")
 }
 
+func TestLocalFile_DownStmts(t *testing.T) {
+	f := migrate.NewLocalFile("1.sql", []byte(`CREATE TABLE t1(c int);
+CREATE TABLE t2(c int);
+`))
+	_, err := f.DownStmts()
+	require.ErrorIs(t, err, migrate.ErrNoDownStmts)
+
+	f = migrate.NewLocalFile("1.sql", []byte(`CREATE TABLE t1(c int);
+-- atlas:down
+DROP TABLE t1;
+`))
+	stmts, err := f.Stmts()
+	require.NoError(t, err)
+	require.Equal(t, []string{"CREATE TABLE t1(c int);"}, stmts)
+	stmts, err = f.DownStmts()
+	require.NoError(t, err)
+	require.Equal(t, []string{"DROP TABLE t1;"}, stmts)
+
+	f = migrate.NewLocalFile("1.sql", []byte(`-- atlas:delimiter //
+
+CREATE PROCEDURE p1() BEGIN SELECT 1; END//
+
+-- atlas:down
+DROP PROCEDURE p1//
+`))
+	decls, err := f.StmtDecls()
+	require.NoError(t, err)
+	require.Len(t, decls, 1)
+	require.Equal(t, "CREATE PROCEDURE p1() BEGIN SELECT 1; END", decls[0].Text)
+	stmts, err = f.DownStmts()
+	require.NoError(t, err)
+	require.Equal(t, []string{"DROP PROCEDURE p1"}, stmts)
+
+	// An empty down section denotes there is nothing to revert.
+	f = migrate.NewLocalFile("1.sql", []byte("CREATE TABLE t1(c int);\n-- atlas:down\n"))
+	stmts, err = f.DownStmts()
+	require.NoError(t, err)
+	require.Empty(t, stmts)
+}
+
 func TestDirTar(t *testing.T) {
 	d := migrate.OpenMemDir("")
 	defer d.Close()
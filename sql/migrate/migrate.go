@@ -10,6 +10,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -33,6 +34,17 @@ type (
 
 		// Changes defines the list of changeset in the plan.
 		Changes []*Change
+
+		// Delimiter is an optional custom delimiter for separating the statements of the
+		// plan. It is set by drivers in case one of the statements cannot be separated with
+		// the default delimiter (e.g. MySQL stored routines with BEGIN ... END blocks), and
+		// it is written as an atlas:delimiter directive to the migration file.
+		Delimiter string
+
+		// Phase of the plan in an expand/contract migration (see PlanWithExpandContract),
+		// or empty if the plan is not phased. It is written as an atlas:phase directive to
+		// the migration file.
+		Phase string
 	}
 
 	// A Change of migration.
@@ -47,14 +59,39 @@ type (
 		Comment string
 
 		// Reverse contains the "reversed" statement(s) if
-		// the command is reversible.
+		// the command is reversible. An empty, non-nil list
+		// marks a reversible change that requires no statements
+		// to be reverted. e.g., validating a constraint.
 		Reverse any // string | []string
 
 		// The Source that caused this change, or nil.
-		Source schema.Change
+		// It is not encoded when a plan is serialized.
+		Source schema.Change `json:"-"`
+
+		// Separate indicates the change should be written to a separate migration
+		// file that is executed after the plan file, and therefore, in a different
+		// transaction. For example, validating a constraint that was added as NOT VALID.
+		Separate bool
+
+		// Contract indicates the change belongs to the contract phase of an expand/contract
+		// migration. Contract changes are written to a following migration file, that should
+		// be applied only after all running application versions stopped using the columns
+		// that were replaced in the expand phase.
+		Contract bool
+
+		// Warning describes an issue with the change that should be reported to the
+		// user. For example, a change that could not be planned as requested by the
+		// diff options, and was planned in a different way instead.
+		Warning string
 	}
 )
 
+// Phases of an expand/contract migration.
+const (
+	PhaseExpand   = "expand"
+	PhaseContract = "contract"
+)
+
 // ReverseStmts returns the reverse statements of a Change, if any.
 func (c *Change) ReverseStmts() (cmd []string, err error) {
 	switch r := c.Reverse.(type) {
@@ -106,6 +143,9 @@ type (
 		// This is useful to indicate to the driver whether the context is a live database, an empty one, or the
 		// versioned migration workflow.
 		Mode PlanMode
+		// ExpandContract instructs the driver to plan breaking column changes, such as
+		// renames and type changes, in expand and contract phases. See Change.Contract.
+		ExpandContract bool
 	}
 
 	// PlanMode defines the plan mode to use.
@@ -159,7 +199,9 @@ func Schema(s *schema.Schema) StateReader {
 	return StateReaderFunc(func(context.Context) (*schema.Realm, error) {
 		r := &schema.Realm{Schemas: []*schema.Schema{s}}
 		if s.Realm != nil {
-			r.Attrs = s.Realm.Attrs
+			// Roles are defined at the realm level, and are kept
+			// in case they were inspected alongside the schema.
+			r.Attrs, r.Roles = s.Realm.Attrs, s.Realm.Roles
 		}
 		s.Realm = r
 		return r, nil
@@ -194,6 +236,7 @@ type (
 		sum      bool                // whether to create a sum file for the migration directory
 		planOpts []PlanOption        // plan options
 		diffOpts []schema.DiffOption // diff options
+		mode     schema.InspectMode  // inspection mode of the replayed state
 	}
 
 	// PlannerOption allows managing a Planner using functional arguments.
@@ -243,6 +286,7 @@ type (
 		baselineVer string             // Start the first migration after the given baseline version.
 		allowDirty  bool               // Allow start working on a non-clean database.
 		operator    string             // Revision.OperatorVersion
+		reverter    Reverter           // Compute down statements of files that do not store them.
 	}
 
 	// ExecutorOption allows configuring an Executor using functional arguments.
@@ -339,6 +383,18 @@ func PlanWithMode(m PlanMode) PlannerOption {
 	}
 }
 
+// PlanWithExpandContract configures the Planner to split breaking column changes, such as
+// renames and type changes, into an expand migration file that adds the new columns, keeps
+// them in sync with the replaced ones and backfills them, and a following contract migration
+// file that drops the replaced columns. Drivers that do not support this mode ignore it.
+func PlanWithExpandContract(b bool) PlannerOption {
+	return func(p *Planner) {
+		p.planOpts = append(p.planOpts, func(o *PlanOptions) {
+			o.ExpandContract = b
+		})
+	}
+}
+
 // PlanWithDiffOptions allows setting custom diff options.
 func PlanWithDiffOptions(opts ...schema.DiffOption) PlannerOption {
 	return func(p *Planner) {
@@ -346,6 +402,14 @@ func PlanWithDiffOptions(opts ...schema.DiffOption) PlannerOption {
 	}
 }
 
+// PlanWithInspectMode sets the mode used for inspecting the dev database after the
+// migration directory was replayed on it. For example, in order to include roles.
+func PlanWithInspectMode(m schema.InspectMode) PlannerOption {
+	return func(p *Planner) {
+		p.mode = m
+	}
+}
+
 // PlanFormat sets the Formatter of a Planner.
 func PlanFormat(fmt Formatter) PlannerOption {
 	return func(p *Planner) {
@@ -389,11 +453,11 @@ func (p *Planner) plan(ctx context.Context, name string, to StateReader, realmSc
 	}
 	current, err := from.Replay(ctx, func() StateReader {
 		if realmScope {
-			return RealmConn(p.drv, nil)
+			return RealmConn(p.drv, &schema.InspectRealmOption{Mode: p.mode})
 		}
 		// In case the scope is the schema connection,
 		// inspect it and return its connected realm.
-		return SchemaConn(p.drv, "", nil)
+		return SchemaConn(p.drv, "", &schema.InspectOptions{Mode: p.mode})
 	}())
 	if err != nil {
 		return nil, err
@@ -437,16 +501,22 @@ func (p *Planner) plan(ctx context.Context, name string, to StateReader, realmSc
 
 // WritePlan writes the given Plan to the Dir based on the configured Formatter.
 func (p *Planner) WritePlan(plan *Plan) error {
-	// Format the plan into files.
-	files, err := p.fmt.Format(plan)
+	plans, err := splitPlan(plan, p.dir)
 	if err != nil {
 		return err
 	}
-	// Store the files in the migration directory.
-	for _, f := range files {
-		if err := p.dir.WriteFile(f.Name(), f.Bytes()); err != nil {
+	for _, plan := range plans {
+		// Format the plan into files.
+		files, err := p.fmt.Format(plan)
+		if err != nil {
 			return err
 		}
+		// Store the files in the migration directory.
+		for _, f := range files {
+			if err := p.dir.WriteFile(f.Name(), f.Bytes()); err != nil {
+				return err
+			}
+		}
 	}
 	// If enabled, update the sum file.
 	if p.sum {
@@ -459,6 +529,87 @@ func (p *Planner) WritePlan(plan *Plan) error {
 	return nil
 }
 
+// splitPlan moves the changes that are marked as separate to a following plan, named
+// with the "separate" suffix, and the changes that belong to the contract phase of an
+// expand/contract migration to a last plan, named with the "contract" suffix. Plans are
+// versioned sequentially, skipping versions that are taken by files in the directory, and
+// the version of a plan without a version is generated to compute the following ones.
+func splitPlan(plan *Plan, dir Dir) ([]*Plan, error) {
+	var main, separate, contract []*Change
+	for _, c := range plan.Changes {
+		switch {
+		case c.Contract:
+			contract = append(contract, c)
+		case c.Separate:
+			separate = append(separate, c)
+		default:
+			main = append(main, c)
+		}
+	}
+	var phase string
+	if len(contract) > 0 {
+		phase = PhaseExpand
+	}
+	type group struct {
+		suffix, phase string
+		changes       []*Change
+	}
+	var groups []group
+	for _, g := range []group{
+		{"", phase, main},
+		{"separate", phase, separate},
+		{"contract", PhaseContract, contract},
+	} {
+		if len(g.changes) > 0 {
+			groups = append(groups, g)
+		}
+	}
+	if len(groups) < 2 {
+		p := *plan
+		if len(groups) == 1 {
+			p.Phase = groups[0].phase
+		}
+		return []*Plan{&p}, nil
+	}
+	v := plan.Version
+	if v == "" {
+		v = time.Now().UTC().Format("20060102150405")
+	}
+	n, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("sql/migrate: cannot split plan with non-numeric version %q", v)
+	}
+	files, err := dir.Files()
+	if err != nil {
+		return nil, err
+	}
+	taken := make(map[string]bool, len(files))
+	for _, f := range files {
+		taken[f.Version()] = true
+	}
+	plans := make([]*Plan, 0, len(groups))
+	for i, g := range groups {
+		p := *plan
+		p.Changes, p.Phase = g.changes, g.phase
+		// The first plan keeps the original version and name.
+		if i > 0 {
+			for n++; taken[strconv.FormatUint(n, 10)]; n++ {
+				// Skip versions that are taken.
+			}
+			v = strconv.FormatUint(n, 10)
+			if g.suffix != "" {
+				if p.Name != "" {
+					p.Name += "_"
+				}
+				p.Name += g.suffix
+			}
+		}
+		p.Version = v
+		plans = append(plans, &p)
+	}
+	return plans, nil
+}
+
 var (
 	// ErrNoPendingFiles is returned if there are no pending migration files to execute on the managed database.
 	ErrNoPendingFiles = errors.New("sql/migrate: execute: nothing to do")
@@ -468,6 +619,8 @@ var (
 	ErrCleanCheckerUnsupported = errors.New("sql/migrate: driver does not support checking if database is clean")
 	// ErrRevisionNotExist is returned if the requested revision is not found in the storage.
 	ErrRevisionNotExist = errors.New("sql/migrate: revision not found")
+	// ErrNoAppliedFiles is returned if there are no applied migration files to revert on the managed database.
+	ErrNoAppliedFiles = errors.New("sql/migrate: down: nothing to do")
 )
 
 // MissingMigrationError is returned if a revision is partially applied but
@@ -558,6 +711,15 @@ func WithOperatorVersion(v string) ExecutorOption {
 	}
 }
 
+// WithReverter sets the Reverter of an Executor. It is used to compute the down
+// statements of migration files that were written without them.
+func WithReverter(r Reverter) ExecutorOption {
+	return func(ex *Executor) error {
+		ex.reverter = r
+		return nil
+	}
+}
+
 // Pending returns all pending (not fully applied) migration files in the migration directory.
 func (e *Executor) Pending(ctx context.Context) ([]File, error) {
 	// Don't operate with a broken migration directory.
@@ -843,6 +1005,247 @@ func (e *Executor) Replay(ctx context.Context, r StateReader, opts ...ReplayOpti
 	return r.ReadState(ctx)
 }
 
+// Applied returns all applied migration files that can be reverted, ordered by their version.
+// Baseline revisions are not included, as they were never executed on the database.
+func (e *Executor) Applied(ctx context.Context) ([]File, error) {
+	// Don't operate with a broken migration directory.
+	if err := Validate(e.dir); err != nil {
+		return nil, fmt.Errorf("sql/migrate: down: validate migration directory: %w", err)
+	}
+	revs, err := e.rrw.ReadRevisions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("sql/migrate: down: read revisions: %w", err)
+	}
+	migrations, err := e.dir.Files()
+	if err != nil {
+		return nil, fmt.Errorf("sql/migrate: down: select migration files: %w", err)
+	}
+	var applied []File
+	for _, r := range revs {
+		if r.Type == RevisionTypeBaseline {
+			continue
+		}
+		if r.Applied != r.Total {
+			return nil, fmt.Errorf("sql/migrate: down: revision %q is partially applied", r.Version)
+		}
+		idx := FilesLastIndex(migrations, func(f File) bool {
+			return f.Version() == r.Version
+		})
+		if idx == -1 {
+			return nil, fmt.Errorf("sql/migrate: down: migration file of revision %q was not found", r.Version)
+		}
+		applied = append(applied, migrations[idx])
+	}
+	if len(applied) == 0 {
+		return nil, ErrNoAppliedFiles
+	}
+	return applied, nil
+}
+
+// ExecuteDownN reverts the n last applied migration files. If n<=0 all applied migration files are reverted.
+func (e *Executor) ExecuteDownN(ctx context.Context, n int) error {
+	l, err := e.DownN(ctx, n)
+	if err != nil {
+		return err
+	}
+	return e.down(ctx, l)
+}
+
+// ExecuteDownTo reverts all applied migration files with a version greater than the given version.
+// Hence, the migration file with the given version is the last applied file after the execution.
+func (e *Executor) ExecuteDownTo(ctx context.Context, version string) error {
+	l, err := e.DownTo(ctx, version)
+	if err != nil {
+		return err
+	}
+	return e.down(ctx, l)
+}
+
+// DownN returns the execution of reverting the n last applied migration files. If n<=0 all
+// applied migration files are included. The files of the returned execution are ordered in
+// the way they should be reverted, and its To field holds the version that remains applied.
+func (e *Executor) DownN(ctx context.Context, n int) (LogExecution, error) {
+	applied, err := e.Applied(ctx)
+	if err != nil {
+		return LogExecution{}, err
+	}
+	if n <= 0 || n > len(applied) {
+		n = len(applied)
+	}
+	return downExecution(applied, n, ""), nil
+}
+
+// DownTo returns the execution of reverting all applied migration files with a version greater
+// than the given version. The version can be either of an applied file, or of the baseline revision
+// of the database. The files of the returned execution are ordered in the way they should be reverted.
+func (e *Executor) DownTo(ctx context.Context, version string) (LogExecution, error) {
+	applied, err := e.Applied(ctx)
+	if err != nil {
+		return LogExecution{}, err
+	}
+	idx := FilesLastIndex(applied, func(f File) bool {
+		return f.Version() == version
+	})
+	switch {
+	case idx == len(applied)-1:
+		return LogExecution{}, ErrNoAppliedFiles
+	case idx != -1:
+		return downExecution(applied, len(applied)-idx-1, ""), nil
+	}
+	// Baseline revisions are not returned by Applied, as they
+	// cannot be reverted. However, they can be reverted to.
+	switch r, err := e.rrw.ReadRevision(ctx, version); {
+	case err != nil && !errors.Is(err, ErrRevisionNotExist):
+		return LogExecution{}, fmt.Errorf("sql/migrate: down: read revision: %w", err)
+	case err == nil && r.Type == RevisionTypeBaseline:
+		return downExecution(applied, len(applied), version), nil
+	default:
+		return LogExecution{}, fmt.Errorf("sql/migrate: down: applied migration with version %q not found", version)
+	}
+}
+
+// downExecution returns the execution of reverting the n last files of the applied
+// migration files. The "to" argument is used as the target version in case all files
+// are reverted.
+func downExecution(applied []File, n int, to string) LogExecution {
+	l := LogExecution{From: applied[len(applied)-1].Version(), To: to}
+	if n < len(applied) {
+		l.To = applied[len(applied)-n-1].Version()
+	}
+	for i := len(applied) - 1; i >= len(applied)-n; i-- {
+		l.Files = append(l.Files, applied[i])
+	}
+	return l
+}
+
+// down reverts the files of the given execution.
+func (e *Executor) down(ctx context.Context, l LogExecution) error {
+	e.log.Log(l)
+	for _, f := range l.Files {
+		if err := e.Revert(ctx, f); err != nil {
+			return err
+		}
+	}
+	e.log.Log(LogDone{})
+	return nil
+}
+
+// Revert reverts the given applied migration file on the database and deletes its revision. The statements
+// stored in the down section of the file are used, and if there are none, they are computed by the Reverter.
+//
+// Note that Revert does not wrap the execution in a transaction. Callers that need the statements and the
+// revision deletion to be executed atomically should create the Executor with a transactional Driver and
+// RevisionReadWriter, as done by 'atlas migrate down'.
+func (e *Executor) Revert(ctx context.Context, f File) error {
+	e.log.Log(LogFile{File: f, Version: f.Version(), Desc: f.Desc()})
+	stmts, err := e.downStmts(ctx, f)
+	if err != nil {
+		e.log.Log(LogError{Error: err})
+		return err
+	}
+	for _, stmt := range stmts {
+		e.log.Log(LogStmt{stmt})
+		if _, err := e.drv.ExecContext(ctx, stmt); err != nil {
+			e.log.Log(LogError{SQL: stmt, Error: err})
+			return fmt.Errorf("sql/migrate: down: executing statement %q from version %q: %w", stmt, f.Version(), err)
+		}
+	}
+	if err := e.rrw.DeleteRevision(ctx, f.Version()); err != nil {
+		return fmt.Errorf("sql/migrate: down: delete revision: %w", err)
+	}
+	return nil
+}
+
+// downStmts returns the statements for reverting the given file.
+func (e *Executor) downStmts(ctx context.Context, f File) ([]string, error) {
+	if df, ok := f.(DownFile); ok {
+		stmts, err := df.DownStmts()
+		if err == nil {
+			return stmts, nil
+		}
+		if !errors.Is(err, ErrNoDownStmts) {
+			return nil, fmt.Errorf("sql/migrate: down: scanning down statements from %q: %w", f.Name(), err)
+		}
+	}
+	if e.reverter == nil {
+		return nil, fmt.Errorf("sql/migrate: down: file %q does not store down statements and no reverter was given", f.Name())
+	}
+	stmts, err := e.reverter.RevertStmts(ctx, f)
+	if err != nil {
+		return nil, fmt.Errorf("sql/migrate: down: computing down statements of %q: %w", f.Name(), err)
+	}
+	return stmts, nil
+}
+
+type (
+	// A Reverter computes the statements for reverting a migration file
+	// in case the file does not store its down statements.
+	Reverter interface {
+		// RevertStmts returns the statements that revert the changes of the
+		// given migration file, restoring the state of the previous file.
+		RevertStmts(context.Context, File) ([]string, error)
+	}
+
+	// DevReverter implements the Reverter interface by replaying the migration directory
+	// on a dev database and planning the changes between the states after and before
+	// the reverted file.
+	DevReverter struct {
+		Dev     Driver       // The Driver of the dev database.
+		Dir     Dir          // The Dir with migration files to replay.
+		State   StateReader  // The StateReader of the dev database.
+		Options []PlanOption // Options for planning the reverse changes.
+	}
+)
+
+// RevertStmts implements the Reverter interface.
+func (r *DevReverter) RevertStmts(ctx context.Context, f File) ([]string, error) {
+	files, err := r.Dir.Files()
+	if err != nil {
+		return nil, err
+	}
+	idx := FilesLastIndex(files, func(f2 File) bool {
+		return f2.Version() == f.Version()
+	})
+	if idx == -1 {
+		return nil, fmt.Errorf("migration file %q was not found in the migration directory", f.Name())
+	}
+	ex, err := NewExecutor(r.Dev, r.Dir, NopRevisionReadWriter{})
+	if err != nil {
+		return nil, err
+	}
+	current, err := ex.Replay(ctx, r.State, ReplayToVersion(f.Version()))
+	if err != nil {
+		return nil, err
+	}
+	var prev *schema.Realm
+	switch {
+	case idx == 0:
+		// The state before the first file is the clean dev database.
+		prev, err = r.State.ReadState(ctx)
+	default:
+		prev, err = ex.Replay(ctx, r.State, ReplayToVersion(files[idx-1].Version()))
+	}
+	if err != nil {
+		return nil, err
+	}
+	changes, err := r.Dev.RealmDiff(current, prev)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	plan, err := r.Dev.PlanChanges(ctx, "", changes, r.Options...)
+	if err != nil {
+		return nil, err
+	}
+	stmts := make([]string, len(plan.Changes))
+	for i, c := range plan.Changes {
+		stmts[i] = c.Cmd
+	}
+	return stmts, nil
+}
+
 type (
 	// Snapshoter wraps the Snapshot method.
 	Snapshoter interface {
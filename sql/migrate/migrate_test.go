@@ -10,6 +10,7 @@ import (
 	_ "embed"
 	"errors"
 	"io/fs"
+	"os"
 	"path/filepath"
 	"testing"
 	"text/template"
@@ -60,6 +61,84 @@ func TestPlanner_WritePlan(t *testing.T) {
 	require.Equal(t, countFiles(t, d), 1)
 	requireFileEqual(t, d, v+"_add_t1_and_t2.sql", "CREATE TABLE t1(c int);\nCREATE TABLE t2(c int);\n")
 
+	// DefaultFormatter with a custom delimiter.
+	plan.Name, plan.Delimiter = "add_p1", "//"
+	plan.Changes = []*migrate.Change{
+		{Cmd: "CREATE TABLE t3(c int)", Comment: "create t3"},
+		{Cmd: "CREATE PROCEDURE p1() BEGIN SELECT 1; SELECT 2; END"},
+	}
+	require.NoError(t, pl.WritePlan(plan))
+	require.Equal(t, countFiles(t, d), 2)
+	requireFileEqual(t, d, v+"_add_p1.sql", "-- atlas:delimiter //\n\n-- Create t3\nCREATE TABLE t3(c int)//\nCREATE PROCEDURE p1() BEGIN SELECT 1; SELECT 2; END//\n")
+	buf, err := os.ReadFile(filepath.Join(p, v+"_add_p1.sql"))
+	require.NoError(t, err)
+	stmts, err := migrate.Stmts(string(buf))
+	require.NoError(t, err)
+	require.Len(t, stmts, 2)
+	require.Equal(t, "CREATE TABLE t3(c int)", stmts[0].Text)
+	require.Equal(t, "CREATE PROCEDURE p1() BEGIN SELECT 1; SELECT 2; END", stmts[1].Text)
+
+	// Separate changes are written to a following file.
+	plan.Version, plan.Name, plan.Delimiter = "20240101000000", "add_fk", ""
+	plan.Changes = []*migrate.Change{
+		{Cmd: "ALTER TABLE t1 ADD CONSTRAINT fk FOREIGN KEY (c) REFERENCES t2 (c) NOT VALID"},
+		{Cmd: "ALTER TABLE t1 VALIDATE CONSTRAINT fk", Separate: true},
+	}
+	require.NoError(t, pl.WritePlan(plan))
+	require.Equal(t, countFiles(t, d), 4)
+	requireFileEqual(t, d, "20240101000000_add_fk.sql", "ALTER TABLE t1 ADD CONSTRAINT fk FOREIGN KEY (c) REFERENCES t2 (c) NOT VALID;\n")
+	requireFileEqual(t, d, "20240101000001_add_fk_separate.sql", "ALTER TABLE t1 VALIDATE CONSTRAINT fk;\n")
+	plan.Version = "v1"
+	require.EqualError(t, pl.WritePlan(plan), `sql/migrate: cannot split plan with non-numeric version "v1"`)
+	// Versions that are taken by the directory are skipped.
+	plan.Version = "20240101000000"
+	require.NoError(t, pl.WritePlan(plan))
+	require.Equal(t, countFiles(t, d), 5)
+	requireFileEqual(t, d, "20240101000002_add_fk_separate.sql", "ALTER TABLE t1 VALIDATE CONSTRAINT fk;\n")
+	require.NoError(t, os.Remove(filepath.Join(p, "20240101000002_add_fk_separate.sql")))
+
+	// Contract changes are written to a last file, and the files record their phases.
+	plan.Version, plan.Name = "20240201000000", "rename_c"
+	plan.Changes = []*migrate.Change{
+		{Cmd: "ALTER TABLE t1 ADD COLUMN d int"},
+		{Cmd: "ALTER TABLE t1 VALIDATE CONSTRAINT fk", Separate: true},
+		{Cmd: "ALTER TABLE t1 DROP COLUMN c", Contract: true},
+	}
+	require.NoError(t, pl.WritePlan(plan))
+	require.Equal(t, countFiles(t, d), 7)
+	requireFileEqual(t, d, "20240201000000_rename_c.sql", "-- atlas:phase expand\n\nALTER TABLE t1 ADD COLUMN d int;\n")
+	requireFileEqual(t, d, "20240201000001_rename_c_separate.sql", "-- atlas:phase expand\n\nALTER TABLE t1 VALIDATE CONSTRAINT fk;\n")
+	requireFileEqual(t, d, "20240201000002_rename_c_contract.sql", "-- atlas:phase contract\n\nALTER TABLE t1 DROP COLUMN c;\n")
+	files, err := d.Files()
+	require.NoError(t, err)
+	require.Equal(t, migrate.PhaseExpand, files[2].(*migrate.LocalFile).Phase())
+	require.Equal(t, migrate.PhaseContract, files[4].(*migrate.LocalFile).Phase())
+	require.Empty(t, files[0].(*migrate.LocalFile).Phase())
+
+	// File directives are written in one header block.
+	plan.Version, plan.Name, plan.Delimiter = "20240301000000", "add_p2", "//"
+	plan.Changes = []*migrate.Change{
+		{Cmd: "CREATE PROCEDURE p2() BEGIN SELECT 1; END"},
+		{Cmd: "DROP PROCEDURE p1", Contract: true},
+	}
+	require.NoError(t, pl.WritePlan(plan))
+	requireFileEqual(t, d, "20240301000000_add_p2.sql", "-- atlas:delimiter //\n-- atlas:phase expand\n\nCREATE PROCEDURE p2() BEGIN SELECT 1; END//\n")
+	requireFileEqual(t, d, "20240301000001_add_p2_contract.sql", "-- atlas:delimiter //\n-- atlas:phase contract\n\nDROP PROCEDURE p1//\n")
+	files, err = d.Files()
+	require.NoError(t, err)
+	require.Equal(t, migrate.PhaseExpand, files[5].(*migrate.LocalFile).Phase())
+	require.Equal(t, migrate.PhaseContract, files[6].(*migrate.LocalFile).Phase())
+	stmts, err = files[5].StmtDecls()
+	require.NoError(t, err)
+	require.Len(t, stmts, 1)
+	require.Equal(t, "CREATE PROCEDURE p2() BEGIN SELECT 1; END", stmts[0].Text)
+	plan.Delimiter = ""
+	plan.Version, plan.Name = "", "add_t1_and_t2"
+	plan.Changes = []*migrate.Change{
+		{Cmd: "CREATE TABLE t1(c int)", Reverse: "DROP TABLE t1 IF EXISTS"},
+		{Cmd: "CREATE TABLE t2(c int)", Reverse: "DROP TABLE t2"},
+	}
+
 	// Custom formatter (creates "up" and "down" migration files).
 	fmt, err := migrate.NewTemplateFormatter(
 		template.Must(template.New("").Parse("{{ .Name }}.up.sql")),
@@ -71,7 +150,7 @@ func TestPlanner_WritePlan(t *testing.T) {
 	pl = migrate.NewPlanner(nil, d, migrate.PlanFormat(fmt), migrate.PlanWithChecksum(false))
 	require.NotNil(t, pl)
 	require.NoError(t, pl.WritePlan(plan))
-	require.Equal(t, countFiles(t, d), 3)
+	require.Equal(t, countFiles(t, d), 11)
 	requireFileEqual(t, d, "add_t1_and_t2.up.sql", "CREATE TABLE t1(c int)\nCREATE TABLE t2(c int)\n")
 	requireFileEqual(t, d, "add_t1_and_t2.down.sql", "DROP TABLE t1 IF EXISTS\nDROP TABLE t2\n")
 }
@@ -104,6 +183,13 @@ func TestPlanner_Plan(t *testing.T) {
 	plan, err = pl.Plan(ctx, "", migrate.Realm(nil))
 	require.NoError(t, err)
 	require.Equal(t, drv.plan, plan)
+	require.Zero(t, drv.mode)
+
+	// The replayed state is inspected with the given mode.
+	pl = migrate.NewPlanner(drv, d, migrate.PlanWithInspectMode(schema.InspectSchemas|schema.InspectTables|schema.InspectRoles))
+	_, err = pl.Plan(ctx, "", migrate.Realm(nil))
+	require.NoError(t, err)
+	require.Equal(t, schema.InspectSchemas|schema.InspectTables|schema.InspectRoles, drv.mode)
 }
 
 func TestPlanner_PlanSchema(t *testing.T) {
@@ -525,6 +611,152 @@ func TestExecutor_FromVersion(t *testing.T) {
 	require.Nil(t, files)
 }
 
+func TestDownFormatter(t *testing.T) {
+	plan := &migrate.Plan{
+		Version:    "1",
+		Name:       "add_t1_and_t2",
+		Reversible: true,
+		Changes: []*migrate.Change{
+			{Cmd: "CREATE TABLE t1(c int)", Reverse: "DROP TABLE t1", Comment: "create t1"},
+			{Cmd: "CREATE TABLE t2(c int)", Reverse: []string{"DROP TABLE t2", "DROP TABLE t3"}},
+		},
+	}
+	files, err := migrate.DownFormatter.Format(plan)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.Equal(t, "1_add_t1_and_t2.sql", files[0].Name())
+	require.Equal(t, "-- Create t1\nCREATE TABLE t1(c int);\nCREATE TABLE t2(c int);\n\n-- atlas:down\nDROP TABLE t2;\nDROP TABLE t3;\n-- Reverse: create t1\nDROP TABLE t1;\n", string(files[0].Bytes()))
+	stmts, err := files[0].Stmts()
+	require.NoError(t, err)
+	require.Equal(t, []string{"CREATE TABLE t1(c int);", "CREATE TABLE t2(c int);"}, stmts)
+	stmts, err = files[0].(migrate.DownFile).DownStmts()
+	require.NoError(t, err)
+	require.Equal(t, []string{"DROP TABLE t2;", "DROP TABLE t3;", "DROP TABLE t1;"}, stmts)
+
+	// Irreversible plans are written without a down section.
+	plan.Reversible = false
+	files, err = migrate.DownFormatter.Format(plan)
+	require.NoError(t, err)
+	require.Equal(t, "-- Create t1\nCREATE TABLE t1(c int);\nCREATE TABLE t2(c int);\n", string(files[0].Bytes()))
+	_, err = files[0].(migrate.DownFile).DownStmts()
+	require.ErrorIs(t, err, migrate.ErrNoDownStmts)
+}
+
+func TestExecutor_Down(t *testing.T) {
+	var (
+		ctx = context.Background()
+		drv = &mockDriver{}
+		log = &mockLogger{}
+		dir = &migrate.MemDir{}
+		rrw = &mockRevisionReadWriter{}
+	)
+	require.NoError(t, dir.WriteFile("1_t1.sql", []byte("CREATE TABLE t1(c int);\n\n-- atlas:down\nDROP TABLE t1;\n")))
+	require.NoError(t, dir.WriteFile("2_t2.sql", []byte("-- atlas:delimiter //\n\nCREATE TABLE t2(c int)//\n\n-- atlas:down\nDROP TABLE t2//\n")))
+	require.NoError(t, dir.WriteFile("3_t3.sql", []byte("CREATE TABLE t3(c int);\n")))
+	sum, err := dir.Checksum()
+	require.NoError(t, err)
+	require.NoError(t, migrate.WriteSumFile(dir, sum))
+
+	ex, err := migrate.NewExecutor(drv, dir, rrw, migrate.WithLogger(log))
+	require.NoError(t, err)
+	require.ErrorIs(t, ex.ExecuteDownN(ctx, 1), migrate.ErrNoAppliedFiles)
+	require.NoError(t, ex.ExecuteN(ctx, 0))
+	require.Len(t, *rrw, 3)
+
+	// Files without down statements cannot be reverted without a reverter.
+	drv.executed = nil
+	require.EqualError(t, ex.ExecuteDownN(ctx, 1), `sql/migrate: down: file "3_t3.sql" does not store down statements and no reverter was given`)
+	require.Len(t, *rrw, 3)
+
+	// Down statements are computed by the reverter.
+	r := &mockReverter{stmts: []string{"DROP TABLE t3"}}
+	*log = nil
+	ex, err = migrate.NewExecutor(drv, dir, rrw, migrate.WithLogger(log), migrate.WithReverter(r))
+	require.NoError(t, err)
+	require.NoError(t, ex.ExecuteDownN(ctx, 1))
+	require.Equal(t, []string{"3_t3.sql"}, r.files)
+	require.Equal(t, []string{"DROP TABLE t3"}, drv.executed)
+	require.Len(t, *rrw, 2)
+	files, err := dir.Files()
+	require.NoError(t, err)
+	require.Equal(t, []migrate.LogEntry{
+		migrate.LogExecution{From: "3", To: "2", Files: files[2:3]},
+		migrate.LogFile{File: files[2], Version: "3", Desc: "t3"},
+		migrate.LogStmt{SQL: "DROP TABLE t3"},
+		migrate.LogDone{},
+	}, []migrate.LogEntry(*log))
+
+	// Stored down statements take precedence over the reverter.
+	drv.executed = nil
+	require.EqualError(t, ex.ExecuteDownTo(ctx, "3"), `sql/migrate: down: applied migration with version "3" not found`)
+	require.ErrorIs(t, ex.ExecuteDownTo(ctx, "2"), migrate.ErrNoAppliedFiles)
+	require.NoError(t, ex.ExecuteDownTo(ctx, "1"))
+	require.Equal(t, []string{"DROP TABLE t2"}, drv.executed)
+	require.Len(t, *rrw, 1)
+	require.NoError(t, ex.ExecuteDownN(ctx, 0))
+	require.Equal(t, []string{"DROP TABLE t2", "DROP TABLE t1;"}, drv.executed)
+	require.Empty(t, *rrw)
+	require.Equal(t, []string{"3_t3.sql"}, r.files)
+
+	// Baseline revisions cannot be reverted.
+	*rrw = mockRevisionReadWriter{{Version: "1", Type: migrate.RevisionTypeBaseline}}
+	require.ErrorIs(t, ex.ExecuteDownN(ctx, 0), migrate.ErrNoAppliedFiles)
+	require.ErrorIs(t, ex.ExecuteDownTo(ctx, "1"), migrate.ErrNoAppliedFiles)
+
+	// But they can be reverted to.
+	*rrw = mockRevisionReadWriter{
+		{Version: "1", Type: migrate.RevisionTypeBaseline},
+		{Version: "2", Type: migrate.RevisionTypeExecute, Applied: 1, Total: 1},
+		{Version: "3", Type: migrate.RevisionTypeExecute, Applied: 1, Total: 1},
+	}
+	l, err := ex.DownN(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, migrate.LogExecution{From: "3", To: "2", Files: files[2:3]}, l)
+	l, err = ex.DownTo(ctx, "1")
+	require.NoError(t, err)
+	require.Equal(t, migrate.LogExecution{From: "3", To: "1", Files: []migrate.File{files[2], files[1]}}, l)
+	require.EqualError(t, ex.ExecuteDownTo(ctx, "0"), `sql/migrate: down: applied migration with version "0" not found`)
+	drv.executed = nil
+	require.NoError(t, ex.ExecuteDownTo(ctx, "1"))
+	require.Equal(t, []string{"DROP TABLE t3", "DROP TABLE t2"}, drv.executed)
+	require.Equal(t, mockRevisionReadWriter{{Version: "1", Type: migrate.RevisionTypeBaseline}}, *rrw)
+
+	*rrw = mockRevisionReadWriter{{Version: "1", Type: migrate.RevisionTypeExecute, Applied: 1, Total: 2}}
+	require.EqualError(t, ex.ExecuteDownN(ctx, 0), `sql/migrate: down: revision "1" is partially applied`)
+}
+
+func TestDevReverter(t *testing.T) {
+	var (
+		ctx = context.Background()
+		drv = &mockDriver{
+			changes: []schema.Change{&schema.DropTable{T: schema.NewTable("t2")}},
+			plan:    &migrate.Plan{Changes: []*migrate.Change{{Cmd: "DROP TABLE t2"}}},
+		}
+		dir = &migrate.MemDir{}
+	)
+	require.NoError(t, dir.WriteFile("1_t1.sql", []byte("CREATE TABLE t1(c int);\n")))
+	require.NoError(t, dir.WriteFile("2_t2.sql", []byte("CREATE TABLE t2(c int);\n")))
+	sum, err := dir.Checksum()
+	require.NoError(t, err)
+	require.NoError(t, migrate.WriteSumFile(dir, sum))
+	files, err := dir.Files()
+	require.NoError(t, err)
+
+	r := &migrate.DevReverter{Dev: drv, Dir: dir, State: migrate.RealmConn(drv, nil)}
+	stmts, err := r.RevertStmts(ctx, files[1])
+	require.NoError(t, err)
+	require.Equal(t, []string{"DROP TABLE t2"}, stmts)
+	// Both states were replayed on the dev database.
+	require.Equal(t, []string{"CREATE TABLE t1(c int);", "CREATE TABLE t2(c int);", "CREATE TABLE t1(c int);"}, drv.executed)
+
+	drv.changes = nil
+	stmts, err = r.RevertStmts(ctx, files[0])
+	require.NoError(t, err)
+	require.Empty(t, stmts)
+	_, err = r.RevertStmts(ctx, migrate.NewLocalFile("3_t3.sql", nil))
+	require.EqualError(t, err, `migration file "3_t3.sql" was not found in the migration directory`)
+}
+
 type (
 	mockDriver struct {
 		migrate.Driver
@@ -536,6 +768,7 @@ type (
 		failCounter int
 		failWith    error
 		dirty       bool
+		mode        schema.InspectMode
 	}
 )
 
@@ -563,7 +796,10 @@ func (m *mockDriver) InspectSchema(context.Context, string, *schema.InspectOptio
 	return m.realm.Schemas[0], nil
 }
 
-func (m *mockDriver) InspectRealm(context.Context, *schema.InspectRealmOption) (*schema.Realm, error) {
+func (m *mockDriver) InspectRealm(_ context.Context, opts *schema.InspectRealmOption) (*schema.Realm, error) {
+	if opts != nil {
+		m.mode = opts.Mode
+	}
 	return &m.realm, nil
 }
 
@@ -660,6 +896,16 @@ func (rrw *mockRevisionReadWriter) clean() {
 	*rrw = []*migrate.Revision{}
 }
 
+type mockReverter struct {
+	stmts []string
+	files []string
+}
+
+func (r *mockReverter) RevertStmts(_ context.Context, f migrate.File) ([]string, error) {
+	r.files = append(r.files, f.Name())
+	return r.stmts, nil
+}
+
 type mockLogger []migrate.LogEntry
 
 func (m *mockLogger) Log(e migrate.LogEntry) { *m = append(*m, e) }
@@ -11,7 +11,9 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"unicode"
 
+	"ariga.io/atlas/schemahcl"
 	"ariga.io/atlas/sql/internal/sqlx"
 	"ariga.io/atlas/sql/schema"
 )
@@ -55,8 +57,17 @@ func (d *diff) SchemaAttrDiff(from, to *schema.Schema) []schema.Change {
 
 // SchemaObjectDiff returns a changeset for migrating schema objects from
 // one state to the other.
-func (*diff) SchemaObjectDiff(_, _ *schema.Schema) ([]schema.Change, error) {
-	return nil, nil
+func (*diff) SchemaObjectDiff(from, to *schema.Schema) ([]schema.Change, error) {
+	for _, s := range []*schema.Schema{from, to} {
+		for _, o := range s.Objects {
+			switch o.(type) {
+			case *schema.Func, *schema.Proc, *Event:
+			default:
+				return nil, fmt.Errorf("unsupported object type %T", o)
+			}
+		}
+	}
+	return append(routinesDiff(from, to), eventsDiff(from, to)...), nil
 }
 
 // TableAttrDiff returns a changeset for migrating table attributes from one state to the other.
@@ -77,6 +88,9 @@ func (d *diff) TableAttrDiff(from, to *schema.Table) ([]schema.Change, error) {
 	if change := d.engineChange(from.Attrs, to.Attrs); change != noChange {
 		changes = append(changes, change)
 	}
+	if change := d.partitionChange(from.Attrs, to.Attrs); change != noChange {
+		changes = append(changes, change)
+	}
 	if !d.SupportsCheck() && sqlx.Has(to.Attrs, &schema.Check{}) {
 		return nil, fmt.Errorf("version %q does not support CHECK constraints", d.V)
 	}
@@ -203,6 +217,26 @@ func (*diff) ReferenceChanged(from, to schema.ReferenceOption) bool {
 	return from != to
 }
 
+// ObjectName implements the sqlx.GrantDiffer interface. Privileges in MySQL are
+// granted only on schemas, tables, views and routines that are handled by sqlx.
+func (*diff) ObjectName(schema.Object) (string, bool) {
+	return "", false
+}
+
+// AllPrivileges returns the privileges that ALL PRIVILEGES stands for on the given object.
+func (*diff) AllPrivileges(o schema.Object, column bool) []string {
+	if column {
+		return []string{"INSERT", "REFERENCES", "SELECT", "UPDATE"}
+	}
+	if _, ok := o.(*schema.Schema); ok {
+		return []string{
+			"ALTER", "ALTER ROUTINE", "CREATE", "CREATE ROUTINE", "CREATE TEMPORARY TABLES", "CREATE VIEW", "DELETE", "DROP",
+			"EVENT", "EXECUTE", "INDEX", "INSERT", "LOCK TABLES", "REFERENCES", "SELECT", "SHOW VIEW", "TRIGGER", "UPDATE",
+		}
+	}
+	return []string{"ALTER", "CREATE", "CREATE VIEW", "DELETE", "DROP", "INDEX", "INSERT", "REFERENCES", "SELECT", "SHOW VIEW", "TRIGGER", "UPDATE"}
+}
+
 // Normalize implements the sqlx.Normalizer interface.
 func (d *diff) Normalize(from, to *schema.Table) error {
 	indexes := make([]*schema.Index, 0, len(from.Indexes))
@@ -262,6 +296,49 @@ func (d *diff) FindTable(s *schema.Schema, name string) (*schema.Table, error) {
 	}
 }
 
+// DiffOptions defines MySQL specific schema diffing process.
+type DiffOptions struct {
+	// OnlineDDL plans table modifications with the ALGORITHM and LOCK clauses
+	// that allow concurrent reads and writes to the table. Changes that cannot
+	// be applied online fail the planning, unless Unsupported is set to "warn".
+	OnlineDDL struct {
+		Enabled     bool   `spec:"enabled"`
+		Unsupported string `spec:"unsupported"`
+	} `spec:"online_ddl"`
+}
+
+// AnnotateChanges implements the sqlx.ChangeAnnotator interface.
+func (*diff) AnnotateChanges(changes []schema.Change, opts *schema.DiffOptions) error {
+	var extra DiffOptions
+	switch ex := opts.Extra.(type) {
+	case nil:
+		return nil
+	case schemahcl.DefaultExtension:
+		if err := ex.Extra.As(&extra); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("mysql: unexpected DiffOptions.Extra type %T", opts.Extra)
+	}
+	if !extra.OnlineDDL.Enabled {
+		return nil
+	}
+	online := &OnlineDDL{}
+	switch u := strings.ToLower(extra.OnlineDDL.Unsupported); u {
+	case "", "error":
+	case "warn":
+		online.Warn = true
+	default:
+		return fmt.Errorf("mysql: unexpected online_ddl.unsupported value %q. expect \"error\" or \"warn\"", extra.OnlineDDL.Unsupported)
+	}
+	for _, c := range changes {
+		if m, ok := c.(*schema.ModifyTable); ok {
+			m.Extra = append(m.Extra, online)
+		}
+	}
+	return nil
+}
+
 // collationChange returns the schema change for migrating the collation if
 // it was changed, and it is not the default attribute inherited from its parent.
 func (*diff) collationChange(from, top, to []schema.Attr) schema.Change {
@@ -320,6 +397,116 @@ func (*diff) engineChange(from, to []schema.Attr) schema.Change {
 	return noChange
 }
 
+// partitionChange returns the schema change for migrating the table
+// partitioning in case it was added, dropped or changed.
+func (*diff) partitionChange(from, to []schema.Attr) schema.Change {
+	var fromP, toP Partition
+	switch fromHas, toHas := sqlx.Has(from, &fromP), sqlx.Has(to, &toP); {
+	case fromHas && !toHas:
+		return &schema.DropAttr{
+			A: &fromP,
+		}
+	case !fromHas && toHas:
+		return &schema.AddAttr{
+			A: &toP,
+		}
+	case fromHas && toHas && (partitionKeyChanged(&fromP, &toP) || partitionsChanged(&fromP, &toP)):
+		return &schema.ModifyAttr{
+			From: &fromP,
+			To:   &toP,
+		}
+	}
+	return noChange
+}
+
+// partitionKeyChanged reports if the partitioning type or key was changed.
+func partitionKeyChanged(from, to *Partition) bool {
+	if !strings.EqualFold(from.T, to.T) || from.Linear != to.Linear || len(from.Columns) != len(to.Columns) ||
+		partitionValue(from.Expr) != partitionValue(to.Expr) {
+		return true
+	}
+	for i := range from.Columns {
+		if from.Columns[i].Name != to.Columns[i].Name {
+			return true
+		}
+	}
+	return false
+}
+
+// partitionsChanged reports if the partitions of a table were changed.
+// Note, the order of the partitions matters only for RANGE partitioning.
+func partitionsChanged(from, to *Partition) bool {
+	switch {
+	case strings.EqualFold(to.T, PartitionTypeHash), strings.EqualFold(to.T, PartitionTypeKey):
+		return partitionsN(from) != partitionsN(to)
+	case len(from.Defs) != len(to.Defs):
+		return true
+	case strings.EqualFold(to.T, PartitionTypeList):
+		for _, d1 := range from.Defs {
+			if d2, ok := partitionDef(to.Defs, d1.Name); !ok || !partitionDefEqual(d1, d2) {
+				return true
+			}
+		}
+	default:
+		for i := range from.Defs {
+			if !partitionDefEqual(from.Defs[i], to.Defs[i]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// partitionsN returns the number of partitions of a HASH or KEY partitioning.
+// If not defined, MySQL creates a single partition.
+func partitionsN(p *Partition) int {
+	if p.N > 0 {
+		return p.N
+	}
+	return 1
+}
+
+// partitionDef returns the partition definition with the given name.
+func partitionDef(defs []*PartitionDef, name string) (*PartitionDef, bool) {
+	for _, d := range defs {
+		if d.Name == name {
+			return d, true
+		}
+	}
+	return nil, false
+}
+
+// partitionDefEqual reports if the two partition definitions are equal.
+func partitionDefEqual(d1, d2 *PartitionDef) bool {
+	return d1.Name == d2.Name && partitionValue(d1.Values) == partitionValue(d2.Values)
+}
+
+// partitionValue normalizes a partitioning expression or a values list for
+// comparison, by removing identifier quotes and whitespace, and lowering the
+// case of the characters that are not part of string literals.
+func partitionValue(s string) string {
+	var (
+		b     strings.Builder
+		quote rune
+	)
+	for _, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+			b.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote = r
+			b.WriteRune(r)
+		case r == '`' || unicode.IsSpace(r):
+		default:
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
+
 // charsetChange returns the schema change for migrating the collation if
 // it was changed, and it is not the default attribute inherited from its parent.
 func (*diff) charsetChange(from, top, to []schema.Attr) schema.Change {
@@ -7,6 +7,7 @@ package mysql
 import (
 	"testing"
 
+	"ariga.io/atlas/schemahcl"
 	"ariga.io/atlas/sql/schema"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -624,6 +625,89 @@ func TestDiff_UnsupportedChecks(t *testing.T) {
 	require.EqualError(t, err, `version "5.6.35" does not support CHECK constraints`)
 }
 
+func TestDiff_Partitions(t *testing.T) {
+	var (
+		s    = schema.New("public")
+		id   = schema.NewIntColumn("id", "int")
+		from = schema.NewTable("t").SetSchema(s).AddColumns(id)
+		to   = schema.NewTable("t").SetSchema(s).AddColumns(id)
+	)
+	changes, err := DefaultDiff.TableDiff(from, to.AddAttrs(&Partition{T: PartitionTypeHash, Expr: "id"}))
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.IsType(t, &schema.AddAttr{}, changes[0])
+
+	// Expressions and values are compared after normalization, and the
+	// number of partitions defaults to 1.
+	from.AddAttrs(&Partition{T: PartitionTypeHash, Expr: "`id`", N: 1})
+	changes, err = DefaultDiff.TableDiff(from, to)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	// LIST partitions are not ordered.
+	from.Attrs[0] = &Partition{T: PartitionTypeList, Expr: "`id`", Defs: []*PartitionDef{{Name: "a", Values: "1,2"}, {Name: "b", Values: "3"}}}
+	to.Attrs[0] = &Partition{T: PartitionTypeList, Expr: "id", Defs: []*PartitionDef{{Name: "b", Values: "3"}, {Name: "a", Values: "1, 2"}}}
+	changes, err = DefaultDiff.TableDiff(from, to)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	// RANGE partitions are ordered.
+	from.Attrs[0] = &Partition{T: PartitionTypeRange, Expr: "`id`", Defs: []*PartitionDef{{Name: "a", Values: "1"}, {Name: "b", Values: "MAXVALUE"}}}
+	to.Attrs[0] = &Partition{T: PartitionTypeRange, Expr: "id", Defs: []*PartitionDef{{Name: "b", Values: "maxvalue"}, {Name: "a", Values: "1"}}}
+	changes, err = DefaultDiff.TableDiff(from, to)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.Equal(t, &schema.ModifyAttr{From: from.Attrs[0], To: to.Attrs[0]}, changes[0])
+
+	to.Attrs = nil
+	changes, err = DefaultDiff.TableDiff(from, to)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.IsType(t, &schema.DropAttr{}, changes[0])
+}
+
+func TestDiff_AnnotateOnlineDDL(t *testing.T) {
+	eval := func(t *testing.T, src string) schemahcl.DefaultExtension {
+		var cfg struct {
+			schemahcl.DefaultExtension
+		}
+		require.NoError(t, schemahcl.New().EvalBytes([]byte(src), &cfg, nil))
+		return cfg.DefaultExtension
+	}
+	var (
+		from = schema.New("public").AddTables(schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "int")))
+		to   = schema.New("public").AddTables(schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "int"), schema.NewIntColumn("age", "int")))
+	)
+	// language=hcl
+	ex := eval(t, `
+online_ddl {
+  enabled     = true
+  unsupported = "warn"
+}
+`)
+	changes, err := DefaultDiff.SchemaDiff(from, to, func(opts *schema.DiffOptions) { opts.Extra = ex })
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.Equal(t, []schema.Clause{&OnlineDDL{Warn: true}}, changes[0].(*schema.ModifyTable).Extra)
+
+	// Disabled by default.
+	ex = eval(t, `online_ddl {}`)
+	changes, err = DefaultDiff.SchemaDiff(from, to, func(opts *schema.DiffOptions) { opts.Extra = ex })
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.Empty(t, changes[0].(*schema.ModifyTable).Extra)
+
+	// language=hcl
+	ex = eval(t, `
+online_ddl {
+  enabled     = true
+  unsupported = "copy"
+}
+`)
+	_, err = DefaultDiff.SchemaDiff(from, to, func(opts *schema.DiffOptions) { opts.Extra = ex })
+	require.EqualError(t, err, `mysql: unexpected online_ddl.unsupported value "copy". expect "error" or "warn"`)
+}
+
 func TestDiff_SchemaDiff(t *testing.T) {
 	db, m, err := sqlmock.New()
 	require.NoError(t, err)
@@ -50,7 +50,6 @@ func init() {
 		DriverName,
 		sqlclient.OpenerFunc(opener),
 		sqlclient.RegisterDriverOpener(Open),
-		sqlclient.RegisterCodec(MarshalHCL, EvalHCL),
 		sqlclient.RegisterFlavours("mysql+unix", "maria", "maria+unix", "mariadb", "mariadb+unix"),
 		sqlclient.RegisterURLParser(parser{}),
 	)
@@ -96,22 +95,72 @@ func opener(_ context.Context, u *url.URL) (*sqlclient.Client, error) {
 		return nil, err
 	}
 	drv.(*Driver).schema = ur.Schema
-	return &sqlclient.Client{
-		Name:   DriverName,
-		DB:     db,
-		URL:    ur,
-		Driver: drv,
-	}, nil
+	c := &sqlclient.Client{
+		Name:      DriverName,
+		DB:        db,
+		URL:       ur,
+		Driver:    drv,
+		Marshaler: MarshalHCL,
+		Evaluator: EvalHCL,
+	}
+	// The ALGORITHM of views is inspected only on MariaDB.
+	if !drv.(*Driver).Maria() {
+		c.Marshaler = marshalMySQL
+	}
+	return c, nil
 }
 
 // NormalizeRealm returns the normal representation of the given database.
 func (d *Driver) NormalizeRealm(ctx context.Context, r *schema.Realm) (*schema.Realm, error) {
-	return (&sqlx.DevDriver{Driver: d}).NormalizeRealm(ctx, r)
+	desired := viewAlgorithms(r.Schemas...)
+	nr, err := (&sqlx.DevDriver{Driver: d}).NormalizeRealm(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	if !d.Maria() {
+		for _, s := range nr.Schemas {
+			keepViewAlgorithms(s, desired)
+		}
+	}
+	return nr, nil
 }
 
 // NormalizeSchema returns the normal representation of the given database.
 func (d *Driver) NormalizeSchema(ctx context.Context, s *schema.Schema) (*schema.Schema, error) {
-	return (&sqlx.DevDriver{Driver: d}).NormalizeSchema(ctx, s)
+	desired := viewAlgorithms(s)
+	ns, err := (&sqlx.DevDriver{Driver: d}).NormalizeSchema(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	if !d.Maria() {
+		keepViewAlgorithms(ns, desired)
+	}
+	return ns, nil
+}
+
+// viewAlgorithms returns the ALGORITHM attributes of the views in the given
+// schemas, keyed by their qualified names.
+func viewAlgorithms(schemas ...*schema.Schema) map[[2]string]*ViewAlgorithm {
+	algs := make(map[[2]string]*ViewAlgorithm)
+	for _, s := range schemas {
+		for _, v := range s.Views {
+			if a := (ViewAlgorithm{}); sqlx.Has(v.Attrs, &a) {
+				algs[[2]string{s.Name, v.Name}] = &a
+			}
+		}
+	}
+	return algs
+}
+
+// keepViewAlgorithms copies the desired ALGORITHM attributes to the normalized
+// views, as MySQL does not expose them in INFORMATION_SCHEMA, and therefore, they
+// are lost when the views are inspected from the dev database.
+func keepViewAlgorithms(s *schema.Schema, algs map[[2]string]*ViewAlgorithm) {
+	for _, v := range s.Views {
+		if a, ok := algs[[2]string{s.Name, v.Name}]; ok {
+			schema.ReplaceOrAppend(&v.Attrs, a)
+		}
+	}
 }
 
 // Lock implements the schema.Locker interface.
@@ -388,12 +437,31 @@ const (
 	IndexParserNGram = "ngram"
 	IndexParserMeCab = "mecab"
 
+	ViewAlgorithmUndefined = "UNDEFINED"
+	ViewAlgorithmMerge     = "MERGE"
+	ViewAlgorithmTempTable = "TEMPTABLE"
+
+	ViewSecurityDefiner = "DEFINER"
+	ViewSecurityInvoker = "INVOKER"
+
 	EngineInnoDB = "InnoDB"
 	EngineMyISAM = "MyISAM"
 	EngineMemory = "Memory"
 	EngineCSV    = "CSV"
 	EngineNDB    = "NDB" // NDBCLUSTER
 
+	PartitionTypeRange = "RANGE"
+	PartitionTypeList  = "LIST"
+	PartitionTypeHash  = "HASH"
+	PartitionTypeKey   = "KEY"
+
+	DataAccessContainsSQL  = "CONTAINS SQL"
+	DataAccessNoSQL        = "NO SQL"
+	DataAccessReadsSQL     = "READS SQL DATA"
+	DataAccessModifiesSQL  = "MODIFIES SQL DATA"
+	RoutineSecurityDefiner = "DEFINER"
+	RoutineSecurityInvoker = "INVOKER"
+
 	currentTS     = "current_timestamp"
 	defaultGen    = "default_generated"
 	autoIncrement = "auto_increment"
@@ -8,33 +8,932 @@ package mysql
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"ariga.io/atlas/schemahcl"
+	"ariga.io/atlas/sql/internal/sqlx"
+	"ariga.io/atlas/sql/migrate"
 	"ariga.io/atlas/sql/schema"
 )
 
 var specOptions []schemahcl.Option
 
-func (*inspect) inspectViews(context.Context, *schema.Realm, *schema.InspectOptions) error {
-	return nil // unimplemented.
+// inspectViews queries and appends the views of the given realm, including
+// their columns and the tables and views they depend on.
+func (i *inspect) inspectViews(ctx context.Context, r *schema.Realm, _ *schema.InspectOptions) error {
+	if err := i.views(ctx, r); err != nil {
+		return err
+	}
+	for _, s := range r.Schemas {
+		if len(s.Views) == 0 {
+			continue
+		}
+		if err := i.viewColumns(ctx, s); err != nil {
+			return err
+		}
+	}
+	if i.SupportsViewUsage() {
+		return i.viewDeps(ctx, r)
+	}
+	return nil
 }
 
-func (*state) addView(*schema.AddView) error {
-	return nil // unimplemented.
+// views queries and appends the views of the given realm.
+func (i *inspect) views(ctx context.Context, r *schema.Realm) error {
+	query := viewsQuery
+	if i.Maria() {
+		query = viewsQueryMaria
+	}
+	args := make([]any, 0, len(r.Schemas))
+	for _, s := range r.Schemas {
+		args = append(args, s.Name)
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(query, nArgs(len(r.Schemas))), args...)
+	if err != nil {
+		return fmt.Errorf("mysql: querying views: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var vSchema, name, def, check, security, algorithm sql.NullString
+		if err := rows.Scan(&vSchema, &name, &def, &check, &security, &algorithm); err != nil {
+			return fmt.Errorf("mysql: scanning view information: %w", err)
+		}
+		if !sqlx.ValidString(vSchema) || !sqlx.ValidString(name) {
+			return fmt.Errorf("mysql: invalid schema or view name: %q.%q", vSchema.String, name.String)
+		}
+		s, ok := r.Schema(vSchema.String)
+		if !ok {
+			return fmt.Errorf("mysql: schema %q was not found in realm", vSchema.String)
+		}
+		v := schema.NewView(name.String, def.String)
+		s.AddViews(v)
+		if a := strings.ToUpper(algorithm.String); a != "" && a != ViewAlgorithmUndefined {
+			v.AddAttrs(&ViewAlgorithm{V: a})
+		}
+		if c := strings.ToUpper(security.String); c != "" && c != ViewSecurityDefiner {
+			v.AddAttrs(&ViewSecurity{V: c})
+		}
+		if c := strings.ToUpper(check.String); c == schema.ViewCheckOptionLocal || c == schema.ViewCheckOptionCascaded {
+			v.SetCheckOption(c)
+		}
+	}
+	return rows.Err()
 }
 
-func (*state) dropView(*schema.DropView) error {
-	return nil // unimplemented.
+// viewColumns queries and appends the columns of the schema views.
+func (i *inspect) viewColumns(ctx context.Context, s *schema.Schema) error {
+	query := columnsQuery
+	if i.SupportsGeneratedColumns() {
+		query = columnsExprQuery
+	}
+	args := []any{s.Name}
+	for _, v := range s.Views {
+		args = append(args, v.Name)
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(query, nArgs(len(s.Views))), args...)
+	if err != nil {
+		return fmt.Errorf("mysql: query schema %q view columns: %w", s.Name, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var view, name, typ, comment, nullable, key, defaults, extra, charset, collation, expr sql.NullString
+		if err := rows.Scan(&view, &name, &typ, &comment, &nullable, &key, &defaults, &extra, &charset, &collation, &expr); err != nil {
+			return fmt.Errorf("mysql: scanning view column: %w", err)
+		}
+		v, ok := s.View(view.String)
+		if !ok {
+			return fmt.Errorf("mysql: view %q was not found in schema", view.String)
+		}
+		c := &schema.Column{
+			Name: name.String,
+			Type: &schema.ColumnType{
+				Raw:  typ.String,
+				Null: nullable.String == "YES",
+			},
+		}
+		if c.Type.Type, err = ParseType(c.Type.Raw); err != nil {
+			return fmt.Errorf("mysql: %w", err)
+		}
+		if sqlx.ValidString(comment) {
+			c.SetComment(comment.String)
+		}
+		if sqlx.ValidString(charset) {
+			c.SetCharset(charset.String)
+		}
+		if sqlx.ValidString(collation) {
+			c.SetCollation(collation.String)
+		}
+		v.AddColumns(c)
+	}
+	return rows.Err()
 }
 
-func (*state) modifyView(*schema.ModifyView) error {
-	return nil // unimplemented.
+// viewDeps queries and links the tables and views each view depends on.
+// Objects that reside in schemas that were not inspected are ignored.
+func (i *inspect) viewDeps(ctx context.Context, r *schema.Realm) error {
+	var (
+		args = make([]any, 0, len(r.Schemas))
+		has  bool
+	)
+	for _, s := range r.Schemas {
+		args = append(args, s.Name)
+		has = has || len(s.Views) > 0
+	}
+	if !has {
+		return nil
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(viewDepsQuery, nArgs(len(r.Schemas))), args...)
+	if err != nil {
+		return fmt.Errorf("mysql: querying view dependencies: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var vSchema, vName, refSchema, refName string
+		if err := rows.Scan(&vSchema, &vName, &refSchema, &refName); err != nil {
+			return fmt.Errorf("mysql: scanning view dependencies: %w", err)
+		}
+		s, ok := r.Schema(vSchema)
+		if !ok {
+			continue
+		}
+		v, ok := s.View(vName)
+		if !ok {
+			continue
+		}
+		rs, ok := r.Schema(refSchema)
+		if !ok {
+			continue
+		}
+		// VIEW_TABLE_USAGE lists both tables and views.
+		if dep, ok := rs.View(refName); ok {
+			v.AddDeps(dep)
+		} else if dep, ok := rs.Table(refName); ok {
+			v.AddDeps(dep)
+		}
+	}
+	return rows.Err()
 }
 
-func (*state) renameView(*schema.RenameView) {
-	// unimplemented.
+// addView builds and executes the query for creating a view.
+func (s *state) addView(add *schema.AddView) error {
+	s.append(&migrate.Change{
+		Cmd:     s.createView(add.V, "CREATE"),
+		Source:  add,
+		Comment: fmt.Sprintf("create %q view", add.V.Name),
+		Reverse: s.Build("DROP VIEW").View(add.V).String(),
+	})
+	return nil
 }
 
-func (d *diff) ViewAttrChanged(_, _ *schema.View) bool {
-	return false // Not implemented.
+// dropView builds and executes the query for dropping a view.
+func (s *state) dropView(drop *schema.DropView) error {
+	b := s.Build("DROP VIEW")
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.View(drop.V).String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop %q view", drop.V.Name),
+		Reverse: s.createView(drop.V, "CREATE"),
+	})
+	return nil
 }
+
+// modifyView builds and executes the query for modifying a view.
+func (s *state) modifyView(modify *schema.ModifyView) error {
+	s.append(&migrate.Change{
+		Cmd:     s.createView(modify.To, "ALTER"),
+		Source:  modify,
+		Comment: fmt.Sprintf("modify %q view", modify.To.Name),
+		Reverse: s.createView(modify.From, "ALTER"),
+	})
+	return nil
+}
+
+// renameView builds and executes the query for renaming a view.
+func (s *state) renameView(c *schema.RenameView) {
+	s.append(&migrate.Change{
+		Source:  c,
+		Comment: fmt.Sprintf("rename a view from %q to %q", c.From.Name, c.To.Name),
+		Cmd:     s.Build("RENAME TABLE").View(c.From).P("TO").View(c.To).String(),
+		Reverse: s.Build("RENAME TABLE").View(c.To).P("TO").View(c.From).String(),
+	})
+}
+
+// createView returns the statement for creating (or altering) the view using the given command.
+func (s *state) createView(v *schema.View, cmd string) string {
+	b := s.Build(cmd)
+	if a := (ViewAlgorithm{}); sqlx.Has(v.Attrs, &a) && a.V != "" {
+		b.P("ALGORITHM", "=", strings.ToUpper(a.V))
+	}
+	if c := (ViewSecurity{}); sqlx.Has(v.Attrs, &c) && c.V != "" {
+		b.P("SQL SECURITY", strings.ToUpper(c.V))
+	}
+	b.P("VIEW").View(v).P("AS", sqlx.TrimViewExtra(v.Def))
+	if c := viewCheckOption(v); c != schema.ViewCheckOptionNone {
+		b.P("WITH", c, "CHECK OPTION")
+	}
+	return b.String()
+}
+
+// ViewAttrChanged reports if the view attributes were changed.
+func (d *diff) ViewAttrChanged(from, to *schema.View) bool {
+	// The view algorithm is not exposed in MySQL INFORMATION_SCHEMA, and
+	// therefore, it is compared only on MariaDB, where it can be inspected.
+	return d.Maria() && viewAlgorithm(from) != viewAlgorithm(to) ||
+		viewSecurity(from) != viewSecurity(to) ||
+		viewCheckOption(from) != viewCheckOption(to)
+}
+
+// viewAlgorithm returns the algorithm of the view, or UNDEFINED if not set.
+func viewAlgorithm(v *schema.View) string {
+	if a := (ViewAlgorithm{}); sqlx.Has(v.Attrs, &a) && a.V != "" {
+		return strings.ToUpper(a.V)
+	}
+	return ViewAlgorithmUndefined
+}
+
+// viewSecurity returns the SQL security of the view, or DEFINER if not set.
+func viewSecurity(v *schema.View) string {
+	if c := (ViewSecurity{}); sqlx.Has(v.Attrs, &c) && c.V != "" {
+		return strings.ToUpper(c.V)
+	}
+	return ViewSecurityDefiner
+}
+
+// viewCheckOption returns the check option of the view, or NONE if not set.
+func viewCheckOption(v *schema.View) string {
+	if c := (schema.ViewCheckOption{}); sqlx.Has(v.Attrs, &c) && c.V != "" {
+		return strings.ToUpper(c.V)
+	}
+	return schema.ViewCheckOptionNone
+}
+
+// inspectTriggers queries and appends the triggers of the tables in the given realm.
+func (i *inspect) inspectTriggers(ctx context.Context, r *schema.Realm, _ *schema.InspectOptions) error {
+	var (
+		args = make([]any, 0, len(r.Schemas))
+		has  bool
+	)
+	for _, s := range r.Schemas {
+		args = append(args, s.Name)
+		has = has || len(s.Tables) > 0
+	}
+	if !has {
+		return nil
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(triggersQuery, nArgs(len(r.Schemas))), args...)
+	if err != nil {
+		return fmt.Errorf("mysql: querying triggers: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var tSchema, tName, name, timing, event, orientation, body string
+		if err := rows.Scan(&tSchema, &tName, &name, &timing, &event, &orientation, &body); err != nil {
+			return fmt.Errorf("mysql: scanning trigger information: %w", err)
+		}
+		s, ok := r.Schema(tSchema)
+		if !ok {
+			return fmt.Errorf("mysql: schema %q was not found in realm", tSchema)
+		}
+		// Triggers of tables that were not inspected are ignored.
+		t, ok := s.Table(tName)
+		if !ok {
+			continue
+		}
+		t.AddTriggers(
+			schema.NewTrigger(name).
+				SetActionTime(schema.TriggerTime(strings.ToUpper(timing))).
+				AddEvents(schema.TriggerEvent{Name: strings.ToUpper(event)}).
+				SetFor(schema.TriggerFor(strings.ToUpper(orientation))).
+				SetBody(body),
+		)
+	}
+	return rows.Err()
+}
+
+// addTrigger builds and executes the query for creating a trigger.
+func (s *state) addTrigger(add *schema.AddTrigger) error {
+	create, err := s.createTrigger(add.T)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     create,
+		Source:  add,
+		Comment: fmt.Sprintf("create %q trigger", add.T.Name),
+		Reverse: s.Build("DROP TRIGGER").Trigger(add.T).String(),
+	})
+	return nil
+}
+
+// dropTrigger builds and executes the query for dropping a trigger.
+func (s *state) dropTrigger(drop *schema.DropTrigger) error {
+	create, err := s.createTrigger(drop.T)
+	if err != nil {
+		return err
+	}
+	b := s.Build("DROP TRIGGER")
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.Trigger(drop.T).String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop %q trigger", drop.T.Name),
+		Reverse: create,
+	})
+	return nil
+}
+
+// modifyTrigger builds and executes the queries for modifying a trigger.
+// MySQL does not support ALTER TRIGGER, and therefore, the trigger is
+// dropped and created with its new definition.
+func (s *state) modifyTrigger(modify *schema.ModifyTrigger) error {
+	if err := s.dropTrigger(&schema.DropTrigger{T: modify.From}); err != nil {
+		return err
+	}
+	return s.addTrigger(&schema.AddTrigger{T: modify.To})
+}
+
+// createTrigger returns the statement for creating the trigger.
+func (s *state) createTrigger(t *schema.Trigger) (string, error) {
+	switch {
+	case t.Table == nil:
+		return "", fmt.Errorf("mysql: missing table for trigger %q", t.Name)
+	case len(t.Events) != 1:
+		return "", fmt.Errorf("mysql: trigger %q must have exactly one event, got %d", t.Name, len(t.Events))
+	case len(t.Events[0].Columns) > 0:
+		return "", fmt.Errorf("mysql: UPDATE OF columns are not supported by trigger %q", t.Name)
+	case t.When != "":
+		return "", fmt.Errorf("mysql: WHEN condition is not supported by trigger %q", t.Name)
+	case t.Body == "":
+		return "", fmt.Errorf("mysql: missing body for trigger %q", t.Name)
+	}
+	return s.Build("CREATE TRIGGER").
+		Trigger(t).
+		P(strings.ToUpper(string(t.ActionTime)), strings.ToUpper(t.Events[0].Name), "ON").
+		Table(t.Table).
+		P("FOR EACH ROW", t.Body).
+		String(), nil
+}
+
+// inspectFuncs queries and appends the stored functions and procedures of the given realm.
+func (i *inspect) inspectFuncs(ctx context.Context, r *schema.Realm, _ *schema.InspectOptions) error {
+	if len(r.Schemas) == 0 {
+		return nil
+	}
+	args := make([]any, 0, len(r.Schemas))
+	for _, s := range r.Schemas {
+		args = append(args, s.Name)
+	}
+	n, err := i.routines(ctx, r, args)
+	if err != nil || n == 0 {
+		return err
+	}
+	return i.routineParams(ctx, r, args)
+}
+
+// routines queries and appends the stored functions and procedures of the given
+// realm, and returns their number. Their parameters are queried separately.
+func (i *inspect) routines(ctx context.Context, r *schema.Realm, args []any) (int, error) {
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(routinesQuery, nArgs(len(args))), args...)
+	if err != nil {
+		return 0, fmt.Errorf("mysql: querying routines: %w", err)
+	}
+	defer rows.Close()
+	var n int
+	for rows.Next() {
+		var (
+			rSchema, name, kind, deterministic, access, security string
+			ret, body, comment                                   sql.NullString
+		)
+		if err := rows.Scan(&rSchema, &name, &kind, &ret, &body, &deterministic, &access, &security, &comment); err != nil {
+			return 0, fmt.Errorf("mysql: scanning routine information: %w", err)
+		}
+		s, ok := r.Schema(rSchema)
+		if !ok {
+			return 0, fmt.Errorf("mysql: schema %q was not found in realm", rSchema)
+		}
+		var attrs []schema.Attr
+		if deterministic == "YES" {
+			attrs = append(attrs, &Deterministic{})
+		}
+		if a := strings.ToUpper(access); a != "" && a != DataAccessContainsSQL {
+			attrs = append(attrs, &DataAccess{V: a})
+		}
+		if v := strings.ToUpper(security); v != "" && v != RoutineSecurityDefiner {
+			attrs = append(attrs, &RoutineSecurity{V: v})
+		}
+		if sqlx.ValidString(comment) {
+			attrs = append(attrs, &schema.Comment{Text: comment.String})
+		}
+		if kind == "PROCEDURE" {
+			s.AddProcs(&schema.Proc{Name: name, Body: strings.TrimSpace(body.String), Attrs: attrs})
+		} else {
+			s.AddFuncs(&schema.Func{Name: name, Ret: routineType(ret.String), Body: strings.TrimSpace(body.String), Attrs: attrs})
+		}
+		n++
+	}
+	return n, rows.Err()
+}
+
+// routineParams queries and appends the parameters of the inspected routines.
+func (i *inspect) routineParams(ctx context.Context, r *schema.Realm, args []any) error {
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(paramsQuery, nArgs(len(args))), args...)
+	if err != nil {
+		return fmt.Errorf("mysql: querying routine parameters: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			pSchema, rName, kind, typ string
+			mode, name                sql.NullString
+		)
+		if err := rows.Scan(&pSchema, &rName, &kind, &mode, &name, &typ); err != nil {
+			return fmt.Errorf("mysql: scanning routine parameter information: %w", err)
+		}
+		s, ok := r.Schema(pSchema)
+		if !ok {
+			return fmt.Errorf("mysql: schema %q was not found in realm", pSchema)
+		}
+		a := &schema.FuncArg{Name: name.String, Type: routineType(typ)}
+		// IN is the default mode, and functions accept only IN parameters.
+		if m := strings.ToUpper(mode.String); m == string(schema.FuncArgModeOut) || m == string(schema.FuncArgModeInOut) {
+			a.Mode = schema.FuncArgMode(m)
+		}
+		o, ok := s.Object(func(o schema.Object) bool {
+			f, ok := routineOf(o)
+			return ok && f.kind == kind && f.name == rName
+		})
+		switch o := o.(type) {
+		case *schema.Func:
+			o.Args = append(o.Args, a)
+		case *schema.Proc:
+			o.Args = append(o.Args, a)
+		}
+	}
+	return rows.Err()
+}
+
+// routineType parses the data type of a routine parameter or its return value.
+// The character set and collation of string types are ignored.
+func routineType(typ string) schema.Type {
+	typ = strings.ToLower(typ)
+	for _, k := range []string{" charset ", " collate "} {
+		if i := strings.Index(typ, k); i > 0 {
+			typ = typ[:i]
+		}
+	}
+	t, err := ParseType(strings.TrimSpace(typ))
+	if err != nil {
+		return &schema.UnsupportedType{T: typ}
+	}
+	return t
+}
+
+// inspectEvents queries and appends the scheduled events of the given realm.
+func (i *inspect) inspectEvents(ctx context.Context, r *schema.Realm, _ *schema.InspectOptions) error {
+	if len(r.Schemas) == 0 {
+		return nil
+	}
+	args := make([]any, 0, len(r.Schemas))
+	for _, s := range r.Schemas {
+		args = append(args, s.Name)
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(eventsQuery, nArgs(len(r.Schemas))), args...)
+	if err != nil {
+		return fmt.Errorf("mysql: querying events: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			eSchema, name, body, typ, status, completion string
+			at, value, field, starts, ends, comment      sql.NullString
+		)
+		if err := rows.Scan(&eSchema, &name, &body, &typ, &at, &value, &field, &starts, &ends, &status, &completion, &comment); err != nil {
+			return fmt.Errorf("mysql: scanning event information: %w", err)
+		}
+		s, ok := r.Schema(eSchema)
+		if !ok {
+			return fmt.Errorf("mysql: schema %q was not found in realm", eSchema)
+		}
+		e := &Event{
+			Name:     name,
+			Schema:   s,
+			Body:     strings.TrimSpace(body),
+			Disabled: status != "ENABLED",
+			Preserve: completion == "PRESERVE",
+		}
+		if typ == "ONE TIME" {
+			e.At = at.String
+		} else {
+			e.Every, e.Starts, e.Ends = eventInterval(value.String, field.String), starts.String, ends.String
+		}
+		if sqlx.ValidString(comment) {
+			e.Attrs = append(e.Attrs, &schema.Comment{Text: comment.String})
+		}
+		s.AddObjects(e)
+	}
+	return rows.Err()
+}
+
+// eventInterval returns the interval of a recurring event. Values of composite
+// interval units (e.g. '1:30' MINUTE_SECOND) are quoted.
+func eventInterval(value, field string) string {
+	if _, err := strconv.ParseFloat(value, 64); err != nil {
+		value = "'" + value + "'"
+	}
+	return value + " " + strings.ToUpper(field)
+}
+
+// routine holds the common definition of stored functions and procedures.
+type routine struct {
+	kind  string // FUNCTION or PROCEDURE.
+	name  string
+	ns    *schema.Schema
+	args  []*schema.FuncArg
+	ret   schema.Type // Nil for procedures.
+	body  string
+	attrs []schema.Attr
+}
+
+// routineOf returns the routine definition of the given object, if it is a function or a procedure.
+func routineOf(o schema.Object) (*routine, bool) {
+	switch o := o.(type) {
+	case *schema.Func:
+		return &routine{kind: "FUNCTION", name: o.Name, ns: o.Schema, args: o.Args, ret: o.Ret, body: o.Body, attrs: o.Attrs}, true
+	case *schema.Proc:
+		return &routine{kind: "PROCEDURE", name: o.Name, ns: o.Schema, args: o.Args, body: o.Body, attrs: o.Attrs}, true
+	default:
+		return nil, false
+	}
+}
+
+// dataAccess returns the SQL data access characteristic of the routine, or CONTAINS SQL if not set.
+func (r *routine) dataAccess() string {
+	if a := (DataAccess{}); sqlx.Has(r.attrs, &a) && a.V != "" {
+		return strings.ToUpper(a.V)
+	}
+	return DataAccessContainsSQL
+}
+
+// security returns the SQL security characteristic of the routine, or DEFINER if not set.
+func (r *routine) security() string {
+	if v := (RoutineSecurity{}); sqlx.Has(r.attrs, &v) && v.V != "" {
+		return strings.ToUpper(v.V)
+	}
+	return RoutineSecurityDefiner
+}
+
+// changed reports if the definition or the characteristics of the routine were changed.
+func (r *routine) changed(to *routine) bool {
+	if routineTypeString(r.ret) != routineTypeString(to.ret) || len(r.args) != len(to.args) {
+		return true
+	}
+	for i, a1 := range r.args {
+		a2 := to.args[i]
+		if a1.Name != a2.Name || routineArgMode(a1) != routineArgMode(a2) || routineTypeString(a1.Type) != routineTypeString(a2.Type) {
+			return true
+		}
+	}
+	return strings.TrimSpace(r.body) != strings.TrimSpace(to.body) ||
+		sqlx.Has(r.attrs, &Deterministic{}) != sqlx.Has(to.attrs, &Deterministic{}) ||
+		r.dataAccess() != to.dataAccess() ||
+		r.security() != to.security() ||
+		sqlx.CommentDiff(r.attrs, to.attrs) != nil
+}
+
+// routineArgMode returns the mode of the argument, or IN if not set.
+func routineArgMode(a *schema.FuncArg) schema.FuncArgMode {
+	if a.Mode == "" {
+		return schema.FuncArgModeIn
+	}
+	return schema.FuncArgMode(strings.ToUpper(string(a.Mode)))
+}
+
+// routineTypeString returns the string representation of the given type used for
+// comparing the parameters and the return types of routines.
+func routineTypeString(t schema.Type) string {
+	if t == nil {
+		return ""
+	}
+	f, err := FormatType(t)
+	if err != nil {
+		return fmt.Sprintf("%T", t)
+	}
+	return strings.ToLower(f)
+}
+
+// routinesDiff returns the changes for migrating the stored functions and procedures of the schema.
+func routinesDiff(from, to *schema.Schema) []schema.Change {
+	var changes []schema.Change
+	find := func(s *schema.Schema, r *routine) (schema.Object, bool) {
+		return s.Object(func(o schema.Object) bool {
+			r2, ok := routineOf(o)
+			return ok && r.kind == r2.kind && r.name == r2.name
+		})
+	}
+	for _, o1 := range from.Objects {
+		r1, ok := routineOf(o1)
+		if !ok {
+			continue
+		}
+		o2, ok := find(to, r1)
+		if !ok {
+			changes = append(changes, &schema.DropObject{O: o1})
+			continue
+		}
+		if r2, _ := routineOf(o2); r1.changed(r2) {
+			changes = append(changes, &schema.ModifyObject{From: o1, To: o2})
+		}
+	}
+	for _, o1 := range to.Objects {
+		if r1, ok := routineOf(o1); ok {
+			if _, ok := find(from, r1); !ok {
+				changes = append(changes, &schema.AddObject{O: o1})
+			}
+		}
+	}
+	return changes
+}
+
+// eventsDiff returns the changes for migrating the scheduled events of the schema. The STARTS
+// and ENDS of recurring events are compared only if they are set explicitly on the desired
+// state, as MySQL sets the start time of recurring events to their creation time by default.
+// Event times are compared as timestamps, and expressions (e.g. CURRENT_TIMESTAMP + INTERVAL
+// 1 DAY) are skipped, as they are evaluated by MySQL when the event is created.
+func eventsDiff(from, to *schema.Schema) []schema.Change {
+	var changes []schema.Change
+	for _, o1 := range from.Objects {
+		e1, ok := o1.(*Event)
+		if !ok {
+			continue
+		}
+		e2, ok := schemaEvent(to, e1.Name)
+		if !ok {
+			changes = append(changes, &schema.DropObject{O: e1})
+			continue
+		}
+		if (e1.At == "") != (e2.At == "") || eventTimeChanged(e1.At, e2.At) || !strings.EqualFold(e1.Every, e2.Every) ||
+			eventTimeChanged(e1.Starts, e2.Starts) || eventTimeChanged(e1.Ends, e2.Ends) ||
+			e1.Preserve != e2.Preserve || e1.Disabled != e2.Disabled ||
+			strings.TrimSpace(e1.Body) != strings.TrimSpace(e2.Body) || sqlx.CommentDiff(e1.Attrs, e2.Attrs) != nil {
+			changes = append(changes, &schema.ModifyObject{From: e1, To: e2})
+		}
+	}
+	for _, o1 := range to.Objects {
+		if e1, ok := o1.(*Event); ok {
+			if _, ok := schemaEvent(from, e1.Name); !ok {
+				changes = append(changes, &schema.AddObject{O: e1})
+			}
+		}
+	}
+	return changes
+}
+
+// eventTimeChanged reports if the event time was changed. Desired
+// values that are not timestamps (or not set) are not compared.
+func eventTimeChanged(from, to string) bool {
+	t2, ok := eventTime(to)
+	if !ok {
+		return false
+	}
+	t1, ok := eventTime(from)
+	return !ok || !t1.Equal(t2)
+}
+
+// eventTime parses the given event time as a timestamp. The second
+// return value reports if the value is a timestamp literal.
+func eventTime(v string) (time.Time, bool) {
+	if v = strings.TrimSpace(v); sqlx.IsQuoted(v, '"', '\'') {
+		v = v[1 : len(v)-1]
+	}
+	for _, layout := range []string{"2006-01-02 15:04:05.999999", "2006-01-02 15:04", "2006-01-02", time.RFC3339Nano, "2006-01-02T15:04:05.999999"} {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// eventTimeExpr returns the SQL representation of the given event time.
+// Timestamps are quoted, and expressions are written as is.
+func eventTimeExpr(v string) string {
+	if _, ok := eventTime(v); ok {
+		return quote(v)
+	}
+	return v
+}
+
+// schemaEvent returns the event with the given name from the schema.
+func schemaEvent(s *schema.Schema, name string) (*Event, bool) {
+	o, ok := s.Object(func(o schema.Object) bool {
+		e, ok := o.(*Event)
+		return ok && e.Name == name
+	})
+	if !ok {
+		return nil, false
+	}
+	return o.(*Event), true
+}
+
+// addObject builds and executes the query for creating a stored routine or an event.
+func (s *state) addObject(add *schema.AddObject) error {
+	create, drop, err := s.objectCmds(add.O, false)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     create,
+		Source:  add,
+		Comment: fmt.Sprintf("create %s", objectDesc(add.O)),
+		Reverse: drop,
+	})
+	return nil
+}
+
+// dropObject builds and executes the query for dropping a stored routine or an event.
+func (s *state) dropObject(drop *schema.DropObject) error {
+	create, cmd, err := s.objectCmds(drop.O, sqlx.Has(drop.Extra, &schema.IfExists{}))
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     cmd,
+		Source:  drop,
+		Comment: fmt.Sprintf("drop %s", objectDesc(drop.O)),
+		Reverse: create,
+	})
+	return nil
+}
+
+// modifyObject builds and executes the queries for modifying a stored routine or an event.
+// The definition of routines cannot be changed with ALTER, and therefore, routines and
+// events are dropped and created with their new definition.
+func (s *state) modifyObject(modify *schema.ModifyObject) error {
+	if err := s.dropObject(&schema.DropObject{O: modify.From}); err != nil {
+		return err
+	}
+	return s.addObject(&schema.AddObject{O: modify.To})
+}
+
+// objectCmds returns the statements for creating and dropping the given object.
+func (s *state) objectCmds(o schema.Object, ifExists bool) (create, drop string, err error) {
+	var b *sqlx.Builder
+	switch o := o.(type) {
+	case *Event:
+		if create, err = s.createEvent(o); err != nil {
+			return "", "", err
+		}
+		b = s.Build("DROP EVENT")
+		if ifExists {
+			b.P("IF EXISTS")
+		}
+		b.SchemaResource(o.Schema, o.Name)
+	default:
+		r, ok := routineOf(o)
+		if !ok {
+			return "", "", fmt.Errorf("mysql: unsupported object %T", o)
+		}
+		if create, err = s.createRoutine(r); err != nil {
+			return "", "", err
+		}
+		b = s.Build("DROP", r.kind)
+		if ifExists {
+			b.P("IF EXISTS")
+		}
+		b.SchemaResource(r.ns, r.name)
+	}
+	return create, b.String(), nil
+}
+
+// createRoutine returns the statement for creating the stored function or procedure.
+func (s *state) createRoutine(r *routine) (string, error) {
+	switch {
+	case r.kind == "FUNCTION" && r.ret == nil:
+		return "", fmt.Errorf("mysql: missing return type for function %q", r.name)
+	case r.body == "":
+		return "", fmt.Errorf("mysql: missing body for %s %q", strings.ToLower(r.kind), r.name)
+	}
+	args := make([]string, 0, len(r.args))
+	for _, a := range r.args {
+		t, err := FormatType(a.Type)
+		if err != nil {
+			return "", fmt.Errorf("mysql: format parameter %q of %s %q: %w", a.Name, strings.ToLower(r.kind), r.name, err)
+		}
+		b := s.Build()
+		// Parameters of functions are always IN parameters.
+		if r.kind == "PROCEDURE" && routineArgMode(a) != schema.FuncArgModeIn {
+			b.P(string(routineArgMode(a)))
+		}
+		args = append(args, b.Ident(a.Name).P(t).String())
+	}
+	b := s.Build("CREATE", r.kind).SchemaResource(r.ns, r.name).Wrap(func(b *sqlx.Builder) {
+		b.MapComma(args, func(i int, b *sqlx.Builder) {
+			b.WriteString(args[i])
+		})
+	})
+	if r.ret != nil {
+		t, err := FormatType(r.ret)
+		if err != nil {
+			return "", fmt.Errorf("mysql: format return type of function %q: %w", r.name, err)
+		}
+		b.P("RETURNS", t)
+	}
+	if sqlx.Has(r.attrs, &Deterministic{}) {
+		b.P("DETERMINISTIC")
+	}
+	if a := r.dataAccess(); a != DataAccessContainsSQL {
+		b.P(a)
+	}
+	if v := r.security(); v != RoutineSecurityDefiner {
+		b.P("SQL SECURITY", v)
+	}
+	if c := (schema.Comment{}); sqlx.Has(r.attrs, &c) && c.Text != "" {
+		b.P("COMMENT", quote(c.Text))
+	}
+	return b.P(strings.TrimSpace(r.body)).String(), nil
+}
+
+// createEvent returns the statement for creating the scheduled event.
+func (s *state) createEvent(e *Event) (string, error) {
+	switch {
+	case e.At == "" && e.Every == "", e.At != "" && e.Every != "":
+		return "", fmt.Errorf("mysql: event %q must have exactly one of AT or EVERY schedules", e.Name)
+	case e.Body == "":
+		return "", fmt.Errorf("mysql: missing body for event %q", e.Name)
+	}
+	b := s.Build("CREATE EVENT").SchemaResource(e.Schema, e.Name).P("ON SCHEDULE")
+	if e.At != "" {
+		b.P("AT", eventTimeExpr(e.At))
+	} else {
+		b.P("EVERY", e.Every)
+		if e.Starts != "" {
+			b.P("STARTS", eventTimeExpr(e.Starts))
+		}
+		if e.Ends != "" {
+			b.P("ENDS", eventTimeExpr(e.Ends))
+		}
+	}
+	if e.Preserve {
+		b.P("ON COMPLETION PRESERVE")
+	}
+	if e.Disabled {
+		b.P("DISABLE")
+	}
+	if c := (schema.Comment{}); sqlx.Has(e.Attrs, &c) && c.Text != "" {
+		b.P("COMMENT", quote(c.Text))
+	}
+	return b.P("DO", strings.TrimSpace(e.Body)).String(), nil
+}
+
+// objectBody returns the body of a stored routine or an event.
+func objectBody(o schema.Object) string {
+	if e, ok := o.(*Event); ok {
+		return e.Body
+	}
+	if r, ok := routineOf(o); ok {
+		return r.body
+	}
+	return ""
+}
+
+// objectDesc returns the description of a stored routine or an event used in change comments.
+func objectDesc(o schema.Object) string {
+	if e, ok := o.(*Event); ok {
+		return fmt.Sprintf("%q event", e.Name)
+	}
+	if r, ok := routineOf(o); ok {
+		return fmt.Sprintf("%q %s", r.name, strings.ToLower(r.kind))
+	}
+	return fmt.Sprintf("%T", o)
+}
+
+const (
+	// Query to list views.
+	viewsQuery = "SELECT `TABLE_SCHEMA`, `TABLE_NAME`, `VIEW_DEFINITION`, `CHECK_OPTION`, `SECURITY_TYPE`, NULL AS `ALGORITHM` FROM `INFORMATION_SCHEMA`.`VIEWS` WHERE `TABLE_SCHEMA` IN (%s) ORDER BY `TABLE_SCHEMA`, `TABLE_NAME`"
+
+	// Query to list views in MariaDB.
+	viewsQueryMaria = "SELECT `TABLE_SCHEMA`, `TABLE_NAME`, `VIEW_DEFINITION`, `CHECK_OPTION`, `SECURITY_TYPE`, `ALGORITHM` FROM `INFORMATION_SCHEMA`.`VIEWS` WHERE `TABLE_SCHEMA` IN (%s) ORDER BY `TABLE_SCHEMA`, `TABLE_NAME`"
+
+	// Query to list the tables and views that views depend on.
+	viewDepsQuery = "SELECT `VIEW_SCHEMA`, `VIEW_NAME`, `TABLE_SCHEMA`, `TABLE_NAME` FROM `INFORMATION_SCHEMA`.`VIEW_TABLE_USAGE` WHERE `VIEW_SCHEMA` IN (%s) ORDER BY `VIEW_SCHEMA`, `VIEW_NAME`, `TABLE_SCHEMA`, `TABLE_NAME`"
+
+	// Query to list the stored functions and procedures.
+	routinesQuery = "SELECT `ROUTINE_SCHEMA`, `ROUTINE_NAME`, `ROUTINE_TYPE`, `DTD_IDENTIFIER`, `ROUTINE_DEFINITION`, `IS_DETERMINISTIC`, `SQL_DATA_ACCESS`, `SECURITY_TYPE`, `ROUTINE_COMMENT` FROM `INFORMATION_SCHEMA`.`ROUTINES` WHERE `ROUTINE_SCHEMA` IN (%s) ORDER BY `ROUTINE_SCHEMA`, `ROUTINE_NAME`"
+
+	// Query to list the parameters of stored functions and procedures. Return values are skipped.
+	paramsQuery = "SELECT `SPECIFIC_SCHEMA`, `SPECIFIC_NAME`, `ROUTINE_TYPE`, `PARAMETER_MODE`, `PARAMETER_NAME`, `DTD_IDENTIFIER` FROM `INFORMATION_SCHEMA`.`PARAMETERS` WHERE `SPECIFIC_SCHEMA` IN (%s) AND `ORDINAL_POSITION` > 0 ORDER BY `SPECIFIC_SCHEMA`, `SPECIFIC_NAME`, `ORDINAL_POSITION`"
+
+	// Query to list the scheduled events.
+	eventsQuery = "SELECT `EVENT_SCHEMA`, `EVENT_NAME`, `EVENT_DEFINITION`, `EVENT_TYPE`, CAST(`EXECUTE_AT` AS CHAR), `INTERVAL_VALUE`, `INTERVAL_FIELD`, CAST(`STARTS` AS CHAR), CAST(`ENDS` AS CHAR), `STATUS`, `ON_COMPLETION`, `EVENT_COMMENT` FROM `INFORMATION_SCHEMA`.`EVENTS` WHERE `EVENT_SCHEMA` IN (%s) ORDER BY `EVENT_SCHEMA`, `EVENT_NAME`"
+
+	// Query to list the triggers of tables.
+	triggersQuery = "SELECT `TRIGGER_SCHEMA`, `EVENT_OBJECT_TABLE`, `TRIGGER_NAME`, `ACTION_TIMING`, `EVENT_MANIPULATION`, `ACTION_ORIENTATION`, `ACTION_STATEMENT` FROM `INFORMATION_SCHEMA`.`TRIGGERS` WHERE `TRIGGER_SCHEMA` IN (%s) ORDER BY `TRIGGER_SCHEMA`, `EVENT_OBJECT_TABLE`, `TRIGGER_NAME`"
+)
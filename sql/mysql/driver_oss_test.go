@@ -0,0 +1,600 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+//go:build !ent
+
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"ariga.io/atlas/sql/internal/sqltest"
+	"ariga.io/atlas/sql/schema"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDriver_InspectViews(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("8.0.13")
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= SCHEMA()"))).
+		WillReturnRows(sqltest.Rows(`
++-------------+----------------------------+------------------------+
+| SCHEMA_NAME | DEFAULT_CHARACTER_SET_NAME | DEFAULT_COLLATION_NAME |
++-------------+----------------------------+------------------------+
+| public      | utf8mb4                    | utf8mb4_unicode_ci     |
++-------------+----------------------------+------------------------+
+`))
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(viewsQuery, "?"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
++--------------+------------+-------------------------------+--------------+---------------+-----------+
+| TABLE_SCHEMA | TABLE_NAME | VIEW_DEFINITION               | CHECK_OPTION | SECURITY_TYPE | ALGORITHM |
++--------------+------------+-------------------------------+--------------+---------------+-----------+
+| public       | v1         | select 1 AS a                 | NONE         | DEFINER       | NULL      |
+| public       | v2         | select v1.a AS a from v1      | LOCAL        | INVOKER       | NULL      |
++--------------+------------+-------------------------------+--------------+---------------+-----------+
+`))
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(columnsExprQuery, "?, ?"))).
+		WithArgs("public", "v1", "v2").
+		WillReturnRows(sqltest.Rows(`
++------------+-------------+-------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
+| TABLE_NAME | COLUMN_NAME | COLUMN_TYPE | COLUMN_COMMENT | IS_NULLABLE | COLUMN_KEY | COLUMN_DEFAULT | EXTRA | CHARACTER_SET_NAME | COLLATION_NAME | GENERATION_EXPRESSION |
++------------+-------------+-------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
+| v1         | a           | int         |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
+| v2         | a           | int         |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
++------------+-------------+-------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
+`))
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(viewDepsQuery, "?"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
++-------------+-----------+--------------+------------+
+| VIEW_SCHEMA | VIEW_NAME | TABLE_SCHEMA | TABLE_NAME |
++-------------+-----------+--------------+------------+
+| public      | v2        | public       | v1         |
+| public      | v2        | other        | t1         |
++-------------+-----------+--------------+------------+
+`))
+	drv, err := Open(db)
+	require.NoError(t, err)
+	s, err := drv.InspectSchema(context.Background(), "", &schema.InspectOptions{
+		Mode: schema.InspectViews,
+	})
+	require.NoError(t, err)
+	require.Len(t, s.Views, 2)
+	v1, ok := s.View("v1")
+	require.True(t, ok)
+	require.Equal(t, "select 1 AS a", v1.Def)
+	require.Empty(t, v1.Attrs)
+	require.Equal(t, []*schema.Column{
+		{Name: "a", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}}},
+	}, v1.Columns)
+	v2, ok := s.View("v2")
+	require.True(t, ok)
+	require.Equal(t, []schema.Attr{
+		&ViewSecurity{V: ViewSecurityInvoker},
+		&schema.ViewCheckOption{V: schema.ViewCheckOptionLocal},
+	}, v2.Attrs, "view algorithm is not inspected on MySQL")
+	// Dependencies on uninspected schemas are ignored.
+	require.Equal(t, []schema.Object{v1}, v2.Deps)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestPlanChanges_Views(t *testing.T) {
+	var (
+		s  = schema.New("public")
+		v1 = schema.NewView("v1", "SELECT 1 AS a")
+		v2 = schema.NewView("v2", "SELECT a FROM v1").
+			AddAttrs(&ViewAlgorithm{V: ViewAlgorithmMerge}, &ViewSecurity{V: ViewSecurityInvoker}).
+			SetCheckOption(schema.ViewCheckOptionCascaded).
+			AddDeps(v1)
+	)
+	s.AddViews(v1, v2)
+	tests := []struct {
+		changes []schema.Change
+		want    [][2]string
+	}{
+		{
+			changes: []schema.Change{
+				&schema.AddView{V: v2},
+				&schema.AddView{V: v1},
+			},
+			want: [][2]string{
+				{"CREATE VIEW `public`.`v1` AS SELECT 1 AS a", "DROP VIEW `public`.`v1`"},
+				{"CREATE ALGORITHM = MERGE SQL SECURITY INVOKER VIEW `public`.`v2` AS SELECT a FROM v1 WITH CASCADED CHECK OPTION", "DROP VIEW `public`.`v2`"},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.DropView{V: v1, Extra: []schema.Clause{&schema.IfExists{}}},
+				&schema.DropView{V: v2},
+			},
+			want: [][2]string{
+				{"DROP VIEW `public`.`v2`", "CREATE ALGORITHM = MERGE SQL SECURITY INVOKER VIEW `public`.`v2` AS SELECT a FROM v1 WITH CASCADED CHECK OPTION"},
+				{"DROP VIEW IF EXISTS `public`.`v1`", "CREATE VIEW `public`.`v1` AS SELECT 1 AS a"},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.ModifyView{
+					From: v2,
+					To:   schema.NewView("v2", "SELECT a, 2 AS b FROM v1").SetSchema(s).AddAttrs(&ViewAlgorithm{V: ViewAlgorithmTempTable}),
+				},
+			},
+			want: [][2]string{
+				{"ALTER ALGORITHM = TEMPTABLE VIEW `public`.`v2` AS SELECT a, 2 AS b FROM v1", "ALTER ALGORITHM = MERGE SQL SECURITY INVOKER VIEW `public`.`v2` AS SELECT a FROM v1 WITH CASCADED CHECK OPTION"},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.RenameView{From: v1, To: schema.NewView("v3", v1.Def).SetSchema(s)},
+			},
+			want: [][2]string{
+				{"RENAME TABLE `public`.`v1` TO `public`.`v3`", "RENAME TABLE `public`.`v3` TO `public`.`v1`"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		db, mk, err := sqlmock.New()
+		require.NoError(t, err)
+		mock{mk}.version("8.0.13")
+		drv, err := Open(db)
+		require.NoError(t, err)
+		plan, err := drv.PlanChanges(context.Background(), "plan", tt.changes)
+		require.NoError(t, err)
+		require.Len(t, plan.Changes, len(tt.want))
+		for i, c := range plan.Changes {
+			require.Equal(t, tt.want[i][0], c.Cmd)
+			require.Equal(t, tt.want[i][1], c.Reverse)
+		}
+	}
+}
+
+func TestDriver_InspectTriggers(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("8.0.13")
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= SCHEMA()"))).
+		WillReturnRows(sqltest.Rows(`
++-------------+----------------------------+------------------------+
+| SCHEMA_NAME | DEFAULT_CHARACTER_SET_NAME | DEFAULT_COLLATION_NAME |
++-------------+----------------------------+------------------------+
+| public      | utf8mb4                    | utf8mb4_unicode_ci     |
++-------------+----------------------------+------------------------+
+`))
+	mk.tableExists("public", "users", true)
+	mk.ExpectQuery(queryColumns).
+		WithArgs("public", "users").
+		WillReturnRows(sqltest.Rows(`
++------------+-------------+-------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
+| TABLE_NAME | COLUMN_NAME | COLUMN_TYPE | COLUMN_COMMENT | IS_NULLABLE | COLUMN_KEY | COLUMN_DEFAULT | EXTRA | CHARACTER_SET_NAME | COLLATION_NAME | GENERATION_EXPRESSION |
++------------+-------------+-------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
+| users      | a           | int         |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
++------------+-------------+-------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
+`))
+	mk.noIndexes()
+	mk.noFKs()
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(triggersQuery, "?"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
++----------------+--------------------+--------------+---------------+--------------------+--------------------+-----------------------------------+
+| TRIGGER_SCHEMA | EVENT_OBJECT_TABLE | TRIGGER_NAME | ACTION_TIMING | EVENT_MANIPULATION | ACTION_ORIENTATION | ACTION_STATEMENT                  |
++----------------+--------------------+--------------+---------------+--------------------+--------------------+-----------------------------------+
+| public         | other              | tr0          | BEFORE        | INSERT             | ROW                | SET NEW.a = 1                     |
+| public         | users              | tr1          | BEFORE        | INSERT             | ROW                | SET NEW.a = NEW.a + 1             |
+| public         | users              | tr2          | AFTER         | DELETE             | ROW                | BEGIN DELETE FROM t2; END         |
++----------------+--------------------+--------------+---------------+--------------------+--------------------+-----------------------------------+
+`))
+	drv, err := Open(db)
+	require.NoError(t, err)
+	s, err := drv.InspectSchema(context.Background(), "", &schema.InspectOptions{
+		Mode: schema.InspectTables | schema.InspectTriggers,
+	})
+	require.NoError(t, err)
+	users, ok := s.Table("users")
+	require.True(t, ok)
+	// Triggers of uninspected tables are ignored.
+	require.Len(t, users.Triggers, 2)
+	tr1, ok := users.Trigger("tr1")
+	require.True(t, ok)
+	require.Equal(t, users, tr1.Table)
+	require.Equal(t, schema.TriggerTimeBefore, tr1.ActionTime)
+	require.Equal(t, schema.TriggerForRow, tr1.For)
+	require.Equal(t, []schema.TriggerEvent{schema.TriggerEventInsert}, tr1.Events)
+	require.Equal(t, "SET NEW.a = NEW.a + 1", tr1.Body)
+	tr2, ok := users.Trigger("tr2")
+	require.True(t, ok)
+	require.Equal(t, schema.TriggerTimeAfter, tr2.ActionTime)
+	require.Equal(t, []schema.TriggerEvent{schema.TriggerEventDelete}, tr2.Events)
+	require.Equal(t, "BEGIN DELETE FROM t2; END", tr2.Body)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestPlanChanges_Triggers(t *testing.T) {
+	var (
+		s  = schema.New("public")
+		c1 = schema.NewIntColumn("a", "int")
+		t1 = schema.NewTable("t1").AddColumns(c1)
+		tr = schema.NewTrigger("tr1").
+			SetActionTime(schema.TriggerTimeBefore).
+			AddEvents(schema.TriggerEventInsert).
+			SetFor(schema.TriggerForRow).
+			SetBody("SET NEW.a = NEW.a + 1")
+		tr3 = schema.NewTrigger("tr3").
+			SetActionTime(schema.TriggerTimeAfter).
+			AddEvents(schema.TriggerEventInsert, schema.TriggerEventDelete).
+			SetBody("SET @a = 1")
+		tr2 = schema.NewTrigger("tr1").
+			SetActionTime(schema.TriggerTimeBefore).
+			AddEvents(schema.TriggerEventUpdate).
+			SetFor(schema.TriggerForRow).
+			SetBody("SET NEW.a = NEW.a + 1")
+	)
+	s.AddTables(t1.AddTriggers(tr))
+	tr2.Table, tr3.Table = t1, t1
+	tests := []struct {
+		changes []schema.Change
+		want    [][2]string
+		wantErr string
+	}{
+		{
+			changes: []schema.Change{
+				&schema.AddTrigger{T: tr},
+			},
+			want: [][2]string{
+				{"CREATE TRIGGER `public`.`tr1` BEFORE INSERT ON `public`.`t1` FOR EACH ROW SET NEW.a = NEW.a + 1", "DROP TRIGGER `public`.`tr1`"},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.DropTrigger{T: tr, Extra: []schema.Clause{&schema.IfExists{}}},
+			},
+			want: [][2]string{
+				{"DROP TRIGGER IF EXISTS `public`.`tr1`", "CREATE TRIGGER `public`.`tr1` BEFORE INSERT ON `public`.`t1` FOR EACH ROW SET NEW.a = NEW.a + 1"},
+			},
+		},
+		// MySQL does not support altering triggers.
+		{
+			changes: []schema.Change{
+				&schema.ModifyTrigger{From: tr, To: tr2},
+			},
+			want: [][2]string{
+				{"DROP TRIGGER `public`.`tr1`", "CREATE TRIGGER `public`.`tr1` BEFORE INSERT ON `public`.`t1` FOR EACH ROW SET NEW.a = NEW.a + 1"},
+				{"CREATE TRIGGER `public`.`tr1` BEFORE UPDATE ON `public`.`t1` FOR EACH ROW SET NEW.a = NEW.a + 1", "DROP TRIGGER `public`.`tr1`"},
+			},
+		},
+		// MySQL triggers are limited to a single event.
+		{
+			changes: []schema.Change{
+				&schema.AddTrigger{T: tr3},
+			},
+			wantErr: `mysql: trigger "tr3" must have exactly one event, got 2`,
+		},
+	}
+	for _, tt := range tests {
+		db, mk, err := sqlmock.New()
+		require.NoError(t, err)
+		mock{mk}.version("8.0.13")
+		drv, err := Open(db)
+		require.NoError(t, err)
+		plan, err := drv.PlanChanges(context.Background(), "plan", tt.changes)
+		if tt.wantErr != "" {
+			require.EqualError(t, err, tt.wantErr)
+			continue
+		}
+		require.NoError(t, err)
+		require.Len(t, plan.Changes, len(tt.want))
+		for i, c := range plan.Changes {
+			require.Equal(t, tt.want[i][0], c.Cmd)
+			require.Equal(t, tt.want[i][1], c.Reverse)
+		}
+	}
+}
+
+func TestDiff_ViewAttrChanged(t *testing.T) {
+	var (
+		d  = &diff{conn: &conn{V: "10.7.1-MariaDB"}}
+		v1 = schema.NewView("v1", "SELECT 1")
+		v2 = schema.NewView("v1", "SELECT 1").AddAttrs(&ViewAlgorithm{V: "undefined"}, &ViewSecurity{V: ViewSecurityDefiner})
+	)
+	require.False(t, d.ViewAttrChanged(v1, v2))
+	v2.AddAttrs(&ViewSecurity{V: ViewSecurityInvoker})
+	require.False(t, d.ViewAttrChanged(v1, v2), "first attribute is used")
+	v2 = schema.NewView("v1", "SELECT 1").AddAttrs(&ViewSecurity{V: ViewSecurityInvoker})
+	require.True(t, d.ViewAttrChanged(v1, v2))
+	v2 = schema.NewView("v1", "SELECT 1").AddAttrs(&ViewAlgorithm{V: ViewAlgorithmMerge})
+	require.True(t, d.ViewAttrChanged(v1, v2))
+	v2 = schema.NewView("v1", "SELECT 1").SetCheckOption(schema.ViewCheckOptionLocal)
+	require.True(t, d.ViewAttrChanged(v1, v2))
+
+	// The algorithm is not inspected on MySQL.
+	d = &diff{conn: &conn{V: "8.0.13"}}
+	v2 = schema.NewView("v1", "SELECT 1").AddAttrs(&ViewAlgorithm{V: ViewAlgorithmMerge})
+	require.False(t, d.ViewAttrChanged(v1, v2))
+	v2.AddAttrs(&ViewSecurity{V: ViewSecurityInvoker})
+	require.True(t, d.ViewAttrChanged(v1, v2))
+}
+
+func TestKeepViewAlgorithms(t *testing.T) {
+	var (
+		desired = schema.New("public").AddViews(
+			schema.NewView("v1", "SELECT 1"),
+			schema.NewView("v2", "SELECT 1").AddAttrs(&ViewAlgorithm{V: ViewAlgorithmMerge}),
+		)
+		normal = schema.New("public").AddViews(
+			schema.NewView("v1", "select 1 AS `1`"),
+			schema.NewView("v2", "select 1 AS `1`").AddAttrs(&ViewSecurity{V: ViewSecurityDefiner}),
+		)
+	)
+	keepViewAlgorithms(normal, viewAlgorithms(desired))
+	require.Empty(t, normal.Views[0].Attrs)
+	require.Equal(t, []schema.Attr{&ViewSecurity{V: ViewSecurityDefiner}, &ViewAlgorithm{V: ViewAlgorithmMerge}}, normal.Views[1].Attrs)
+}
+
+func TestDriver_InspectFuncs(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("8.0.13")
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= SCHEMA()"))).
+		WillReturnRows(sqltest.Rows(`
++-------------+----------------------------+------------------------+
+| SCHEMA_NAME | DEFAULT_CHARACTER_SET_NAME | DEFAULT_COLLATION_NAME |
++-------------+----------------------------+------------------------+
+| public      | utf8mb4                    | utf8mb4_unicode_ci     |
++-------------+----------------------------+------------------------+
+`))
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(routinesQuery, "?"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
++----------------+--------------+--------------+----------------+----------------------------------+------------------+-----------------+---------------+-----------------+
+| ROUTINE_SCHEMA | ROUTINE_NAME | ROUTINE_TYPE | DTD_IDENTIFIER | ROUTINE_DEFINITION               | IS_DETERMINISTIC | SQL_DATA_ACCESS | SECURITY_TYPE | ROUTINE_COMMENT |
++----------------+--------------+--------------+----------------+----------------------------------+------------------+-----------------+---------------+-----------------+
+| public         | f1           | FUNCTION     | int            | RETURN a + 1                     | YES              | NO SQL          | DEFINER       |                 |
+| public         | p1           | PROCEDURE    | NULL           | BEGIN SELECT 1; SELECT a; END    | NO               | CONTAINS SQL    | INVOKER       | proc comment    |
++----------------+--------------+--------------+----------------+----------------------------------+------------------+-----------------+---------------+-----------------+
+`))
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(paramsQuery, "?"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
++-----------------+---------------+--------------+----------------+----------------+-----------------------------------------+
+| SPECIFIC_SCHEMA | SPECIFIC_NAME | ROUTINE_TYPE | PARAMETER_MODE | PARAMETER_NAME | DTD_IDENTIFIER                          |
++-----------------+---------------+--------------+----------------+----------------+-----------------------------------------+
+| public          | f1            | FUNCTION     | NULL           | a              | int                                     |
+| public          | p1            | PROCEDURE    | IN             | a              | varchar(10) CHARSET utf8mb4             |
+| public          | p1            | PROCEDURE    | OUT            | b              | bigint                                  |
++-----------------+---------------+--------------+----------------+----------------+-----------------------------------------+
+`))
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(eventsQuery, "?"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
++--------------+------------+------------------------+------------+---------------------+----------------+----------------+---------------------+------+----------+---------------+---------------+
+| EVENT_SCHEMA | EVENT_NAME | EVENT_DEFINITION       | EVENT_TYPE | EXECUTE_AT          | INTERVAL_VALUE | INTERVAL_FIELD | STARTS              | ENDS | STATUS   | ON_COMPLETION | EVENT_COMMENT |
++--------------+------------+------------------------+------------+---------------------+----------------+----------------+---------------------+------+----------+---------------+---------------+
+| public       | e1         | DELETE FROM t1         | RECURRING  | NULL                | 1              | DAY            | 2024-01-01 00:00:00 | NULL | ENABLED  | NOT PRESERVE  |               |
+| public       | e2         | INSERT INTO t1 VALUES  | ONE TIME   | 2030-01-01 00:00:00 | NULL           | NULL           | NULL                | NULL | DISABLED | PRESERVE      | one time      |
+| public       | e3         | DELETE FROM t2         | RECURRING  | NULL                | 1:30           | MINUTE_SECOND  | 2024-01-01 00:00:00 | NULL | ENABLED  | NOT PRESERVE  |               |
++--------------+------------+------------------------+------------+---------------------+----------------+----------------+---------------------+------+----------+---------------+---------------+
+`))
+	drv, err := Open(db)
+	require.NoError(t, err)
+	s, err := drv.InspectSchema(context.Background(), "", &schema.InspectOptions{
+		Mode: schema.InspectFuncs | schema.InspectEvents,
+	})
+	require.NoError(t, err)
+	require.Len(t, s.Objects, 5)
+	f1 := s.Objects[0].(*schema.Func)
+	require.Equal(t, "f1", f1.Name)
+	require.Equal(t, s, f1.Schema)
+	require.Equal(t, &schema.IntegerType{T: TypeInt}, f1.Ret)
+	require.Equal(t, []*schema.FuncArg{{Name: "a", Type: &schema.IntegerType{T: TypeInt}}}, f1.Args)
+	require.Equal(t, "RETURN a + 1", f1.Body)
+	require.Equal(t, []schema.Attr{&Deterministic{}, &DataAccess{V: DataAccessNoSQL}}, f1.Attrs)
+	p1 := s.Objects[1].(*schema.Proc)
+	require.Equal(t, "p1", p1.Name)
+	require.Equal(t, []*schema.FuncArg{
+		{Name: "a", Type: &schema.StringType{T: TypeVarchar, Size: 10}},
+		{Name: "b", Type: &schema.IntegerType{T: TypeBigInt}, Mode: schema.FuncArgModeOut},
+	}, p1.Args)
+	require.Equal(t, "BEGIN SELECT 1; SELECT a; END", p1.Body)
+	require.Equal(t, []schema.Attr{&RoutineSecurity{V: RoutineSecurityInvoker}, &schema.Comment{Text: "proc comment"}}, p1.Attrs)
+	require.Equal(t, &Event{Name: "e1", Schema: s, Every: "1 DAY", Starts: "2024-01-01 00:00:00", Body: "DELETE FROM t1"}, s.Objects[2])
+	require.Equal(t, &Event{Name: "e2", Schema: s, At: "2030-01-01 00:00:00", Preserve: true, Disabled: true, Body: "INSERT INTO t1 VALUES", Attrs: []schema.Attr{&schema.Comment{Text: "one time"}}}, s.Objects[3])
+	require.Equal(t, "'1:30' MINUTE_SECOND", s.Objects[4].(*Event).Every)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestPlanChanges_Funcs(t *testing.T) {
+	var (
+		s  = schema.New("public")
+		f1 = schema.NewFunc("f1").
+			AddArgs(schema.NewFuncArg("a", &schema.IntegerType{T: TypeInt})).
+			SetReturn(&schema.IntegerType{T: TypeInt}).
+			SetBody("RETURN a + 1").
+			AddAttrs(&Deterministic{}, &DataAccess{V: DataAccessNoSQL})
+		p1 = schema.NewProc("p1").
+			AddArgs(
+				schema.NewFuncArg("a", &schema.IntegerType{T: TypeInt}),
+				schema.NewFuncArg("b", &schema.IntegerType{T: TypeBigInt}).SetMode(schema.FuncArgModeOut),
+			).
+			SetBody("BEGIN SELECT a; SET b = a; END").
+			AddAttrs(&RoutineSecurity{V: RoutineSecurityInvoker}, &schema.Comment{Text: "c"})
+		p2 = schema.NewProc("p1").SetBody("SELECT 1")
+		e1 = &Event{Name: "e1", Schema: s, Every: "1 DAY", Starts: "2024-01-01 00:00:00", Body: "DELETE FROM t1"}
+		e2 = &Event{Name: "e1", Schema: s, At: "2030-01-01 00:00:00", Preserve: true, Disabled: true, Body: "DELETE FROM t1", Attrs: []schema.Attr{&schema.Comment{Text: "c"}}}
+	)
+	s.AddFuncs(f1).AddProcs(p1, p2)
+	tests := []struct {
+		changes   []schema.Change
+		want      [][2]string
+		delimiter string
+		wantErr   string
+	}{
+		{
+			changes: []schema.Change{
+				&schema.AddObject{O: f1},
+			},
+			want: [][2]string{
+				{"CREATE FUNCTION `public`.`f1` (`a` int) RETURNS int DETERMINISTIC NO SQL RETURN a + 1", "DROP FUNCTION `public`.`f1`"},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.AddObject{O: p1},
+			},
+			want: [][2]string{
+				{"CREATE PROCEDURE `public`.`p1` (`a` int, OUT `b` bigint) SQL SECURITY INVOKER COMMENT \"c\" BEGIN SELECT a; SET b = a; END", "DROP PROCEDURE `public`.`p1`"},
+			},
+			delimiter: "//",
+		},
+		{
+			changes: []schema.Change{
+				&schema.DropObject{O: p1, Extra: []schema.Clause{&schema.IfExists{}}},
+			},
+			want: [][2]string{
+				{"DROP PROCEDURE IF EXISTS `public`.`p1`", "CREATE PROCEDURE `public`.`p1` (`a` int, OUT `b` bigint) SQL SECURITY INVOKER COMMENT \"c\" BEGIN SELECT a; SET b = a; END"},
+			},
+			delimiter: "//",
+		},
+		// Routines are dropped and created with their new definition.
+		{
+			changes: []schema.Change{
+				&schema.ModifyObject{From: p2, To: p1},
+			},
+			want: [][2]string{
+				{"DROP PROCEDURE `public`.`p1`", "CREATE PROCEDURE `public`.`p1` () SELECT 1"},
+				{"CREATE PROCEDURE `public`.`p1` (`a` int, OUT `b` bigint) SQL SECURITY INVOKER COMMENT \"c\" BEGIN SELECT a; SET b = a; END", "DROP PROCEDURE `public`.`p1`"},
+			},
+			delimiter: "//",
+		},
+		{
+			changes: []schema.Change{
+				&schema.AddObject{O: e1},
+			},
+			want: [][2]string{
+				{"CREATE EVENT `public`.`e1` ON SCHEDULE EVERY 1 DAY STARTS \"2024-01-01 00:00:00\" DO DELETE FROM t1", "DROP EVENT `public`.`e1`"},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.ModifyObject{From: e1, To: e2},
+			},
+			want: [][2]string{
+				{"DROP EVENT `public`.`e1`", "CREATE EVENT `public`.`e1` ON SCHEDULE EVERY 1 DAY STARTS \"2024-01-01 00:00:00\" DO DELETE FROM t1"},
+				{"CREATE EVENT `public`.`e1` ON SCHEDULE AT \"2030-01-01 00:00:00\" ON COMPLETION PRESERVE DISABLE COMMENT \"c\" DO DELETE FROM t1", "DROP EVENT `public`.`e1`"},
+			},
+		},
+		// Expressions are not quoted.
+		{
+			changes: []schema.Change{
+				&schema.AddObject{O: &Event{Name: "e3", Schema: s, At: "CURRENT_TIMESTAMP + INTERVAL 1 HOUR", Body: "DELETE FROM t1"}},
+			},
+			want: [][2]string{
+				{"CREATE EVENT `public`.`e3` ON SCHEDULE AT CURRENT_TIMESTAMP + INTERVAL 1 HOUR DO DELETE FROM t1", "DROP EVENT `public`.`e3`"},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.AddObject{O: &Event{Name: "e3", Schema: s, Every: "1 DAY", Body: "BEGIN DELETE FROM t1; DELETE FROM t2; END"}},
+			},
+			want: [][2]string{
+				{"CREATE EVENT `public`.`e3` ON SCHEDULE EVERY 1 DAY DO BEGIN DELETE FROM t1; DELETE FROM t2; END", "DROP EVENT `public`.`e3`"},
+			},
+			delimiter: "//",
+		},
+		// Statements other than routine, trigger and event
+		// definitions do not require a custom delimiter.
+		{
+			changes: []schema.Change{
+				&schema.ModifyTable{T: schema.NewTable("t1").SetSchema(s).AddColumns(schema.NewIntColumn("id", "int")), Changes: []schema.Change{
+					&schema.ModifyAttr{From: &schema.Comment{}, To: &schema.Comment{Text: "a;b"}},
+				}},
+			},
+			want: [][2]string{
+				{"ALTER TABLE `public`.`t1` COMMENT \"a;b\"", "ALTER TABLE `public`.`t1` COMMENT \"\""},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.AddObject{O: schema.NewFunc("f2").SetBody("RETURN 1")},
+			},
+			wantErr: `mysql: missing return type for function "f2"`,
+		},
+		{
+			changes: []schema.Change{
+				&schema.AddObject{O: &Event{Name: "e2", Body: "DELETE FROM t1"}},
+			},
+			wantErr: `mysql: event "e2" must have exactly one of AT or EVERY schedules`,
+		},
+	}
+	for _, tt := range tests {
+		db, mk, err := sqlmock.New()
+		require.NoError(t, err)
+		mock{mk}.version("8.0.13")
+		drv, err := Open(db)
+		require.NoError(t, err)
+		plan, err := drv.PlanChanges(context.Background(), "plan", tt.changes)
+		if tt.wantErr != "" {
+			require.EqualError(t, err, tt.wantErr)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, tt.delimiter, plan.Delimiter)
+		require.Len(t, plan.Changes, len(tt.want))
+		for i, c := range plan.Changes {
+			require.Equal(t, tt.want[i][0], c.Cmd)
+			require.Equal(t, tt.want[i][1], c.Reverse)
+		}
+	}
+}
+
+func TestDiff_Funcs(t *testing.T) {
+	var (
+		d    = &diff{}
+		from = schema.New("public")
+		to   = schema.New("public")
+	)
+	from.AddFuncs(
+		schema.NewFunc("f1").SetReturn(&schema.IntegerType{T: TypeInt}).SetBody("RETURN 1"),
+		schema.NewFunc("f2").SetReturn(&schema.IntegerType{T: TypeInt}).SetBody("RETURN 1"),
+	).AddProcs(
+		schema.NewProc("p1").SetBody("SELECT 1").AddAttrs(&DataAccess{V: DataAccessContainsSQL}),
+	).AddObjects(
+		&Event{Name: "e1", Schema: from, Every: "1 DAY", Starts: "2024-01-01 00:00:00", Body: "DELETE FROM t1"},
+		&Event{Name: "e2", Schema: from, Every: "1 DAY", Body: "DELETE FROM t1"},
+	)
+	to.AddFuncs(
+		schema.NewFunc("f1").SetReturn(&schema.IntegerType{T: TypeInt}).SetBody("RETURN 1").AddAttrs(&Deterministic{}),
+	).AddProcs(
+		schema.NewProc("p1").SetBody("SELECT 1").AddAttrs(&RoutineSecurity{V: RoutineSecurityDefiner}),
+		schema.NewProc("p2").SetBody("SELECT 1"),
+	).AddObjects(
+		// STARTS is compared only if it is set on the desired state.
+		&Event{Name: "e1", Schema: to, Every: "1 day", Body: "DELETE FROM t1"},
+		&Event{Name: "e2", Schema: to, Every: "1 HOUR", Body: "DELETE FROM t1"},
+		// Times are compared as timestamps, and expressions are skipped.
+		&Event{Name: "e3", Schema: to, At: "'2030-01-01 00:00'", Body: "DELETE FROM t1"},
+		&Event{Name: "e4", Schema: to, At: "CURRENT_TIMESTAMP + INTERVAL 1 DAY", Body: "DELETE FROM t1"},
+		&Event{Name: "e5", Schema: to, At: "2030-01-02T00:00:00Z", Body: "DELETE FROM t1"},
+	)
+	from.AddObjects(
+		&Event{Name: "e3", Schema: from, At: "2030-01-01 00:00:00", Body: "DELETE FROM t1"},
+		&Event{Name: "e4", Schema: from, At: "2030-01-01 00:00:00", Body: "DELETE FROM t1"},
+		&Event{Name: "e5", Schema: from, At: "2030-01-01 00:00:00", Body: "DELETE FROM t1"},
+	)
+	changes, err := d.SchemaObjectDiff(from, to)
+	require.NoError(t, err)
+	require.Len(t, changes, 5)
+	require.Equal(t, &schema.ModifyObject{From: from.Objects[0], To: to.Objects[0]}, changes[0])
+	require.Equal(t, &schema.DropObject{O: from.Objects[1]}, changes[1])
+	require.Equal(t, &schema.AddObject{O: to.Objects[2]}, changes[2])
+	require.Equal(t, &schema.ModifyObject{From: from.Objects[4], To: to.Objects[4]}, changes[3])
+	require.Equal(t, &schema.ModifyObject{From: from.Objects[7], To: to.Objects[7]}, changes[4])
+}
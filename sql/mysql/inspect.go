@@ -44,6 +44,26 @@ func (i *inspect) InspectRealm(ctx context.Context, opts *schema.InspectRealmOpt
 				return nil, err
 			}
 		}
+		if mode.Is(schema.InspectTriggers) {
+			if err := i.inspectTriggers(ctx, r, nil); err != nil {
+				return nil, err
+			}
+		}
+		if mode.Is(schema.InspectFuncs) {
+			if err := i.inspectFuncs(ctx, r, nil); err != nil {
+				return nil, err
+			}
+		}
+		if mode.Is(schema.InspectEvents) {
+			if err := i.inspectEvents(ctx, r, nil); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if sqlx.ModeInspectRealm(opts).Is(schema.InspectRoles) {
+		if err := i.inspectRoles(ctx, r); err != nil {
+			return nil, err
+		}
 	}
 	return sqlx.ExcludeRealm(r, opts.Exclude)
 }
@@ -76,6 +96,26 @@ func (i *inspect) InspectSchema(ctx context.Context, name string, opts *schema.I
 			return nil, err
 		}
 	}
+	if sqlx.ModeInspectSchema(opts).Is(schema.InspectTriggers) {
+		if err := i.inspectTriggers(ctx, r, opts); err != nil {
+			return nil, err
+		}
+	}
+	if sqlx.ModeInspectSchema(opts).Is(schema.InspectFuncs) {
+		if err := i.inspectFuncs(ctx, r, opts); err != nil {
+			return nil, err
+		}
+	}
+	if sqlx.ModeInspectSchema(opts).Is(schema.InspectEvents) {
+		if err := i.inspectEvents(ctx, r, opts); err != nil {
+			return nil, err
+		}
+	}
+	if sqlx.ModeInspectSchema(opts).Is(schema.InspectRoles) {
+		if err := i.inspectRoles(ctx, r); err != nil {
+			return nil, err
+		}
+	}
 	return sqlx.ExcludeSchema(r.Schemas[0], opts.Exclude)
 }
 
@@ -99,6 +139,9 @@ func (i *inspect) inspectTables(ctx context.Context, r *schema.Realm, opts *sche
 		if err := i.checks(ctx, s); err != nil {
 			return err
 		}
+		if err := i.partitions(ctx, s); err != nil {
+			return err
+		}
 		if err := i.showCreate(ctx, s); err != nil {
 			return err
 		}
@@ -205,9 +248,17 @@ func (i *inspect) tables(ctx context.Context, realm *schema.Realm, opts *schema.
 			})
 		}
 		if sqlx.ValidString(options) {
-			t.Attrs = append(t.Attrs, &CreateOptions{
-				V: options.String,
-			})
+			// Partitioning is inspected separately from the PARTITIONS
+			// table, and therefore, it is removed from the options.
+			if v, ok := trimPartitioned(options.String); ok {
+				options.String = v
+				t.Attrs = append(t.Attrs, &Partition{})
+			}
+			if options.String != "" {
+				t.Attrs = append(t.Attrs, &CreateOptions{
+					V: options.String,
+				})
+			}
 		}
 		if sqlx.ValidString(engine) && defaultE.Valid {
 			t.Attrs = append(t.Attrs, &Engine{
@@ -520,6 +571,95 @@ func parseExtra(extra string) (*extraAttr, error) {
 	return attr, nil
 }
 
+// partitions queries and sets the partitioning of the partitioned tables in the schema.
+func (i *inspect) partitions(ctx context.Context, s *schema.Schema) error {
+	var (
+		args  = []any{s.Name}
+		names = make(map[string]*Partition)
+	)
+	for _, t := range s.Tables {
+		for _, a := range t.Attrs {
+			if p, ok := a.(*Partition); ok {
+				names[t.Name] = p
+				args = append(args, t.Name)
+			}
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(partitionsQuery, nArgs(len(names))), args...)
+	if err != nil {
+		return fmt.Errorf("mysql: querying schema %q partitions: %w", s.Name, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var table, name, method, expr, desc sql.NullString
+		if err := rows.Scan(&table, &name, &method, &expr, &desc); err != nil {
+			return fmt.Errorf("mysql: scan partition information: %w", err)
+		}
+		p, ok := names[table.String]
+		if !ok {
+			return fmt.Errorf("table %q was not found in schema", table.String)
+		}
+		// First partition of the table.
+		if p.T == "" {
+			t, _ := s.Table(table.String)
+			if err := setPartitionKey(t, p, method.String, expr.String); err != nil {
+				return err
+			}
+		}
+		switch p.T {
+		case PartitionTypeRange, PartitionTypeList:
+			p.Defs = append(p.Defs, &PartitionDef{Name: name.String, Values: desc.String})
+		default:
+			p.N++
+		}
+	}
+	return rows.Err()
+}
+
+// setPartitionKey sets the partitioning type and key based on the
+// PARTITION_METHOD and PARTITION_EXPRESSION columns.
+func setPartitionKey(t *schema.Table, p *Partition, method, expr string) error {
+	parts := strings.Fields(strings.ToUpper(method))
+	if len(parts) > 0 && parts[0] == "LINEAR" {
+		p.Linear, parts = true, parts[1:]
+	}
+	if len(parts) == 0 {
+		return fmt.Errorf("mysql: unexpected partition method %q for table %q", method, t.Name)
+	}
+	p.T = parts[0]
+	// Expression-based partitioning.
+	if p.T != PartitionTypeKey && (len(parts) == 1 || parts[1] != "COLUMNS") {
+		p.Expr = expr
+		return nil
+	}
+	for _, name := range strings.Split(expr, ",") {
+		if name = strings.Trim(strings.TrimSpace(name), "`"); name == "" {
+			continue
+		}
+		c, ok := t.Column(name)
+		if !ok {
+			return fmt.Errorf("mysql: partition column %q was not found in table %q", name, t.Name)
+		}
+		p.Columns = append(p.Columns, c)
+	}
+	return nil
+}
+
+// trimPartitioned removes the "partitioned" option from the
+// CREATE_OPTIONS and reports if it was found.
+func trimPartitioned(options string) (string, bool) {
+	fields := strings.Fields(options)
+	for i, f := range fields {
+		if strings.EqualFold(f, "partitioned") {
+			return strings.Join(append(fields[:i], fields[i+1:]...), " "), true
+		}
+	}
+	return options, false
+}
+
 // showCreate sets and fixes schema elements that require information from
 // the 'SHOW CREATE' command.
 func (i *inspect) showCreate(ctx context.Context, s *schema.Schema) error {
@@ -656,6 +796,138 @@ func (i *inspect) marDefaultExpr(c *schema.Column, x string) schema.Expr {
 	return &schema.RawExpr{X: sqlx.MayWrap(x)}
 }
 
+// inspectRoles queries and appends the roles and users of the realm, and the
+// privileges that were granted to them on the inspected schemas and tables.
+func (i *inspect) inspectRoles(ctx context.Context, r *schema.Realm) error {
+	if err := i.roles(ctx, r); err != nil {
+		return err
+	}
+	if len(r.Roles) == 0 || len(r.Schemas) == 0 {
+		return nil
+	}
+	return i.grants(ctx, r)
+}
+
+// roles queries and appends the accounts of the realm, and links them to the roles they were granted.
+func (i *inspect) roles(ctx context.Context, r *schema.Realm) error {
+	query := usersQuery
+	switch {
+	case i.Maria() && i.SupportsRoles():
+		query = rolesQueryMaria
+	case i.SupportsRoles():
+		query = rolesQuery
+	}
+	rows, err := i.QueryContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("mysql: querying roles: %w", err)
+	}
+	defer rows.Close()
+	members := make(map[*schema.Role][]string)
+	for rows.Next() {
+		var (
+			name     string
+			login    bool
+			memberOf sql.NullString
+		)
+		if err := rows.Scan(&name, &login, &memberOf); err != nil {
+			return fmt.Errorf("mysql: scanning role information: %w", err)
+		}
+		ro := &schema.Role{Name: name, User: login}
+		r.AddRoles(ro)
+		if memberOf.String != "" {
+			members[ro] = strings.Split(memberOf.String, ",")
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	// Roles that were not inspected are ignored.
+	for _, ro := range r.Roles {
+		for _, name := range members[ro] {
+			if m, ok := r.Role(name); ok {
+				ro.AddMemberOf(m)
+			}
+		}
+	}
+	return nil
+}
+
+// grants queries and appends the privileges that were granted to
+// the realm roles on the inspected schemas, tables, views and columns.
+func (i *inspect) grants(ctx context.Context, r *schema.Realm) error {
+	// The schema names are parameterized in each of the privilege tables.
+	args := make([]any, 0, 3*len(r.Schemas))
+	for j := 0; j < 3; j++ {
+		for _, s := range r.Schemas {
+			args = append(args, s.Name)
+		}
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(grantsQuery, nArgs(len(r.Schemas))), args...)
+	if err != nil {
+		return fmt.Errorf("mysql: querying grants: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			grantee, kind, ns, name, privilege string
+			colName                            sql.NullString
+			grantable                          bool
+		)
+		if err := rows.Scan(&grantee, &kind, &ns, &name, &colName, &privilege, &grantable); err != nil {
+			return fmt.Errorf("mysql: scanning grant information: %w", err)
+		}
+		// Privileges of accounts that were not inspected are ignored.
+		ro, ok := r.Role(accountName(grantee))
+		if !ok {
+			continue
+		}
+		s, ok := r.Schema(ns)
+		if !ok {
+			continue
+		}
+		var (
+			on     schema.Object
+			column *schema.Column
+		)
+		switch kind {
+		case "schema":
+			on = s
+		case "table", "column":
+			if t, ok := s.Table(name); ok {
+				on = t
+				if kind == "column" {
+					if column, ok = t.Column(colName.String); !ok {
+						return fmt.Errorf("mysql: column %q of grant was not found in table %q", colName.String, t.Name)
+					}
+				}
+			} else if v, ok := s.View(name); ok && kind == "table" {
+				// Column-level privileges of views are not supported.
+				on = v
+			}
+		}
+		// Objects that were not inspected are ignored.
+		if on == nil {
+			continue
+		}
+		sqlx.AddPrivilege(ro, on, column, privilege, grantable)
+	}
+	return rows.Err()
+}
+
+// accountName returns the role name of the given account ('user'@'host').
+// The host part is omitted in case the account matches any host.
+func accountName(account string) string {
+	u, h := account, ""
+	if i := strings.LastIndexByte(account, '@'); i > 0 {
+		u, h = account[:i], account[i+1:]
+	}
+	u, h = strings.Trim(u, "'`"), strings.Trim(h, "'`")
+	if h == "" || h == "%" {
+		return u
+	}
+	return u + "@" + h
+}
+
 func (i *inspect) querySchema(ctx context.Context, query string, s *schema.Schema) (*sql.Rows, error) {
 	// Number of times the schema name is parameterized.
 	args := make([]any, strings.Count(query, "?"))
@@ -736,6 +1008,25 @@ WHERE
 ORDER BY
 	TABLE_SCHEMA, TABLE_NAME`
 
+	// Query to list table partitions. In case of sub-partitioning,
+	// only the first sub-partition of each partition is returned.
+	partitionsQuery = `
+SELECT
+	TABLE_NAME,
+	PARTITION_NAME,
+	PARTITION_METHOD,
+	PARTITION_EXPRESSION,
+	PARTITION_DESCRIPTION
+FROM
+	INFORMATION_SCHEMA.PARTITIONS
+WHERE
+	TABLE_SCHEMA = ?
+	AND TABLE_NAME IN (%s)
+	AND PARTITION_NAME IS NOT NULL
+	AND (SUBPARTITION_ORDINAL_POSITION IS NULL OR SUBPARTITION_ORDINAL_POSITION = 1)
+ORDER BY
+	TABLE_NAME, PARTITION_ORDINAL_POSITION`
+
 	// Query to list table check constraints.
 	myChecksQuery  = `SELECT t1.TABLE_NAME, t1.CONSTRAINT_NAME, t2.CHECK_CLAUSE, t1.ENFORCED` + checksQuery
 	marChecksQuery = `SELECT t1.TABLE_NAME, t1.CONSTRAINT_NAME, t2.CHECK_CLAUSE, "YES" AS ENFORCED` + checksQuery
@@ -777,6 +1068,94 @@ ORDER BY
 	BINARY t1.TABLE_NAME,
 	BINARY t1.CONSTRAINT_NAME,
 	t1.ORDINAL_POSITION`
+
+	// Query to list the accounts of the server and the roles they were granted (MySQL 8).
+	// Roles are locked accounts with an expired empty password, as created by CREATE ROLE.
+	// System accounts, root and the current account are excluded.
+	rolesQuery = `
+SELECT
+	IF(u.Host = '%', u.User, CONCAT(u.User, '@', u.Host)) AS role_name,
+	NOT (u.account_locked = 'Y' AND u.password_expired = 'Y' AND u.authentication_string = '') AS login,
+	(SELECT GROUP_CONCAT(IF(e.FROM_HOST = '%', e.FROM_USER, CONCAT(e.FROM_USER, '@', e.FROM_HOST)) ORDER BY e.FROM_USER, e.FROM_HOST) FROM mysql.role_edges AS e WHERE e.TO_USER = u.User AND e.TO_HOST = u.Host) AS member_of
+FROM
+	mysql.user AS u
+WHERE
+	u.User NOT IN ('', 'root', 'mysql.sys', 'mysql.session', 'mysql.infoschema')
+	AND CONCAT(u.User, '@', u.Host) <> CURRENT_USER()
+ORDER BY
+	u.User, u.Host`
+
+	// Query to list the accounts of the server and the roles they were granted (MariaDB).
+	rolesQueryMaria = `
+SELECT
+	IF(u.Host IN ('%', ''), u.User, CONCAT(u.User, '@', u.Host)) AS role_name,
+	u.is_role = 'N' AS login,
+	(SELECT GROUP_CONCAT(m.Role ORDER BY m.Role) FROM mysql.roles_mapping AS m WHERE m.User = u.User AND m.Host = u.Host) AS member_of
+FROM
+	mysql.user AS u
+WHERE
+	u.User NOT IN ('', 'root', 'mariadb.sys', 'mysql.sys', 'mysql.session')
+	AND CONCAT(u.User, '@', u.Host) <> CURRENT_USER()
+ORDER BY
+	u.User, u.Host`
+
+	// Query to list the accounts of the server, in versions that do not support roles.
+	usersQuery = `
+SELECT
+	IF(u.Host = '%', u.User, CONCAT(u.User, '@', u.Host)) AS role_name,
+	TRUE AS login,
+	NULL AS member_of
+FROM
+	mysql.user AS u
+WHERE
+	u.User NOT IN ('', 'root', 'mysql.sys', 'mysql.session')
+	AND CONCAT(u.User, '@', u.Host) <> CURRENT_USER()
+ORDER BY
+	u.User, u.Host`
+
+	// Query to list the privileges that were granted on the given schemas,
+	// and their tables and columns, a row per privilege.
+	grantsQuery = `
+SELECT
+	GRANTEE,
+	'schema' AS OBJECT_TYPE,
+	TABLE_SCHEMA,
+	TABLE_SCHEMA AS OBJECT_NAME,
+	NULL AS COLUMN_NAME,
+	PRIVILEGE_TYPE,
+	IS_GRANTABLE = 'YES' AS GRANTABLE
+FROM
+	INFORMATION_SCHEMA.SCHEMA_PRIVILEGES
+WHERE
+	TABLE_SCHEMA IN (%[1]s)
+UNION ALL
+SELECT
+	GRANTEE,
+	'table',
+	TABLE_SCHEMA,
+	TABLE_NAME,
+	NULL,
+	PRIVILEGE_TYPE,
+	IS_GRANTABLE = 'YES'
+FROM
+	INFORMATION_SCHEMA.TABLE_PRIVILEGES
+WHERE
+	TABLE_SCHEMA IN (%[1]s)
+UNION ALL
+SELECT
+	GRANTEE,
+	'column',
+	TABLE_SCHEMA,
+	TABLE_NAME,
+	COLUMN_NAME,
+	PRIVILEGE_TYPE,
+	IS_GRANTABLE = 'YES'
+FROM
+	INFORMATION_SCHEMA.COLUMN_PRIVILEGES
+WHERE
+	TABLE_SCHEMA IN (%[1]s)
+ORDER BY
+	1, 2, 3, 4, 5`
 )
 
 type (
@@ -793,6 +1172,27 @@ type (
 		V string
 	}
 
+	// Partition describes the PARTITION BY clause of a table.
+	Partition struct {
+		schema.Attr
+		T      string // RANGE, LIST, HASH or KEY.
+		Linear bool   // LINEAR HASH or LINEAR KEY.
+		// Partitioning columns of RANGE COLUMNS, LIST COLUMNS and KEY
+		// partitioning, or an expression for RANGE, LIST and HASH.
+		Columns []*schema.Column
+		Expr    string
+		Defs    []*PartitionDef // Partition definitions of RANGE and LIST.
+		N       int             // Number of partitions of HASH and KEY.
+	}
+
+	// PartitionDef describes a single partition of a RANGE or LIST
+	// partitioned table. Values holds the values list used by the
+	// VALUES LESS THAN or VALUES IN clauses, without the parentheses.
+	PartitionDef struct {
+		Name   string
+		Values string
+	}
+
 	// CreateStmt describes the SQL statement used to create a table.
 	CreateStmt struct {
 		schema.Attr
@@ -806,6 +1206,54 @@ type (
 		Default bool   // The default engine used by the server.
 	}
 
+	// ViewAlgorithm describes the ALGORITHM clause of a view. Note, the
+	// algorithm is inspected only on MariaDB, as MySQL does not expose
+	// it in INFORMATION_SCHEMA, and it is not marshaled for MySQL clients.
+	ViewAlgorithm struct {
+		schema.Attr
+		V string // UNDEFINED, MERGE or TEMPTABLE.
+	}
+
+	// ViewSecurity describes the SQL SECURITY characteristic of a view.
+	ViewSecurity struct {
+		schema.Attr
+		V string // DEFINER or INVOKER.
+	}
+
+	// Deterministic describes the DETERMINISTIC characteristic of a stored function or procedure.
+	Deterministic struct {
+		schema.Attr
+	}
+
+	// DataAccess describes the SQL data access characteristic of a stored function or procedure.
+	DataAccess struct {
+		schema.Attr
+		V string // CONTAINS SQL, NO SQL, READS SQL DATA or MODIFIES SQL DATA.
+	}
+
+	// RoutineSecurity describes the SQL SECURITY characteristic of a stored function or procedure.
+	RoutineSecurity struct {
+		schema.Attr
+		V string // DEFINER or INVOKER.
+	}
+
+	// Event describes a scheduled event that is stored in the schema objects.
+	// https://dev.mysql.com/doc/refman/8.0/en/create-event.html
+	Event struct {
+		schema.Object
+		Name   string
+		Schema *schema.Schema
+		// At holds the timestamp of a one-time event, and Every holds the
+		// interval of a recurring event (e.g. "1 DAY" or "'1:30' MINUTE_SECOND").
+		At, Every string
+		// Starts and Ends hold the optional time range of a recurring event.
+		Starts, Ends string
+		Preserve     bool // ON COMPLETION PRESERVE.
+		Disabled     bool // DISABLE.
+		Body         string
+		Attrs        []schema.Attr // Comment.
+	}
+
 	// OnUpdate attribute for columns with "ON UPDATE CURRENT_TIMESTAMP" as a default.
 	OnUpdate struct {
 		schema.Attr
@@ -837,6 +1285,17 @@ type (
 		A string
 	}
 
+	// OnlineDDL describes the ALGORITHM and LOCK clauses that are added to
+	// ALTER TABLE statements to modify the table without blocking concurrent
+	// reads and writes. If Warn is set, changes that cannot be applied online
+	// are planned without these clauses instead of failing the planning, and
+	// the reason is reported in the comment and the warning of the planned change.
+	// https://dev.mysql.com/doc/refman/8.0/en/innodb-online-ddl-operations.html
+	OnlineDDL struct {
+		schema.Clause
+		Warn bool
+	}
+
 	// IndexType represents an index type.
 	IndexType struct {
 		schema.Attr
@@ -95,6 +95,59 @@ func TestDriver_InspectTable(t *testing.T) {
 				}, t.Columns)
 			},
 		},
+		{
+			name: "partitions",
+			before: func(m mock) {
+				m.ExpectQuery(queryTable).
+					WithArgs("public").
+					WillReturnRows(sqltest.Rows(`
++--------------+--------------+--------------------+--------------------+----------------+---------------+------------------------------+------------------+------------------+
+| TABLE_SCHEMA | TABLE_NAME   | CHARACTER_SET_NAME | TABLE_COLLATION    | AUTO_INCREMENT | TABLE_COMMENT | CREATE_OPTIONS               |      ENGINE      |  DEFAULT_ENGINE  |
++--------------+--------------+--------------------+--------------------+----------------+---------------+------------------------------+------------------+------------------+
+| public       | users        | utf8mb4            | utf8mb4_0900_ai_ci | nil            |               | COMPRESSION="ZLIB" partitioned |       InnoDB     |       1          |
++--------------+--------------+--------------------+--------------------+----------------+---------------+------------------------------+------------------+------------------+
+`))
+				m.ExpectQuery(queryColumns).
+					WithArgs("public", "users").
+					WillReturnRows(sqltest.Rows(`
++--------------------+--------------------+----------------------+----------------------+-------------+------------+----------------+----------------+--------------------+----------------+---------------------------+
+| table_name         | column_name        | column_type          | column_comment       | is_nullable | column_key | column_default | extra          | character_set_name | collation_name | generation_expression     |
++--------------------+--------------------+----------------------+----------------------+-------------+------------+----------------+----------------+--------------------+----------------+---------------------------+
+| users              | id                 | int                  |                      | NO          |            | NULL           |                | NULL               | NULL           | NULL                      |
+| users              | c                  | int                  |                      | NO          |            | NULL           |                | NULL               | NULL           | NULL                      |
++--------------------+--------------------+----------------------+----------------------+-------------+------------+----------------+----------------+--------------------+----------------+---------------------------+
+`))
+				m.noIndexes()
+				m.noFKs()
+				m.ExpectQuery(sqltest.Escape(fmt.Sprintf(partitionsQuery, "?"))).
+					WithArgs("public", "users").
+					WillReturnRows(sqltest.Rows(`
++------------+----------------+------------------+----------------------+-----------------------+
+| TABLE_NAME | PARTITION_NAME | PARTITION_METHOD | PARTITION_EXPRESSION | PARTITION_DESCRIPTION |
++------------+----------------+------------------+----------------------+-----------------------+
+| users      | p0             | RANGE COLUMNS    | ` + "`id`,`c`" + `              | 10,20                 |
+| users      | p1             | RANGE COLUMNS    | ` + "`id`,`c`" + `              | MAXVALUE,MAXVALUE     |
++------------+----------------+------------------+----------------------+-----------------------+
+`))
+			},
+			expect: func(require *require.Assertions, t *schema.Table, err error) {
+				require.NoError(err)
+				require.EqualValues([]schema.Attr{
+					&schema.Charset{V: "utf8mb4"},
+					&schema.Collation{V: "utf8mb4_0900_ai_ci"},
+					&Partition{
+						T:       PartitionTypeRange,
+						Columns: t.Columns,
+						Defs: []*PartitionDef{
+							{Name: "p0", Values: "10,20"},
+							{Name: "p1", Values: "MAXVALUE,MAXVALUE"},
+						},
+					},
+					&CreateOptions{V: `COMPRESSION="ZLIB"`},
+					&Engine{V: "InnoDB", Default: true},
+				}, t.Attrs)
+			},
+		},
 		{
 			name: "int types",
 			before: func(m mock) {
@@ -996,6 +1049,70 @@ func TestDriver_Realm(t *testing.T) {
 	}(), realm)
 }
 
+func TestDriver_InspectRoles(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("8.0.13")
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= SCHEMA()"))).
+		WillReturnRows(sqltest.Rows(`
++-------------+----------------------------+------------------------+
+| SCHEMA_NAME | DEFAULT_CHARACTER_SET_NAME | DEFAULT_COLLATION_NAME |
++-------------+----------------------------+------------------------+
+| public      | utf8mb4                    | utf8mb4_unicode_ci     |
++-------------+----------------------------+------------------------+
+`))
+	mk.tableExists("public", "users", true)
+	mk.ExpectQuery(queryColumns).
+		WithArgs("public", "users").
+		WillReturnRows(sqltest.Rows(`
++------------+-------------+-------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
+| TABLE_NAME | COLUMN_NAME | COLUMN_TYPE | COLUMN_COMMENT | IS_NULLABLE | COLUMN_KEY | COLUMN_DEFAULT | EXTRA | CHARACTER_SET_NAME | COLLATION_NAME | GENERATION_EXPRESSION |
++------------+-------------+-------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
+| users      | id          | int         |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
+| users      | email       | varchar(64) |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
++------------+-------------+-------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
+`))
+	mk.noIndexes()
+	mk.noFKs()
+	mk.ExpectQuery(sqltest.Escape(rolesQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"role_name", "login", "member_of"}).
+			AddRow("alice@localhost", 1, "readers,unknown").
+			AddRow("readers", 0, nil))
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(grantsQuery, "?"))).
+		WithArgs("public", "public", "public").
+		WillReturnRows(sqlmock.NewRows([]string{"GRANTEE", "OBJECT_TYPE", "TABLE_SCHEMA", "OBJECT_NAME", "COLUMN_NAME", "PRIVILEGE_TYPE", "GRANTABLE"}).
+			AddRow("'alice'@'localhost'", "column", "public", "users", "email", "UPDATE", 0).
+			AddRow("'bob'@'%'", "table", "public", "users", nil, "SELECT", 0).
+			AddRow("'readers'@'%'", "schema", "public", "public", nil, "SELECT", 1).
+			AddRow("'readers'@'%'", "table", "public", "users", nil, "INSERT", 0))
+	drv, err := Open(db)
+	require.NoError(t, err)
+	s, err := drv.InspectSchema(context.Background(), "", &schema.InspectOptions{
+		Mode: schema.InspectTables | schema.InspectRoles,
+	})
+	require.NoError(t, err)
+	users, ok := s.Table("users")
+	require.True(t, ok)
+	r := s.Realm
+	require.Len(t, r.Roles, 2)
+	alice, readers := r.Roles[0], r.Roles[1]
+	require.Equal(t, "alice@localhost", alice.Name)
+	require.True(t, alice.User)
+	// Roles that were not inspected are ignored.
+	require.Equal(t, []*schema.Role{readers}, alice.MemberOf)
+	require.Equal(t, []*schema.Grant{
+		{To: alice, On: users, Columns: users.Columns[1:], Privileges: []string{"UPDATE"}},
+	}, alice.Grants)
+	require.Equal(t, "readers", readers.Name)
+	require.False(t, readers.User)
+	require.Equal(t, []*schema.Grant{
+		{To: readers, On: s, Privileges: []string{"SELECT"}, GrantOption: true},
+		{To: readers, On: users, Privileges: []string{"INSERT"}},
+	}, readers.Grants)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
 func TestInspectMode_InspectRealm(t *testing.T) {
 	db, m, err := sqlmock.New()
 	require.NoError(t, err)
@@ -80,6 +80,26 @@ func (v V) SupportsRenameColumn() bool {
 	return v.GTE(u)
 }
 
+// SupportsInstantDDL reports if the version supports
+// the ALGORITHM=INSTANT clause on ALTER TABLE.
+func (v V) SupportsInstantDDL() bool {
+	u := "8.0.12"
+	if v.Maria() {
+		u = "10.3.2"
+	}
+	return v.GTE(u)
+}
+
+// SupportsInstantDropColumn reports if the version supports
+// dropping and renaming columns with ALGORITHM=INSTANT.
+func (v V) SupportsInstantDropColumn() bool {
+	u := "8.0.29"
+	if v.Maria() {
+		u = "10.4"
+	}
+	return v.GTE(u)
+}
+
 // SupportsIndexComment reports if the version
 // supports comments on indexes.
 func (v V) SupportsIndexComment() bool {
@@ -94,6 +114,15 @@ func (v V) SupportsViewUsage() bool {
 	return !v.Maria() && v.GTE("8.0.13")
 }
 
+// SupportsRoles reports if the version supports roles.
+func (v V) SupportsRoles() bool {
+	u := "8"
+	if v.Maria() {
+		u = "10.0.5"
+	}
+	return v.GTE(u)
+}
+
 // CharsetToCollate returns the mapping from charset to its default collation.
 func (v V) CharsetToCollate(conn schema.ExecQuerier) (map[string]string, error) {
 	name := "is/charset2collate"
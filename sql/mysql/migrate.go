@@ -47,6 +47,14 @@ func (p *planApply) PlanChanges(_ context.Context, name string, changes []schema
 	if err := sqlx.SetReversible(&s.Plan); err != nil {
 		return nil, err
 	}
+	// Compound statements (e.g. BEGIN ... END bodies of routines, events and triggers)
+	// contain semicolons, and therefore, a custom delimiter is used to separate them.
+	for _, c := range s.Changes {
+		if compoundStmt(c) {
+			s.Plan.Delimiter = delimiter
+			break
+		}
+	}
 	return &s.Plan, nil
 }
 
@@ -74,6 +82,10 @@ func (s *state) plan(changes []schema.Change) error {
 			return err
 		}
 	}
+	changes, before, after := sqlx.RoleChanges(changes)
+	if err := s.planRoles(before); err != nil {
+		return err
+	}
 	planned, err := s.topLevel(changes)
 	if err != nil {
 		return err
@@ -82,9 +94,27 @@ func (s *state) plan(changes []schema.Change) error {
 	if err != nil {
 		return err
 	}
-	var views []schema.Change
+	var views, triggers, objects []schema.Change
+	// Triggers, routines and events are dropped first, as they may depend on the modified tables.
+	for _, c := range planned {
+		switch c := c.(type) {
+		case *schema.DropTrigger:
+			err = s.dropTrigger(c)
+		case *schema.DropObject:
+			err = s.dropObject(c)
+		}
+		if err != nil {
+			return err
+		}
+	}
 	for _, c := range planned {
 		switch c := c.(type) {
+		case *schema.DropTrigger, *schema.DropObject:
+			// Planned above.
+		case *schema.AddTrigger, *schema.ModifyTrigger:
+			triggers = append(triggers, c)
+		case *schema.AddObject, *schema.ModifyObject:
+			objects = append(objects, c)
 		case *schema.AddTable:
 			err = s.addTable(c)
 		case *schema.DropTable:
@@ -116,10 +146,193 @@ func (s *state) plan(changes []schema.Change) error {
 		case *schema.RenameView:
 			s.renameView(c)
 		}
+		if err != nil {
+			return err
+		}
+	}
+	// Triggers are created after the tables they are defined on.
+	for _, c := range triggers {
+		switch c := c.(type) {
+		case *schema.AddTrigger:
+			err = s.addTrigger(c)
+		case *schema.ModifyTrigger:
+			err = s.modifyTrigger(c)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	// Routines and events are created last, as their bodies may use all other resources.
+	for _, c := range objects {
+		switch c := c.(type) {
+		case *schema.AddObject:
+			err = s.addObject(c)
+		case *schema.ModifyObject:
+			err = s.modifyObject(c)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return s.planRoles(after)
+}
+
+// planRoles plans the role and privilege changes. Roles are granted to the
+// created accounts after all accounts were created, as they may depend on each other.
+func (s *state) planRoles(changes []schema.Change) error {
+	for _, c := range changes {
+		if c, ok := c.(*schema.AddRole); ok {
+			s.append(&migrate.Change{
+				Cmd:     s.createRole(c.R),
+				Source:  c,
+				Comment: fmt.Sprintf("create %s %q", roleKind(c.R), c.R.Name),
+				Reverse: s.dropRole(c.R),
+			})
+		}
+	}
+	for _, c := range changes {
+		switch c := c.(type) {
+		case *schema.AddRole:
+			s.append(s.memberships(c, c.R, nil, c.R.MemberOf)...)
+		case *schema.ModifyRole:
+			if c.From.User != c.To.User {
+				return fmt.Errorf("mysql: changing %s %q to a %s is not supported", roleKind(c.From), c.From.Name, roleKind(c.To))
+			}
+			add, drop := sqlx.MembershipsDiff(c.From.MemberOf, c.To.MemberOf)
+			s.append(s.memberships(c, c.To, drop, add)...)
+		case *schema.DropRole:
+			reverse := []string{s.createRole(c.R)}
+			for _, m := range s.memberships(c, c.R, nil, c.R.MemberOf) {
+				reverse = append(reverse, m.Cmd)
+			}
+			s.append(&migrate.Change{
+				Cmd:     s.dropRole(c.R),
+				Source:  c,
+				Comment: fmt.Sprintf("drop %s %q", roleKind(c.R), c.R.Name),
+				Reverse: reverse,
+			})
+		case *schema.AddGrant:
+			grant, revoke, err := s.grant(c.G)
+			if err != nil {
+				return err
+			}
+			s.append(&migrate.Change{
+				Cmd:     grant,
+				Source:  c,
+				Comment: fmt.Sprintf("grant privileges to %q", c.G.To.Name),
+				Reverse: revoke,
+			})
+		case *schema.DropGrant:
+			grant, revoke, err := s.grant(c.G)
+			if err != nil {
+				return err
+			}
+			s.append(&migrate.Change{
+				Cmd:     revoke,
+				Source:  c,
+				Comment: fmt.Sprintf("revoke privileges from %q", c.G.To.Name),
+				Reverse: grant,
+			})
+		}
 	}
 	return nil
 }
 
+// createRole returns the statement for creating the role or the user.
+func (s *state) createRole(r *schema.Role) string {
+	if r.User {
+		return s.Build("CREATE USER", account(r.Name)).String()
+	}
+	return s.Build("CREATE ROLE", account(r.Name)).String()
+}
+
+// dropRole returns the statement for dropping the role or the user.
+func (s *state) dropRole(r *schema.Role) string {
+	if r.User {
+		return s.Build("DROP USER", account(r.Name)).String()
+	}
+	return s.Build("DROP ROLE", account(r.Name)).String()
+}
+
+// memberships returns the statements for granting the "add" roles to the account, and revoking the "drop" roles from it.
+func (s *state) memberships(c schema.Change, r *schema.Role, drop, add []*schema.Role) []*migrate.Change {
+	changes := make([]*migrate.Change, 0, len(drop)+len(add))
+	for _, m := range drop {
+		changes = append(changes, &migrate.Change{
+			Cmd:     s.Build("REVOKE", account(m.Name), "FROM", account(r.Name)).String(),
+			Source:  c,
+			Comment: fmt.Sprintf("revoke role %q from %q", m.Name, r.Name),
+			Reverse: s.Build("GRANT", account(m.Name), "TO", account(r.Name)).String(),
+		})
+	}
+	for _, m := range add {
+		changes = append(changes, &migrate.Change{
+			Cmd:     s.Build("GRANT", account(m.Name), "TO", account(r.Name)).String(),
+			Source:  c,
+			Comment: fmt.Sprintf("grant role %q to %q", m.Name, r.Name),
+			Reverse: s.Build("REVOKE", account(m.Name), "FROM", account(r.Name)).String(),
+		})
+	}
+	return changes
+}
+
+// grant returns the GRANT and the REVOKE statements of the given grant.
+func (s *state) grant(g *schema.Grant) (grant, revoke string, err error) {
+	if g.To == nil {
+		return "", "", errors.New("mysql: missing account for grant")
+	}
+	var on string
+	switch o := g.On.(type) {
+	case *schema.Schema:
+		on = s.Build().Ident(o.Name).String() + ".*"
+	case *schema.Table:
+		on = s.Build().Table(o).String()
+	case *schema.View:
+		on = s.Build().View(o).String()
+	default:
+		return "", "", fmt.Errorf("mysql: unsupported object %T for grant to %q", g.On, g.To.Name)
+	}
+	privs := s.Build().MapComma(g.Privileges, func(i int, b *sqlx.Builder) {
+		b.P(sqlx.PrivilegeName(g.Privileges[i]))
+		if len(g.Columns) > 0 {
+			b.Wrap(func(b *sqlx.Builder) {
+				b.MapComma(g.Columns, func(i int, b *sqlx.Builder) {
+					b.Ident(g.Columns[i].Name)
+				})
+			})
+		}
+	}).String()
+	b := s.Build("GRANT", privs, "ON", on, "TO", account(g.To.Name))
+	if g.GrantOption {
+		b.P("WITH GRANT OPTION")
+	}
+	return b.String(), s.Build("REVOKE", privs, "ON", on, "FROM", account(g.To.Name)).String(), nil
+}
+
+// account returns the quoted account name ('user'@'host') of the given role name.
+// The host part is omitted for names without a host, which match any host.
+func account(name string) string {
+	u, h := name, ""
+	if i := strings.LastIndexByte(name, '@'); i > 0 {
+		u, h = name[:i], name[i+1:]
+	}
+	q := func(s string) string {
+		return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+	}
+	if h == "" {
+		return q(u)
+	}
+	return q(u) + "@" + q(h)
+}
+
+// roleKind returns the kind of the role for describing its changes.
+func roleKind(r *schema.Role) string {
+	if r.User {
+		return "user"
+	}
+	return "role"
+}
+
 // topLevel appends first the changes for creating or dropping schemas (top-level schema elements).
 func (s *state) topLevel(changes []schema.Change) ([]schema.Change, error) {
 	planned := make([]schema.Change, 0, len(changes))
@@ -276,6 +489,9 @@ func (s *state) addTable(add *schema.AddTable) error {
 		return fmt.Errorf("create table %q: %s", add.T.Name, strings.Join(errs, ", "))
 	}
 	s.tableAttr(b, add, add.T.Attrs...)
+	if p := (&Partition{}); sqlx.Has(add.T.Attrs, p) {
+		partitionBy(b, p)
+	}
 	s.append(&migrate.Change{
 		Cmd:     b.String(),
 		Source:  add,
@@ -309,11 +525,31 @@ func (s *state) dropTable(drop *schema.DropTable) error {
 // modifyTable builds and appends the migration changes for
 // bringing the table into its modified state.
 func (s *state) modifyTable(modify *schema.ModifyTable) error {
-	var changes [2][]schema.Change
+	var (
+		changes   [2][]schema.Change
+		partition schema.Change
+	)
 	if len(modify.T.Columns) == 0 {
 		return fmt.Errorf("table %q has no columns; drop the table instead", modify.T.Name)
 	}
+	if s.ExpandContract {
+		phased, others, err := sqlx.PhasedColumns(modify)
+		if err != nil {
+			return fmt.Errorf("modify table %q: %w", modify.T.Name, err)
+		}
+		for _, c := range phased {
+			if err := s.phasedColumn(c, modify.Extra); err != nil {
+				return err
+			}
+		}
+		modify = &schema.ModifyTable{T: modify.T, Changes: others, Extra: modify.Extra}
+	}
 	for _, change := range skipAutoChanges(modify.Changes) {
+		// Partitioning changes are executed in a separate statement.
+		if _, _, ok := partitionChange(change); ok {
+			partition = change
+			continue
+		}
 		switch change := change.(type) {
 		// Foreign-key modification is translated into 2 steps.
 		// Dropping the current foreign key and creating a new one.
@@ -343,28 +579,293 @@ func (s *state) modifyTable(modify *schema.ModifyTable) error {
 			changes[1] = append(changes[1], &schema.AddIndex{
 				I: change.To,
 			})
+		// Columns are renamed before they are modified, as
+		// the modifications refer to their new names.
+		case *schema.RenameColumn:
+			changes[0] = append(changes[0], change)
 		case *schema.DropAttr:
 			return fmt.Errorf("unsupported change type: %v", change.A)
 		default:
 			changes[1] = append(changes[1], change)
 		}
 	}
+	// Partitioning is removed before the table is modified, as
+	// it may reference columns that are dropped in the same change.
+	if _, ok := partition.(*schema.DropAttr); ok {
+		if err := s.alterPartition(modify.T, partition, modify.Extra); err != nil {
+			return err
+		}
+	}
 	for i := range changes {
 		if len(changes[i]) > 0 {
-			if err := s.alterTable(modify.T, changes[i]); err != nil {
+			if err := s.alterTable(modify.T, changes[i], modify.Extra); err != nil {
 				return err
 			}
 		}
 	}
+	if _, ok := partition.(*schema.DropAttr); partition != nil && !ok {
+		if err := s.alterPartition(modify.T, partition, modify.Extra); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// phasedColumn plans the given column change in expand and contract phases. The expand
+// phase adds the shadow column, creates triggers that keep it in sync with the replaced
+// column, and backfills it. The contract phase drops the triggers and the replaced column.
+func (s *state) phasedColumn(c *sqlx.PhasedColumn, extra []schema.Clause) error {
+	var (
+		t    = c.T
+		name = fmt.Sprintf("%s_%s_sync", t.Name, c.From.Name)
+		from = s.Build().Ident(c.From.Name).String()
+		to   = s.Build().Ident(c.Shadow.Name).String()
+		// MySQL triggers are defined on one event, and therefore,
+		// inserts and updates are synced by separate triggers.
+		triggers = []*schema.Trigger{
+			schema.NewTrigger(name + "_insert").
+				SetActionTime(schema.TriggerTimeBefore).
+				AddEvents(schema.TriggerEventInsert).
+				SetBody(fmt.Sprintf("SET NEW.%[1]s = COALESCE(NEW.%[2]s, NEW.%[1]s), NEW.%[2]s = NEW.%[1]s", from, to)),
+			schema.NewTrigger(name + "_update").
+				SetActionTime(schema.TriggerTimeBefore).
+				AddEvents(schema.TriggerEventUpdate).
+				SetBody(fmt.Sprintf("SET NEW.%[2]s = IF(NEW.%[1]s <=> OLD.%[1]s, NEW.%[2]s, NEW.%[1]s), NEW.%[1]s = IF(NEW.%[2]s <=> OLD.%[2]s, NEW.%[1]s, NEW.%[2]s)", from, to)),
+		}
+	)
+	if err := s.alterTable(t, []schema.Change{&schema.AddColumn{C: c.Expanded()}}, extra); err != nil {
+		return err
+	}
+	for _, tr := range triggers {
+		tr.Table = t
+		if err := s.addTrigger(&schema.AddTrigger{T: tr}); err != nil {
+			return err
+		}
+	}
+	s.append(&migrate.Change{
+		Cmd:     s.Build("UPDATE").Table(t).P("SET", to, "=", from).String(),
+		Comment: fmt.Sprintf("backfill column %q of table %q", c.Shadow.Name, t.Name),
+		Reverse: s.Build("UPDATE").Table(t).P("SET", from, "=", to).String(),
+	})
+	if !c.Shadow.Type.Null {
+		modify := &schema.ModifyColumn{From: c.Expanded(), To: c.Shadow, Change: schema.ChangeNull}
+		if err := s.alterTable(t, []schema.Change{modify}, extra); err != nil {
+			return err
+		}
+	}
+	n := len(s.Changes)
+	for _, tr := range triggers {
+		if err := s.dropTrigger(&schema.DropTrigger{T: tr}); err != nil {
+			return err
+		}
+	}
+	contract := []schema.Change{&schema.DropColumn{C: c.From}}
+	if c.Shadow.Name != c.To.Name {
+		contract = append(contract, &schema.RenameColumn{From: c.Shadow, To: c.To})
+	}
+	for _, change := range contract {
+		if err := s.alterTable(t, []schema.Change{change}, extra); err != nil {
+			return err
+		}
+	}
+	for _, c := range s.Changes[n:] {
+		c.Contract = true
+	}
 	return nil
 }
 
+// alterPartition builds and appends the migration change for
+// modifying the partitioning of the table.
+func (s *state) alterPartition(t *schema.Table, c schema.Change, extra []schema.Clause) error {
+	from, to, _ := partitionChange(c)
+	change := &migrate.Change{
+		Cmd:     s.partitionCmd(t, from, to),
+		Source:  c,
+		Reverse: s.partitionCmd(t, to, from),
+		Comment: fmt.Sprintf("modify partitioning of %q table", t.Name),
+	}
+	// Repartitioning copies the table data, and
+	// therefore, cannot be applied online.
+	if online := (OnlineDDL{}); sqlx.Has(extra, &online) {
+		reason := "modifying the table partitioning"
+		if !online.Warn {
+			return fmt.Errorf("alter table %q: %s cannot be applied online", t.Name, reason)
+		}
+		change.Comment += fmt.Sprintf(" (%s cannot be applied online)", reason)
+	}
+	s.append(change)
+	return nil
+}
+
+// partitionCmd returns the ALTER TABLE command for migrating the
+// table partitioning from one state to the other.
+func (s *state) partitionCmd(t *schema.Table, from, to *Partition) string {
+	b := s.Build("ALTER TABLE").Table(t)
+	switch {
+	case to == nil:
+		b.P("REMOVE PARTITIONING")
+	case from == nil || partitionKeyChanged(from, to):
+		partitionBy(b, to)
+	case to.T == PartitionTypeHash || to.T == PartitionTypeKey:
+		if n, m := partitionsN(from), partitionsN(to); m > n {
+			b.P("ADD PARTITION PARTITIONS", strconv.Itoa(m-n))
+		} else {
+			b.P("COALESCE PARTITION", strconv.Itoa(n-m))
+		}
+	case to.T == PartitionTypeList:
+		var dropped, added, changedF, changedT []*PartitionDef
+		for _, d1 := range from.Defs {
+			switch d2, ok := partitionDef(to.Defs, d1.Name); {
+			case !ok:
+				dropped = append(dropped, d1)
+			case !partitionDefEqual(d1, d2):
+				changedF, changedT = append(changedF, d1), append(changedT, d2)
+			}
+		}
+		for _, d2 := range to.Defs {
+			if _, ok := partitionDef(from.Defs, d2.Name); !ok {
+				added = append(added, d2)
+			}
+		}
+		switch {
+		case len(changedF) == 0 && len(added) == 0:
+			b.P("DROP PARTITION")
+			partitionNames(b, dropped)
+		case len(changedF) == 0 && len(dropped) == 0:
+			b.P("ADD PARTITION")
+			partitionDefs(b, to, added)
+		default:
+			b.P("REORGANIZE PARTITION")
+			partitionNames(b, append(dropped, changedF...))
+			b.P("INTO")
+			partitionDefs(b, to, append(changedT, added...))
+		}
+	default:
+		// RANGE partitions are ordered, and therefore, partitions can be added
+		// only at the end, and reorganized only if they are adjacent.
+		i := 0
+		for i < len(from.Defs) && i < len(to.Defs) && partitionDefEqual(from.Defs[i], to.Defs[i]) {
+			i++
+		}
+		switch fromR, toR := from.Defs[i:], to.Defs[i:]; {
+		case len(fromR) == 0:
+			b.P("ADD PARTITION")
+			partitionDefs(b, to, toR)
+		case isSubsequence(toR, fromR):
+			var dropped []*PartitionDef
+			for _, d := range fromR {
+				if len(toR) > 0 && partitionDefEqual(d, toR[0]) {
+					toR = toR[1:]
+				} else {
+					dropped = append(dropped, d)
+				}
+			}
+			b.P("DROP PARTITION")
+			partitionNames(b, dropped)
+		default:
+			b.P("REORGANIZE PARTITION")
+			partitionNames(b, fromR)
+			b.P("INTO")
+			partitionDefs(b, to, toR)
+		}
+	}
+	return b.String()
+}
+
+// partitionBy writes the PARTITION BY clause of the table to the builder.
+func partitionBy(b *sqlx.Builder, p *Partition) {
+	b.P("PARTITION BY")
+	if p.Linear {
+		b.P("LINEAR")
+	}
+	b.P(p.T)
+	if len(p.Columns) > 0 && p.T != PartitionTypeKey {
+		b.P("COLUMNS")
+	}
+	b.Wrap(func(b *sqlx.Builder) {
+		if p.Expr != "" {
+			b.P(p.Expr)
+			return
+		}
+		b.MapComma(p.Columns, func(i int, b *sqlx.Builder) {
+			b.Ident(p.Columns[i].Name)
+		})
+	})
+	switch p.T {
+	case PartitionTypeHash, PartitionTypeKey:
+		if p.N > 0 {
+			b.P("PARTITIONS", strconv.Itoa(p.N))
+		}
+	default:
+		if len(p.Defs) > 0 {
+			b.WriteByte(' ')
+			partitionDefs(b, p, p.Defs)
+		}
+	}
+}
+
+// partitionDefs writes the given partition definitions to the builder.
+func partitionDefs(b *sqlx.Builder, p *Partition, defs []*PartitionDef) {
+	b.Wrap(func(b *sqlx.Builder) {
+		b.MapComma(defs, func(i int, b *sqlx.Builder) {
+			b.P("PARTITION").Ident(defs[i].Name).P("VALUES")
+			switch {
+			case p.T == PartitionTypeList:
+				b.P("IN")
+			case len(p.Columns) == 0 && strings.EqualFold(defs[i].Values, "MAXVALUE"):
+				b.P("LESS THAN MAXVALUE")
+				return
+			default:
+				b.P("LESS THAN")
+			}
+			b.Wrap(func(b *sqlx.Builder) {
+				b.P(defs[i].Values)
+			})
+		})
+	})
+}
+
+// partitionNames writes the names of the given partitions to the builder.
+func partitionNames(b *sqlx.Builder, defs []*PartitionDef) {
+	b.MapComma(defs, func(i int, b *sqlx.Builder) {
+		b.Ident(defs[i].Name)
+	})
+}
+
+// partitionChange returns the partitioning states of the
+// given change, if it is a partitioning change.
+func partitionChange(c schema.Change) (from, to *Partition, ok bool) {
+	switch c := c.(type) {
+	case *schema.AddAttr:
+		to, ok = c.A.(*Partition)
+	case *schema.DropAttr:
+		from, ok = c.A.(*Partition)
+	case *schema.ModifyAttr:
+		if from, ok = c.From.(*Partition); ok {
+			to, ok = c.To.(*Partition)
+		}
+	}
+	return from, to, ok
+}
+
+// isSubsequence reports if the partitions in sub are a subsequence of defs.
+func isSubsequence(sub, defs []*PartitionDef) bool {
+	for _, d := range defs {
+		if len(sub) > 0 && partitionDefEqual(d, sub[0]) {
+			sub = sub[1:]
+		}
+	}
+	return len(sub) == 0
+}
+
 // alterTable modifies the given table by executing on it a list of
 // changes in one SQL statement.
-func (s *state) alterTable(t *schema.Table, changes []schema.Change) error {
+func (s *state) alterTable(t *schema.Table, changes []schema.Change, extra []schema.Clause) error {
 	var (
 		reverse    []schema.Change
 		reversible = true
+		online     OnlineDDL
+		isOnline   = sqlx.Has(extra, &online)
 	)
 	build := func(changes []schema.Change) (string, error) {
 		b := s.Build("ALTER TABLE").Table(t)
@@ -481,6 +982,11 @@ func (s *state) alterTable(t *schema.Table, changes []schema.Change) error {
 		if err != nil {
 			return "", err
 		}
+		if isOnline {
+			if algo, reason := s.onlineAlgorithm(t, changes); reason == "" {
+				b.Comma().P(algo)
+			}
+		}
 		return b.String(), nil
 	}
 	cmd, err := build(changes)
@@ -492,9 +998,19 @@ func (s *state) alterTable(t *schema.Table, changes []schema.Change) error {
 		Source: &schema.ModifyTable{
 			T:       t,
 			Changes: changes,
+			Extra:   extra,
 		},
 		Comment: fmt.Sprintf("modify %q table", t.Name),
 	}
+	if isOnline {
+		if _, reason := s.onlineAlgorithm(t, changes); reason != "" {
+			if !online.Warn {
+				return fmt.Errorf("alter table %q: %s cannot be applied online", t.Name, reason)
+			}
+			change.Comment += fmt.Sprintf(" (%s cannot be applied online)", reason)
+			change.Warning = fmt.Sprintf("alter table %q: %s cannot be applied online", t.Name, reason)
+		}
+	}
 	if reversible {
 		// Changes should be reverted in
 		// a reversed order they were created.
@@ -507,6 +1023,97 @@ func (s *state) alterTable(t *schema.Table, changes []schema.Change) error {
 	return nil
 }
 
+// onlineAlgorithm returns the ALGORITHM and LOCK clauses for applying the given
+// changes without blocking concurrent reads and writes to the table, or the
+// reason the changes cannot be applied this way.
+// https://dev.mysql.com/doc/refman/8.0/en/innodb-online-ddl-operations.html
+func (s *state) onlineAlgorithm(t *schema.Table, changes []schema.Change) (string, string) {
+	instant := s.SupportsInstantDDL()
+	for _, c := range changes {
+		switch c := c.(type) {
+		case *schema.AddColumn:
+			var x schema.GeneratedExpr
+			switch {
+			case sqlx.Has(c.C.Attrs, &AutoIncrement{}):
+				return "", fmt.Sprintf("adding AUTO_INCREMENT column %q", c.C.Name)
+			case sqlx.Has(c.C.Attrs, &x) && storedOrVirtual(x.Type) == stored:
+				return "", fmt.Sprintf("adding STORED generated column %q", c.C.Name)
+			// Columns cannot be added instantly to tables with FULLTEXT indexes.
+			case hasFullText(t):
+				instant = false
+			}
+		case *schema.DropColumn:
+			instant = instant && s.SupportsInstantDropColumn()
+		case *schema.RenameColumn:
+			instant = instant && s.SupportsInstantDropColumn() && s.SupportsRenameColumn()
+		case *schema.ModifyColumn:
+			switch k := c.Change; {
+			case k.Is(schema.ChangeType | schema.ChangeCharset | schema.ChangeCollate | schema.ChangeGenerated | schema.ChangeAttr):
+				return "", fmt.Sprintf("changing the definition of column %q", c.From.Name)
+			// Only setting or dropping the column default is instant.
+			case k&^schema.ChangeDefault != schema.NoChange:
+				instant = false
+			}
+		case *schema.AddIndex:
+			var x IndexType
+			if sqlx.Has(c.I.Attrs, &x) && (strings.ToUpper(x.T) == IndexTypeFullText || strings.ToUpper(x.T) == IndexTypeSpatial) {
+				return "", fmt.Sprintf("adding %s index %q", strings.ToUpper(x.T), c.I.Name)
+			}
+			instant = false
+		case *schema.DropIndex, *schema.RenameIndex, *schema.AddPrimaryKey, *schema.ModifyPrimaryKey,
+			*schema.DropForeignKey, *schema.DropCheck:
+			instant = false
+		case *schema.DropPrimaryKey:
+			return "", "dropping the primary key"
+		// Adding foreign keys is performed in place only when foreign_key_checks is disabled.
+		case *schema.AddForeignKey:
+			return "", fmt.Sprintf("adding foreign key %q", c.F.Symbol)
+		case *schema.AddCheck:
+			return "", fmt.Sprintf("adding check constraint %q", c.C.Name)
+		case *schema.ModifyCheck:
+			return "", fmt.Sprintf("modifying check constraint %q", c.From.Name)
+		// Besides the comment and the AUTO_INCREMENT value,
+		// changing table options requires rebuilding the table.
+		case *schema.AddAttr:
+			if !onlineAttr(c.A) {
+				return "", "changing the table options"
+			}
+			instant = false
+		case *schema.ModifyAttr:
+			if !onlineAttr(c.To) {
+				return "", "changing the table options"
+			}
+			instant = false
+		default:
+			return "", fmt.Sprintf("change %T", c)
+		}
+	}
+	if instant {
+		return "ALGORITHM=INSTANT", ""
+	}
+	return "ALGORITHM=INPLACE, LOCK=NONE", ""
+}
+
+// onlineAttr reports if the table attribute can be changed online.
+func onlineAttr(a schema.Attr) bool {
+	switch a.(type) {
+	case *schema.Comment, *AutoIncrement:
+		return true
+	}
+	return false
+}
+
+// hasFullText reports if the table has a FULLTEXT index.
+func hasFullText(t *schema.Table) bool {
+	for _, idx := range t.Indexes {
+		var x IndexType
+		if sqlx.Has(idx.Attrs, &x) && strings.ToUpper(x.T) == IndexTypeFullText {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *state) renameTable(c *schema.RenameTable) {
 	s.append(&migrate.Change{
 		Source:  c,
@@ -712,8 +1319,8 @@ func (s *state) collation(t *schema.Table) string {
 	return s.collate
 }
 
-func (s *state) append(c *migrate.Change) {
-	s.Changes = append(s.Changes, c)
+func (s *state) append(c ...*migrate.Change) {
+	s.Changes = append(s.Changes, c...)
 }
 
 func (*state) attr(b *sqlx.Builder, attrs ...schema.Attr) {
@@ -823,6 +1430,38 @@ func checkChangeGenerated(from, to *schema.Column) error {
 	return nil
 }
 
+// delimiter is the custom delimiter used by plans with compound statements.
+const delimiter = "//"
+
+// compoundStmt reports if the change creates or drops a routine, a trigger or an event
+// with a compound body (e.g. BEGIN ... END) that cannot be separated using the default
+// delimiter. Note, the reverse statement of a dropped object creates it, and therefore,
+// the body is checked for both cases. Other statements, such as ones that contain string
+// literals with semicolons, do not require a custom delimiter.
+func compoundStmt(c *migrate.Change) bool {
+	var bodies []string
+	switch s := c.Source.(type) {
+	case *schema.AddTrigger:
+		bodies = append(bodies, s.T.Body)
+	case *schema.DropTrigger:
+		bodies = append(bodies, s.T.Body)
+	case *schema.ModifyTrigger:
+		bodies = append(bodies, s.From.Body, s.To.Body)
+	case *schema.AddObject:
+		bodies = append(bodies, objectBody(s.O))
+	case *schema.DropObject:
+		bodies = append(bodies, objectBody(s.O))
+	case *schema.ModifyObject:
+		bodies = append(bodies, objectBody(s.From), objectBody(s.To))
+	}
+	for _, b := range bodies {
+		if strings.Contains(b, ";") {
+			return true
+		}
+	}
+	return false
+}
+
 func quote(s string) string {
 	if sqlx.IsQuoted(s, '"', '\'') {
 		return s
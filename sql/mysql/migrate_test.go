@@ -1013,6 +1013,39 @@ func TestPlanChanges(t *testing.T) {
 				},
 			},
 		},
+		{
+			changes: []schema.Change{
+				&schema.ModifyTable{
+					T: schema.NewTable("t1").
+						SetSchema(schema.New("s1")).
+						AddColumns(schema.NewIntColumn("b", "bigint")),
+					Changes: []schema.Change{
+						&schema.RenameColumn{
+							From: schema.NewIntColumn("a", "int"),
+							To:   schema.NewIntColumn("b", "bigint"),
+						},
+						&schema.ModifyColumn{
+							From:   schema.NewIntColumn("b", "int"),
+							To:     schema.NewIntColumn("b", "bigint"),
+							Change: schema.ChangeType,
+						},
+					},
+				},
+			},
+			wantPlan: &migrate.Plan{
+				Reversible: true,
+				Changes: []*migrate.Change{
+					{
+						Cmd:     "ALTER TABLE `s1`.`t1` RENAME COLUMN `a` TO `b`",
+						Reverse: "ALTER TABLE `s1`.`t1` RENAME COLUMN `b` TO `a`",
+					},
+					{
+						Cmd:     "ALTER TABLE `s1`.`t1` MODIFY COLUMN `b` bigint NOT NULL",
+						Reverse: "ALTER TABLE `s1`.`t1` MODIFY COLUMN `b` int NOT NULL",
+					},
+				},
+			},
+		},
 		{
 			changes: []schema.Change{
 				&schema.ModifyTable{
@@ -1113,6 +1146,354 @@ func TestPlanChanges(t *testing.T) {
 	}
 }
 
+func TestPlanChanges_Roles(t *testing.T) {
+	var (
+		users   = schema.NewTable("users").SetSchema(schema.New("public")).AddColumns(schema.NewIntColumn("id", "int"), schema.NewStringColumn("email", "varchar(64)"))
+		readers = schema.NewRole("readers")
+		alice   = schema.NewUser("alice@localhost").AddMemberOf(readers)
+	)
+	tests := []struct {
+		changes []schema.Change
+		want    [][2]any
+	}{
+		// Accounts are created before the other changes, and privileges are granted after them.
+		{
+			changes: []schema.Change{
+				&schema.AddGrant{G: schema.NewGrant(users, "SELECT", "INSERT").SetGrantOption(true)},
+				&schema.AddTable{T: users},
+				&schema.AddRole{R: alice},
+				&schema.AddRole{R: readers},
+				&schema.AddGrant{G: schema.NewGrant(users.Schema, "all")},
+			},
+			want: [][2]any{
+				{"CREATE USER 'alice'@'localhost'", "DROP USER 'alice'@'localhost'"},
+				{"CREATE ROLE 'readers'", "DROP ROLE 'readers'"},
+				{"GRANT 'readers' TO 'alice'@'localhost'", "REVOKE 'readers' FROM 'alice'@'localhost'"},
+				{"CREATE TABLE `public`.`users` (`id` int NOT NULL, `email` varchar(64) NOT NULL)", "DROP TABLE `public`.`users`"},
+				{"GRANT SELECT, INSERT ON `public`.`users` TO 'alice'@'localhost' WITH GRANT OPTION", "REVOKE SELECT, INSERT ON `public`.`users` FROM 'alice'@'localhost'"},
+				{"GRANT ALL PRIVILEGES ON `public`.* TO 'readers'", "REVOKE ALL PRIVILEGES ON `public`.* FROM 'readers'"},
+			},
+		},
+		// Privileges are revoked before accounts are dropped.
+		{
+			changes: []schema.Change{
+				&schema.DropRole{R: alice},
+				&schema.AddGrant{G: schema.NewGrant(users, "UPDATE").SetColumns(users.Columns[1])},
+				&schema.DropGrant{G: schema.NewGrant(users, "DELETE")},
+			},
+			want: [][2]any{
+				{"REVOKE DELETE ON `public`.`users` FROM 'readers'", "GRANT DELETE ON `public`.`users` TO 'readers'"},
+				{"GRANT UPDATE (`email`) ON `public`.`users` TO 'readers'", "REVOKE UPDATE (`email`) ON `public`.`users` FROM 'readers'"},
+				{"DROP USER 'alice'@'localhost'", []string{"CREATE USER 'alice'@'localhost'", "GRANT 'readers' TO 'alice'@'localhost'"}},
+			},
+		},
+	}
+	// Grants are linked to their roles.
+	tests[0].changes[0].(*schema.AddGrant).G.To = alice
+	tests[0].changes[4].(*schema.AddGrant).G.To = readers
+	tests[1].changes[1].(*schema.AddGrant).G.To = readers
+	tests[1].changes[2].(*schema.DropGrant).G.To = readers
+	for _, tt := range tests {
+		db, mk, err := sqlmock.New()
+		require.NoError(t, err)
+		mock{mk}.version("8.0.13")
+		drv, err := Open(db)
+		require.NoError(t, err)
+		plan, err := drv.PlanChanges(context.Background(), "plan", tt.changes)
+		require.NoError(t, err)
+		require.Len(t, plan.Changes, len(tt.want))
+		for i, c := range plan.Changes {
+			require.Equal(t, tt.want[i][0], c.Cmd)
+			require.Equal(t, tt.want[i][1], c.Reverse)
+		}
+	}
+
+	// Roles cannot be converted to users, and vice versa.
+	_, err := DefaultPlan.PlanChanges(context.Background(), "plan", []schema.Change{&schema.ModifyRole{From: readers, To: schema.NewUser("readers")}})
+	require.EqualError(t, err, `mysql: changing role "readers" to a user is not supported`)
+}
+
+func TestPlanChanges_Partitions(t *testing.T) {
+	var (
+		id   = schema.NewIntColumn("id", "int")
+		c    = schema.NewStringColumn("c", "varchar(8)")
+		logs = schema.NewTable("logs").SetSchema(schema.New("public")).AddColumns(id, c)
+		rng  = func(defs ...*PartitionDef) *Partition {
+			return &Partition{T: PartitionTypeRange, Expr: "`id`", Defs: defs}
+		}
+		list = func(defs ...*PartitionDef) *Partition {
+			return &Partition{T: PartitionTypeList, Columns: []*schema.Column{c}, Defs: defs}
+		}
+		p0 = &PartitionDef{Name: "p0", Values: "10"}
+		p1 = &PartitionDef{Name: "p1", Values: "20"}
+		p2 = &PartitionDef{Name: "p2", Values: "MAXVALUE"}
+	)
+	tests := []struct {
+		changes []schema.Change
+		want    [][2]string
+	}{
+		{
+			changes: []schema.Change{
+				&schema.AddTable{T: schema.NewTable("logs").SetSchema(logs.Schema).AddColumns(id).AddAttrs(rng(p0, p2))},
+			},
+			want: [][2]string{
+				{"CREATE TABLE `public`.`logs` (`id` int NOT NULL) PARTITION BY RANGE (`id`) (PARTITION `p0` VALUES LESS THAN (10), PARTITION `p2` VALUES LESS THAN MAXVALUE)", "DROP TABLE `public`.`logs`"},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.AddTable{T: schema.NewTable("logs").SetSchema(logs.Schema).AddColumns(id).AddAttrs(&Partition{T: PartitionTypeKey, Linear: true, Columns: []*schema.Column{id}, N: 4})},
+			},
+			want: [][2]string{
+				{"CREATE TABLE `public`.`logs` (`id` int NOT NULL) PARTITION BY LINEAR KEY (`id`) PARTITIONS 4", "DROP TABLE `public`.`logs`"},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.ModifyTable{T: logs, Changes: []schema.Change{&schema.AddAttr{A: &Partition{T: PartitionTypeHash, Expr: "`id`", N: 2}}}},
+			},
+			want: [][2]string{
+				{"ALTER TABLE `public`.`logs` PARTITION BY HASH (`id`) PARTITIONS 2", "ALTER TABLE `public`.`logs` REMOVE PARTITIONING"},
+			},
+		},
+		// Partitioning is removed before the columns are dropped.
+		{
+			changes: []schema.Change{
+				&schema.ModifyTable{T: logs, Changes: []schema.Change{
+					&schema.DropColumn{C: c},
+					&schema.DropAttr{A: list(&PartitionDef{Name: "p0", Values: "'a','b'"})},
+				}},
+			},
+			want: [][2]string{
+				{"ALTER TABLE `public`.`logs` REMOVE PARTITIONING", "ALTER TABLE `public`.`logs` PARTITION BY LIST COLUMNS (`c`) (PARTITION `p0` VALUES IN ('a','b'))"},
+				{"ALTER TABLE `public`.`logs` DROP COLUMN `c`", "ALTER TABLE `public`.`logs` ADD COLUMN `c` varchar(8) NOT NULL"},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.ModifyTable{T: logs, Changes: []schema.Change{&schema.ModifyAttr{From: rng(p0), To: rng(p0, p1)}}},
+			},
+			want: [][2]string{
+				{"ALTER TABLE `public`.`logs` ADD PARTITION (PARTITION `p1` VALUES LESS THAN (20))", "ALTER TABLE `public`.`logs` DROP PARTITION `p1`"},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.ModifyTable{T: logs, Changes: []schema.Change{&schema.ModifyAttr{From: rng(p0, p1, p2), To: rng(p0, p2)}}},
+			},
+			want: [][2]string{
+				{"ALTER TABLE `public`.`logs` DROP PARTITION `p1`", "ALTER TABLE `public`.`logs` REORGANIZE PARTITION `p2` INTO (PARTITION `p1` VALUES LESS THAN (20), PARTITION `p2` VALUES LESS THAN MAXVALUE)"},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.ModifyTable{T: logs, Changes: []schema.Change{&schema.ModifyAttr{From: rng(p0, p2), To: rng(p0, &PartitionDef{Name: "p1", Values: "30"}, p2)}}},
+			},
+			want: [][2]string{
+				{"ALTER TABLE `public`.`logs` REORGANIZE PARTITION `p2` INTO (PARTITION `p1` VALUES LESS THAN (30), PARTITION `p2` VALUES LESS THAN MAXVALUE)", "ALTER TABLE `public`.`logs` DROP PARTITION `p1`"},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.ModifyTable{T: logs, Changes: []schema.Change{&schema.ModifyAttr{
+					From: list(&PartitionDef{Name: "a", Values: "'a'"}, &PartitionDef{Name: "b", Values: "'b'"}),
+					To:   list(&PartitionDef{Name: "a", Values: "'a','b'"}, &PartitionDef{Name: "c", Values: "'c'"}),
+				}}},
+			},
+			want: [][2]string{
+				{"ALTER TABLE `public`.`logs` REORGANIZE PARTITION `b`, `a` INTO (PARTITION `a` VALUES IN ('a','b'), PARTITION `c` VALUES IN ('c'))", "ALTER TABLE `public`.`logs` REORGANIZE PARTITION `c`, `a` INTO (PARTITION `a` VALUES IN ('a'), PARTITION `b` VALUES IN ('b'))"},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.ModifyTable{T: logs, Changes: []schema.Change{&schema.ModifyAttr{From: &Partition{T: PartitionTypeHash, Expr: "`id`", N: 2}, To: &Partition{T: PartitionTypeHash, Expr: "`id`", N: 6}}}},
+			},
+			want: [][2]string{
+				{"ALTER TABLE `public`.`logs` ADD PARTITION PARTITIONS 4", "ALTER TABLE `public`.`logs` COALESCE PARTITION 4"},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.ModifyTable{T: logs, Changes: []schema.Change{&schema.ModifyAttr{From: &Partition{T: PartitionTypeHash, Expr: "`id`", N: 2}, To: &Partition{T: PartitionTypeKey, N: 2}}}},
+			},
+			want: [][2]string{
+				{"ALTER TABLE `public`.`logs` PARTITION BY KEY () PARTITIONS 2", "ALTER TABLE `public`.`logs` PARTITION BY HASH (`id`) PARTITIONS 2"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		db, mk, err := sqlmock.New()
+		require.NoError(t, err)
+		mock{mk}.version("8.0.13")
+		drv, err := Open(db)
+		require.NoError(t, err)
+		plan, err := drv.PlanChanges(context.Background(), "plan", tt.changes)
+		require.NoError(t, err)
+		require.Len(t, plan.Changes, len(tt.want))
+		for i, c := range plan.Changes {
+			require.Equal(t, tt.want[i][0], c.Cmd)
+			require.Equal(t, tt.want[i][1], c.Reverse)
+		}
+	}
+}
+
+func TestPlanChanges_OnlineDDL(t *testing.T) {
+	var (
+		users = func() *schema.Table {
+			return schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "int"), schema.NewStringColumn("name", "varchar(255)"))
+		}
+		online = []schema.Clause{&OnlineDDL{}}
+	)
+	tests := []struct {
+		version string
+		changes func(*schema.Table) []schema.Change
+		extra   []schema.Clause
+		want    [2]string
+		comment string
+		warning string
+		wantErr string
+	}{
+		{
+			version: "8.0.30",
+			changes: func(t *schema.Table) []schema.Change {
+				return []schema.Change{
+					&schema.AddColumn{C: schema.NewNullIntColumn("age", "int")},
+					&schema.DropColumn{C: t.Columns[1]},
+				}
+			},
+			want: [2]string{
+				"ALTER TABLE `users` ADD COLUMN `age` int NULL, DROP COLUMN `name`, ALGORITHM=INSTANT",
+				"ALTER TABLE `users` ADD COLUMN `name` varchar(255) NOT NULL, DROP COLUMN `age`, ALGORITHM=INSTANT",
+			},
+		},
+		{
+			version: "8.0.13",
+			changes: func(t *schema.Table) []schema.Change {
+				return []schema.Change{
+					&schema.DropColumn{C: t.Columns[1]},
+				}
+			},
+			want: [2]string{
+				"ALTER TABLE `users` DROP COLUMN `name`, ALGORITHM=INPLACE, LOCK=NONE",
+				"ALTER TABLE `users` ADD COLUMN `name` varchar(255) NOT NULL, ALGORITHM=INSTANT",
+			},
+		},
+		{
+			version: "5.7",
+			changes: func(t *schema.Table) []schema.Change {
+				return []schema.Change{
+					&schema.AddIndex{I: schema.NewIndex("name").AddColumns(t.Columns[1])},
+				}
+			},
+			want: [2]string{
+				"ALTER TABLE `users` ADD INDEX `name` (`name`), ALGORITHM=INPLACE, LOCK=NONE",
+				"ALTER TABLE `users` DROP INDEX `name`, ALGORITHM=INPLACE, LOCK=NONE",
+			},
+		},
+		{
+			version: "8.0.30",
+			changes: func(t *schema.Table) []schema.Change {
+				return []schema.Change{
+					&schema.ModifyColumn{From: t.Columns[1], To: schema.NewStringColumn("name", "varchar(255)").SetDefault(&schema.Literal{V: "'a8m'"}), Change: schema.ChangeDefault},
+				}
+			},
+			want: [2]string{
+				"ALTER TABLE `users` MODIFY COLUMN `name` varchar(255) NOT NULL DEFAULT 'a8m', ALGORITHM=INSTANT",
+				"ALTER TABLE `users` MODIFY COLUMN `name` varchar(255) NOT NULL, ALGORITHM=INSTANT",
+			},
+		},
+		{
+			version: "8.0.30",
+			changes: func(t *schema.Table) []schema.Change {
+				return []schema.Change{
+					&schema.ModifyColumn{From: t.Columns[1], To: schema.NewStringColumn("name", "text"), Change: schema.ChangeType},
+				}
+			},
+			wantErr: "alter table \"users\": changing the definition of column \"name\" cannot be applied online",
+		},
+		{
+			version: "8.0.30",
+			changes: func(t *schema.Table) []schema.Change {
+				return []schema.Change{
+					&schema.AddCheck{C: schema.NewCheck().SetName("name_len").SetExpr("length(name) > 0")},
+				}
+			},
+			extra: []schema.Clause{&OnlineDDL{Warn: true}},
+			want: [2]string{
+				"ALTER TABLE `users` ADD CONSTRAINT `name_len` CHECK (length(name) > 0)",
+				"ALTER TABLE `users` DROP CONSTRAINT `name_len`, ALGORITHM=INPLACE, LOCK=NONE",
+			},
+			comment: `modify "users" table (adding check constraint "name_len" cannot be applied online)`,
+			warning: `alter table "users": adding check constraint "name_len" cannot be applied online`,
+		},
+	}
+	for _, tt := range tests {
+		db, mk, err := sqlmock.New()
+		require.NoError(t, err)
+		mock{mk}.version(tt.version)
+		drv, err := Open(db)
+		require.NoError(t, err)
+		extra := tt.extra
+		if extra == nil {
+			extra = online
+		}
+		u := users()
+		plan, err := drv.PlanChanges(context.Background(), "plan", []schema.Change{
+			&schema.ModifyTable{T: u, Changes: tt.changes(u), Extra: extra},
+		})
+		if tt.wantErr != "" {
+			require.EqualError(t, err, tt.wantErr)
+			continue
+		}
+		require.NoError(t, err)
+		require.Len(t, plan.Changes, 1)
+		require.Equal(t, tt.want[0], plan.Changes[0].Cmd)
+		require.Equal(t, tt.want[1], plan.Changes[0].Reverse)
+		if tt.comment != "" {
+			require.Equal(t, tt.comment, plan.Changes[0].Comment)
+		}
+		require.Equal(t, tt.warning, plan.Changes[0].Warning)
+	}
+}
+
+func TestPlanChanges_ExpandContract(t *testing.T) {
+	var (
+		age   = schema.NewIntColumn("age", "bigint")
+		users = schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "int"), age)
+	)
+	plan, err := DefaultPlan.PlanChanges(context.Background(), "plan", []schema.Change{
+		&schema.ModifyTable{T: users, Changes: []schema.Change{&schema.ModifyColumn{From: schema.NewIntColumn("age", "int"), To: age, Change: schema.ChangeType}}},
+	}, func(o *migrate.PlanOptions) { o.ExpandContract = true })
+	require.NoError(t, err)
+	var expand, contract [][2]string
+	for _, c := range plan.Changes {
+		if c.Contract {
+			contract = append(contract, [2]string{c.Cmd, c.Reverse.(string)})
+		} else {
+			expand = append(expand, [2]string{c.Cmd, c.Reverse.(string)})
+		}
+	}
+	require.Equal(t, [][2]string{
+		{"ALTER TABLE `users` ADD COLUMN `age_new` bigint NULL", "ALTER TABLE `users` DROP COLUMN `age_new`"},
+		{"CREATE TRIGGER `users_age_sync_insert` BEFORE INSERT ON `users` FOR EACH ROW SET NEW.`age` = COALESCE(NEW.`age_new`, NEW.`age`), NEW.`age_new` = NEW.`age`", "DROP TRIGGER `users_age_sync_insert`"},
+		{"CREATE TRIGGER `users_age_sync_update` BEFORE UPDATE ON `users` FOR EACH ROW SET NEW.`age_new` = IF(NEW.`age` <=> OLD.`age`, NEW.`age_new`, NEW.`age`), NEW.`age` = IF(NEW.`age_new` <=> OLD.`age_new`, NEW.`age`, NEW.`age_new`)", "DROP TRIGGER `users_age_sync_update`"},
+		{"UPDATE `users` SET `age_new` = `age`", "UPDATE `users` SET `age` = `age_new`"},
+		{"ALTER TABLE `users` MODIFY COLUMN `age_new` bigint NOT NULL", "ALTER TABLE `users` MODIFY COLUMN `age_new` bigint NULL"},
+	}, expand)
+	require.Len(t, contract, 4)
+	require.Equal(t, "DROP TRIGGER `users_age_sync_insert`", contract[0][0])
+	require.Equal(t, "DROP TRIGGER `users_age_sync_update`", contract[1][0])
+	require.Equal(t, [2]string{"ALTER TABLE `users` DROP COLUMN `age`", "ALTER TABLE `users` ADD COLUMN `age` int NOT NULL"}, contract[2])
+	require.Equal(t, [2]string{"ALTER TABLE `users` RENAME COLUMN `age_new` TO `age`", "ALTER TABLE `users` RENAME COLUMN `age` TO `age_new`"}, contract[3])
+
+	// Columns that are used by indexes cannot be changed in phases.
+	users.AddIndexes(schema.NewIndex("age").AddColumns(age))
+	_, err = DefaultPlan.PlanChanges(context.Background(), "plan", []schema.Change{
+		&schema.ModifyTable{T: users, Changes: []schema.Change{&schema.ModifyColumn{From: schema.NewIntColumn("age", "int"), To: age, Change: schema.ChangeType}}},
+	}, func(o *migrate.PlanOptions) { o.ExpandContract = true })
+	require.EqualError(t, err, `modify table "users": cannot change column "age" in phases: column is used by index "age"`)
+}
+
 func TestDefaultPlan(t *testing.T) {
 	changes, err := DefaultPlan.PlanChanges(context.Background(), "plan", []schema.Change{
 		&schema.AddTable{T: schema.NewTable("t1").SetSchema(schema.New("s1")).AddColumns(schema.NewIntColumn("a", "int"))},
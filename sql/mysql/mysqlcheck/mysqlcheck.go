@@ -11,12 +11,14 @@ import (
 	"strings"
 
 	"ariga.io/atlas/schemahcl"
+	"ariga.io/atlas/sql/internal/sqlx"
 	"ariga.io/atlas/sql/migrate"
 	"ariga.io/atlas/sql/mysql"
 	"ariga.io/atlas/sql/schema"
 	"ariga.io/atlas/sql/sqlcheck"
 	"ariga.io/atlas/sql/sqlcheck/condrop"
 	"ariga.io/atlas/sql/sqlcheck/datadepend"
+	"ariga.io/atlas/sql/sqlcheck/design"
 	"ariga.io/atlas/sql/sqlcheck/destructive"
 	"ariga.io/atlas/sql/sqlcheck/incompatible"
 	"ariga.io/atlas/sql/sqlcheck/naming"
@@ -24,9 +26,11 @@ import (
 
 var (
 	// codeImplicitUpdate is a MySQL specific code for reporting implicit update.
-	codeImplicitUpdate = sqlcheck.Code("MY101")
+	codeImplicitUpdate = sqlcheck.CodeWithText("MY101", "Adding a non-nullable column without a DEFAULT value to an existing table")
 	// codeInlineRef is a MySQL specific code for reporting columns with inline references.
-	codeInlineRef = sqlcheck.Code("MY102")
+	codeInlineRef = sqlcheck.CodeWithText("MY102", "Adding a column with an inline REFERENCES clause has no actual effect")
+	// codeKeyLength is a MySQL specific code for reporting indexes that exceed the maximum key length.
+	codeKeyLength = sqlcheck.CodeWithText("MY103", "Index key exceeds the maximum key length")
 )
 
 func addNotNull(p *datadepend.ColumnPass) (diags []sqlcheck.Diagnostic, err error) {
@@ -53,6 +57,10 @@ func addNotNull(p *datadepend.ColumnPass) (diags []sqlcheck.Diagnostic, err erro
 			})
 		}
 	}
+	// The dev database is required to detect the server flavor.
+	if p.Dev == nil {
+		return nil, nil
+	}
 	drv, ok := p.Dev.Driver.(*mysql.Driver)
 	if !ok {
 		return nil, fmt.Errorf("unexpected migrate driver %T", p.Dev.Driver)
@@ -197,6 +205,103 @@ func inlineRefs(_ context.Context, p *sqlcheck.Pass) error {
 	return nil
 }
 
+// maxKeyLength is the maximum length (in bytes) of an index key in InnoDB
+// tables that use the DYNAMIC or COMPRESSED row formats (the default).
+const maxKeyLength = 3072
+
+// keyLength is a state analyzer function that detects indexes with string
+// columns (e.g., VARCHAR) that exceed the maximum key length of InnoDB.
+func keyLength(_ context.Context, p *sqlcheck.StatePass) error {
+	var diags []sqlcheck.Diagnostic
+	for _, s := range p.Realm.Schemas {
+		for _, t := range s.Tables {
+			idxs := make([]*schema.Index, 0, len(t.Indexes)+1)
+			if t.PrimaryKey != nil {
+				idxs = append(idxs, t.PrimaryKey)
+			}
+			for _, idx := range append(idxs, t.Indexes...) {
+				var n int
+				for _, part := range idx.Parts {
+					if part.C != nil {
+						n += keyPartLength(s, t, part)
+					}
+				}
+				if n > maxKeyLength {
+					name := idx.Name
+					if name == "" && idx == t.PrimaryKey {
+						name = "PRIMARY"
+					}
+					diags = append(diags, sqlcheck.Diagnostic{
+						Code: codeKeyLength,
+						Text: fmt.Sprintf("Index %q of table %q is %d bytes long and exceeds the maximum key length of %d bytes", name, t.Name, n, maxKeyLength),
+					})
+				}
+			}
+		}
+	}
+	if len(diags) > 0 {
+		p.Reporter.WriteReport(sqlcheck.Report{Text: "oversized index keys detected", Diagnostics: diags})
+	}
+	return nil
+}
+
+// keyPartLength returns the maximum length (in bytes) of a string key part.
+func keyPartLength(s *schema.Schema, t *schema.Table, p *schema.IndexPart) int {
+	var (
+		n   int
+		sub = &mysql.SubPart{}
+	)
+	switch ct := p.C.Type.Type.(type) {
+	case *schema.BinaryType:
+		if ct.Size != nil && (ct.T == mysql.TypeBinary || ct.T == mysql.TypeVarBinary) {
+			n = *ct.Size
+		}
+		if sqlx.Has(p.Attrs, sub) {
+			n = sub.Len
+		}
+		return n
+	case *schema.StringType:
+		if ct.T == mysql.TypeChar || ct.T == mysql.TypeVarchar {
+			n = ct.Size
+		}
+		if sqlx.Has(p.Attrs, sub) {
+			n = sub.Len
+		}
+		// The charset is inherited from the table or the schema, and it
+		// defaults to utf8mb4 in case it was not defined on any of them.
+		cs := &schema.Charset{V: "utf8mb4"}
+		for _, attrs := range [][]schema.Attr{s.Attrs, t.Attrs, p.C.Attrs} {
+			sqlx.Has(attrs, cs)
+		}
+		if m, ok := charsetMaxLen[strings.ToLower(cs.V)]; ok {
+			return n * m
+		}
+		return n
+	}
+	return 0
+}
+
+// charsetMaxLen holds the maximum length (in bytes) of a character in multibyte
+// charsets. Other charsets supported by MySQL use a single byte per character.
+var charsetMaxLen = map[string]int{
+	"big5":    2,
+	"cp932":   2,
+	"eucjpms": 3,
+	"euckr":   2,
+	"gb18030": 4,
+	"gb2312":  2,
+	"gbk":     2,
+	"sjis":    2,
+	"ucs2":    2,
+	"ujis":    3,
+	"utf16":   4,
+	"utf16le": 4,
+	"utf32":   4,
+	"utf8":    3,
+	"utf8mb3": 3,
+	"utf8mb4": 4,
+}
+
 func init() {
 	sqlcheck.Register(mysql.DriverName, func(r *schemahcl.Resource) ([]sqlcheck.Analyzer, error) {
 		ds, err := destructive.New(r)
@@ -223,4 +328,17 @@ func init() {
 		}
 		return []sqlcheck.Analyzer{ds, dd, cd, bc, nm, sqlcheck.AnalyzerFunc(inlineRefs)}, nil
 	})
+	sqlcheck.RegisterState(mysql.DriverName, func(r *schemahcl.Resource) ([]sqlcheck.StateAnalyzer, error) {
+		de, err := design.New(r, design.Handler{
+			Reserved: isReserved,
+		})
+		if err != nil {
+			return nil, err
+		}
+		nm, err := naming.New(r)
+		if err != nil {
+			return nil, err
+		}
+		return []sqlcheck.StateAnalyzer{de, nm, sqlcheck.StateAnalyzerFunc(keyLength)}, nil
+	})
 }
@@ -72,6 +72,11 @@ func TestDataDepend_MySQL_ImplicitUpdate(t *testing.T) {
 	require.Equal(t, report.Diagnostics[2].Text, `Adding a non-nullable "varchar" column "d" on table "users" without a default value implicitly sets existing rows with ""`)
 	require.Equal(t, report.Diagnostics[3].Text, `Adding a non-nullable "enum" column "e" on table "users" without a default value implicitly sets existing rows with "foo"`)
 	require.Equal(t, report.Diagnostics[4].Text, `Adding a non-nullable "timestamp" column "f" on table "users" without a default value implicitly sets existing rows with CURRENT_TIMESTAMP`)
+
+	// Checks that require the dev database are skipped without it.
+	report, pass.Dev = nil, nil
+	require.NoError(t, sqlcheck.Analyzers(azs).Analyze(context.Background(), pass))
+	require.Nil(t, report)
 }
 
 func TestDataDepend_MySQL8_ImplicitUpdate(t *testing.T) {
@@ -229,6 +234,43 @@ func TestDataDepend_Maria_ImplicitUpdate(t *testing.T) {
 
 }
 
+func TestState_MySQL_KeyLength(t *testing.T) {
+	users := schema.NewTable("users").
+		AddColumns(
+			schema.NewIntColumn("id", mysql.TypeInt),
+			schema.NewStringColumn("email", mysql.TypeVarchar, schema.StringSize(1024)),
+			schema.NewStringColumn("name", mysql.TypeVarchar, schema.StringSize(1024)),
+		)
+	users.Columns[2].SetCharset("latin1")
+	users.SetPrimaryKey(schema.NewPrimaryKey(users.Columns[0])).
+		AddIndexes(
+			schema.NewUniqueIndex("users_email").AddColumns(users.Columns[1]),
+			schema.NewIndex("users_name").AddColumns(users.Columns[2]),
+			schema.NewIndex("users_email_prefix").AddParts(
+				schema.NewColumnPart(users.Columns[1]).AddAttrs(&mysql.SubPart{Len: 255}),
+			),
+		)
+	var (
+		reports []sqlcheck.Report
+		pass    = &sqlcheck.StatePass{
+			Realm: schema.NewRealm(schema.New("test").AddTables(users)),
+			Reporter: sqlcheck.ReportWriterFunc(func(r sqlcheck.Report) {
+				reports = append(reports, r)
+			}),
+		}
+	)
+	azs, err := sqlcheck.StateAnalyzerFor(mysql.DriverName, nil)
+	require.NoError(t, err)
+	for _, az := range azs {
+		require.NoError(t, az.AnalyzeState(context.Background(), pass))
+	}
+	require.Len(t, reports, 1)
+	require.Equal(t, "oversized index keys detected", reports[0].Text)
+	require.Equal(t, []sqlcheck.Diagnostic{
+		{Code: "MY103", Text: `Index "users_email" of table "users" is 4096 bytes long and exceeds the maximum key length of 3072 bytes`},
+	}, reports[0].Diagnostics)
+}
+
 type testFile struct {
 	name string
 	migrate.File
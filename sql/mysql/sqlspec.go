@@ -20,10 +20,29 @@ import (
 	"github.com/zclconf/go-cty/cty"
 )
 
-type doc struct {
-	Tables  []*sqlspec.Table  `spec:"table"`
-	Views   []*sqlspec.View   `spec:"view"`
-	Schemas []*sqlspec.Schema `spec:"schema"`
+type (
+	doc struct {
+		Tables   []*sqlspec.Table   `spec:"table"`
+		Views    []*sqlspec.View    `spec:"view"`
+		Triggers []*sqlspec.Trigger `spec:"trigger"`
+		Funcs    []*sqlspec.Func    `spec:"function"`
+		Procs    []*sqlspec.Proc    `spec:"procedure"`
+		Events   []*event           `spec:"event"`
+		Schemas  []*sqlspec.Schema  `spec:"schema"`
+		Roles    []*sqlspec.Role    `spec:"role"`
+		Users    []*sqlspec.Role    `spec:"user"`
+	}
+	// event holds a specification for a scheduled event. The schedule,
+	// the definition and the comment of the event are stored as extra attributes.
+	event struct {
+		Name   string         `spec:",name"`
+		Schema *schemahcl.Ref `spec:"schema"`
+		schemahcl.DefaultExtension
+	}
+)
+
+func init() {
+	schemahcl.Register("event", &event{})
 }
 
 // evalSpec evaluates an Atlas DDL document into v using the input.
@@ -35,8 +54,8 @@ func evalSpec(p *hclparse.Parser, v any, input map[string]cty.Value) error {
 			return err
 		}
 		if err := specutil.Scan(v,
-			&specutil.ScanDoc{Schemas: d.Schemas, Tables: d.Tables, Views: d.Views},
-			&specutil.ScanFuncs{Table: convertTable, View: convertView},
+			&specutil.ScanDoc{Schemas: d.Schemas, Tables: d.Tables, Views: d.Views, Triggers: d.Triggers},
+			&specutil.ScanFuncs{Table: convertTable, View: convertView, Trigger: convertTrigger},
 		); err != nil {
 			return fmt.Errorf("mysql: failed converting to *schema.Realm: %w", err)
 		}
@@ -49,6 +68,15 @@ func evalSpec(p *hclparse.Parser, v any, input map[string]cty.Value) error {
 				return err
 			}
 		}
+		if err := convertFuncs(d.Funcs, d.Procs, v); err != nil {
+			return err
+		}
+		if err := convertEvents(d.Events, v); err != nil {
+			return err
+		}
+		if err := specutil.Roles(v, d.Roles, d.Users, nil); err != nil {
+			return err
+		}
 	case *schema.Schema:
 		var d doc
 		if err := hclState.Eval(p, &d, input); err != nil {
@@ -59,14 +87,23 @@ func evalSpec(p *hclparse.Parser, v any, input map[string]cty.Value) error {
 		}
 		r := &schema.Realm{}
 		if err := specutil.Scan(r,
-			&specutil.ScanDoc{Schemas: d.Schemas, Tables: d.Tables, Views: d.Views},
-			&specutil.ScanFuncs{Table: convertTable, View: convertView},
+			&specutil.ScanDoc{Schemas: d.Schemas, Tables: d.Tables, Views: d.Views, Triggers: d.Triggers},
+			&specutil.ScanFuncs{Table: convertTable, View: convertView, Trigger: convertTrigger},
 		); err != nil {
 			return err
 		}
 		if err := convertCharset(d.Schemas[0], &r.Schemas[0].Attrs); err != nil {
 			return err
 		}
+		if err := convertFuncs(d.Funcs, d.Procs, r); err != nil {
+			return err
+		}
+		if err := convertEvents(d.Events, r); err != nil {
+			return err
+		}
+		if err := specutil.Roles(r, d.Roles, d.Users, nil); err != nil {
+			return err
+		}
 		*v = *r.Schemas[0]
 	case schema.Schema, schema.Realm:
 		return fmt.Errorf("mysql: Eval expects a pointer: received %[1]T, expected *%[1]T", v)
@@ -78,7 +115,61 @@ func evalSpec(p *hclparse.Parser, v any, input map[string]cty.Value) error {
 
 // MarshalSpec marshals v into an Atlas DDL document using a schemahcl.Marshaler.
 func MarshalSpec(v any, marshaler schemahcl.Marshaler) ([]byte, error) {
-	return specutil.Marshal(v, marshaler, schemaSpec)
+	return marshalSpec(v, marshaler, true)
+}
+
+// marshalSpec marshals v into an Atlas DDL document using a schemahcl.Marshaler.
+// If algorithm is false, the ALGORITHM attribute of views is not marshaled.
+func marshalSpec(v any, marshaler schemahcl.Marshaler, algorithm bool) ([]byte, error) {
+	var d doc
+	switch s := v.(type) {
+	case *schema.Schema:
+		doc, err := schemaSpec(s)
+		if err != nil {
+			return nil, fmt.Errorf("specutil: failed converting schema to spec: %w", err)
+		}
+		d = *doc
+	case *schema.Realm:
+		for _, s := range s.Schemas {
+			doc, err := schemaSpec(s)
+			if err != nil {
+				return nil, fmt.Errorf("specutil: failed converting schema to spec: %w", err)
+			}
+			d.Tables = append(d.Tables, doc.Tables...)
+			d.Views = append(d.Views, doc.Views...)
+			d.Triggers = append(d.Triggers, doc.Triggers...)
+			d.Funcs = append(d.Funcs, doc.Funcs...)
+			d.Procs = append(d.Procs, doc.Procs...)
+			d.Events = append(d.Events, doc.Events...)
+			d.Schemas = append(d.Schemas, doc.Schemas...)
+		}
+		if err := specutil.QualifyTables(d.Tables); err != nil {
+			return nil, err
+		}
+		if err := specutil.QualifyViews(d.Views); err != nil {
+			return nil, err
+		}
+		if err := specutil.QualifyReferences(d.Tables, s); err != nil {
+			return nil, err
+		}
+		var err error
+		if d.Roles, d.Users, err = specutil.FromRoles(s, nil); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("specutil: failed marshaling spec. %T is not supported", v)
+	}
+	if !algorithm {
+		for _, v := range d.Views {
+			for i, a := range v.Extra.Attrs {
+				if a.K == "algorithm" {
+					v.Extra.Attrs = append(v.Extra.Attrs[:i], v.Extra.Attrs[i+1:]...)
+					break
+				}
+			}
+		}
+	}
+	return marshaler.MarshalSpec(&d)
 }
 
 var (
@@ -87,7 +178,17 @@ var (
 			specOptions,
 			schemahcl.WithTypes("table.column.type", TypeRegistry.Specs()),
 			schemahcl.WithTypes("view.column.type", TypeRegistry.Specs()),
+			schemahcl.WithTypes("function.arg.type", TypeRegistry.Specs()),
+			schemahcl.WithTypes("function.return", TypeRegistry.Specs()),
+			schemahcl.WithTypes("procedure.arg.type", TypeRegistry.Specs()),
 			schemahcl.WithScopedEnums("view.check_option", schema.ViewCheckOptionLocal, schema.ViewCheckOptionCascaded),
+			schemahcl.WithScopedEnums("view.algorithm", ViewAlgorithmUndefined, ViewAlgorithmMerge, ViewAlgorithmTempTable),
+			schemahcl.WithScopedEnums("view.security", ViewSecurityDefiner, ViewSecurityInvoker),
+			schemahcl.WithScopedEnums("trigger.for", string(schema.TriggerForRow)),
+			schemahcl.WithScopedEnums("function.security", RoutineSecurityDefiner, RoutineSecurityInvoker),
+			schemahcl.WithScopedEnums("procedure.security", RoutineSecurityDefiner, RoutineSecurityInvoker),
+			schemahcl.WithScopedEnums("procedure.arg.mode", string(schema.FuncArgModeIn), string(schema.FuncArgModeOut), string(schema.FuncArgModeInOut)),
+			schemahcl.WithScopedEnums("table.partition.type", PartitionTypeRange, PartitionTypeList, PartitionTypeHash, PartitionTypeKey),
 			schemahcl.WithScopedEnums("table.engine", EngineInnoDB, EngineMyISAM, EngineMemory, EngineCSV, EngineNDB),
 			schemahcl.WithScopedEnums("table.index.type", IndexTypeBTree, IndexTypeHash, IndexTypeFullText, IndexTypeSpatial),
 			schemahcl.WithScopedEnums("table.index.parser", IndexParserNGram, IndexParserMeCab),
@@ -101,6 +202,12 @@ var (
 	MarshalHCL = schemahcl.MarshalerFunc(func(v any) ([]byte, error) {
 		return MarshalSpec(v, hclState)
 	})
+	// marshalMySQL marshals v into an Atlas HCL DDL document for MySQL connections.
+	// The ALGORITHM of views is not marshaled, as MySQL does not expose it in the
+	// INFORMATION_SCHEMA, and it cannot be inspected back from the database.
+	marshalMySQL = schemahcl.MarshalerFunc(func(v any) ([]byte, error) {
+		return marshalSpec(v, hclState, false)
+	})
 	// EvalHCL implements the schemahcl.Evaluator interface.
 	EvalHCL = schemahcl.EvalFunc(evalSpec)
 
@@ -136,9 +243,112 @@ func convertTable(spec *sqlspec.Table, parent *schema.Schema) (*schema.Table, er
 		}
 		t.AddAttrs(&Engine{V: v})
 	}
+	if err := convertPartition(spec.Extra, t); err != nil {
+		return nil, err
+	}
 	return t, err
 }
 
+// convertPartition converts and appends the partition block into the table attributes if exists.
+func convertPartition(spec schemahcl.Resource, table *schema.Table) error {
+	r, ok := spec.Resource("partition")
+	if !ok {
+		return nil
+	}
+	var p struct {
+		Type       string           `spec:"type"`
+		Linear     bool             `spec:"linear"`
+		Columns    []*schemahcl.Ref `spec:"columns"`
+		Expr       string           `spec:"expr"`
+		Partitions int              `spec:"partitions"`
+		Parts      []*struct {
+			Name   string `spec:",name"`
+			Values string `spec:"values"`
+		} `spec:"part"`
+	}
+	if err := r.As(&p); err != nil {
+		return fmt.Errorf("parsing %s.partition: %w", table.Name, err)
+	}
+	if p.Type == "" {
+		return fmt.Errorf("missing attribute %s.partition.type", table.Name)
+	}
+	key := &Partition{T: strings.ToUpper(p.Type), Linear: p.Linear, Expr: p.Expr, N: p.Partitions}
+	switch n := len(p.Columns); {
+	case n > 0 && p.Expr != "":
+		return fmt.Errorf(`multiple definitions for %s.partition, use "columns" or "expr"`, table.Name)
+	case n == 0 && p.Expr == "" && key.T != PartitionTypeKey:
+		return fmt.Errorf("missing columns or expression for %s.partition", table.Name)
+	case key.T == PartitionTypeKey && p.Expr != "":
+		return fmt.Errorf(`%s.partition of type KEY supports only "columns"`, table.Name)
+	case key.T == PartitionTypeHash && n > 0:
+		return fmt.Errorf(`%s.partition of type HASH supports only "expr"`, table.Name)
+	}
+	for _, r := range p.Columns {
+		c, err := specutil.ColumnByRef(table, r)
+		if err != nil {
+			return err
+		}
+		key.Columns = append(key.Columns, c)
+	}
+	switch key.T {
+	case PartitionTypeRange, PartitionTypeList:
+		if p.Partitions > 0 {
+			return fmt.Errorf(`%s.partition of type %s does not support "partitions", use "part" blocks instead`, table.Name, key.T)
+		}
+		if len(p.Parts) == 0 {
+			return fmt.Errorf("missing part definitions for %s.partition", table.Name)
+		}
+		for _, d := range p.Parts {
+			if d.Values == "" {
+				return fmt.Errorf("missing values for %s.partition.part.%s", table.Name, d.Name)
+			}
+			key.Defs = append(key.Defs, &PartitionDef{Name: d.Name, Values: d.Values})
+		}
+	case PartitionTypeHash, PartitionTypeKey:
+		if len(p.Parts) > 0 {
+			return fmt.Errorf(`%s.partition of type %s does not support "part" blocks, use "partitions" instead`, table.Name, key.T)
+		}
+	default:
+		return fmt.Errorf("unexpected %s.partition.type: %q", table.Name, p.Type)
+	}
+	table.AddAttrs(key)
+	return nil
+}
+
+// fromPartition returns the resource spec for representing the partition block.
+func fromPartition(p *Partition) *schemahcl.Resource {
+	key := &schemahcl.Resource{
+		Type: "partition",
+		Attrs: []*schemahcl.Attr{
+			specutil.VarAttr("type", strings.ToUpper(p.T)),
+		},
+	}
+	if p.Linear {
+		key.Attrs = append(key.Attrs, schemahcl.BoolAttr("linear", true))
+	}
+	if len(p.Columns) > 0 {
+		columns := make([]*schemahcl.Ref, 0, len(p.Columns))
+		for _, c := range p.Columns {
+			columns = append(columns, specutil.ColumnRef(c.Name))
+		}
+		key.Attrs = append(key.Attrs, schemahcl.RefsAttr("columns", columns...))
+	}
+	if p.Expr != "" {
+		key.Attrs = append(key.Attrs, schemahcl.StringAttr("expr", p.Expr))
+	}
+	if p.N > 0 {
+		key.Attrs = append(key.Attrs, schemahcl.IntAttr("partitions", p.N))
+	}
+	for _, d := range p.Defs {
+		key.Children = append(key.Children, &schemahcl.Resource{
+			Type:  "part",
+			Name:  d.Name,
+			Attrs: []*schemahcl.Attr{schemahcl.StringAttr("values", d.Values)},
+		})
+	}
+	return key
+}
+
 // convertView converts a sqlspec.View to a schema.View.
 func convertView(spec *sqlspec.View, parent *schema.Schema) (*schema.View, error) {
 	v, err := specutil.View(spec, parent, func(c *sqlspec.Column, _ *schema.View) (*schema.Column, error) {
@@ -147,9 +357,36 @@ func convertView(spec *sqlspec.View, parent *schema.Schema) (*schema.View, error
 	if err != nil {
 		return nil, err
 	}
+	if attr, ok := spec.Attr("algorithm"); ok {
+		a, err := attr.String()
+		if err != nil {
+			return nil, err
+		}
+		v.AddAttrs(&ViewAlgorithm{V: a})
+	}
+	if attr, ok := spec.Attr("security"); ok {
+		c, err := attr.String()
+		if err != nil {
+			return nil, err
+		}
+		v.AddAttrs(&ViewSecurity{V: c})
+	}
 	return v, nil
 }
 
+// convertTrigger converts a sqlspec.Trigger to a schema.Trigger.
+func convertTrigger(spec *sqlspec.Trigger, r *schema.Realm) (*schema.Trigger, error) {
+	t, err := specutil.Trigger(spec, r)
+	if err != nil {
+		return nil, err
+	}
+	// MySQL supports only FOR EACH ROW triggers.
+	if t.For == "" {
+		t.SetFor(schema.TriggerForRow)
+	}
+	return t, nil
+}
+
 // convertPK converts a sqlspec.PrimaryKey into a schema.Index.
 func convertPK(spec *sqlspec.PrimaryKey, parent *schema.Table) (*schema.Index, error) {
 	idx, err := specutil.PrimaryKey(spec, parent)
@@ -269,7 +506,7 @@ func convertColumnType(spec *sqlspec.Column) (schema.Type, error) {
 }
 
 // schemaSpec converts from a concrete MySQL schema to Atlas specification.
-func schemaSpec(s *schema.Schema) (*specutil.SchemaSpec, error) {
+func schemaSpec(s *schema.Schema) (*doc, error) {
 	spec, err := specutil.FromSchema(s, tableSpec, viewSpec)
 	if err != nil {
 		return nil, err
@@ -280,7 +517,212 @@ func schemaSpec(s *schema.Schema) (*specutil.SchemaSpec, error) {
 	if c, ok := sqlx.Collate(s.Attrs, nil); ok {
 		spec.Schema.Extra.Attrs = append(spec.Schema.Extra.Attrs, schemahcl.StringAttr("collate", c))
 	}
-	return spec, nil
+	d := &doc{
+		Tables:   spec.Tables,
+		Views:    spec.Views,
+		Triggers: spec.Triggers,
+		Schemas:  []*sqlspec.Schema{spec.Schema},
+	}
+	for _, o := range s.Objects {
+		switch o := o.(type) {
+		case *schema.Func:
+			f, err := specutil.FromFunc(o, funcTypeSpec, routineAttrs(o.Attrs)...)
+			if err != nil {
+				return nil, err
+			}
+			f.Schema = specutil.SchemaRef(spec.Schema.Name)
+			d.Funcs = append(d.Funcs, f)
+		case *schema.Proc:
+			p, err := specutil.FromProc(o, funcTypeSpec, routineAttrs(o.Attrs)...)
+			if err != nil {
+				return nil, err
+			}
+			p.Schema = specutil.SchemaRef(spec.Schema.Name)
+			d.Procs = append(d.Procs, p)
+		case *Event:
+			e := eventSpec(o)
+			e.Schema = specutil.SchemaRef(spec.Schema.Name)
+			d.Events = append(d.Events, e)
+		}
+	}
+	return d, nil
+}
+
+// routineAttrs returns the characteristics of a stored function
+// or procedure that are not the defaults as spec attributes.
+func routineAttrs(attrs []schema.Attr) []*schemahcl.Attr {
+	r := &routine{attrs: attrs}
+	var specs []*schemahcl.Attr
+	if sqlx.Has(attrs, &Deterministic{}) {
+		specs = append(specs, schemahcl.BoolAttr("deterministic", true))
+	}
+	if a := r.dataAccess(); a != DataAccessContainsSQL {
+		specs = append(specs, schemahcl.StringAttr("data_access", a))
+	}
+	if v := r.security(); v != RoutineSecurityDefiner {
+		specs = append(specs, specutil.VarAttr("security", v))
+	}
+	return specs
+}
+
+// eventSpec converts from a concrete MySQL Event to its spec.
+func eventSpec(e *Event) *event {
+	spec := &event{Name: e.Name}
+	for _, a := range []struct{ k, v string }{
+		{k: "at", v: e.At},
+		{k: "every", v: e.Every},
+		{k: "starts", v: e.Starts},
+		{k: "ends", v: e.Ends},
+	} {
+		if a.v != "" {
+			spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.StringAttr(a.k, a.v))
+		}
+	}
+	if e.Preserve {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.BoolAttr("preserve", true))
+	}
+	if e.Disabled {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.BoolAttr("disable", true))
+	}
+	spec.Extra.Attrs = append(spec.Extra.Attrs, specutil.SQLAttr("as", e.Body))
+	if c := (schema.Comment{}); sqlx.Has(e.Attrs, &c) {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.StringAttr("comment", c.Text))
+	}
+	return spec
+}
+
+// funcTypeSpec converts the parameter or return type of a routine into its spec.
+func funcTypeSpec(t schema.Type) (*schemahcl.Type, error) {
+	c, err := columnTypeSpec(t)
+	if err != nil {
+		return nil, err
+	}
+	return c.Type, nil
+}
+
+// convertFuncs converts the stored functions and procedures specs and adds them to their schemas.
+func convertFuncs(funcs []*sqlspec.Func, procs []*sqlspec.Proc, r *schema.Realm) error {
+	conv := func(t *schemahcl.Type) (schema.Type, error) {
+		return convertColumnType(&sqlspec.Column{Type: t})
+	}
+	for _, spec := range funcs {
+		s, err := objectSchema(spec.Schema, r, "function", spec.Name)
+		if err != nil {
+			return err
+		}
+		f, err := specutil.Func(spec, conv)
+		if err != nil {
+			return err
+		}
+		if err := convertRoutineAttrs(spec, "function", spec.Name, &f.Attrs); err != nil {
+			return err
+		}
+		s.AddFuncs(f)
+	}
+	for _, spec := range procs {
+		s, err := objectSchema(spec.Schema, r, "procedure", spec.Name)
+		if err != nil {
+			return err
+		}
+		p, err := specutil.Proc(spec, conv)
+		if err != nil {
+			return err
+		}
+		if err := convertRoutineAttrs(spec, "procedure", spec.Name, &p.Attrs); err != nil {
+			return err
+		}
+		s.AddProcs(p)
+	}
+	return nil
+}
+
+// convertRoutineAttrs converts the characteristics of a stored function or procedure.
+func convertRoutineAttrs(spec specutil.Attrer, kind, name string, attrs *[]schema.Attr) error {
+	if a, ok := spec.Attr("deterministic"); ok {
+		b, err := a.Bool()
+		if err != nil {
+			return fmt.Errorf("expect bool value for attribute %s.%s.deterministic: %w", kind, name, err)
+		}
+		if b {
+			*attrs = append(*attrs, &Deterministic{})
+		}
+	}
+	if a, ok := spec.Attr("data_access"); ok {
+		v, err := a.String()
+		if err != nil {
+			return fmt.Errorf("expect string value for attribute %s.%s.data_access: %w", kind, name, err)
+		}
+		switch v = strings.ToUpper(v); v {
+		case DataAccessContainsSQL:
+		case DataAccessNoSQL, DataAccessReadsSQL, DataAccessModifiesSQL:
+			*attrs = append(*attrs, &DataAccess{V: v})
+		default:
+			return fmt.Errorf("unexpected %s.%s.data_access value: %q", kind, name, v)
+		}
+	}
+	if a, ok := spec.Attr("security"); ok {
+		v, err := a.String()
+		if err != nil {
+			return fmt.Errorf("expect string value for attribute %s.%s.security: %w", kind, name, err)
+		}
+		if v = strings.ToUpper(v); v != RoutineSecurityDefiner {
+			*attrs = append(*attrs, &RoutineSecurity{V: v})
+		}
+	}
+	return nil
+}
+
+// convertEvents converts the scheduled events specs and adds them to their schemas.
+func convertEvents(events []*event, r *schema.Realm) error {
+	for _, spec := range events {
+		s, err := objectSchema(spec.Schema, r, "event", spec.Name)
+		if err != nil {
+			return err
+		}
+		e := &Event{Name: spec.Name, Schema: s}
+		for k, v := range map[string]*string{"at": &e.At, "every": &e.Every, "starts": &e.Starts, "ends": &e.Ends, "as": &e.Body} {
+			if a, ok := spec.Attr(k); ok {
+				if *v, err = a.String(); err != nil {
+					return fmt.Errorf("expect string value for attribute event.%s.%s: %w", spec.Name, k, err)
+				}
+			}
+		}
+		for k, v := range map[string]*bool{"preserve": &e.Preserve, "disable": &e.Disabled} {
+			if a, ok := spec.Attr(k); ok {
+				if *v, err = a.Bool(); err != nil {
+					return fmt.Errorf("expect bool value for attribute event.%s.%s: %w", spec.Name, k, err)
+				}
+			}
+		}
+		switch e.Body = strings.TrimSpace(e.Body); {
+		case e.Body == "":
+			return fmt.Errorf("missing 'as' definition for event %q", spec.Name)
+		case (e.At == "") == (e.Every == ""):
+			return fmt.Errorf("event %q must define exactly one of the 'at' or 'every' attributes", spec.Name)
+		}
+		if a, ok := spec.Attr("comment"); ok {
+			c, err := a.String()
+			if err != nil {
+				return fmt.Errorf("expect string value for attribute event.%s.comment: %w", spec.Name, err)
+			}
+			e.Attrs = append(e.Attrs, &schema.Comment{Text: c})
+		}
+		s.AddObjects(e)
+	}
+	return nil
+}
+
+// objectSchema returns the schema of a schema-level object (e.g. a routine or an event).
+func objectSchema(ref *schemahcl.Ref, r *schema.Realm, kind, name string) (*schema.Schema, error) {
+	ns, err := specutil.SchemaName(ref)
+	if err != nil {
+		return nil, fmt.Errorf("extract schema name from %s %q reference: %w", kind, name, err)
+	}
+	s, ok := r.Schema(ns)
+	if !ok {
+		return nil, fmt.Errorf("schema %q defined on %s %q was not found in realm", ns, kind, name)
+	}
+	return s, nil
 }
 
 // tableSpec converts from a concrete MySQL sqlspec.Table to a schema.Table.
@@ -313,6 +755,9 @@ func tableSpec(t *schema.Table) (*sqlspec.Table, error) {
 		}
 		ts.Extra.Attrs = append(ts.Extra.Attrs, attr)
 	}
+	if p := (&Partition{}); sqlx.Has(t.Attrs, p) {
+		ts.Extra.Children = append(ts.Extra.Children, fromPartition(p))
+	}
 	return ts, nil
 }
 
@@ -324,6 +769,13 @@ func viewSpec(view *schema.View) (*sqlspec.View, error) {
 	if err != nil {
 		return nil, err
 	}
+	// Marshal the view attributes only if they are not the defaults.
+	if a := viewAlgorithm(view); a != ViewAlgorithmUndefined {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, specutil.VarAttr("algorithm", a))
+	}
+	if c := viewSecurity(view); c != ViewSecurityDefiner {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, specutil.VarAttr("security", c))
+	}
 	return spec, nil
 }
 
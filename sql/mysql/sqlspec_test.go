@@ -321,6 +321,125 @@ schema "public" {
 	require.EqualValues(t, exp, &s)
 }
 
+func TestMarshalTriggers(t *testing.T) {
+	t1 := schema.NewTable("t1").AddColumns(schema.NewIntColumn("id", "int"))
+	s := schema.New("public").AddTables(t1)
+	schema.NewRealm(s)
+	t1.AddTriggers(
+		schema.NewTrigger("tr1").
+			SetActionTime(schema.TriggerTimeBefore).
+			AddEvents(schema.TriggerEventInsert).
+			SetFor(schema.TriggerForRow).
+			SetBody("BEGIN\n  SET NEW.id = NEW.id + 1;\nEND"),
+	)
+	buf, err := MarshalHCL(s)
+	require.NoError(t, err)
+	const expected = `table "t1" {
+  schema = schema.public
+  column "id" {
+    null = false
+    type = int
+  }
+}
+trigger "tr1" {
+  on = table.t1
+  before {
+    insert = true
+  }
+  for = ROW
+  as  = <<-SQL
+  BEGIN
+    SET NEW.id = NEW.id + 1;
+  END
+  SQL
+}
+schema "public" {
+}
+`
+	require.Equal(t, expected, string(buf))
+	var got schema.Schema
+	require.NoError(t, EvalHCLBytes(buf, &got, nil))
+	require.EqualValues(t, s, &got)
+
+	// FOR EACH ROW is the default.
+	f := `table "t1" {
+  schema = schema.public
+  column "id" {
+    null = false
+    type = int
+  }
+}
+trigger "tr1" {
+  on = table.t1
+  before {
+    insert = true
+  }
+  as = "BEGIN\n  SET NEW.id = NEW.id + 1;\nEND"
+}
+schema "public" {
+}
+`
+	got = schema.Schema{}
+	require.NoError(t, EvalHCLBytes([]byte(f), &got, nil))
+	require.EqualValues(t, s, &got)
+}
+
+func TestMarshalSpec_ViewAttrs(t *testing.T) {
+	s := schema.New("public").
+		AddViews(
+			// Defaults are not printed.
+			schema.NewView("v1", "SELECT 1").
+				AddAttrs(&ViewAlgorithm{V: ViewAlgorithmUndefined}, &ViewSecurity{V: ViewSecurityDefiner}),
+			schema.NewView("v2", "SELECT 2").
+				AddAttrs(&ViewAlgorithm{V: "merge"}, &ViewSecurity{V: ViewSecurityInvoker}).
+				SetCheckOption(schema.ViewCheckOptionCascaded),
+		)
+	buf, err := MarshalSpec(s, hclState)
+	require.NoError(t, err)
+	const expected = `view "v1" {
+  schema = schema.public
+  as     = "SELECT 1"
+}
+view "v2" {
+  schema       = schema.public
+  algorithm    = MERGE
+  security     = INVOKER
+  as           = "SELECT 2"
+  check_option = CASCADED
+}
+schema "public" {
+}
+`
+	require.EqualValues(t, expected, string(buf))
+
+	var got schema.Schema
+	require.NoError(t, EvalHCLBytes(buf, &got, nil))
+	require.Len(t, got.Views, 2)
+	require.Empty(t, got.Views[0].Attrs)
+	require.Equal(t, []schema.Attr{
+		&schema.ViewCheckOption{V: schema.ViewCheckOptionCascaded},
+		&ViewAlgorithm{V: ViewAlgorithmMerge},
+		&ViewSecurity{V: ViewSecurityInvoker},
+	}, got.Views[1].Attrs)
+
+	// The algorithm is not marshaled for MySQL connections.
+	buf, err = marshalMySQL.MarshalSpec(s)
+	require.NoError(t, err)
+	require.EqualValues(t, `view "v1" {
+  schema = schema.public
+  as     = "SELECT 1"
+}
+view "v2" {
+  schema       = schema.public
+  security     = INVOKER
+  as           = "SELECT 2"
+  check_option = CASCADED
+}
+schema "public" {
+}
+`, string(buf))
+}
+
 func TestMarshalSpec_Charset(t *testing.T) {
 	s := &schema.Schema{
 		Name: "test",
@@ -704,6 +823,114 @@ schema "a8m" {}
 	}
 }
 
+func TestMarshalSpec_Partition(t *testing.T) {
+	var (
+		s    = schema.New("test")
+		id   = schema.NewIntColumn("id", TypeInt)
+		name = schema.NewStringColumn("name", TypeVarchar, schema.StringSize(8))
+	)
+	s.AddTables(
+		schema.NewTable("logs").
+			AddColumns(id, name).
+			AddAttrs(&Partition{
+				T:       PartitionTypeRange,
+				Columns: []*schema.Column{id, name},
+				Defs: []*PartitionDef{
+					{Name: "p0", Values: "10,'a'"},
+					{Name: "p1", Values: "MAXVALUE,MAXVALUE"},
+				},
+			}),
+		schema.NewTable("users").
+			AddColumns(id).
+			AddAttrs(&Partition{T: PartitionTypeHash, Linear: true, Expr: "`id`", N: 4}),
+	)
+	buf, err := MarshalSpec(s, hclState)
+	require.NoError(t, err)
+	const expected = `table "logs" {
+  schema = schema.test
+  column "id" {
+    null = false
+    type = int
+  }
+  column "name" {
+    null = false
+    type = varchar(8)
+  }
+  partition {
+    type    = RANGE
+    columns = [column.id, column.name]
+    part "p0" {
+      values = "10,'a'"
+    }
+    part "p1" {
+      values = "MAXVALUE,MAXVALUE"
+    }
+  }
+}
+table "users" {
+  schema = schema.test
+  column "id" {
+    null = false
+    type = int
+  }
+  partition {
+    type       = HASH
+    linear     = true
+    expr       = "` + "`id`" + `"
+    partitions = 4
+  }
+}
+schema "test" {
+}
+`
+	require.EqualValues(t, expected, string(buf))
+
+	var got schema.Schema
+	require.NoError(t, EvalHCLBytes(buf, &got, nil))
+	for i, name := range []string{"logs", "users"} {
+		t1, ok := got.Table(name)
+		require.True(t, ok)
+		require.Len(t, t1.Attrs, 1)
+		p1, p2 := t1.Attrs[0].(*Partition), s.Tables[i].Attrs[0].(*Partition)
+		require.Equal(t, p2.T, p1.T)
+		require.Equal(t, p2.Linear, p1.Linear)
+		require.Equal(t, p2.Expr, p1.Expr)
+		require.Equal(t, p2.N, p1.N)
+		require.Equal(t, p2.Defs, p1.Defs)
+		require.Len(t, p1.Columns, len(p2.Columns))
+		for j := range p2.Columns {
+			require.Equal(t, p2.Columns[j].Name, p1.Columns[j].Name)
+		}
+	}
+}
+
+func TestUnmarshalSpec_Partition(t *testing.T) {
+	for _, tt := range []struct {
+		body, err string
+	}{
+		{body: `type = RANGE`, err: "missing columns or expression for t.partition"},
+		{body: "type = KEY\nexpr = \"id\"", err: `t.partition of type KEY supports only "columns"`},
+		{body: "type = HASH\ncolumns = [column.id]", err: `t.partition of type HASH supports only "expr"`},
+		{body: "type = RANGE\nexpr = \"id\"\npartitions = 2", err: `t.partition of type RANGE does not support "partitions", use "part" blocks instead`},
+		{body: "type = LIST\nexpr = \"id\"", err: "missing part definitions for t.partition"},
+		{body: "type = HASH\nexpr = \"id\"\npart \"p0\" {\nvalues = \"1\"\n}", err: `t.partition of type HASH does not support "part" blocks, use "partitions" instead`},
+	} {
+		var s schema.Schema
+		f := fmt.Sprintf(`table "t" {
+  schema = schema.test
+  column "id" {
+    type = int
+  }
+  partition {
+    %s
+  }
+}
+schema "test" {}
+`, tt.body)
+		require.EqualError(t, EvalHCLBytes([]byte(f), &s, nil), `specutil: cannot convert table "t": `+tt.err)
+	}
+}
+
 func TestUnmarshalSpec_IndexParts(t *testing.T) {
 	var (
 		s schema.Schema
@@ -1732,6 +1959,66 @@ schema "s2" {
 		string(got))
 }
 
+func TestMarshalSpec_Roles(t *testing.T) {
+	var (
+		t1 = schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "int"))
+		t2 = schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "int"))
+		r  = schema.NewRealm(schema.New("s1").AddTables(t1), schema.New("s2").AddTables(t2))
+	)
+	readers := schema.NewRole("readers").AddGrants(schema.NewGrant(r.Schemas[0], "SELECT"))
+	r.AddRoles(
+		readers,
+		schema.NewUser("alice@localhost").
+			AddMemberOf(readers).
+			AddGrants(schema.NewGrant(t2, "UPDATE").SetColumns(t2.Columns[0])),
+	)
+	got, err := MarshalHCL.MarshalSpec(r)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		`table "s1" "users" {
+  schema = schema.s1
+  column "id" {
+    null = false
+    type = int
+  }
+}
+table "s2" "users" {
+  schema = schema.s2
+  column "id" {
+    null = false
+    type = int
+  }
+}
+schema "s1" {
+}
+schema "s2" {
+}
+role "readers" {
+  grant {
+    privileges = ["SELECT"]
+    on         = schema.s1
+  }
+}
+user "alice@localhost" {
+  member_of = [role.readers]
+  grant {
+    privileges = ["UPDATE"]
+    on         = table.s2.users
+    columns    = [table.s2.users.column.id]
+  }
+}
+`,
+		string(got))
+	var r2 schema.Realm
+	require.NoError(t, EvalHCLBytes(got, &r2, nil))
+	require.Len(t, r2.Roles, 2)
+	require.Equal(t, []*schema.Grant{
+		{To: r2.Roles[1], On: r2.Schemas[1].Tables[0], Columns: r2.Schemas[1].Tables[0].Columns, Privileges: []string{"UPDATE"}},
+	}, r2.Roles[1].Grants)
+	require.Equal(t, []*schema.Role{r2.Roles[0]}, r2.Roles[1].MemberOf)
+}
+
 func TestUnmarshalSpec_QuotedIdentifiers(t *testing.T) {
 	var (
 		r schema.Realm
@@ -1819,3 +2106,128 @@ schema "nati.schema" {
 }
 `, string(got))
 }
+
+func TestMarshalSpec_Funcs(t *testing.T) {
+	s := schema.New("test")
+	s.AddFuncs(
+		schema.NewFunc("f1").
+			AddArgs(schema.NewFuncArg("a", &schema.IntegerType{T: TypeInt})).
+			SetReturn(&schema.IntegerType{T: TypeInt}).
+			SetBody("RETURN a + 1").
+			AddAttrs(&Deterministic{}, &DataAccess{V: DataAccessNoSQL}),
+	).AddProcs(
+		schema.NewProc("p1").
+			AddArgs(
+				schema.NewFuncArg("a", &schema.StringType{T: TypeVarchar, Size: 10}),
+				schema.NewFuncArg("b", &schema.IntegerType{T: TypeBigInt}).SetMode(schema.FuncArgModeOut),
+			).
+			SetBody("BEGIN\n  SELECT a;\n  SET b = 1;\nEND").
+			SetComment("proc comment").
+			AddAttrs(&RoutineSecurity{V: RoutineSecurityInvoker}),
+	).AddObjects(
+		&Event{Name: "e1", Schema: s, Every: "1 DAY", Starts: "2024-01-01 00:00:00", Preserve: true, Body: "DELETE FROM t1"},
+	)
+	buf, err := MarshalSpec(s, hclState)
+	require.NoError(t, err)
+	const expected = `function "f1" {
+  schema = schema.test
+  return = int
+  arg "a" {
+    type = int
+  }
+  deterministic = true
+  data_access   = "NO SQL"
+  as            = "RETURN a + 1"
+}
+procedure "p1" {
+  schema = schema.test
+  arg "a" {
+    type = varchar(10)
+  }
+  arg "b" {
+    type = bigint
+    mode = OUT
+  }
+  security = INVOKER
+  as       = <<-SQL
+  BEGIN
+    SELECT a;
+    SET b = 1;
+  END
+  SQL
+  comment  = "proc comment"
+}
+event "e1" {
+  schema   = schema.test
+  every    = "1 DAY"
+  starts   = "2024-01-01 00:00:00"
+  preserve = true
+  as       = "DELETE FROM t1"
+}
+schema "test" {
+}
+`
+	require.EqualValues(t, expected, string(buf))
+
+	var got schema.Schema
+	require.NoError(t, EvalHCLBytes(buf, &got, nil))
+	require.Len(t, got.Objects, 3)
+	changes, err := DefaultDiff.SchemaDiff(s, &got)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+}
+
+func TestUnmarshalSpec_Funcs(t *testing.T) {
+	var (
+		s schema.Schema
+		f = `
+schema "test" {}
+
+function "f1" {
+  schema = schema.test
+  arg "a" {
+    type = int
+  }
+  return      = int
+  data_access = "reads sql data"
+  as          = "RETURN a"
+}
+
+procedure "p1" {
+  schema = schema.test
+  arg "b" {
+    type = int
+    mode = INOUT
+  }
+  security = INVOKER
+  as       = "SET b = b + 1"
+}
+
+event "e1" {
+  schema  = schema.test
+  at      = "2030-01-01 00:00:00"
+  disable = true
+  as      = "DELETE FROM t1"
+  comment = "one time"
+}
+`
+	)
+	require.NoError(t, EvalHCLBytes([]byte(f), &s, nil))
+	require.Len(t, s.Objects, 3)
+	f1 := s.Objects[0].(*schema.Func)
+	require.Equal(t, &schema.IntegerType{T: TypeInt}, f1.Ret)
+	require.Equal(t, []schema.Attr{&DataAccess{V: DataAccessReadsSQL}}, f1.Attrs)
+	p1 := s.Objects[1].(*schema.Proc)
+	require.Equal(t, schema.FuncArgModeInOut, p1.Args[0].Mode)
+	require.Equal(t, []schema.Attr{&RoutineSecurity{V: RoutineSecurityInvoker}}, p1.Attrs)
+	require.Equal(t, &Event{Name: "e1", Schema: &s, At: "2030-01-01 00:00:00", Disabled: true, Body: "DELETE FROM t1", Attrs: []schema.Attr{&schema.Comment{Text: "one time"}}}, s.Objects[2])
+
+	err := EvalHCLBytes([]byte(`
+schema "test" {}
+event "e1" {
+  schema = schema.test
+  as     = "DELETE FROM t1"
+}
+`), &s, nil)
+	require.EqualError(t, err, `event "e1" must define exactly one of the 'at' or 'every' attributes`)
+}
@@ -42,6 +42,16 @@ func FormatType(t schema.Type) (string, error) {
 			return "", errors.New("postgres: missing enum type name")
 		}
 		f = t.T
+	case *Domain:
+		if t.T == "" {
+			return "", errors.New("postgres: missing domain type name")
+		}
+		f = t.T
+	case *Composite:
+		if t.T == "" {
+			return "", errors.New("postgres: missing composite type name")
+		}
+		f = t.T
 	case *schema.IntegerType:
 		switch f = strings.ToLower(t.T); f {
 		case TypeSmallInt, TypeInteger, TypeBigInt:
@@ -194,11 +204,32 @@ func ParseType(typ string) (schema.Type, error) {
 	// If the type is unknown (to us), we fall back to user-defined but expect
 	// to improve this in future versions by ensuring this against the database.
 	if ut, ok := t.(*schema.UnsupportedType); ok {
-		t = &UserDefinedType{T: ut.T}
+		t = userDefinedType(ut.T)
 	}
 	return t, nil
 }
 
+// userDefinedType returns the type of user-defined types, that are inspected from
+// the database or unknown to the parser. Types that are provided by extensions and
+// have a dedicated representation (e.g. PostGIS types) are resolved to it, and all
+// others are represented as UserDefinedType (e.g. citext or hstore).
+func userDefinedType(t string) schema.Type {
+	name := strings.ToLower(t)
+	if i := strings.IndexByte(name, '('); i > 0 {
+		name = name[:i]
+	}
+	// Extension types can be qualified with the schema they are installed in.
+	if i := strings.LastIndexByte(name, '.'); i > 0 {
+		name = name[i+1:]
+	}
+	switch strings.Trim(strings.TrimSpace(name), `"`) {
+	case TypeGeometry, TypeGeography:
+		return &schema.SpatialType{T: t}
+	default:
+		return &UserDefinedType{T: t}
+	}
+}
+
 func columnType(c *columnDesc) (schema.Type, error) {
 	var typ schema.Type
 	switch t := c.typ; strings.ToLower(t) {
@@ -216,7 +247,7 @@ func columnType(c *columnDesc) (schema.Type, error) {
 		typ = &schema.StringType{T: t, Size: int(c.size)}
 	case TypeCIDR, TypeInet, TypeMACAddr, TypeMACAddr8:
 		typ = &NetworkType{T: t}
-	case TypeCircle, TypeLine, TypeLseg, TypeBox, TypePath, TypePolygon, TypePoint, TypeGeometry:
+	case TypeCircle, TypeLine, TypeLseg, TypeBox, TypePath, TypePolygon, TypePoint, TypeGeometry, TypeGeography:
 		typ = &schema.SpatialType{T: t}
 	case TypeDate:
 		typ = &schema.TimeType{T: t}
@@ -279,7 +310,7 @@ func columnType(c *columnDesc) (schema.Type, error) {
 		typeRegOper, typeRegOperator, typeRegProc, typeRegProcedure, typeRegRole, typeRegType:
 		typ = &OIDType{T: t}
 	case TypeUserDefined:
-		typ = &UserDefinedType{T: c.fmtype}
+		typ = userDefinedType(c.fmtype)
 	default:
 		typ = &schema.UnsupportedType{T: t}
 	}
@@ -290,8 +321,8 @@ func columnType(c *columnDesc) (schema.Type, error) {
 		// https://postgresql.org/docs/current/catalog-pg-type.html
 		typ = newEnumType(c.fmtype, c.typid)
 	case "d":
-		// Use user-defined for domain types as we do not
-		// support their creation at this stage.
+		// Domain types are resolved to their schema objects
+		// after they are inspected. See inspectTypes for details.
 		typ = &UserDefinedType{T: c.fmtype}
 	}
 	return typ, nil
@@ -8,7 +8,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"unicode"
@@ -45,10 +47,14 @@ func skipDefaultComment(s *schema.Schema) []schema.Attr {
 
 // SchemaObjectDiff returns a changeset for migrating schema objects from
 // one state to the other.
-func (*diff) SchemaObjectDiff(from, to *schema.Schema) ([]schema.Change, error) {
+func (d *diff) SchemaObjectDiff(from, to *schema.Schema) ([]schema.Change, error) {
 	var changes []schema.Change
 	// Drop or modify enums.
 	for _, o1 := range from.Objects {
+		switch o1.(type) {
+		case *Extension, *Sequence, *Domain, *Composite, *schema.Func, *schema.Proc:
+			continue
+		}
 		e1, ok := o1.(*schema.EnumType)
 		if !ok {
 			return nil, fmt.Errorf("unsupported object type %T", o1)
@@ -67,6 +73,10 @@ func (*diff) SchemaObjectDiff(from, to *schema.Schema) ([]schema.Change, error)
 	}
 	// Add new enums.
 	for _, o1 := range to.Objects {
+		switch o1.(type) {
+		case *Extension, *Sequence, *Domain, *Composite, *schema.Func, *schema.Proc:
+			continue
+		}
 		e1, ok := o1.(*schema.EnumType)
 		if !ok {
 			return nil, fmt.Errorf("unsupported object type %T", o1)
@@ -78,7 +88,236 @@ func (*diff) SchemaObjectDiff(from, to *schema.Schema) ([]schema.Change, error)
 			changes = append(changes, &schema.AddObject{O: e1})
 		}
 	}
-	return changes, nil
+	// Extensions are changed before the objects that may depend on them,
+	// and domains and composite types after the enums they may use.
+	changes = append(append(extsDiff(from, to), changes...), typesDiff(from, to)...)
+	changes = append(changes, seqsDiff(from, to)...)
+	// Functions and procedures are changed after the enums they may use.
+	drops, funcs := d.funcsDiff(from, to)
+	return append(append(drops, changes...), funcs...), nil
+}
+
+// extsDiff returns the changes for migrating the extensions of the schema. The extension
+// version is compared only if it is set explicitly on the desired state.
+func extsDiff(from, to *schema.Schema) []schema.Change {
+	var changes []schema.Change
+	for _, o1 := range from.Objects {
+		e1, ok := o1.(*Extension)
+		if !ok {
+			continue
+		}
+		e2, ok := schemaExt(to, e1.Name)
+		if !ok {
+			changes = append(changes, &schema.DropObject{O: e1})
+			continue
+		}
+		if e2.Version != "" && e1.Version != e2.Version || sqlx.CommentDiff(e1.Attrs, e2.Attrs) != nil {
+			changes = append(changes, &schema.ModifyObject{From: e1, To: e2})
+		}
+	}
+	for _, o1 := range to.Objects {
+		if e1, ok := o1.(*Extension); ok {
+			if _, ok := schemaExt(from, e1.Name); !ok {
+				changes = append(changes, &schema.AddObject{O: e1})
+			}
+		}
+	}
+	return changes
+}
+
+// schemaExt returns the extension with the given name from the schema.
+func schemaExt(s *schema.Schema, name string) (*Extension, bool) {
+	o, ok := s.Object(func(o schema.Object) bool {
+		e, ok := o.(*Extension)
+		return ok && e.Name == name
+	})
+	if !ok {
+		return nil, false
+	}
+	return o.(*Extension), true
+}
+
+// typesDiff returns the changes for migrating the domains and the composite types of the schema.
+func typesDiff(from, to *schema.Schema) []schema.Change {
+	var changes []schema.Change
+	for _, o1 := range from.Objects {
+		switch o1 := o1.(type) {
+		case *Domain:
+			o2, ok := schemaDomain(to, o1.T)
+			if !ok {
+				changes = append(changes, &schema.DropObject{O: o1})
+			} else if domainChanged(o1, o2) {
+				changes = append(changes, &schema.ModifyObject{From: o1, To: o2})
+			}
+		case *Composite:
+			o2, ok := schemaComposite(to, o1.T)
+			if !ok {
+				changes = append(changes, &schema.DropObject{O: o1})
+			} else if compositeChanged(o1, o2) {
+				changes = append(changes, &schema.ModifyObject{From: o1, To: o2})
+			}
+		}
+	}
+	for _, o1 := range to.Objects {
+		switch o1 := o1.(type) {
+		case *Domain:
+			if _, ok := schemaDomain(from, o1.T); !ok {
+				changes = append(changes, &schema.AddObject{O: o1})
+			}
+		case *Composite:
+			if _, ok := schemaComposite(from, o1.T); !ok {
+				changes = append(changes, &schema.AddObject{O: o1})
+			}
+		}
+	}
+	return changes
+}
+
+// schemaDomain returns the domain with the given name from the schema.
+func schemaDomain(s *schema.Schema, name string) (*Domain, bool) {
+	o, ok := s.Object(func(o schema.Object) bool {
+		d, ok := o.(*Domain)
+		return ok && d.T == name
+	})
+	if !ok {
+		return nil, false
+	}
+	return o.(*Domain), true
+}
+
+// schemaComposite returns the composite type with the given name from the schema.
+func schemaComposite(s *schema.Schema, name string) (*Composite, bool) {
+	o, ok := s.Object(func(o schema.Object) bool {
+		c, ok := o.(*Composite)
+		return ok && c.T == name
+	})
+	if !ok {
+		return nil, false
+	}
+	return o.(*Composite), true
+}
+
+// domainChanged reports if the domain definition was changed.
+func domainChanged(from, to *Domain) bool {
+	if from.Null != to.Null || formatT(from.Base) != formatT(to.Base) ||
+		domainDefaultChanged(from, to) || sqlx.CommentDiff(from.Attrs, to.Attrs) != nil {
+		return true
+	}
+	drops, adds := domainChecksDiff(from, to)
+	return len(drops) > 0 || len(adds) > 0
+}
+
+// domainDefaultChanged reports if the default value of the domain was changed.
+func domainDefaultChanged(from, to *Domain) bool {
+	if (from.Default == nil) != (to.Default == nil) {
+		return true
+	}
+	d1, d2 := exprValue(from.Default), exprValue(to.Default)
+	return trimCast(d1) != trimCast(d2) && quote(d1) != quote(d2)
+}
+
+// domainChecksDiff returns the checks that were dropped from the domain, and the checks that
+// were added to it. Checks are matched by their names, and modified checks are returned in both.
+func domainChecksDiff(from, to *Domain) (drops, adds []*schema.Check) {
+	byName := func(cs []*schema.Check, name string) (*schema.Check, bool) {
+		for _, c := range cs {
+			if c.Name == name {
+				return c, true
+			}
+		}
+		return nil, false
+	}
+	for _, c1 := range from.Checks {
+		if c2, ok := byName(to.Checks, c1.Name); !ok || !checkExprEqual(c1.Expr, c2.Expr) {
+			drops = append(drops, c1)
+		}
+	}
+	for _, c2 := range to.Checks {
+		if c1, ok := byName(from.Checks, c2.Name); !ok || !checkExprEqual(c1.Expr, c2.Expr) {
+			adds = append(adds, c2)
+		}
+	}
+	return drops, adds
+}
+
+// compositeChanged reports if the composite type definition was changed.
+func compositeChanged(from, to *Composite) bool {
+	if len(from.Fields) != len(to.Fields) || sqlx.CommentDiff(from.Attrs, to.Attrs) != nil {
+		return true
+	}
+	for i := range from.Fields {
+		if from.Fields[i].Name != to.Fields[i].Name || formatT(from.Fields[i].Type.Type) != formatT(to.Fields[i].Type.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatT returns the formatted name of the type, or its Go
+// type in case it cannot be formatted (e.g. unsupported types).
+func formatT(t schema.Type) string {
+	f, err := FormatType(t)
+	if err != nil {
+		return fmt.Sprintf("%T", t)
+	}
+	return f
+}
+
+// exprValue returns the string value of the given expression.
+func exprValue(x schema.Expr) string {
+	switch x := x.(type) {
+	case *schema.Literal:
+		return x.V
+	case *schema.RawExpr:
+		return x.X
+	default:
+		return ""
+	}
+}
+
+// checkExprEqual reports if the two check expressions are equal,
+// ignoring the wrapping parentheses added by the database.
+func checkExprEqual(x1, x2 string) bool {
+	return x1 == x2 || sqlx.MayWrap(x1) == sqlx.MayWrap(x2)
+}
+
+// seqsDiff returns the changes for migrating the sequences of the schema.
+func seqsDiff(from, to *schema.Schema) []schema.Change {
+	var changes []schema.Change
+	for _, o1 := range from.Objects {
+		s1, ok := o1.(*Sequence)
+		if !ok {
+			continue
+		}
+		s2, ok := schemaSeq(to, s1.Name)
+		if !ok {
+			changes = append(changes, &schema.DropObject{O: s1})
+			continue
+		}
+		if seqOptions(s1) != seqOptions(s2) || seqOwner(s1) != seqOwner(s2) || sqlx.CommentDiff(s1.Attrs, s2.Attrs) != nil {
+			changes = append(changes, &schema.ModifyObject{From: s1, To: s2})
+		}
+	}
+	for _, o1 := range to.Objects {
+		if s1, ok := o1.(*Sequence); ok {
+			if _, ok := schemaSeq(from, s1.Name); !ok {
+				changes = append(changes, &schema.AddObject{O: s1})
+			}
+		}
+	}
+	return changes
+}
+
+// schemaSeq returns the standalone sequence with the given name from the schema.
+func schemaSeq(s *schema.Schema, name string) (*Sequence, bool) {
+	o, ok := s.Object(func(o schema.Object) bool {
+		s, ok := o.(*Sequence)
+		return ok && s.Name == name
+	})
+	if !ok {
+		return nil, false
+	}
+	return o.(*Sequence), true
 }
 
 // TableAttrDiff returns a changeset for migrating table attributes from one state to the other.
@@ -90,11 +329,186 @@ func (d *diff) TableAttrDiff(from, to *schema.Table) ([]schema.Change, error) {
 	if err := d.partitionChanged(from, to); err != nil {
 		return nil, err
 	}
+	changes = append(changes, partitionsDiff(from, to)...)
+	changes = append(changes, rowSecurityDiff(from, to)...)
 	return append(changes, sqlx.CheckDiff(from, to, func(c1, c2 *schema.Check) bool {
-		return sqlx.Has(c1.Attrs, &NoInherit{}) == sqlx.Has(c2.Attrs, &NoInherit{})
+		return sqlx.Has(c1.Attrs, &NoInherit{}) == sqlx.Has(c2.Attrs, &NoInherit{}) && !notValidChanged(c1.Attrs, c2.Attrs)
 	})...), nil
 }
 
+// ObjectName returns a unique name in the realm for the PostgreSQL objects that privileges can be granted on.
+func (*diff) ObjectName(o schema.Object) (string, bool) {
+	if seq, ok := o.(*Sequence); ok && seq.Schema != nil {
+		return "sequence:" + seq.Schema.Name + "." + seq.Name, true
+	}
+	return "", false
+}
+
+// AllPrivileges returns the privileges that ALL PRIVILEGES stands for on the given object.
+func (*diff) AllPrivileges(o schema.Object, column bool) []string {
+	switch o.(type) {
+	case *schema.Schema:
+		return []string{"CREATE", "USAGE"}
+	case *Sequence:
+		return []string{"SELECT", "UPDATE", "USAGE"}
+	}
+	if column {
+		return []string{"INSERT", "REFERENCES", "SELECT", "UPDATE"}
+	}
+	return []string{"DELETE", "INSERT", "REFERENCES", "SELECT", "TRIGGER", "TRUNCATE", "UPDATE"}
+}
+
+// partitionsDiff returns the changes for migrating the child partitions of the table.
+func partitionsDiff(from, to *schema.Table) []schema.Change {
+	var changes []schema.Change
+	for _, p1 := range tablePartitions(from) {
+		p2, ok := tablePartition(to, p1.Name)
+		switch {
+		case !ok:
+			changes = append(changes, &schema.DropAttr{A: p1})
+		case formatPartitionBound(p1.Bound) != formatPartitionBound(p2.Bound):
+			changes = append(changes, &schema.ModifyAttr{From: p1, To: p2})
+		}
+	}
+	for _, p2 := range tablePartitions(to) {
+		if _, ok := tablePartition(from, p2.Name); !ok {
+			changes = append(changes, &schema.AddAttr{A: p2})
+		}
+	}
+	return changes
+}
+
+// tablePartitions returns the child partitions of the table.
+func tablePartitions(t *schema.Table) []*PartitionChild {
+	var ps []*PartitionChild
+	for _, a := range t.Attrs {
+		if p, ok := a.(*PartitionChild); ok {
+			ps = append(ps, p)
+		}
+	}
+	return ps
+}
+
+// tablePartition returns the child partition with the given name from the table.
+func tablePartition(t *schema.Table, name string) (*PartitionChild, bool) {
+	for _, p := range tablePartitions(t) {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// rowSecurityDiff returns the changes for migrating the row-level
+// security configuration and the policies of the table.
+func rowSecurityDiff(from, to *schema.Table) []schema.Change {
+	var (
+		changes []schema.Change
+		r1, ok1 = rowSecurity(from)
+		r2, ok2 = rowSecurity(to)
+	)
+	switch {
+	case !ok1 && ok2:
+		changes = append(changes, &schema.AddAttr{A: r2})
+	case ok1 && !ok2:
+		changes = append(changes, &schema.DropAttr{A: r1})
+	case ok1 && ok2 && *r1 != *r2:
+		changes = append(changes, &schema.ModifyAttr{From: r1, To: r2})
+	}
+	for _, p1 := range tablePolicies(from) {
+		p2, ok := tablePolicy(to, p1.Name)
+		switch {
+		case !ok:
+			changes = append(changes, &schema.DropAttr{A: p1})
+		case policyChanged(p1, p2):
+			changes = append(changes, &schema.ModifyAttr{From: p1, To: p2})
+		}
+	}
+	for _, p2 := range tablePolicies(to) {
+		if _, ok := tablePolicy(from, p2.Name); !ok {
+			changes = append(changes, &schema.AddAttr{A: p2})
+		}
+	}
+	return changes
+}
+
+// rowSecurity returns the row-level security configuration of the table. A table
+// without the attribute, or with a disabled configuration, is reported as false.
+func rowSecurity(t *schema.Table) (*RowSecurity, bool) {
+	for _, a := range t.Attrs {
+		if r, ok := a.(*RowSecurity); ok && (r.Enabled || r.Enforced) {
+			return r, true
+		}
+	}
+	return nil, false
+}
+
+// tablePolicies returns the row-level security policies of the table.
+func tablePolicies(t *schema.Table) []*Policy {
+	var ps []*Policy
+	for _, a := range t.Attrs {
+		if p, ok := a.(*Policy); ok {
+			ps = append(ps, p)
+		}
+	}
+	return ps
+}
+
+// tablePolicy returns the policy with the given name from the table.
+func tablePolicy(t *schema.Table, name string) (*Policy, bool) {
+	for _, p := range tablePolicies(t) {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// policyChanged reports if the policy definition was changed.
+func policyChanged(p1, p2 *Policy) bool {
+	return policyAs(p1) != policyAs(p2) || policyFor(p1) != policyFor(p2) ||
+		strings.Join(policyTo(p1), ",") != strings.Join(policyTo(p2), ",") ||
+		!policyExprEqual(p1.Using, p2.Using) || !policyExprEqual(p1.Check, p2.Check)
+}
+
+// policyAs returns the type of the policy, or its default.
+func policyAs(p *Policy) string {
+	if p.As == "" {
+		return PolicyAsPermissive
+	}
+	return strings.ToUpper(p.As)
+}
+
+// policyFor returns the command of the policy, or its default.
+func policyFor(p *Policy) string {
+	if p.For == "" {
+		return PolicyForAll
+	}
+	return strings.ToUpper(p.For)
+}
+
+// policyTo returns the sorted roles of the policy, or its default.
+func policyTo(p *Policy) []string {
+	if len(p.To) == 0 {
+		return []string{policyToPublic}
+	}
+	to := make([]string, len(p.To))
+	for i, r := range p.To {
+		if strings.EqualFold(r, policyToPublic) {
+			r = policyToPublic
+		}
+		to[i] = r
+	}
+	sort.Strings(to)
+	return to
+}
+
+// policyExprEqual reports if the two policy expressions are equal,
+// ignoring the wrapping parentheses added by the database.
+func policyExprEqual(x1, x2 string) bool {
+	return x1 == x2 || x1 != "" && x2 != "" && sqlx.MayWrap(x1) == sqlx.MayWrap(x2)
+}
+
 // ColumnChange returns the schema changes (if any) for migrating one column to the other.
 func (d *diff) ColumnChange(_ *schema.Table, from, to *schema.Column) (schema.ChangeKind, error) {
 	change := sqlx.CommentChange(from.Attrs, to.Attrs)
@@ -231,7 +645,7 @@ func (*diff) IndexAttrChanged(from, to []schema.Attr) bool {
 	if sqlx.Has(from, &p1) != sqlx.Has(to, &p2) || (p1.P != p2.P && p1.P != sqlx.MayWrap(p2.P)) {
 		return true
 	}
-	if indexIncludeChanged(from, to) {
+	if indexIncludeChanged(from, to) || deferrableChanged(from, to) {
 		return true
 	}
 	s1, ok1 := indexStorageParams(from)
@@ -249,6 +663,10 @@ func (*diff) IndexPartAttrChanged(fromI, toI *schema.Index, i int) bool {
 	if p1.NullsFirst != p2.NullsFirst || p1.NullsLast != p2.NullsLast {
 		return true
 	}
+	var x1, x2 IndexExcludeOp
+	if sqlx.Has(from.Attrs, &x1) != sqlx.Has(to.Attrs, &x2) || x1.V != x2.V {
+		return true
+	}
 	var fromOp, toOp IndexOpClass
 	switch fromHas, toHas := sqlx.Has(from.Attrs, &fromOp), sqlx.Has(to.Attrs, &toOp); {
 	case fromHas && toHas:
@@ -266,6 +684,24 @@ func (*diff) IndexPartAttrChanged(fromI, toI *schema.Index, i int) bool {
 	}
 }
 
+// ForeignKeyAttrChanged reports if the foreign key attributes were changed.
+func (*diff) ForeignKeyAttrChanged(from, to []schema.Attr) bool {
+	return deferrableChanged(from, to) || notValidChanged(from, to)
+}
+
+// deferrableChanged reports if the DEFERRABLE mode of a constraint was changed.
+func deferrableChanged(from, to []schema.Attr) bool {
+	var d1, d2 Deferrable
+	return sqlx.Has(from, &d1) != sqlx.Has(to, &d2) || d1.Deferred != d2.Deferred
+}
+
+// notValidChanged reports if a NOT VALID constraint should be validated. Note, a
+// validated constraint cannot be changed back to NOT VALID, and therefore, the
+// desired state is allowed to be NOT VALID, while the constraint is validated.
+func notValidChanged(from, to []schema.Attr) bool {
+	return sqlx.Has(from, &NotValid{}) && !sqlx.Has(to, &NotValid{})
+}
+
 // ReferenceChanged reports if the foreign key referential action was changed.
 func (*diff) ReferenceChanged(from, to schema.ReferenceOption) bool {
 	// According to PostgreSQL, the NO ACTION rule is set
@@ -285,6 +721,14 @@ type DiffOptions struct {
 		Add  bool `spec:"add"`
 		Drop bool `spec:"drop"`
 	} `spec:"concurrent_index"`
+	// NotValid adds foreign keys and checks to existing tables as NOT VALID,
+	// and validates them in a following VALIDATE CONSTRAINT statement. If
+	// Separate is set, the statement is written to a separate migration file.
+	NotValid struct {
+		ForeignKey bool `spec:"foreign_key"`
+		Check      bool `spec:"check"`
+		Separate   bool `spec:"separate"`
+	} `spec:"not_valid"`
 }
 
 // AnnotateChanges implements the sqlx.ChangeAnnotator interface.
@@ -315,6 +759,14 @@ func (*diff) AnnotateChanges(changes []schema.Change, opts *schema.DiffOptions)
 				if extra.ConcurrentIndex.Drop {
 					c.Extra = append(c.Extra, &Concurrently{})
 				}
+			case *schema.AddForeignKey:
+				if extra.NotValid.ForeignKey {
+					c.Extra = append(c.Extra, &ValidateLater{Separate: extra.NotValid.Separate})
+				}
+			case *schema.AddCheck:
+				if extra.NotValid.Check {
+					c.Extra = append(c.Extra, &ValidateLater{Separate: extra.NotValid.Separate})
+				}
 			}
 		}
 	}
@@ -345,10 +797,16 @@ func (d *diff) typeChanged(from, to *schema.Column) (bool, error) {
 		changed = t1 != t2
 	case *UserDefinedType:
 		toT := toT.(*UserDefinedType)
-		changed = toT.T != fromT.T &&
+		changed = !strings.EqualFold(toT.T, fromT.T) &&
 			// In case the type is defined with schema qualifier, but returned without
 			// (inspecting a schema scope), or vice versa, remove before comparing.
-			d.schema != "" && d.trimSchema(toT.T) != d.trimSchema(toT.T)
+			(d.schema == "" || !strings.EqualFold(d.trimSchema(toT.T), d.trimSchema(fromT.T)))
+	case *Domain:
+		toT := toT.(*Domain)
+		changed = fromT.T != toT.T || (toT.Schema != nil && fromT.Schema != nil && fromT.Schema.Name != toT.Schema.Name)
+	case *Composite:
+		toT := toT.(*Composite)
+		changed = fromT.T != toT.T || (toT.Schema != nil && fromT.Schema != nil && fromT.Schema.Name != toT.Schema.Name)
 	case *schema.EnumType:
 		toT := toT.(*schema.EnumType)
 		// Column type was changed if the underlying enum type was changed.
@@ -421,13 +879,88 @@ func (d *diff) defaultEqual(from, to schema.Expr) (bool, error) {
 	return b, nil
 }
 
-// Default IDENTITY attributes.
+// Default IDENTITY and sequence attributes.
 const (
 	defaultIdentityGen  = "BY DEFAULT"
 	defaultSeqStart     = 1
 	defaultSeqIncrement = 1
+	defaultSeqCache     = 1
 )
 
+// seqOpts holds the effective options of a sequence, with the defaults applied.
+type seqOpts struct {
+	typ                         string
+	start, inc, min, max, cache int64
+	cycle                       bool
+}
+
+// seqOptions returns the effective options of the given sequence. The defaults
+// of the MINVALUE, MAXVALUE and START options depend on the sequence direction.
+// https://postgresql.org/docs/current/sql-createsequence.html
+func seqOptions(s *Sequence) seqOpts {
+	o := seqOpts{typ: TypeBigInt, start: s.Start, inc: s.Increment, cache: s.Cache, cycle: s.Cycle}
+	if s.Type != nil {
+		if t, err := FormatType(s.Type); err == nil {
+			o.typ = t
+		}
+	}
+	if o.inc == 0 {
+		o.inc = defaultSeqIncrement
+	}
+	if o.cache == 0 {
+		o.cache = defaultSeqCache
+	}
+	lo, hi := int64(math.MinInt64), int64(math.MaxInt64)
+	switch o.typ {
+	case TypeSmallInt:
+		lo, hi = math.MinInt16, math.MaxInt16
+	case TypeInteger:
+		lo, hi = math.MinInt32, math.MaxInt32
+	}
+	switch {
+	case s.Min != nil:
+		o.min = *s.Min
+	case o.inc > 0:
+		o.min = 1
+	default:
+		o.min = lo
+	}
+	switch {
+	case s.Max != nil:
+		o.max = *s.Max
+	case o.inc > 0:
+		o.max = hi
+	default:
+		o.max = -1
+	}
+	if o.start == 0 {
+		o.start = o.min
+		if o.inc < 0 {
+			o.start = o.max
+		}
+	}
+	return o
+}
+
+// seqDefaults returns the default options of a sequence with the type and the increment
+// of the given options. Note, the default START depends on its MINVALUE and MAXVALUE.
+func seqDefaults(o seqOpts) seqOpts {
+	d := seqOptions(&Sequence{Type: &schema.IntegerType{T: o.typ}, Increment: o.inc})
+	d.start = o.min
+	if o.inc < 0 {
+		d.start = o.max
+	}
+	return d
+}
+
+// seqOwner returns the "table.column" owner of the sequence, or an empty string if it has no owner.
+func seqOwner(s *Sequence) string {
+	if s.Owner.Table == nil || s.Owner.Column == nil {
+		return ""
+	}
+	return s.Owner.Table.Name + "." + s.Owner.Column.Name
+}
+
 // identityChanged reports if one of the identity attributes was changed.
 func identityChanged(from, to []schema.Attr) bool {
 	i1, ok1 := identity(from)
@@ -486,6 +1019,24 @@ func formatPartition(p Partition) (string, error) {
 	return b.String(), nil
 }
 
+// formatPartitionBound returns the string representation of the
+// partition bound according to the PostgreSQL format/grammar.
+func formatPartitionBound(b PartitionBound) string {
+	list := func(vs []string) string {
+		return "(" + strings.Join(vs, ", ") + ")"
+	}
+	switch {
+	case b.Default:
+		return "DEFAULT"
+	case len(b.From) > 0 || len(b.To) > 0:
+		return fmt.Sprintf("FOR VALUES FROM %s TO %s", list(b.From), list(b.To))
+	case len(b.In) > 0:
+		return fmt.Sprintf("FOR VALUES IN %s", list(b.In))
+	default:
+		return fmt.Sprintf("FOR VALUES WITH (MODULUS %d, REMAINDER %d)", b.Modulus, b.Remainder)
+	}
+}
+
 // indexStorageParams returns the index storage parameters from the attributes
 // in case it is there, and it is not the default.
 func indexStorageParams(attrs []schema.Attr) (*IndexStorageParams, bool) {
@@ -569,6 +569,408 @@ func TestDiff_SchemaDiff(t *testing.T) {
 	})
 }
 
+func TestDiff_Sequences(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	var (
+		one  = int64(1)
+		from = schema.New("public").AddObjects(
+			&Sequence{Name: "dropped"},
+			&Sequence{Name: "modified"},
+			&Sequence{Name: "unchanged", Type: &schema.IntegerType{T: TypeBigInt}, Start: 1, Increment: 1, Min: &one, Cache: 1},
+		)
+		to = schema.New("public").AddObjects(
+			&Sequence{Name: "modified", Cycle: true},
+			// Explicit defaults are equal to omitted options.
+			&Sequence{Name: "unchanged"},
+			&Sequence{Name: "added"},
+		)
+	)
+	changes, err := drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.EqualValues(t, []schema.Change{
+		&schema.DropObject{O: from.Objects[0]},
+		&schema.ModifyObject{From: from.Objects[1], To: to.Objects[0]},
+		&schema.AddObject{O: to.Objects[2]},
+	}, changes)
+}
+
+func TestDiff_Extensions(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	var (
+		from = schema.New("public").AddObjects(
+			&Extension{Name: "hstore", Version: "1.8"},
+			&Extension{Name: "citext", Version: "1.5"},
+			&Extension{Name: "postgis", Version: "3.3.2"},
+		)
+		to = schema.New("public").AddObjects(
+			&Extension{Name: "citext", Version: "1.6"},
+			// Versions are compared only if they are set.
+			&Extension{Name: "postgis"},
+			&Extension{Name: "uuid-ossp"},
+		)
+	)
+	changes, err := drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.EqualValues(t, []schema.Change{
+		&schema.DropObject{O: from.Objects[0]},
+		&schema.ModifyObject{From: from.Objects[1], To: to.Objects[0]},
+		&schema.AddObject{O: to.Objects[2]},
+	}, changes)
+}
+
+func TestDiff_MaterializedViews(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	var (
+		from = schema.New("public").AddViews(
+			schema.NewMaterializedView("m1", "SELECT a FROM t1").
+				AddColumns(schema.NewIntColumn("a", "int"), schema.NewIntColumn("b", "int")),
+			schema.NewMaterializedView("m2", "SELECT a FROM t1").
+				AddAttrs(&Tablespace{N: "fast"}),
+		)
+		to = schema.New("public").AddViews(
+			schema.NewMaterializedView("m1", "SELECT a FROM t1").
+				AddColumns(schema.NewIntColumn("a", "int"), schema.NewIntColumn("b", "int")),
+			// Tablespace was reset to the default.
+			schema.NewMaterializedView("m2", "SELECT a FROM t1"),
+		)
+	)
+	from.Views[0].AddIndexes(
+		schema.NewIndex("m1_a").AddColumns(from.Views[0].Columns[0]),
+		schema.NewIndex("m1_b").AddColumns(from.Views[0].Columns[1]),
+	)
+	to.Views[0].AddIndexes(
+		schema.NewUniqueIndex("m1_a").AddColumns(to.Views[0].Columns[0]),
+		schema.NewIndex("m1_ab").AddColumns(to.Views[0].Columns...),
+	)
+	changes, err := drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.EqualValues(t, []schema.Change{
+		&schema.ModifyView{
+			From: from.Views[0],
+			To:   to.Views[0],
+			Changes: []schema.Change{
+				&schema.ModifyIndex{From: from.Views[0].Indexes[0], To: to.Views[0].Indexes[0], Change: schema.ChangeUnique},
+				&schema.DropIndex{I: from.Views[0].Indexes[1]},
+				&schema.AddIndex{I: to.Views[0].Indexes[1]},
+			},
+		},
+		&schema.ModifyView{From: from.Views[1], To: to.Views[1]},
+	}, changes)
+}
+
+func TestDiff_Types(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	var (
+		from = schema.New("public").AddObjects(
+			&Domain{T: "d1", Base: &schema.StringType{T: "text"}, Null: true},
+			&Domain{T: "d2", Base: &schema.IntegerType{T: "integer"}, Checks: []*schema.Check{{Name: "c", Expr: "(VALUE > 0)"}}},
+			&Domain{T: "d3", Base: &schema.IntegerType{T: "integer"}, Default: &schema.RawExpr{X: "'1'::integer"}},
+			&Composite{T: "c1", Fields: []*schema.Column{{Name: "a", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "integer"}}}}},
+			&Composite{T: "c2", Fields: []*schema.Column{{Name: "a", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "int"}}}}},
+			&Domain{T: "d5", Base: &schema.StringType{T: "text"}, Default: &schema.Literal{V: "'x'"}},
+		)
+		to = schema.New("public").AddObjects(
+			// Checks are compared by their names and expressions.
+			&Domain{T: "d2", Base: &schema.IntegerType{T: "int"}, Checks: []*schema.Check{{Name: "c", Expr: "VALUE > 0"}}},
+			&Domain{T: "d3", Base: &schema.IntegerType{T: "integer"}, Default: &schema.Literal{V: "2"}},
+			&Domain{T: "d4", Base: &schema.StringType{T: "text"}, Null: true},
+			&Composite{T: "c1", Fields: []*schema.Column{{Name: "a", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "bigint"}}}}},
+			&Composite{T: "c2", Fields: []*schema.Column{{Name: "a", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "integer"}}}}},
+			// Default values are compared without their quotes.
+			&Domain{T: "d5", Base: &schema.StringType{T: "text"}, Default: &schema.Literal{V: "x"}},
+		)
+	)
+	changes, err := drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.EqualValues(t, []schema.Change{
+		&schema.DropObject{O: from.Objects[0]},
+		&schema.ModifyObject{From: from.Objects[2], To: to.Objects[1]},
+		&schema.ModifyObject{From: from.Objects[3], To: to.Objects[3]},
+		&schema.AddObject{O: to.Objects[2]},
+	}, changes)
+
+	// Columns that use domains are changed only if their type was changed.
+	d1, d2 := &Domain{T: "d1", Schema: from}, &Domain{T: "d2", Schema: from}
+	changed, err := (&diff{}).typeChanged(
+		schema.NewColumn("c").SetType(d1),
+		schema.NewColumn("c").SetType(&Domain{T: "d1", Schema: to}),
+	)
+	require.NoError(t, err)
+	require.False(t, changed)
+	changed, err = (&diff{}).typeChanged(schema.NewColumn("c").SetType(d1), schema.NewColumn("c").SetType(d2))
+	require.NoError(t, err)
+	require.True(t, changed)
+}
+
+func TestDiff_Policies(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	var (
+		from = schema.NewTable("users").
+			SetSchema(schema.New("public")).
+			AddColumns(schema.NewStringColumn("owner", "text")).
+			AddAttrs(
+				&RowSecurity{Enabled: true},
+				&Policy{Name: "p1", As: PolicyAsPermissive, For: PolicyForAll, Using: "(owner = CURRENT_USER)"},
+				&Policy{Name: "p2", For: PolicyForSelect, Using: "true"},
+				&Policy{Name: "p3", To: []string{"admin"}, Using: "true"},
+			)
+		to = schema.NewTable("users").
+			SetSchema(schema.New("public")).
+			AddColumns(schema.NewStringColumn("owner", "text")).
+			AddAttrs(
+				&RowSecurity{Enabled: true, Enforced: true},
+				// Defaults and wrapping parentheses are ignored.
+				&Policy{Name: "p1", Using: "owner = CURRENT_USER"},
+				&Policy{Name: "p3", To: []string{"admin", "editor"}, Using: "true"},
+				&Policy{Name: "p4", For: PolicyForInsert, Check: "true"},
+			)
+	)
+	changes, err := drv.TableDiff(from, to)
+	require.NoError(t, err)
+	require.Equal(t, []schema.Change{
+		&schema.ModifyAttr{From: from.Attrs[0], To: to.Attrs[0]},
+		&schema.DropAttr{A: from.Attrs[2]},
+		&schema.ModifyAttr{From: from.Attrs[3], To: to.Attrs[2]},
+		&schema.AddAttr{A: to.Attrs[3]},
+	}, changes)
+
+	// Disabling row-level security drops the attribute.
+	changes, err = drv.TableDiff(to, schema.NewTable("users").SetSchema(schema.New("public")).AddColumns(schema.NewStringColumn("owner", "text")))
+	require.NoError(t, err)
+	require.Equal(t, &schema.DropAttr{A: to.Attrs[0]}, changes[0])
+}
+
+func TestDiff_Partitions(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	var (
+		c    = schema.NewIntColumn("id", "int")
+		key  = &Partition{T: PartitionTypeHash, Parts: []*PartitionPart{{C: c}}}
+		from = schema.NewTable("users").
+			SetSchema(schema.New("public")).
+			AddColumns(c).
+			AddAttrs(
+				key,
+				&PartitionChild{Name: "users_0", Bound: PartitionBound{Modulus: 2, Remainder: 0}},
+				&PartitionChild{Name: "users_1", Bound: PartitionBound{Modulus: 2, Remainder: 1}},
+				&PartitionChild{Name: "users_x", Bound: PartitionBound{Modulus: 2, Remainder: 1}},
+			)
+		to = schema.NewTable("users").
+			SetSchema(schema.New("public")).
+			AddColumns(c).
+			AddAttrs(
+				key,
+				&PartitionChild{Name: "users_0", Bound: PartitionBound{Modulus: 2, Remainder: 0}},
+				&PartitionChild{Name: "users_1", Bound: PartitionBound{Modulus: 4, Remainder: 1}},
+				&PartitionChild{Name: "users_3", Bound: PartitionBound{Modulus: 4, Remainder: 3}},
+			)
+	)
+	changes, err := drv.TableDiff(from, to)
+	require.NoError(t, err)
+	require.Equal(t, []schema.Change{
+		&schema.ModifyAttr{From: from.Attrs[2], To: to.Attrs[2]},
+		&schema.DropAttr{A: from.Attrs[3]},
+		&schema.AddAttr{A: to.Attrs[3]},
+	}, changes)
+}
+
+func TestDiff_Constraints(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	var (
+		users = schema.NewTable("users").SetSchema(schema.New("public")).AddColumns(schema.NewIntColumn("id", "int"))
+		table = func(fkAttrs []schema.Attr, uniqAttrs []schema.Attr, op string) *schema.Table {
+			c1, c2 := schema.NewIntColumn("c1", "int"), schema.NewIntColumn("c2", "int")
+			t := schema.NewTable("t").SetSchema(users.Schema).AddColumns(c1, c2)
+			t.AddForeignKeys(schema.NewForeignKey("fk").AddColumns(c1).SetRefTable(users).AddRefColumns(users.Columns[0]).AddAttrs(fkAttrs...))
+			t.AddIndexes(
+				schema.NewUniqueIndex("uniq").AddColumns(c1).AddAttrs(uniqAttrs...),
+				schema.NewIndex("excl").AddAttrs(&IndexType{T: IndexTypeGiST}).AddParts(
+					schema.NewColumnPart(c1).AddAttrs(&IndexExcludeOp{V: "="}),
+					schema.NewColumnPart(c2).AddAttrs(&IndexExcludeOp{V: op}),
+				),
+			)
+			return t
+		}
+	)
+	from := table(nil, []schema.Attr{&Deferrable{}}, "=")
+	changes, err := drv.TableDiff(from, table(nil, []schema.Attr{&Deferrable{}}, "="))
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	to := table([]schema.Attr{&Deferrable{Deferred: true}}, []schema.Attr{&Deferrable{Deferred: true}}, "<>")
+	changes, err = drv.TableDiff(from, to)
+	require.NoError(t, err)
+	require.Equal(t, []schema.Change{
+		&schema.ModifyIndex{From: from.Indexes[0], To: to.Indexes[0], Change: schema.ChangeAttr},
+		&schema.ModifyIndex{From: from.Indexes[1], To: to.Indexes[1], Change: schema.ChangeParts},
+		&schema.ModifyForeignKey{From: from.ForeignKeys[0], To: to.ForeignKeys[0], Change: schema.ChangeAttr},
+	}, changes)
+}
+
+func TestDiff_Roles(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	var (
+		from = func() *schema.Realm {
+			users := schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "int"), schema.NewStringColumn("email", "text"))
+			r := schema.NewRealm(schema.New("public").AddTables(users))
+			readers, legacy := schema.NewRole("readers"), schema.NewRole("legacy")
+			r.AddRoles(
+				readers,
+				legacy.AddGrants(schema.NewGrant(users, "SELECT")),
+				schema.NewUser("alice").
+					AddMemberOf(readers).
+					AddGrants(
+						schema.NewGrant(users, "ALL"),
+						schema.NewGrant(r.Schemas[0], "USAGE"),
+					),
+			)
+			return r
+		}()
+		to = func() *schema.Realm {
+			users := schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "int"), schema.NewStringColumn("email", "text"))
+			r := schema.NewRealm(schema.New("public").AddTables(users))
+			readers := schema.NewRole("readers")
+			r.AddRoles(
+				readers,
+				schema.NewUser("alice").
+					AddGrants(
+						// ALL PRIVILEGES is expanded, and grants are compared by their single privileges.
+						schema.NewGrant(users, "select", "insert", "update", "delete", "truncate", "references"),
+						schema.NewGrant(r.Schemas[0], "USAGE"),
+					),
+				schema.NewUser("bob").
+					AddMemberOf(readers).
+					AddGrants(schema.NewGrant(users, "SELECT").SetColumns(users.Columns...).SetGrantOption(true)),
+			)
+			return r
+		}()
+	)
+	// Roles are not compared, unless they were inspected.
+	changes, err := drv.RealmDiff(from, to)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	changes, err = drv.RealmDiff(from, to, schema.DiffRoles())
+	require.NoError(t, err)
+	fromT, toT := from.Schemas[0].Tables[0], to.Schemas[0].Tables[0]
+	require.Equal(t, []schema.Change{
+		&schema.DropGrant{G: &schema.Grant{To: from.Roles[1], On: fromT, Privileges: []string{"SELECT"}}},
+		&schema.DropRole{R: from.Roles[1]},
+		&schema.ModifyRole{From: from.Roles[2], To: to.Roles[1]},
+		&schema.DropGrant{G: &schema.Grant{To: from.Roles[2], On: fromT, Privileges: []string{"TRIGGER"}}},
+		&schema.AddRole{R: to.Roles[2]},
+		&schema.AddGrant{G: &schema.Grant{To: to.Roles[2], On: toT, Columns: toT.Columns, Privileges: []string{"SELECT"}, GrantOption: true}},
+	}, changes)
+
+	// Roles are created after the roles they are member of.
+	editors := schema.NewRole("editors")
+	to.AddRoles(schema.NewUser("carol").AddMemberOf(editors), editors)
+	changes, err = drv.RealmDiff(from, to, schema.DiffRoles())
+	require.NoError(t, err)
+	require.Len(t, changes, 8)
+	require.Equal(t, &schema.AddRole{R: editors}, changes[6])
+	require.Equal(t, &schema.AddRole{R: to.Roles[3]}, changes[7])
+}
+
+func TestDiff_Renames(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	var (
+		from = func() *schema.Schema {
+			users := schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "int"), schema.NewStringColumn("name", "text"))
+			users.AddIndexes(schema.NewIndex("users_name").AddColumns(users.Columns[1]))
+			return schema.New("public").AddTables(users, schema.NewTable("pets").AddColumns(schema.NewIntColumn("id", "int")))
+		}()
+		to = func() *schema.Schema {
+			users := schema.NewTable("users").AddColumns(
+				schema.NewIntColumn("id", "bigint"),
+				schema.NewStringColumn("full_name", "text").AddAttrs(&schema.RenamedFrom{Name: "name"}),
+			)
+			users.AddIndexes(schema.NewIndex("users_full_name").AddColumns(users.Columns[1]))
+			return schema.New("public").AddTables(users, schema.NewTable("animals").AddColumns(schema.NewIntColumn("id", "int")))
+		}()
+	)
+	// Without resolving, only hinted renames are detected.
+	changes, err := drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+	modify := changes[0].(*schema.ModifyTable)
+	require.Len(t, modify.Changes, 4)
+	require.Equal(t, &schema.ModifyColumn{From: from.Tables[0].Columns[0], To: to.Tables[0].Columns[0], Change: schema.ChangeType}, modify.Changes[0])
+	require.Equal(t, &schema.RenameColumn{From: from.Tables[0].Columns[1], To: to.Tables[0].Columns[1]}, modify.Changes[1])
+	require.Equal(t, "name", from.Tables[0].Columns[1].Name, "current state should not be changed")
+	require.Equal(t, &schema.DropIndex{I: from.Tables[0].Indexes[0]}, modify.Changes[2])
+	require.Equal(t, &schema.AddIndex{I: to.Tables[0].Indexes[0]}, modify.Changes[3])
+	require.Equal(t, &schema.DropTable{T: from.Tables[1]}, changes[1])
+	require.Equal(t, &schema.AddTable{T: to.Tables[1]}, changes[2])
+
+	// Resolve the structurally similar candidates.
+	var asked []schema.Change
+	changes, err = drv.SchemaDiff(from, to, schema.DiffAskRename(func(c schema.Change) (bool, error) {
+		asked = append(asked, c)
+		return true, nil
+	}))
+	require.NoError(t, err)
+	require.Equal(t, []schema.Change{
+		&schema.RenameTable{From: from.Tables[1], To: to.Tables[1]},
+		&schema.RenameIndex{From: from.Tables[0].Indexes[0], To: to.Tables[0].Indexes[0]},
+	}, asked)
+	require.Len(t, changes, 2)
+	modify = changes[0].(*schema.ModifyTable)
+	require.Len(t, modify.Changes, 3)
+	require.Equal(t, &schema.RenameIndex{From: from.Tables[0].Indexes[0], To: to.Tables[0].Indexes[0]}, modify.Changes[2])
+	require.Equal(t, &schema.RenameTable{From: from.Tables[1], To: to.Tables[1]}, changes[1])
+	require.Equal(t, "pets", from.Tables[1].Name, "current state should not be changed")
+	require.Equal(t, "users_name", from.Tables[0].Indexes[0].Name, "current state should not be changed")
+
+	// Rejected candidates are diffed as drop and add.
+	changes, err = drv.SchemaDiff(from, to, schema.DiffAskRename(func(schema.Change) (bool, error) {
+		return false, nil
+	}))
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+
+	// Renames are not detected if they are skipped.
+	changes, err = drv.SchemaDiff(from, to, schema.DiffSkipChanges(&schema.RenameColumn{}))
+	require.NoError(t, err)
+	modify = changes[0].(*schema.ModifyTable)
+	require.Equal(t, &schema.DropColumn{C: from.Tables[0].Columns[1]}, modify.Changes[1])
+}
+
 func TestDefaultDiff(t *testing.T) {
 	changes, err := DefaultDiff.SchemaDiff(
 		schema.New("public").
@@ -607,3 +1009,72 @@ concurrent_index {
 	require.Equal(t, `CREATE INDEX CONCURRENTLY "users_pkey_new" ON "public"."users" ("id")`, plan.Changes[1].Cmd)
 	require.Equal(t, `DROP INDEX CONCURRENTLY "public"."users_pkey_new"`, plan.Changes[1].Reverse)
 }
+
+func TestDiff_AnnotateNotValid(t *testing.T) {
+	var cfg struct {
+		schemahcl.DefaultExtension
+	}
+	// language=hcl
+	err := schemahcl.New().EvalBytes([]byte(`
+not_valid {
+  foreign_key = true
+  check       = true
+  separate    = true
+}
+`), &cfg, nil)
+	require.NoError(t, err)
+	var (
+		users = schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "int"))
+		posts = func() *schema.Table {
+			return schema.NewTable("posts").AddColumns(schema.NewIntColumn("author_id", "int"))
+		}
+		from = schema.New("public").AddTables(users, posts())
+		to   = schema.New("public").AddTables(users, posts())
+	)
+	p := to.Tables[1]
+	p.AddForeignKeys(schema.NewForeignKey("author_fk").AddColumns(p.Columns[0]).SetRefTable(users).AddRefColumns(users.Columns[0]))
+	p.AddChecks(schema.NewCheck().SetName("positive").SetExpr("author_id > 0"))
+	changes, err := DefaultDiff.SchemaDiff(from, to, func(opts *schema.DiffOptions) { opts.Extra = cfg.DefaultExtension })
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	plan, err := DefaultPlan.PlanChanges(context.Background(), "changes", changes)
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 3)
+	require.Equal(t, `ALTER TABLE "public"."posts" ADD CONSTRAINT "positive" CHECK (author_id > 0) NOT VALID, ADD CONSTRAINT "author_fk" FOREIGN KEY ("author_id") REFERENCES "public"."users" ("id") NOT VALID`, plan.Changes[0].Cmd)
+	require.False(t, plan.Changes[0].Separate)
+	require.Equal(t, `ALTER TABLE "public"."posts" VALIDATE CONSTRAINT "positive"`, plan.Changes[1].Cmd)
+	require.True(t, plan.Changes[1].Separate)
+	require.Equal(t, `ALTER TABLE "public"."posts" VALIDATE CONSTRAINT "author_fk"`, plan.Changes[2].Cmd)
+	require.True(t, plan.Changes[2].Separate)
+	// Validating a constraint has a no-op reverse.
+	require.True(t, plan.Reversible)
+	stmts, err := plan.Changes[1].ReverseStmts()
+	require.NoError(t, err)
+	require.Empty(t, stmts)
+
+	// Validate constraints that were inspected as NOT VALID.
+	from, to = to, schema.New("public").AddTables(users, posts())
+	p = to.Tables[1]
+	p.AddForeignKeys(schema.NewForeignKey("author_fk").AddColumns(p.Columns[0]).SetRefTable(users).AddRefColumns(users.Columns[0]))
+	p.AddChecks(schema.NewCheck().SetName("positive").SetExpr("author_id > 0"))
+	from.Tables[1].ForeignKeys[0].AddAttrs(&NotValid{})
+	from.Tables[1].Attrs[0].(*schema.Check).Attrs = []schema.Attr{&NotValid{}}
+	changes, err = DefaultDiff.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.Equal(t, []schema.Change{
+		&schema.ModifyCheck{From: from.Tables[1].Attrs[0].(*schema.Check), To: p.Attrs[0].(*schema.Check)},
+		&schema.ModifyForeignKey{From: from.Tables[1].ForeignKeys[0], To: p.ForeignKeys[0], Change: schema.ChangeAttr},
+	}, changes[0].(*schema.ModifyTable).Changes)
+	plan, err = DefaultPlan.PlanChanges(context.Background(), "changes", changes)
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 2)
+	require.Equal(t, `ALTER TABLE "public"."posts" VALIDATE CONSTRAINT "positive"`, plan.Changes[0].Cmd)
+	require.Equal(t, `ALTER TABLE "public"."posts" VALIDATE CONSTRAINT "author_fk"`, plan.Changes[1].Cmd)
+	require.True(t, plan.Reversible)
+
+	// Validated constraints cannot become NOT VALID.
+	changes, err = DefaultDiff.SchemaDiff(to, from)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+}
@@ -128,8 +128,21 @@ func (d *Driver) dev() *sqlx.DevDriver {
 	return &sqlx.DevDriver{
 		Driver: d,
 		PatchObject: func(s *schema.Schema, o schema.Object) {
-			if e, ok := o.(*schema.EnumType); ok {
-				e.Schema = s
+			switch o := o.(type) {
+			case *schema.EnumType:
+				o.Schema = s
+			case *schema.Func:
+				o.Schema = s
+			case *schema.Proc:
+				o.Schema = s
+			case *Sequence:
+				o.Schema = s
+			case *Extension:
+				o.Schema = s
+			case *Domain:
+				o.Schema = s
+			case *Composite:
+				o.Schema = s
 			}
 		},
 	}
@@ -322,6 +335,16 @@ func (c *conn) supportsIndexNullsDistinct() bool {
 	return c.version >= 15_00_00
 }
 
+// supportsProcedures reports if the server supports procedures (and the pg_proc.prokind column).
+func (c *conn) supportsProcedures() bool {
+	return c.version >= 11_00_00
+}
+
+// supportsTriggerReplace reports if the server supports the CREATE OR REPLACE TRIGGER statement.
+func (c *conn) supportsTriggerReplace() bool {
+	return c.version >= 14_00_00
+}
+
 type parser struct{}
 
 // ParseURL implements the sqlclient.URLParser interface.
@@ -425,6 +448,11 @@ const (
 	TypeDateRange      = "daterange"
 	TypeDateMultiRange = "datemultirange"
 
+	// Types that are provided by common extensions.
+	TypeCIText    = "citext"
+	TypeHStore    = "hstore"
+	TypeGeography = "geography"
+
 	// PostgreSQL internal object types and their aliases.
 	typeOID           = "oid"
 	typeRegClass      = "regclass"
@@ -457,9 +485,28 @@ const (
 	GeneratedTypeByDefault = "BY_DEFAULT" // BY DEFAULT.
 )
 
+// List of function volatility categories.
+const (
+	FuncVolatilityImmutable = "IMMUTABLE"
+	FuncVolatilityStable    = "STABLE"
+	FuncVolatilityVolatile  = "VOLATILE" // Default.
+)
+
 // List of PARTITION KEY types.
 const (
 	PartitionTypeRange = "RANGE"
 	PartitionTypeList  = "LIST"
 	PartitionTypeHash  = "HASH"
 )
+
+// List of row-level security policy types and commands.
+const (
+	PolicyAsPermissive  = "PERMISSIVE" // Default.
+	PolicyAsRestrictive = "RESTRICTIVE"
+	PolicyForAll        = "ALL" // Default.
+	PolicyForSelect     = "SELECT"
+	PolicyForInsert     = "INSERT"
+	PolicyForUpdate     = "UPDATE"
+	PolicyForDelete     = "DELETE"
+	policyToPublic      = "PUBLIC"
+)
@@ -8,33 +8,1674 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
 
 	"ariga.io/atlas/schemahcl"
+	"ariga.io/atlas/sql/internal/sqlx"
+	"ariga.io/atlas/sql/migrate"
 	"ariga.io/atlas/sql/schema"
 )
 
 var specOptions []schemahcl.Option
 
-func (*inspect) inspectViews(context.Context, *schema.Realm, *schema.InspectOptions) error {
-	return nil // unimplemented.
+// inspectViews queries and appends the views of the given realm, including
+// their columns and the tables and views they depend on.
+func (i *inspect) inspectViews(ctx context.Context, r *schema.Realm, _ *schema.InspectOptions) error {
+	if err := i.views(ctx, r); err != nil {
+		return err
+	}
+	if err := i.matViews(ctx, r); err != nil {
+		return err
+	}
+	for _, s := range r.Schemas {
+		if len(s.Views) == 0 {
+			continue
+		}
+		scope := queryScope{
+			exec: i.queryViews,
+			append: func(s *schema.Schema, view string, column *schema.Column) error {
+				v, ok := s.View(view)
+				if !ok {
+					return fmt.Errorf("view %q was not found in schema", view)
+				}
+				v.AddColumns(column)
+				return nil
+			},
+		}
+		if err := i.columns(ctx, s, scope); err != nil {
+			return err
+		}
+		if err := i.matViewColumns(ctx, s, scope); err != nil {
+			return err
+		}
+		if err := i.matViewIndexes(ctx, s); err != nil {
+			return err
+		}
+	}
+	return i.viewDeps(ctx, r)
 }
 
-func (*state) addView(*schema.AddView) error {
-	return nil // unimplemented.
+// views queries and appends the views of the given realm.
+func (i *inspect) views(ctx context.Context, r *schema.Realm) error {
+	args := make([]any, 0, len(r.Schemas))
+	for _, s := range r.Schemas {
+		args = append(args, s.Name)
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(viewsQuery, nArgs(0, len(r.Schemas))), args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying views: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var vSchema, name, def, check, comment sql.NullString
+		if err := rows.Scan(&vSchema, &name, &def, &check, &comment); err != nil {
+			return fmt.Errorf("postgres: scanning view information: %w", err)
+		}
+		if !sqlx.ValidString(vSchema) || !sqlx.ValidString(name) {
+			return fmt.Errorf("postgres: invalid schema or view name: %q.%q", vSchema.String, name.String)
+		}
+		s, ok := r.Schema(vSchema.String)
+		if !ok {
+			return fmt.Errorf("postgres: schema %q was not found in realm", vSchema.String)
+		}
+		v := schema.NewView(name.String, def.String)
+		s.AddViews(v)
+		if c := strings.ToUpper(check.String); c == schema.ViewCheckOptionLocal || c == schema.ViewCheckOptionCascaded {
+			v.SetCheckOption(c)
+		}
+		if sqlx.ValidString(comment) {
+			v.SetComment(comment.String)
+		}
+	}
+	return rows.Close()
 }
 
-func (*state) dropView(*schema.DropView) error {
-	return nil // unimplemented.
+// matViews queries and appends the materialized views of the given realm.
+func (i *inspect) matViews(ctx context.Context, r *schema.Realm) error {
+	args := make([]any, 0, len(r.Schemas))
+	for _, s := range r.Schemas {
+		args = append(args, s.Name)
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(matViewsQuery, nArgs(0, len(r.Schemas))), args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying materialized views: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			populated                                   bool
+			vSchema, name, def, space, options, comment sql.NullString
+		)
+		if err := rows.Scan(&vSchema, &name, &def, &populated, &space, &options, &comment); err != nil {
+			return fmt.Errorf("postgres: scanning materialized view information: %w", err)
+		}
+		if !sqlx.ValidString(vSchema) || !sqlx.ValidString(name) {
+			return fmt.Errorf("postgres: invalid schema or materialized view name: %q.%q", vSchema.String, name.String)
+		}
+		s, ok := r.Schema(vSchema.String)
+		if !ok {
+			return fmt.Errorf("postgres: schema %q was not found in realm", vSchema.String)
+		}
+		v := schema.NewMaterializedView(name.String, def.String)
+		s.AddViews(v)
+		if !populated {
+			v.AddAttrs(&WithNoData{})
+		}
+		if sqlx.ValidString(space) {
+			v.AddAttrs(&Tablespace{N: space.String})
+		}
+		if sqlx.ValidString(options) {
+			p, err := newStorageParams(options.String)
+			if err != nil {
+				return err
+			}
+			v.AddAttrs(p)
+		}
+		if sqlx.ValidString(comment) {
+			v.SetComment(comment.String)
+		}
+	}
+	return rows.Close()
 }
 
-func (*state) modifyView(*schema.ModifyView) error {
-	return nil // unimplemented.
+// matViewColumns queries and appends the columns of the materialized views in the schema.
+// Unlike regular views, materialized views are not listed in INFORMATION_SCHEMA.COLUMNS.
+func (i *inspect) matViewColumns(ctx context.Context, s *schema.Schema, scope queryScope) error {
+	if !hasMatViews(s) {
+		return nil
+	}
+	rows, err := i.queryMatViews(ctx, matViewColumnsQuery, s)
+	if err != nil {
+		return fmt.Errorf("postgres: querying schema %q materialized view columns: %w", s.Name, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := i.addColumn(s, rows, scope); err != nil {
+			return fmt.Errorf("postgres: %w", err)
+		}
+	}
+	return rows.Close()
 }
 
-func (*state) renameView(*schema.RenameView) {
-	// unimplemented.
+// matViewIndexes queries and appends the indexes of the materialized views in the schema.
+func (i *inspect) matViewIndexes(ctx context.Context, s *schema.Schema) error {
+	if !hasMatViews(s) || i.crdb {
+		return nil
+	}
+	rows, err := i.queryMatViews(ctx, i.indexesQuery(), s)
+	if err != nil {
+		return fmt.Errorf("postgres: querying schema %q materialized view indexes: %w", s.Name, err)
+	}
+	defer rows.Close()
+	if err := i.addIndexes(s, rows); err != nil {
+		return err
+	}
+	return rows.Err()
 }
 
-func (d *diff) ViewAttrChanged(_, _ *schema.View) bool {
-	return false // unimplemented.
+// queryMatViews executes the given query on the materialized views of the schema.
+func (i *inspect) queryMatViews(ctx context.Context, query string, s *schema.Schema) (*sql.Rows, error) {
+	args := []any{s.Name}
+	for _, v := range s.Views {
+		if v.Materialized() {
+			args = append(args, v.Name)
+		}
+	}
+	return i.QueryContext(ctx, fmt.Sprintf(query, nArgs(1, len(args)-1)), args...)
 }
+
+// hasMatViews reports if the schema contains materialized views.
+func hasMatViews(s *schema.Schema) bool {
+	for _, v := range s.Views {
+		if v.Materialized() {
+			return true
+		}
+	}
+	return false
+}
+
+// newStorageParams parses and returns the storage parameters of a materialized view.
+func newStorageParams(opts string) (*StorageParams, error) {
+	params := &StorageParams{}
+	for _, p := range strings.Split(strings.Trim(opts, "{}"), ",") {
+		kv := strings.SplitN(p, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("postgres: invalid storage parameter: %s", p)
+		}
+		params.Params = append(params.Params, struct{ N, V string }{N: kv[0], V: kv[1]})
+	}
+	return params, nil
+}
+
+// viewDeps queries and links the tables and views each view depends on.
+// Objects that reside in schemas that were not inspected are ignored.
+func (i *inspect) viewDeps(ctx context.Context, r *schema.Realm) error {
+	var (
+		args = make([]any, 0, len(r.Schemas))
+		has  bool
+	)
+	for _, s := range r.Schemas {
+		args = append(args, s.Name)
+		has = has || len(s.Views) > 0
+	}
+	if !has {
+		return nil
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(viewDepsQuery, nArgs(0, len(r.Schemas))), args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying view dependencies: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var vSchema, vName, refSchema, refName, refKind string
+		if err := rows.Scan(&vSchema, &vName, &refSchema, &refName, &refKind); err != nil {
+			return fmt.Errorf("postgres: scanning view dependencies: %w", err)
+		}
+		s, ok := r.Schema(vSchema)
+		if !ok {
+			continue
+		}
+		v, ok := s.View(vName)
+		if !ok {
+			continue
+		}
+		rs, ok := r.Schema(refSchema)
+		if !ok {
+			continue
+		}
+		switch refKind {
+		case "v", "m":
+			if dep, ok := rs.View(refName); ok {
+				v.AddDeps(dep)
+			}
+		case "r", "p":
+			if dep, ok := rs.Table(refName); ok {
+				v.AddDeps(dep)
+			}
+		}
+	}
+	return rows.Close()
+}
+
+// queryViews executes the given query on the views of the schema.
+func (i *inspect) queryViews(ctx context.Context, query string, s *schema.Schema) (*sql.Rows, error) {
+	args := []any{s.Name}
+	for _, v := range s.Views {
+		args = append(args, v.Name)
+	}
+	return i.QueryContext(ctx, fmt.Sprintf(query, nArgs(1, len(s.Views))), args...)
+}
+
+// addView builds and executes the query for creating a view.
+func (s *state) addView(add *schema.AddView) error {
+	s.append(&migrate.Change{
+		Cmd:     s.createView(add.V, "CREATE VIEW"),
+		Source:  add,
+		Comment: fmt.Sprintf("create %q view", add.V.Name),
+		Reverse: s.Build("DROP", viewKind(add.V)).View(add.V).String(),
+	})
+	if err := s.addViewIndexes(add.V, add.V.Indexes...); err != nil {
+		return err
+	}
+	if c := (schema.Comment{}); sqlx.Has(add.V.Attrs, &c) && c.Text != "" {
+		s.append(s.viewComment(add.V, c.Text, ""))
+	}
+	return nil
+}
+
+// dropView builds and executes the query for dropping a view.
+func (s *state) dropView(drop *schema.DropView) error {
+	b := s.Build("DROP", viewKind(drop.V))
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	b.View(drop.V)
+	if sqlx.Has(drop.Extra, &Cascade{}) {
+		b.P("CASCADE")
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop %q view", drop.V.Name),
+		Reverse: s.createView(drop.V, "CREATE VIEW"),
+	})
+	return nil
+}
+
+// viewRecreated reports if the modified view is dropped and created instead of being
+// modified in place. That is, if PostgreSQL does not allow replacing it, or if it depends
+// on one of the given tables, whose columns cannot be dropped or altered while the view
+// depends on them.
+func (s *state) viewRecreated(modify *schema.ModifyView, altered []*schema.Table) bool {
+	from, to := modify.From, modify.To
+	if from.Materialized() || to.Materialized() {
+		return from.Materialized() != to.Materialized() || sqlx.TrimViewExtra(from.Def) != sqlx.TrimViewExtra(to.Def)
+	}
+	if sqlx.TrimViewExtra(from.Def) == sqlx.TrimViewExtra(to.Def) && viewCheckOption(from) == viewCheckOption(to) {
+		return false
+	}
+	if !s.viewReplaceable(from, to) {
+		return true
+	}
+	for _, d := range from.Deps {
+		t, ok := d.(*schema.Table)
+		if !ok {
+			continue
+		}
+		for _, a := range altered {
+			if a.Name == t.Name && (a.Schema == nil || t.Schema == nil || a.Schema.Name == t.Schema.Name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// alteredTables returns the tables whose columns are dropped or modified by the changes.
+func alteredTables(changes []schema.Change) []*schema.Table {
+	var altered []*schema.Table
+	for _, c := range changes {
+		if m, ok := c.(*schema.ModifyTable); ok && columnsAltered(m) {
+			altered = append(altered, m.T)
+		}
+	}
+	return altered
+}
+
+// columnsAltered reports if the table modification drops or modifies columns.
+func columnsAltered(m *schema.ModifyTable) bool {
+	for _, c := range m.Changes {
+		switch c.(type) {
+		case *schema.DropColumn, *schema.ModifyColumn:
+			return true
+		}
+	}
+	return false
+}
+
+// dropModifiedView builds and executes the query for dropping a recreated view.
+// It is planned before the table changes, and the view is created after them.
+func (s *state) dropModifiedView(modify *schema.ModifyView) {
+	from := modify.From
+	s.append(&migrate.Change{
+		Cmd:     s.Build("DROP", viewKind(from)).View(from).String(),
+		Source:  modify,
+		Comment: fmt.Sprintf("drop %q view", from.Name),
+		Reverse: s.createView(from, "CREATE VIEW"),
+	})
+}
+
+// modifyView builds and executes the queries for modifying a view. The view is replaced
+// in place if PostgreSQL allows it, or created if it was recreated (i.e., dropped before
+// the table changes by dropModifiedView).
+func (s *state) modifyView(modify *schema.ModifyView, recreated bool) error {
+	from, to := modify.From, modify.To
+	if recreated {
+		return s.recreateView(modify)
+	}
+	if from.Materialized() || to.Materialized() {
+		return s.modifyMatView(modify)
+	}
+	if sqlx.TrimViewExtra(from.Def) != sqlx.TrimViewExtra(to.Def) || viewCheckOption(from) != viewCheckOption(to) {
+		s.append(&migrate.Change{
+			Cmd:     s.createView(to, "CREATE OR REPLACE VIEW"),
+			Source:  modify,
+			Comment: fmt.Sprintf("modify %q view", to.Name),
+			Reverse: func() any {
+				// Columns cannot be dropped from views.
+				if len(from.Columns) < len(to.Columns) {
+					return nil
+				}
+				return s.createView(from, "CREATE OR REPLACE VIEW")
+			}(),
+		})
+	}
+	if change := sqlx.CommentDiff(from.Attrs, to.Attrs); change != nil {
+		fromC, toC, err := commentChange(change)
+		if err != nil {
+			return err
+		}
+		s.append(s.viewComment(to, toC, fromC))
+	}
+	return nil
+}
+
+// recreateView builds and executes the queries for creating a view that was dropped
+// by dropModifiedView, along with its indexes, comment and the triggers defined on it.
+func (s *state) recreateView(modify *schema.ModifyView) error {
+	from, to := modify.From, modify.To
+	s.append(&migrate.Change{
+		Cmd:     s.createView(to, "CREATE VIEW"),
+		Source:  modify,
+		Comment: fmt.Sprintf("create %q view", to.Name),
+		Reverse: s.Build("DROP", viewKind(to)).View(to).String(),
+	})
+	if err := s.addViewIndexes(to, to.Indexes...); err != nil {
+		return err
+	}
+	// Comments are dropped with the view.
+	if c := (schema.Comment{}); sqlx.Has(to.Attrs, &c) && c.Text != "" {
+		s.append(s.viewComment(to, c.Text, ""))
+	}
+	// Triggers are dropped with the view, and therefore, the ones that
+	// were not dropped by the plan are recreated before being modified.
+	for _, t := range from.Triggers {
+		if _, ok := to.Trigger(t.Name); ok {
+			if err := s.addTrigger(&schema.AddTrigger{T: t}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// modifyMatView builds and executes the queries for modifying a materialized view
+// in place. Materialized views cannot be replaced, and therefore, they are dropped
+// and created (along with their indexes) if their definition was changed, or if a
+// view was changed to a materialized view or vice versa. See viewRecreated.
+func (s *state) modifyMatView(modify *schema.ModifyView) error {
+	from, to := modify.From, modify.To
+	if t1, t2 := viewTablespace(from), viewTablespace(to); t1 != t2 {
+		b := s.Build("ALTER MATERIALIZED VIEW").View(to).P("SET TABLESPACE")
+		s.append(&migrate.Change{
+			Cmd:     b.Clone().Ident(t2).String(),
+			Source:  modify,
+			Comment: fmt.Sprintf("set tablespace of %q materialized view", to.Name),
+			Reverse: b.Clone().Ident(t1).String(),
+		})
+	}
+	if storageParamsChanged(from, to) {
+		s.append(&migrate.Change{
+			Cmd:     s.alterStorageParams(to, from, to),
+			Source:  modify,
+			Comment: fmt.Sprintf("set storage parameters of %q materialized view", to.Name),
+			Reverse: s.alterStorageParams(to, to, from),
+		})
+	}
+	for _, c := range modify.Changes {
+		switch c := c.(type) {
+		case *schema.AddIndex:
+			if err := s.addViewIndexes(to, c.I); err != nil {
+				return err
+			}
+		case *schema.DropIndex:
+			if err := s.dropViewIndex(from, c.I); err != nil {
+				return err
+			}
+		case *schema.ModifyIndex:
+			if err := s.dropViewIndex(from, c.From); err != nil {
+				return err
+			}
+			if err := s.addViewIndexes(to, c.To); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unsupported materialized view change: %T", c)
+		}
+	}
+	if change := sqlx.CommentDiff(from.Attrs, to.Attrs); change != nil {
+		fromC, toC, err := commentChange(change)
+		if err != nil {
+			return err
+		}
+		s.append(s.viewComment(to, toC, fromC))
+	}
+	return nil
+}
+
+// addViewIndexes builds and appends the queries for creating
+// the given indexes on the materialized view.
+func (s *state) addViewIndexes(v *schema.View, indexes ...*schema.Index) error {
+	for _, idx := range indexes {
+		cmd, err := s.createViewIndexCmd(v, idx)
+		if err != nil {
+			return err
+		}
+		s.append(&migrate.Change{
+			Cmd:     cmd,
+			Comment: fmt.Sprintf("create index %q to materialized view: %q", idx.Name, v.Name),
+			Reverse: s.dropViewIndexCmd(v, idx),
+		})
+		if c := (schema.Comment{}); sqlx.Has(idx.Attrs, &c) && c.Text != "" {
+			b := s.Build("COMMENT ON INDEX").Ident(idx.Name).P("IS")
+			s.append(&migrate.Change{
+				Cmd:     b.Clone().P(quote(c.Text)).String(),
+				Comment: fmt.Sprintf("set comment to index: %q on materialized view: %q", idx.Name, v.Name),
+				Reverse: b.Clone().P(quote("")).String(),
+			})
+		}
+	}
+	return nil
+}
+
+// dropViewIndex builds and appends the query for dropping
+// the given index from the materialized view.
+func (s *state) dropViewIndex(v *schema.View, idx *schema.Index) error {
+	reverse, err := s.createViewIndexCmd(v, idx)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     s.dropViewIndexCmd(v, idx),
+		Comment: fmt.Sprintf("drop index %q from materialized view: %q", idx.Name, v.Name),
+		Reverse: reverse,
+	})
+	return nil
+}
+
+// createViewIndexCmd returns the statement for creating
+// the given index on the materialized view.
+func (s *state) createViewIndexCmd(v *schema.View, idx *schema.Index) (string, error) {
+	b := s.Build("CREATE")
+	if idx.Unique {
+		b.P("UNIQUE")
+	}
+	b.P("INDEX")
+	if idx.Name != "" {
+		b.Ident(idx.Name)
+	}
+	b.P("ON").View(v)
+	if err := s.index(b, idx); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// dropViewIndexCmd returns the statement for dropping the index of a materialized
+// view. Like table indexes, the index is qualified with the schema of the view.
+func (s *state) dropViewIndexCmd(v *schema.View, idx *schema.Index) string {
+	b := s.Build("DROP INDEX")
+	if v.Schema != nil {
+		b.WriteString(s.schemaPrefix(v.Schema))
+	}
+	return b.Ident(idx.Name).String()
+}
+
+// alterStorageParams returns the statement for changing the storage
+// parameters of the materialized view from one state to the other.
+func (s *state) alterStorageParams(v, from, to *schema.View) string {
+	var (
+		set   []string
+		reset []string
+		p1    = storageParams(from)
+		p2    = storageParams(to)
+	)
+	for _, p := range p2 {
+		if v, ok := paramValue(p1, p.N); !ok || v != p.V {
+			set = append(set, fmt.Sprintf("%s = %s", p.N, p.V))
+		}
+	}
+	for _, p := range p1 {
+		if _, ok := paramValue(p2, p.N); !ok {
+			reset = append(reset, p.N)
+		}
+	}
+	var actions []string
+	if len(set) > 0 {
+		actions = append(actions, fmt.Sprintf("SET (%s)", strings.Join(set, ", ")))
+	}
+	if len(reset) > 0 {
+		actions = append(actions, fmt.Sprintf("RESET (%s)", strings.Join(reset, ", ")))
+	}
+	return s.Build("ALTER MATERIALIZED VIEW").View(v).P(strings.Join(actions, ", ")).String()
+}
+
+// renameView builds and executes the query for renaming a view.
+func (s *state) renameView(c *schema.RenameView) {
+	s.append(&migrate.Change{
+		Source:  c,
+		Comment: fmt.Sprintf("rename a view from %q to %q", c.From.Name, c.To.Name),
+		Cmd:     s.Build("ALTER", viewKind(c.From)).View(c.From).P("RENAME TO").Ident(c.To.Name).String(),
+		Reverse: s.Build("ALTER", viewKind(c.To)).View(c.To).P("RENAME TO").Ident(c.From.Name).String(),
+	})
+}
+
+// createView returns the statement for creating the view using the given command.
+// Materialized views cannot be replaced, and are always created using the CREATE
+// MATERIALIZED VIEW command, with their storage parameters and tablespace.
+func (s *state) createView(v *schema.View, cmd string) string {
+	if v.Materialized() {
+		b := s.Build("CREATE MATERIALIZED VIEW").View(v)
+		if ps := storageParams(v); len(ps) > 0 {
+			b.P("WITH").Wrap(func(b *sqlx.Builder) {
+				b.MapComma(ps, func(i int, b *sqlx.Builder) {
+					b.P(fmt.Sprintf("%s = %s", ps[i].N, ps[i].V))
+				})
+			})
+		}
+		if t := (Tablespace{}); sqlx.Has(v.Attrs, &t) && t.N != "" {
+			b.P("TABLESPACE").Ident(t.N)
+		}
+		b.P("AS", sqlx.TrimViewExtra(v.Def))
+		if sqlx.Has(v.Attrs, &WithNoData{}) {
+			b.P("WITH NO DATA")
+		}
+		return b.String()
+	}
+	b := s.Build(cmd).View(v).P("AS", sqlx.TrimViewExtra(v.Def))
+	if c := viewCheckOption(v); c != schema.ViewCheckOptionNone {
+		b.P("WITH", c, "CHECK OPTION")
+	}
+	return b.String()
+}
+
+func (s *state) viewComment(v *schema.View, to, from string) *migrate.Change {
+	b := s.Build("COMMENT ON", viewKind(v)).View(v).P("IS")
+	return &migrate.Change{
+		Cmd:     b.Clone().P(quote(to)).String(),
+		Comment: fmt.Sprintf("set comment to view: %q", v.Name),
+		Reverse: b.Clone().P(quote(from)).String(),
+	}
+}
+
+// viewReplaceable reports if the view can be modified using CREATE OR REPLACE VIEW.
+// PostgreSQL requires that the new query generates the same columns that were
+// generated by the existing query (i.e., the same names in the same order and with
+// the same data types), but it may add additional columns to the end of the list.
+func (s *state) viewReplaceable(from, to *schema.View) bool {
+	// Without column information, the view is assumed
+	// to be replaceable and the database decides.
+	if len(from.Columns) == 0 || len(to.Columns) == 0 {
+		return true
+	}
+	if len(from.Columns) > len(to.Columns) {
+		return false
+	}
+	for i, c1 := range from.Columns {
+		c2 := to.Columns[i]
+		if c1.Name != c2.Name {
+			return false
+		}
+		t1, err1 := s.formatType(c1)
+		t2, err2 := s.formatType(c2)
+		if err1 != nil || err2 != nil || t1 != t2 {
+			return false
+		}
+	}
+	return true
+}
+
+// ViewAttrChanged reports if the view attributes were changed.
+func (d *diff) ViewAttrChanged(from, to *schema.View) bool {
+	return viewCheckOption(from) != viewCheckOption(to) ||
+		from.Materialized() != to.Materialized() ||
+		viewTablespace(from) != viewTablespace(to) ||
+		storageParamsChanged(from, to) ||
+		sqlx.CommentChange(from.Attrs, to.Attrs) != schema.NoChange
+}
+
+// viewKind returns the kind of the view as used in DDL statements.
+func viewKind(v *schema.View) string {
+	if v.Materialized() {
+		return "MATERIALIZED VIEW"
+	}
+	return "VIEW"
+}
+
+// viewTablespace returns the tablespace of the materialized view, or
+// the default tablespace (pg_default) if it was not set explicitly.
+func viewTablespace(v *schema.View) string {
+	if t := (Tablespace{}); sqlx.Has(v.Attrs, &t) && t.N != "" {
+		return t.N
+	}
+	return "pg_default"
+}
+
+// storageParams returns the storage parameters of the materialized view.
+func storageParams(v *schema.View) []struct{ N, V string } {
+	if p := (StorageParams{}); sqlx.Has(v.Attrs, &p) {
+		return p.Params
+	}
+	return nil
+}
+
+// storageParamsChanged reports if the storage parameters of the
+// materialized views were changed, regardless of their order.
+func storageParamsChanged(from, to *schema.View) bool {
+	p1, p2 := storageParams(from), storageParams(to)
+	if len(p1) != len(p2) {
+		return true
+	}
+	for _, p := range p1 {
+		if v, ok := paramValue(p2, p.N); !ok || !strings.EqualFold(v, p.V) {
+			return true
+		}
+	}
+	return false
+}
+
+// paramValue returns the value of the named storage parameter.
+func paramValue(ps []struct{ N, V string }, name string) (string, bool) {
+	for _, p := range ps {
+		if strings.EqualFold(p.N, name) {
+			return p.V, true
+		}
+	}
+	return "", false
+}
+
+// viewCheckOption returns the check option of the view, or NONE if not set.
+func viewCheckOption(v *schema.View) string {
+	if c := (schema.ViewCheckOption{}); sqlx.Has(v.Attrs, &c) && c.V != "" {
+		return strings.ToUpper(c.V)
+	}
+	return schema.ViewCheckOptionNone
+}
+
+// inspectTriggers queries and appends the triggers of the tables
+// and views in the given realm. Internal triggers are ignored.
+func (i *inspect) inspectTriggers(ctx context.Context, r *schema.Realm, _ *schema.InspectOptions) error {
+	var (
+		args = make([]any, 0, len(r.Schemas))
+		has  bool
+	)
+	for _, s := range r.Schemas {
+		args = append(args, s.Name)
+		has = has || len(s.Tables) > 0 || len(s.Views) > 0
+	}
+	// CockroachDB does not support triggers.
+	if !has || i.crdb {
+		return nil
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(triggersQuery, nArgs(0, len(r.Schemas))), args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying triggers: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			typ                             int64
+			tSchema, tName, kind, name, def string
+			columns, comment                sql.NullString
+		)
+		if err := rows.Scan(&tSchema, &tName, &kind, &name, &typ, &columns, &def, &comment); err != nil {
+			return fmt.Errorf("postgres: scanning trigger information: %w", err)
+		}
+		s, ok := r.Schema(tSchema)
+		if !ok {
+			return fmt.Errorf("postgres: schema %q was not found in realm", tSchema)
+		}
+		t := schema.NewTrigger(name).SetFor(schema.TriggerForStmt)
+		if typ&triggerTypeRow != 0 {
+			t.SetFor(schema.TriggerForRow)
+		}
+		switch {
+		case typ&triggerTypeBefore != 0:
+			t.SetActionTime(schema.TriggerTimeBefore)
+		case typ&triggerTypeInstead != 0:
+			t.SetActionTime(schema.TriggerTimeInstead)
+		default:
+			t.SetActionTime(schema.TriggerTimeAfter)
+		}
+		if m := reTriggerBody.FindStringSubmatch(def); len(m) == 2 {
+			t.SetBody(m[1])
+		}
+		if m := reTriggerWhen.FindStringSubmatch(def); len(m) == 2 {
+			t.SetWhen(m[1])
+		}
+		if sqlx.ValidString(comment) {
+			schema.ReplaceOrAppend(&t.Attrs, &schema.Comment{Text: comment.String})
+		}
+		// Triggers of tables or views that were not inspected are ignored.
+		if kind == "v" {
+			v, ok := s.View(tName)
+			if !ok {
+				continue
+			}
+			t.Events = triggerEvents(typ, nil)
+			v.AddTriggers(t)
+			continue
+		}
+		tt, ok := s.Table(tName)
+		if !ok {
+			continue
+		}
+		var update []*schema.Column
+		if sqlx.ValidString(columns) {
+			for _, name := range strings.Split(columns.String, ",") {
+				c, ok := tt.Column(name)
+				if !ok {
+					return fmt.Errorf("postgres: column %q of trigger %q was not found in table %q", name, t.Name, tt.Name)
+				}
+				update = append(update, c)
+			}
+		}
+		t.Events = triggerEvents(typ, update)
+		tt.AddTriggers(t)
+	}
+	return rows.Close()
+}
+
+// Trigger type bits, as defined in pg_trigger.tgtype.
+const (
+	triggerTypeRow      = 1 << 0
+	triggerTypeBefore   = 1 << 1
+	triggerTypeInsert   = 1 << 2
+	triggerTypeDelete   = 1 << 3
+	triggerTypeUpdate   = 1 << 4
+	triggerTypeTruncate = 1 << 5
+	triggerTypeInstead  = 1 << 6
+)
+
+var (
+	reTriggerBody = regexp.MustCompile(`(?is)\s(EXECUTE\s+(?:FUNCTION|PROCEDURE)\s+.+)$`)
+	reTriggerWhen = regexp.MustCompile(`(?is)\sFOR\s+EACH\s+(?:ROW|STATEMENT)\s+WHEN\s+\((.+)\)\s+EXECUTE\s+(?:FUNCTION|PROCEDURE)\s`)
+)
+
+// triggerEvents returns the trigger events encoded in the trigger type, in
+// the same order they are printed by PostgreSQL. The update columns are set
+// to the UPDATE event, if exists.
+func triggerEvents(typ int64, update []*schema.Column) []schema.TriggerEvent {
+	var events []schema.TriggerEvent
+	if typ&triggerTypeInsert != 0 {
+		events = append(events, schema.TriggerEventInsert)
+	}
+	if typ&triggerTypeDelete != 0 {
+		events = append(events, schema.TriggerEventDelete)
+	}
+	if typ&triggerTypeUpdate != 0 {
+		events = append(events, schema.TriggerEventUpdateOf(update...))
+	}
+	if typ&triggerTypeTruncate != 0 {
+		events = append(events, schema.TriggerEventTruncate)
+	}
+	return events
+}
+
+// addTrigger builds and executes the query for creating a trigger.
+func (s *state) addTrigger(add *schema.AddTrigger) error {
+	create, err := s.createTrigger(add.T, "CREATE TRIGGER")
+	if err != nil {
+		return err
+	}
+	drop, err := s.dropTriggerCmd(add.T, false)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     create,
+		Source:  add,
+		Comment: fmt.Sprintf("create %q trigger", add.T.Name),
+		Reverse: drop,
+	})
+	if c := (schema.Comment{}); sqlx.Has(add.T.Attrs, &c) && c.Text != "" {
+		change, err := s.triggerComment(add.T, c.Text, "")
+		if err != nil {
+			return err
+		}
+		s.append(change)
+	}
+	return nil
+}
+
+// dropTrigger builds and executes the query for dropping a trigger.
+func (s *state) dropTrigger(drop *schema.DropTrigger) error {
+	cmd, err := s.dropTriggerCmd(drop.T, sqlx.Has(drop.Extra, &schema.IfExists{}))
+	if err != nil {
+		return err
+	}
+	create, err := s.createTrigger(drop.T, "CREATE TRIGGER")
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     cmd,
+		Source:  drop,
+		Comment: fmt.Sprintf("drop %q trigger", drop.T.Name),
+		Reverse: create,
+	})
+	return nil
+}
+
+// modifyTrigger builds and executes the queries for modifying a trigger. Triggers
+// are replaced in place on PostgreSQL 14 and above, or dropped and created otherwise.
+func (s *state) modifyTrigger(modify *schema.ModifyTrigger) error {
+	from, to := modify.From, modify.To
+	if sqlx.TriggerChanged(from, to) {
+		if s.supportsTriggerReplace() {
+			create, err := s.createTrigger(to, "CREATE OR REPLACE TRIGGER")
+			if err != nil {
+				return err
+			}
+			reverse, err := s.createTrigger(from, "CREATE OR REPLACE TRIGGER")
+			if err != nil {
+				return err
+			}
+			s.append(&migrate.Change{
+				Cmd:     create,
+				Source:  modify,
+				Comment: fmt.Sprintf("modify %q trigger", to.Name),
+				Reverse: reverse,
+			})
+		} else {
+			if err := s.dropTrigger(&schema.DropTrigger{T: from}); err != nil {
+				return err
+			}
+			if err := s.addTrigger(&schema.AddTrigger{T: to}); err != nil {
+				return err
+			}
+			// Comments are dropped with the trigger.
+			return nil
+		}
+	}
+	if change := sqlx.CommentDiff(from.Attrs, to.Attrs); change != nil {
+		fromC, toC, err := commentChange(change)
+		if err != nil {
+			return err
+		}
+		c, err := s.triggerComment(to, toC, fromC)
+		if err != nil {
+			return err
+		}
+		s.append(c)
+	}
+	return nil
+}
+
+// createTrigger returns the statement for creating the trigger using the given command.
+func (s *state) createTrigger(t *schema.Trigger, cmd string) (string, error) {
+	if t.Body == "" {
+		return "", fmt.Errorf("postgres: missing body (EXECUTE clause) for trigger %q", t.Name)
+	}
+	b := s.Build(cmd).Ident(t.Name).P(string(t.ActionTime))
+	for i, e := range t.Events {
+		if i > 0 {
+			b.P("OR")
+		}
+		b.P(e.Name)
+		if len(e.Columns) > 0 {
+			b.P("OF").MapComma(e.Columns, func(i int, b *sqlx.Builder) {
+				b.Ident(e.Columns[i].Name)
+			})
+		}
+	}
+	b.P("ON")
+	if err := triggerOn(b, t); err != nil {
+		return "", err
+	}
+	if t.For != "" {
+		b.P("FOR EACH", string(t.For))
+	}
+	if t.When != "" {
+		b.P("WHEN", sqlx.MayWrap(t.When))
+	}
+	return b.P(t.Body).String(), nil
+}
+
+// dropTriggerCmd returns the statement for dropping the trigger.
+func (s *state) dropTriggerCmd(t *schema.Trigger, ifExists bool) (string, error) {
+	b := s.Build("DROP TRIGGER")
+	if ifExists {
+		b.P("IF EXISTS")
+	}
+	b.Ident(t.Name).P("ON")
+	if err := triggerOn(b, t); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func (s *state) triggerComment(t *schema.Trigger, to, from string) (*migrate.Change, error) {
+	b := s.Build("COMMENT ON TRIGGER").Ident(t.Name).P("ON")
+	if err := triggerOn(b, t); err != nil {
+		return nil, err
+	}
+	b.P("IS")
+	return &migrate.Change{
+		Cmd:     b.Clone().P(quote(to)).String(),
+		Comment: fmt.Sprintf("set comment to trigger: %q", t.Name),
+		Reverse: b.Clone().P(quote(from)).String(),
+	}, nil
+}
+
+// triggerOn writes the table or view the trigger is defined on.
+func triggerOn(b *sqlx.Builder, t *schema.Trigger) error {
+	switch {
+	case t.Table != nil:
+		b.Table(t.Table)
+	case t.View != nil:
+		b.View(t.View)
+	default:
+		return fmt.Errorf("postgres: missing table or view for trigger %q", t.Name)
+	}
+	return nil
+}
+
+// inspectFuncs queries and appends the functions and procedures of the given realm.
+// Aggregate and window functions, functions implemented in C (or internally), and
+// functions that belong to extensions are ignored.
+func (i *inspect) inspectFuncs(ctx context.Context, r *schema.Realm, _ *schema.InspectOptions) error {
+	// CockroachDB does not support user-defined functions in all of its supported versions.
+	if i.crdb || len(r.Schemas) == 0 {
+		return nil
+	}
+	args := make([]any, 0, len(r.Schemas))
+	for _, s := range r.Schemas {
+		args = append(args, s.Name)
+	}
+	kind := "CASE WHEN p.proisagg THEN 'a' WHEN p.proiswindow THEN 'w' ELSE 'f' END"
+	if i.supportsProcedures() {
+		kind = "p.prokind"
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(funcsQuery, kind, nArgs(0, len(r.Schemas))), args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying functions: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			fSchema, name, kind, lang, volatile, body string
+			result, fArgs, comment                    sql.NullString
+		)
+		if err := rows.Scan(&fSchema, &name, &kind, &lang, &volatile, &result, &body, &fArgs, &comment); err != nil {
+			return fmt.Errorf("postgres: scanning function information: %w", err)
+		}
+		s, ok := r.Schema(fSchema)
+		if !ok {
+			return fmt.Errorf("postgres: schema %q was not found in realm", fSchema)
+		}
+		args, err := funcArgs(s, name, fArgs.String)
+		if err != nil {
+			return err
+		}
+		var attrs []schema.Attr
+		if sqlx.ValidString(comment) {
+			attrs = append(attrs, &schema.Comment{Text: comment.String})
+		}
+		if kind == "p" {
+			s.AddProcs(&schema.Proc{Name: name, Args: args, Lang: lang, Body: strings.TrimSpace(body), Attrs: attrs})
+			continue
+		}
+		switch volatile {
+		case "i":
+			attrs = append(attrs, &FuncVolatility{V: FuncVolatilityImmutable})
+		case "s":
+			attrs = append(attrs, &FuncVolatility{V: FuncVolatilityStable})
+		}
+		s.AddFuncs(&schema.Func{
+			Name:  name,
+			Args:  args,
+			Ret:   funcType(s, result.String),
+			Lang:  lang,
+			Body:  strings.TrimSpace(body),
+			Attrs: attrs,
+		})
+	}
+	return rows.Close()
+}
+
+// funcArgs parses the JSON-encoded arguments of a function. The columns of
+// functions that return TABLE are part of the return type, and are skipped.
+func funcArgs(s *schema.Schema, name, args string) ([]*schema.FuncArg, error) {
+	if args == "" {
+		return nil, nil
+	}
+	var specs []struct {
+		Name    *string `json:"name"`
+		Type    string  `json:"type"`
+		Mode    *string `json:"mode"`
+		Default *string `json:"default"`
+	}
+	if err := json.Unmarshal([]byte(args), &specs); err != nil {
+		return nil, fmt.Errorf("postgres: decoding arguments of function %q: %w", name, err)
+	}
+	var fArgs []*schema.FuncArg
+	for _, spec := range specs {
+		a := &schema.FuncArg{Type: funcType(s, spec.Type)}
+		if spec.Name != nil {
+			a.Name = *spec.Name
+		}
+		if spec.Mode != nil {
+			switch *spec.Mode {
+			case "t":
+				continue
+			case "o":
+				a.Mode = schema.FuncArgModeOut
+			case "b":
+				a.Mode = schema.FuncArgModeInOut
+			case "v":
+				a.Mode = schema.FuncArgModeVariadic
+			}
+		}
+		if spec.Default != nil && *spec.Default != "" {
+			a.Default = &schema.RawExpr{X: *spec.Default}
+		}
+		fArgs = append(fArgs, a)
+	}
+	return fArgs, nil
+}
+
+// funcType parses the given argument or return type of a function. Types that reference
+// enums, domains or composite types in the realm are linked to them, and types that cannot be parsed (e.g. SETOF or
+// TABLE return types) are kept as user-defined types.
+func funcType(s *schema.Schema, typ string) schema.Type {
+	ns, name := s, typ
+	if i := strings.LastIndexByte(typ, '.'); i > 0 && s.Realm != nil {
+		if ns1, ok := s.Realm.Schema(strings.Trim(typ[:i], `"`)); ok {
+			ns, name = ns1, typ[i+1:]
+		}
+	}
+	name = strings.Trim(name, `"`)
+	if o, ok := ns.Object(func(o schema.Object) bool {
+		switch o := o.(type) {
+		case *schema.EnumType:
+			return o.T == name
+		case *Domain:
+			return o.T == name
+		case *Composite:
+			return o.T == name
+		}
+		return false
+	}); ok {
+		return o.(schema.Type)
+	}
+	// Set-returning and table functions are not parsed.
+	if u := strings.ToUpper(typ); strings.HasPrefix(u, "SETOF ") || strings.HasPrefix(u, "TABLE(") {
+		return &UserDefinedType{T: typ}
+	}
+	t, err := ParseType(typ)
+	if err != nil {
+		return &UserDefinedType{T: typ}
+	}
+	return t
+}
+
+// funcDef holds the common definition of functions and procedures.
+type funcDef struct {
+	o     schema.Object
+	kind  string // FUNCTION or PROCEDURE.
+	name  string
+	args  []*schema.FuncArg
+	ret   schema.Type // Nil for procedures.
+	lang  string
+	body  string
+	attrs []schema.Attr
+}
+
+// funcOf returns the function definition of the given object, if it is a function or a procedure.
+func funcOf(o schema.Object) (*funcDef, bool) {
+	switch o := o.(type) {
+	case *schema.Func:
+		return &funcDef{o: o, kind: "FUNCTION", name: o.Name, args: o.Args, ret: o.Ret, lang: o.Lang, body: o.Body, attrs: o.Attrs}, true
+	case *schema.Proc:
+		return &funcDef{o: o, kind: "PROCEDURE", name: o.Name, args: o.Args, lang: o.Lang, body: o.Body, attrs: o.Attrs}, true
+	default:
+		return nil, false
+	}
+}
+
+// key returns the key that identifies the function in its schema. PostgreSQL
+// allows overloading functions, and therefore, the input arguments are part of it.
+func (f *funcDef) key() string {
+	types := make([]string, 0, len(f.args))
+	for _, a := range f.args {
+		if a.Mode != schema.FuncArgModeOut {
+			types = append(types, funcTypeString(a.Type))
+		}
+	}
+	return fmt.Sprintf("%s %q(%s)", f.kind, f.name, strings.Join(types, ", "))
+}
+
+// volatility returns the volatility category of the function, or VOLATILE if not set.
+func (f *funcDef) volatility() string {
+	if v := (FuncVolatility{}); sqlx.Has(f.attrs, &v) && v.V != "" {
+		return strings.ToUpper(v.V)
+	}
+	return FuncVolatilityVolatile
+}
+
+// signatureChanged reports if the return type or the arguments of the function were
+// changed. In this case, the function cannot be replaced with CREATE OR REPLACE.
+func (f *funcDef) signatureChanged(to *funcDef) bool {
+	if f.kind != to.kind || funcTypeString(f.ret) != funcTypeString(to.ret) || len(f.args) != len(to.args) {
+		return true
+	}
+	for i, a1 := range f.args {
+		a2 := to.args[i]
+		if a1.Name != a2.Name || funcArgMode(a1) != funcArgMode(a2) || funcTypeString(a1.Type) != funcTypeString(a2.Type) {
+			return true
+		}
+		d1, ok1 := sqlx.DefaultValue(&schema.Column{Default: a1.Default})
+		d2, ok2 := sqlx.DefaultValue(&schema.Column{Default: a2.Default})
+		if ok1 != ok2 || ok1 && trimCast(d1) != trimCast(d2) && quote(trimCast(d1)) != quote(trimCast(d2)) {
+			return true
+		}
+	}
+	return false
+}
+
+// defChanged reports if the definition of the function was changed, ignoring its comment.
+func (f *funcDef) defChanged(to *funcDef) bool {
+	return f.signatureChanged(to) ||
+		!strings.EqualFold(f.lang, to.lang) ||
+		strings.TrimSpace(f.body) != strings.TrimSpace(to.body) ||
+		f.volatility() != to.volatility()
+}
+
+// funcArgMode returns the mode of the argument, or IN if not set.
+func funcArgMode(a *schema.FuncArg) schema.FuncArgMode {
+	if a.Mode == "" {
+		return schema.FuncArgModeIn
+	}
+	return schema.FuncArgMode(strings.ToUpper(string(a.Mode)))
+}
+
+// funcTypeString returns the string representation of the given type used for comparing
+// function signatures. Enum types are compared by their names, as they are not
+// qualified by the pg_get_function_result and format_type functions.
+func funcTypeString(t schema.Type) string {
+	switch t := t.(type) {
+	case nil:
+		return ""
+	case *schema.EnumType:
+		return t.T
+	case *UserDefinedType:
+		return strings.ToLower(t.T)
+	default:
+		f, err := FormatType(t)
+		if err != nil {
+			return fmt.Sprintf("%T", t)
+		}
+		return f
+	}
+}
+
+// funcsDiff returns the changes for migrating the functions and procedures of the schema.
+// Drop changes are returned separately, as they are planned before the other changes.
+func (*diff) funcsDiff(from, to *schema.Schema) (drops, changes []schema.Change) {
+	find := func(s *schema.Schema, f *funcDef) (schema.Object, bool) {
+		return s.Object(func(o schema.Object) bool {
+			f2, ok := funcOf(o)
+			return ok && f.key() == f2.key()
+		})
+	}
+	for _, o1 := range from.Objects {
+		f1, ok := funcOf(o1)
+		if !ok {
+			continue
+		}
+		o2, ok := find(to, f1)
+		if !ok {
+			drops = append(drops, &schema.DropObject{O: o1})
+			continue
+		}
+		if f2, _ := funcOf(o2); f1.defChanged(f2) || sqlx.CommentDiff(f1.attrs, f2.attrs) != nil {
+			changes = append(changes, &schema.ModifyObject{From: o1, To: o2})
+		}
+	}
+	for _, o1 := range to.Objects {
+		f1, ok := funcOf(o1)
+		if !ok {
+			continue
+		}
+		if _, ok := find(from, f1); !ok {
+			changes = append(changes, &schema.AddObject{O: o1})
+		}
+	}
+	return drops, changes
+}
+
+// addFunc builds and executes the queries for creating a function or a procedure.
+func (s *state) addFunc(add *schema.AddObject) error {
+	f, _ := funcOf(add.O)
+	create, err := s.createFunc(f, "CREATE")
+	if err != nil {
+		return err
+	}
+	drop, err := s.dropFuncCmd(f, false)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     create,
+		Source:  add,
+		Comment: fmt.Sprintf("create %q %s", f.name, strings.ToLower(f.kind)),
+		Reverse: drop,
+	})
+	if c := (schema.Comment{}); sqlx.Has(f.attrs, &c) && c.Text != "" {
+		change, err := s.funcComment(f, c.Text, "")
+		if err != nil {
+			return err
+		}
+		s.append(change)
+	}
+	return nil
+}
+
+// dropFunc builds and executes the query for dropping a function or a procedure.
+func (s *state) dropFunc(drop *schema.DropObject) error {
+	f, _ := funcOf(drop.O)
+	cmd, err := s.dropFuncCmd(f, sqlx.Has(drop.Extra, &schema.IfExists{}))
+	if err != nil {
+		return err
+	}
+	create, err := s.createFunc(f, "CREATE")
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     cmd,
+		Source:  drop,
+		Comment: fmt.Sprintf("drop %q %s", f.name, strings.ToLower(f.kind)),
+		Reverse: create,
+	})
+	return nil
+}
+
+// modifyFunc builds and executes the queries for modifying a function or a procedure.
+// Functions are replaced in place in case their signature was not changed, or dropped
+// and created otherwise.
+func (s *state) modifyFunc(modify *schema.ModifyObject) error {
+	from, _ := funcOf(modify.From)
+	to, _ := funcOf(modify.To)
+	switch {
+	case from.signatureChanged(to):
+		if err := s.dropFunc(&schema.DropObject{O: modify.From}); err != nil {
+			return err
+		}
+		// Comments are dropped with the function.
+		return s.addFunc(&schema.AddObject{O: modify.To})
+	case from.defChanged(to):
+		create, err := s.createFunc(to, "CREATE OR REPLACE")
+		if err != nil {
+			return err
+		}
+		reverse, err := s.createFunc(from, "CREATE OR REPLACE")
+		if err != nil {
+			return err
+		}
+		s.append(&migrate.Change{
+			Cmd:     create,
+			Source:  modify,
+			Comment: fmt.Sprintf("modify %q %s", to.name, strings.ToLower(to.kind)),
+			Reverse: reverse,
+		})
+	}
+	if change := sqlx.CommentDiff(from.attrs, to.attrs); change != nil {
+		fromC, toC, err := commentChange(change)
+		if err != nil {
+			return err
+		}
+		c, err := s.funcComment(to, toC, fromC)
+		if err != nil {
+			return err
+		}
+		s.append(c)
+	}
+	return nil
+}
+
+// createFunc returns the statement for creating the function using the given command.
+func (s *state) createFunc(f *funcDef, cmd string) (string, error) {
+	switch {
+	case f.lang == "":
+		return "", fmt.Errorf("postgres: missing language for %s %q", strings.ToLower(f.kind), f.name)
+	case f.kind == "FUNCTION" && f.ret == nil:
+		return "", fmt.Errorf("postgres: missing return type for function %q", f.name)
+	}
+	b := s.Build(cmd, f.kind)
+	f.ident(b)
+	args := make([]string, 0, len(f.args))
+	for _, a := range f.args {
+		ab := s.Build()
+		if a.Mode != "" && funcArgMode(a) != schema.FuncArgModeIn {
+			ab.P(string(funcArgMode(a)))
+		}
+		if a.Name != "" {
+			ab.Ident(a.Name)
+		}
+		t, err := s.funcType(a.Type)
+		if err != nil {
+			return "", fmt.Errorf("postgres: format argument %q of %s %q: %w", a.Name, strings.ToLower(f.kind), f.name, err)
+		}
+		ab.P(t)
+		switch x := a.Default.(type) {
+		case *schema.Literal:
+			v := x.V
+			switch a.Type.(type) {
+			case *schema.BoolType, *schema.DecimalType, *schema.IntegerType, *schema.FloatType:
+			default:
+				v = quote(v)
+			}
+			ab.P("DEFAULT", v)
+		case *schema.RawExpr:
+			ab.P("DEFAULT", x.X)
+		}
+		args = append(args, ab.String())
+	}
+	b.Wrap(func(b *sqlx.Builder) {
+		b.MapComma(args, func(i int, b *sqlx.Builder) {
+			b.WriteString(args[i])
+		})
+	})
+	if f.ret != nil {
+		t, err := s.funcType(f.ret)
+		if err != nil {
+			return "", fmt.Errorf("postgres: format return type of function %q: %w", f.name, err)
+		}
+		b.P("RETURNS", t)
+	}
+	b.P("LANGUAGE", f.lang)
+	if v := f.volatility(); v != FuncVolatilityVolatile {
+		b.P(v)
+	}
+	return b.P("AS", dollarQuote(strings.TrimSpace(f.body))).String(), nil
+}
+
+// dropFuncCmd returns the statement for dropping the function.
+func (s *state) dropFuncCmd(f *funcDef, ifExists bool) (string, error) {
+	b := s.Build("DROP", f.kind)
+	if ifExists {
+		b.P("IF EXISTS")
+	}
+	if err := s.funcSignature(b, f); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func (s *state) funcComment(f *funcDef, to, from string) (*migrate.Change, error) {
+	b := s.Build("COMMENT ON", f.kind)
+	if err := s.funcSignature(b, f); err != nil {
+		return nil, err
+	}
+	b.P("IS")
+	return &migrate.Change{
+		Cmd:     b.Clone().P(quote(to)).String(),
+		Comment: fmt.Sprintf("set comment to %s: %q", strings.ToLower(f.kind), f.name),
+		Reverse: b.Clone().P(quote(from)).String(),
+	}, nil
+}
+
+// funcSignature writes the function name and its input argument types,
+// as expected by the DROP and COMMENT ON commands.
+func (s *state) funcSignature(b *sqlx.Builder, f *funcDef) error {
+	f.ident(b)
+	var types []string
+	for _, a := range f.args {
+		if a.Mode == schema.FuncArgModeOut {
+			continue
+		}
+		t, err := s.funcType(a.Type)
+		if err != nil {
+			return fmt.Errorf("postgres: format argument %q of %s %q: %w", a.Name, strings.ToLower(f.kind), f.name, err)
+		}
+		types = append(types, t)
+	}
+	b.Wrap(func(b *sqlx.Builder) {
+		b.MapComma(types, func(i int, b *sqlx.Builder) {
+			b.WriteString(types[i])
+		})
+	})
+	return nil
+}
+
+// ident writes the (optionally qualified) function name to the builder.
+func (f *funcDef) ident(b *sqlx.Builder) {
+	switch o := f.o.(type) {
+	case *schema.Func:
+		b.Func(o)
+	case *schema.Proc:
+		b.Proc(o)
+	}
+}
+
+// funcType formats the argument or return type of function.
+func (s *state) funcType(t schema.Type) (string, error) {
+	if t == nil {
+		return "", errors.New("missing type")
+	}
+	return s.formatType(&schema.Column{Type: &schema.ColumnType{Type: t}})
+}
+
+// dollarQuote returns the given body wrapped with dollar quotes, using a tag that
+// does not appear in the body. Multi-line bodies are written on their own lines.
+func dollarQuote(body string) string {
+	tag := "$$"
+	for i := 0; strings.Contains(body, tag); i++ {
+		tag = fmt.Sprintf("$_%d$", i)
+	}
+	if strings.Contains(body, "\n") {
+		return tag + "\n" + body + "\n" + tag
+	}
+	return tag + body + tag
+}
+
+const (
+	// Query to list views.
+	viewsQuery = `
+SELECT
+	t1.table_schema,
+	t1.table_name,
+	pg_get_viewdef(t3.oid) AS view_definition,
+	t1.check_option,
+	pg_catalog.obj_description(t3.oid, 'pg_class') AS comment
+FROM
+	INFORMATION_SCHEMA.VIEWS AS t1
+	JOIN pg_catalog.pg_namespace AS t2 ON t2.nspname = t1.table_schema
+	JOIN pg_catalog.pg_class AS t3 ON t3.relnamespace = t2.oid AND t3.relname = t1.table_name
+	LEFT JOIN pg_depend AS t4 ON t4.objid = t3.oid AND t4.deptype = 'e'
+WHERE
+	t1.table_schema IN (%s)
+	AND t4.objid IS NULL
+ORDER BY
+	t1.table_schema, t1.table_name
+`
+	// Query to list materialized views.
+	matViewsQuery = `
+SELECT
+	m.schemaname AS view_schema,
+	m.matviewname AS view_name,
+	pg_get_viewdef(c.oid) AS view_definition,
+	m.ispopulated AS populated,
+	m.tablespace,
+	c.reloptions AS options,
+	pg_catalog.obj_description(c.oid, 'pg_class') AS comment
+FROM
+	pg_catalog.pg_matviews AS m
+	JOIN pg_catalog.pg_namespace AS n ON n.nspname = m.schemaname
+	JOIN pg_catalog.pg_class AS c ON c.relnamespace = n.oid AND c.relname = m.matviewname
+	LEFT JOIN pg_depend AS d ON d.objid = c.oid AND d.deptype = 'e'
+WHERE
+	m.schemaname IN (%s)
+	AND d.objid IS NULL
+ORDER BY
+	m.schemaname, m.matviewname
+`
+	// Query to list the columns of materialized views. The result set has the same
+	// structure as the INFORMATION_SCHEMA.COLUMNS query, and is computed similarly.
+	matViewColumnsQuery = `
+SELECT
+	c.relname AS table_name,
+	a.attname AS column_name,
+	(CASE
+		WHEN t.typelem <> 0 AND t.typlen = -1 THEN 'ARRAY'
+		WHEN tn.nspname = 'pg_catalog' THEN pg_catalog.format_type(a.atttypid, NULL)
+		ELSE 'USER-DEFINED'
+	END) AS data_type,
+	pg_catalog.format_type(a.atttypid, a.atttypmod) AS format_type,
+	(CASE WHEN a.attnotnull THEN 'NO' ELSE 'YES' END) AS is_nullable,
+	NULL AS column_default,
+	information_schema._pg_char_max_length(a.atttypid, a.atttypmod) AS character_maximum_length,
+	information_schema._pg_numeric_precision(a.atttypid, a.atttypmod) AS numeric_precision,
+	information_schema._pg_datetime_precision(a.atttypid, a.atttypmod) AS datetime_precision,
+	information_schema._pg_numeric_scale(a.atttypid, a.atttypmod) AS numeric_scale,
+	information_schema._pg_interval_type(a.atttypid, a.atttypmod) AS interval_type,
+	NULL AS character_set_name,
+	NULL AS collation_name,
+	'NO' AS is_identity,
+	NULL AS identity_start,
+	NULL AS identity_increment,
+	NULL AS identity_last,
+	NULL AS identity_generation,
+	NULL AS generation_expression,
+	col_description(c.oid, a.attnum) AS comment,
+	t.typtype,
+	t.typelem,
+	(CASE WHEN t.typcategory = 'A' AND t.typelem <> 0 THEN (SELECT t2.typtype FROM pg_catalog.pg_type t2 WHERE t2.oid = t.typelem) END) AS elemtyp,
+	t.oid
+FROM
+	pg_catalog.pg_attribute AS a
+	JOIN pg_catalog.pg_class AS c ON c.oid = a.attrelid
+	JOIN pg_catalog.pg_namespace AS n ON n.oid = c.relnamespace
+	JOIN pg_catalog.pg_type AS t ON t.oid = a.atttypid
+	JOIN pg_catalog.pg_namespace AS tn ON tn.oid = t.typnamespace
+WHERE
+	n.nspname = $1 AND c.relname IN (%s) AND c.relkind = 'm'
+	AND a.attnum > 0 AND NOT a.attisdropped
+ORDER BY
+	c.relname, a.attnum
+`
+	// Query to list the tables and views that views depend on.
+	viewDepsQuery = `
+SELECT DISTINCT
+	n1.nspname AS view_schema,
+	c1.relname AS view_name,
+	n2.nspname AS ref_schema,
+	c2.relname AS ref_name,
+	c2.relkind AS ref_kind
+FROM
+	pg_catalog.pg_depend AS d
+	JOIN pg_catalog.pg_rewrite AS r ON r.oid = d.objid
+	JOIN pg_catalog.pg_class AS c1 ON c1.oid = r.ev_class
+	JOIN pg_catalog.pg_namespace AS n1 ON n1.oid = c1.relnamespace
+	JOIN pg_catalog.pg_class AS c2 ON c2.oid = d.refobjid
+	JOIN pg_catalog.pg_namespace AS n2 ON n2.oid = c2.relnamespace
+WHERE
+	d.classid = 'pg_rewrite'::regclass
+	AND d.refclassid = 'pg_class'::regclass
+	AND d.deptype = 'n'
+	AND c1.oid <> c2.oid
+	AND c1.relkind IN ('v', 'm')
+	AND n1.nspname IN (%s)
+ORDER BY
+	view_schema, view_name, ref_schema, ref_name
+`
+	// Query to list the triggers of tables and views, excluding
+	// internal triggers and triggers that belong to extensions.
+	triggersQuery = `
+SELECT
+	n.nspname AS table_schema,
+	c.relname AS table_name,
+	c.relkind AS table_kind,
+	t.tgname AS trigger_name,
+	t.tgtype AS trigger_type,
+	(
+		SELECT string_agg(a.attname, ',' ORDER BY k.n)
+		FROM unnest(t.tgattr::int2[]) WITH ORDINALITY AS k(attnum, n)
+		JOIN pg_catalog.pg_attribute AS a ON a.attrelid = t.tgrelid AND a.attnum = k.attnum
+	) AS update_columns,
+	pg_catalog.pg_get_triggerdef(t.oid) AS definition,
+	pg_catalog.obj_description(t.oid, 'pg_trigger') AS comment
+FROM
+	pg_catalog.pg_trigger AS t
+	JOIN pg_catalog.pg_class AS c ON c.oid = t.tgrelid
+	JOIN pg_catalog.pg_namespace AS n ON n.oid = c.relnamespace
+	LEFT JOIN pg_depend AS d ON d.objid = t.oid AND d.deptype = 'e'
+WHERE
+	n.nspname IN (%s)
+	AND NOT t.tgisinternal
+	AND d.objid IS NULL
+ORDER BY
+	table_schema, table_name, trigger_name
+`
+	// Query to list the functions and procedures of schemas, excluding aggregate and
+	// window functions, functions implemented in C and functions that belong to extensions.
+	funcsQuery = `
+SELECT
+	n.nspname AS schema_name,
+	p.proname AS func_name,
+	%[1]s AS func_kind,
+	l.lanname AS func_lang,
+	p.provolatile AS func_volatile,
+	pg_catalog.pg_get_function_result(p.oid) AS func_result,
+	p.prosrc AS func_body,
+	(
+		SELECT json_agg(json_build_object(
+			'name', a.name,
+			'type', pg_catalog.format_type(a.type, NULL),
+			'mode', a.mode,
+			'default', pg_catalog.pg_get_function_arg_default(p.oid, a.n::int)
+		) ORDER BY a.n)
+		FROM unnest(COALESCE(p.proallargtypes, p.proargtypes::oid[]), p.proargnames, p.proargmodes) WITH ORDINALITY AS a(type, name, mode, n)
+	) AS func_args,
+	pg_catalog.obj_description(p.oid, 'pg_proc') AS comment
+FROM
+	pg_catalog.pg_proc AS p
+	JOIN pg_catalog.pg_namespace AS n ON n.oid = p.pronamespace
+	JOIN pg_catalog.pg_language AS l ON l.oid = p.prolang
+	LEFT JOIN pg_depend AS d ON d.objid = p.oid AND d.deptype = 'e'
+WHERE
+	n.nspname IN (%[2]s)
+	AND %[1]s IN ('f', 'p')
+	AND l.lanname NOT IN ('c', 'internal')
+	AND d.objid IS NULL
+ORDER BY
+	schema_name, func_name, pg_catalog.pg_get_function_identity_arguments(p.oid)
+`
+)
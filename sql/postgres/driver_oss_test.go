@@ -0,0 +1,814 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+//go:build !ent
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"ariga.io/atlas/sql/internal/sqltest"
+	"ariga.io/atlas/sql/schema"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDriver_InspectViews(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= CURRENT_SCHEMA()"))).
+		WillReturnRows(sqltest.Rows(`
+ schema_name | comment 
+-------------+---------
+ public      | 
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(viewsQuery, "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ table_schema | table_name | view_definition             | check_option | comment 
+--------------+------------+-----------------------------+--------------+---------
+ public       | v1         |  SELECT 1 AS a;             | NONE         | 
+ public       | v2         |  SELECT v1.a FROM v1;       | CASCADED     | view comment
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(matViewsQuery, "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ view_schema | view_name | view_definition             | populated | tablespace | options                              | comment 
+-------------+-----------+-----------------------------+-----------+------------+--------------------------------------+---------
+ public      | mv1       |  SELECT v1.a FROM v1;       | f         | fast       | {fillfactor=70,autovacuum_enabled=false} | 
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(columnsQuery, "$2, $3, $4"))).
+		WithArgs("public", "v1", "v2", "mv1").
+		WillReturnRows(sqltest.Rows(`
+table_name |column_name | data_type | formatted | is_nullable | column_default | character_maximum_length | numeric_precision | datetime_precision | numeric_scale | interval_type | character_set_name | collation_name | is_identity | identity_start | identity_increment |   identity_last  | identity_generation | generation_expression | comment | typtype | typelem | elemtyp | oid
+-----------+------------+-----------+-----------+-------------+----------------+--------------------------+-------------------+--------------------+---------------+---------------+--------------------+----------------+-------------+----------------+--------------------+------------------+---------------------+-----------------------+---------+---------+---------+---------+-----
+v1         | a          | integer   | integer   | YES         |                |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |  23
+v2         | a          | integer   | integer   | YES         |                |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |  23
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(matViewColumnsQuery, "$2"))).
+		WithArgs("public", "mv1").
+		WillReturnRows(sqltest.Rows(`
+table_name |column_name | data_type | formatted | is_nullable | column_default | character_maximum_length | numeric_precision | datetime_precision | numeric_scale | interval_type | character_set_name | collation_name | is_identity | identity_start | identity_increment |   identity_last  | identity_generation | generation_expression | comment | typtype | typelem | elemtyp | oid
+-----------+------------+-----------+-----------+-------------+----------------+--------------------------+-------------------+--------------------+---------------+---------------+--------------------+----------------+-------------+----------------+--------------------+------------------+---------------------+-----------------------+---------+---------+---------+---------+-----
+mv1        | a          | integer   | integer   | YES         |                |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |  23
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(indexesAbove11, "$2"))).
+		WithArgs("public", "mv1").
+		WillReturnRows(sqltest.Rows(`
+ table_name | index_name | index_type | column_name | included | primary | unique | constraints | predicate | expression | desc | nulls_first | nulls_last | comment | options | opclass_name | opclass_default | opclass_params | indnullsnotdistinct 
+------------+------------+------------+-------------+----------+---------+--------+-------------+-----------+------------+------+-------------+------------+---------+---------+--------------+-----------------+----------------+---------------------
+ mv1        | mv1_a      | btree      | a           | f        | f       | t      |             |           | a          | f    | f           | f          |         |         | int4_ops     | t               |                | f
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(viewDepsQuery, "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ view_schema | view_name | ref_schema | ref_name | ref_kind 
+-------------+-----------+------------+----------+----------
+ public      | mv1       | public     | v1       | v
+ public      | v2        | public     | v1       | v
+ public      | v2        | other      | t1       | r
+`))
+	mk.noEnums()
+	s, err := drv.InspectSchema(context.Background(), "", &schema.InspectOptions{
+		Mode: schema.InspectViews,
+	})
+	require.NoError(t, err)
+	require.Len(t, s.Views, 3)
+	v1, ok := s.View("v1")
+	require.True(t, ok)
+	require.Equal(t, "SELECT 1 AS a;", v1.Def)
+	require.Empty(t, v1.Attrs)
+	require.Empty(t, v1.Deps)
+	require.Equal(t, []*schema.Column{
+		{Name: "a", Type: &schema.ColumnType{Raw: "integer", Null: true, Type: &schema.IntegerType{T: "integer"}}},
+	}, v1.Columns)
+	v2, ok := s.View("v2")
+	require.True(t, ok)
+	require.Equal(t, []schema.Attr{
+		&schema.ViewCheckOption{V: schema.ViewCheckOptionCascaded},
+		&schema.Comment{Text: "view comment"},
+	}, v2.Attrs)
+	// Dependencies on uninspected schemas are ignored.
+	require.Equal(t, []schema.Object{v1}, v2.Deps)
+	mv1, ok := s.View("mv1")
+	require.True(t, ok)
+	require.True(t, mv1.Materialized())
+	require.Equal(t, []schema.Attr{
+		&schema.Materialized{},
+		&WithNoData{},
+		&Tablespace{N: "fast"},
+		&StorageParams{Params: []struct{ N, V string }{{N: "fillfactor", V: "70"}, {N: "autovacuum_enabled", V: "false"}}},
+	}, mv1.Attrs)
+	require.Len(t, mv1.Columns, 1)
+	require.Len(t, mv1.Indexes, 1)
+	require.Equal(t, "mv1_a", mv1.Indexes[0].Name)
+	require.True(t, mv1.Indexes[0].Unique)
+	require.Equal(t, mv1, mv1.Indexes[0].View)
+	require.Nil(t, mv1.Indexes[0].Table)
+	require.Equal(t, mv1.Columns[0], mv1.Indexes[0].Parts[0].C)
+	require.Equal(t, []schema.Object{v1}, mv1.Deps)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestPlanChanges_Views(t *testing.T) {
+	var (
+		s  = schema.New("public")
+		t1 = schema.NewTable("t1").AddColumns(schema.NewIntColumn("a", "int"))
+		v1 = schema.NewView("v1", "SELECT a FROM t1").
+			AddColumns(schema.NewIntColumn("a", "int")).
+			AddDeps(t1)
+		v2 = schema.NewView("v2", "SELECT a FROM v1").
+			SetCheckOption(schema.ViewCheckOptionLocal).
+			SetComment("c").
+			AddDeps(v1)
+	)
+	s.AddTables(t1).AddViews(v1, v2)
+	tests := []struct {
+		changes []schema.Change
+		want    [][2]string
+	}{
+		{
+			changes: []schema.Change{
+				&schema.AddView{V: v2},
+				&schema.AddView{V: v1},
+			},
+			want: [][2]string{
+				{`CREATE VIEW "public"."v1" AS SELECT a FROM t1`, `DROP VIEW "public"."v1"`},
+				{`CREATE VIEW "public"."v2" AS SELECT a FROM v1 WITH LOCAL CHECK OPTION`, `DROP VIEW "public"."v2"`},
+				{`COMMENT ON VIEW "public"."v2" IS 'c'`, `COMMENT ON VIEW "public"."v2" IS ''`},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.DropView{V: v1},
+				&schema.DropView{V: v2, Extra: []schema.Clause{&schema.IfExists{}}},
+			},
+			want: [][2]string{
+				{`DROP VIEW IF EXISTS "public"."v2"`, `CREATE VIEW "public"."v2" AS SELECT a FROM v1 WITH LOCAL CHECK OPTION`},
+				{`DROP VIEW "public"."v1"`, `CREATE VIEW "public"."v1" AS SELECT a FROM t1`},
+			},
+		},
+		// Replaceable, as a column was added to the end.
+		{
+			changes: []schema.Change{
+				&schema.ModifyView{
+					From: v1,
+					To: schema.NewView("v1", "SELECT a, a AS b FROM t1").
+						SetSchema(s).
+						AddColumns(schema.NewIntColumn("a", "int"), schema.NewIntColumn("b", "int")),
+				},
+			},
+			want: [][2]string{
+				{`CREATE OR REPLACE VIEW "public"."v1" AS SELECT a, a AS b FROM t1`, ""},
+			},
+		},
+		// Column type was changed.
+		{
+			changes: []schema.Change{
+				&schema.ModifyView{
+					From: v1,
+					To: schema.NewView("v1", "SELECT a::text FROM t1").
+						SetSchema(s).
+						AddColumns(schema.NewStringColumn("a", "text")),
+				},
+			},
+			want: [][2]string{
+				{`DROP VIEW "public"."v1"`, `CREATE VIEW "public"."v1" AS SELECT a FROM t1`},
+				{`CREATE VIEW "public"."v1" AS SELECT a::text FROM t1`, `DROP VIEW "public"."v1"`},
+			},
+		},
+		// Only the comment was changed.
+		{
+			changes: []schema.Change{
+				&schema.ModifyView{
+					From: v2,
+					To: schema.NewView("v2", "SELECT a FROM v1").
+						SetSchema(s).
+						SetCheckOption(schema.ViewCheckOptionLocal).
+						SetComment("d"),
+				},
+			},
+			want: [][2]string{
+				{`COMMENT ON VIEW "public"."v2" IS 'd'`, `COMMENT ON VIEW "public"."v2" IS 'c'`},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.RenameView{From: v1, To: schema.NewView("v3", v1.Def).SetSchema(s)},
+			},
+			want: [][2]string{
+				{`ALTER VIEW "public"."v1" RENAME TO "v3"`, `ALTER VIEW "public"."v3" RENAME TO "v1"`},
+			},
+		},
+		// Views are dropped before the columns they depend on.
+		{
+			changes: []schema.Change{
+				&schema.ModifyTable{T: t1, Changes: []schema.Change{&schema.DropColumn{C: t1.Columns[0]}}},
+				&schema.DropView{V: v1},
+				&schema.DropView{V: v2},
+			},
+			want: [][2]string{
+				{`DROP VIEW "public"."v2"`, `CREATE VIEW "public"."v2" AS SELECT a FROM v1 WITH LOCAL CHECK OPTION`},
+				{`DROP VIEW "public"."v1"`, `CREATE VIEW "public"."v1" AS SELECT a FROM t1`},
+				{`ALTER TABLE "public"."t1" DROP COLUMN "a"`, `ALTER TABLE "public"."t1" ADD COLUMN "a" integer NOT NULL`},
+			},
+		},
+		// Replaceable views are recreated if the columns they depend on are modified.
+		{
+			changes: []schema.Change{
+				&schema.ModifyTable{T: t1, Changes: []schema.Change{
+					&schema.ModifyColumn{From: t1.Columns[0], To: schema.NewIntColumn("a", "bigint"), Change: schema.ChangeType},
+				}},
+				&schema.ModifyView{
+					From: v1,
+					To: schema.NewView("v1", "SELECT a, a AS b FROM t1").
+						SetSchema(s).
+						AddColumns(schema.NewIntColumn("a", "int"), schema.NewIntColumn("b", "int")),
+				},
+			},
+			want: [][2]string{
+				{`DROP VIEW "public"."v1"`, `CREATE VIEW "public"."v1" AS SELECT a FROM t1`},
+				{`ALTER TABLE "public"."t1" ALTER COLUMN "a" TYPE bigint`, `ALTER TABLE "public"."t1" ALTER COLUMN "a" TYPE integer`},
+				{`CREATE VIEW "public"."v1" AS SELECT a, a AS b FROM t1`, `DROP VIEW "public"."v1"`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		db, mk, err := sqlmock.New()
+		require.NoError(t, err)
+		mock{mk}.version("130000")
+		drv, err := Open(db)
+		require.NoError(t, err)
+		plan, err := drv.PlanChanges(context.Background(), "plan", tt.changes)
+		require.NoError(t, err)
+		require.Len(t, plan.Changes, len(tt.want))
+		for i, c := range plan.Changes {
+			require.Equal(t, tt.want[i][0], c.Cmd)
+			if tt.want[i][1] == "" {
+				require.Nil(t, c.Reverse)
+			} else {
+				require.Equal(t, tt.want[i][1], c.Reverse)
+			}
+		}
+	}
+}
+
+func TestPlanChanges_MaterializedViews(t *testing.T) {
+	var (
+		s  = schema.New("public")
+		t1 = schema.NewTable("t1").AddColumns(schema.NewIntColumn("a", "int"))
+		m1 = schema.NewMaterializedView("m1", "SELECT a FROM t1").
+			AddColumns(schema.NewIntColumn("a", "int")).
+			AddAttrs(
+				&WithNoData{},
+				&Tablespace{N: "fast"},
+				&StorageParams{Params: []struct{ N, V string }{{N: "fillfactor", V: "70"}}},
+			).
+			SetComment("c").
+			AddDeps(t1)
+	)
+	m1.AddIndexes(schema.NewUniqueIndex("m1_a").AddColumns(m1.Columns[0]))
+	s.AddTables(t1).AddViews(m1)
+	m2 := func() *schema.View {
+		return schema.NewMaterializedView("m1", "SELECT a FROM t1 WHERE a > 0").
+			SetSchema(s).
+			AddColumns(schema.NewIntColumn("a", "int")).
+			SetComment("c")
+	}
+	tests := []struct {
+		changes []schema.Change
+		want    [][2]string
+	}{
+		{
+			changes: []schema.Change{
+				&schema.AddView{V: m1},
+			},
+			want: [][2]string{
+				{`CREATE MATERIALIZED VIEW "public"."m1" WITH (fillfactor = 70) TABLESPACE "fast" AS SELECT a FROM t1 WITH NO DATA`, `DROP MATERIALIZED VIEW "public"."m1"`},
+				{`CREATE UNIQUE INDEX "m1_a" ON "public"."m1" ("a")`, `DROP INDEX "public"."m1_a"`},
+				{`COMMENT ON MATERIALIZED VIEW "public"."m1" IS 'c'`, `COMMENT ON MATERIALIZED VIEW "public"."m1" IS ''`},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.DropView{V: m1, Extra: []schema.Clause{&schema.IfExists{}, &Cascade{}}},
+			},
+			want: [][2]string{
+				{`DROP MATERIALIZED VIEW IF EXISTS "public"."m1" CASCADE`, `CREATE MATERIALIZED VIEW "public"."m1" WITH (fillfactor = 70) TABLESPACE "fast" AS SELECT a FROM t1 WITH NO DATA`},
+			},
+		},
+		// Definition was changed.
+		{
+			changes: []schema.Change{
+				&schema.ModifyView{From: m1, To: m2()},
+			},
+			want: [][2]string{
+				{`DROP MATERIALIZED VIEW "public"."m1"`, `CREATE MATERIALIZED VIEW "public"."m1" WITH (fillfactor = 70) TABLESPACE "fast" AS SELECT a FROM t1 WITH NO DATA`},
+				{`CREATE MATERIALIZED VIEW "public"."m1" AS SELECT a FROM t1 WHERE a > 0`, `DROP MATERIALIZED VIEW "public"."m1"`},
+				{`COMMENT ON MATERIALIZED VIEW "public"."m1" IS 'c'`, `COMMENT ON MATERIALIZED VIEW "public"."m1" IS ''`},
+			},
+		},
+		// Attributes and indexes were changed.
+		{
+			changes: []schema.Change{
+				&schema.ModifyView{
+					From: m1,
+					To: func() *schema.View {
+						v := m2().AddAttrs(
+							&WithNoData{},
+							&StorageParams{Params: []struct{ N, V string }{{N: "autovacuum_enabled", V: "false"}}},
+						)
+						v.Def = m1.Def
+						v.AddIndexes(schema.NewIndex("m1_a").AddColumns(v.Columns[0]))
+						return v
+					}(),
+					Changes: []schema.Change{
+						&schema.ModifyIndex{From: m1.Indexes[0], To: schema.NewIndex("m1_a").AddColumns(m1.Columns[0]), Change: schema.ChangeUnique},
+					},
+				},
+			},
+			want: [][2]string{
+				{`ALTER MATERIALIZED VIEW "public"."m1" SET TABLESPACE "pg_default"`, `ALTER MATERIALIZED VIEW "public"."m1" SET TABLESPACE "fast"`},
+				{`ALTER MATERIALIZED VIEW "public"."m1" SET (autovacuum_enabled = false), RESET (fillfactor)`, `ALTER MATERIALIZED VIEW "public"."m1" SET (fillfactor = 70), RESET (autovacuum_enabled)`},
+				{`DROP INDEX "public"."m1_a"`, `CREATE UNIQUE INDEX "m1_a" ON "public"."m1" ("a")`},
+				{`CREATE INDEX "m1_a" ON "public"."m1" ("a")`, `DROP INDEX "public"."m1_a"`},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.RenameView{From: m1, To: schema.NewMaterializedView("m3", m1.Def).SetSchema(s)},
+			},
+			want: [][2]string{
+				{`ALTER MATERIALIZED VIEW "public"."m1" RENAME TO "m3"`, `ALTER MATERIALIZED VIEW "public"."m3" RENAME TO "m1"`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		db, mk, err := sqlmock.New()
+		require.NoError(t, err)
+		mock{mk}.version("130000")
+		drv, err := Open(db)
+		require.NoError(t, err)
+		plan, err := drv.PlanChanges(context.Background(), "plan", tt.changes)
+		require.NoError(t, err)
+		require.Len(t, plan.Changes, len(tt.want))
+		for i, c := range plan.Changes {
+			require.Equal(t, tt.want[i][0], c.Cmd)
+			require.Equal(t, tt.want[i][1], c.Reverse)
+		}
+	}
+}
+
+func TestDriver_InspectTriggers(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("150000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= CURRENT_SCHEMA()"))).
+		WillReturnRows(sqltest.Rows(`
+ schema_name | comment 
+-------------+---------
+ public      | 
+`))
+	mk.tableExists("public", "users", true)
+	m.ExpectQuery(queryColumns).
+		WithArgs("public", "users").
+		WillReturnRows(sqltest.Rows(`
+table_name |column_name | data_type | formatted | is_nullable | column_default | character_maximum_length | numeric_precision | datetime_precision | numeric_scale | interval_type | character_set_name | collation_name | is_identity | identity_start | identity_increment |   identity_last  | identity_generation | generation_expression | comment | typtype | typelem | elemtyp | oid
+-----------+------------+-----------+-----------+-------------+----------------+--------------------------+-------------------+--------------------+---------------+---------------+--------------------+----------------+-------------+----------------+--------------------+------------------+---------------------+-----------------------+---------+---------+---------+---------+-----
+users      | a          | integer   | integer   | YES         |                |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |  23
+users      | b          | integer   | integer   | YES         |                |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |  23
+`))
+	mk.noIndexes()
+	mk.noFKs()
+	mk.noChecks()
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(triggersQuery, "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqlmock.NewRows([]string{"table_schema", "table_name", "table_kind", "trigger_name", "trigger_type", "update_columns", "definition", "comment"}).
+			AddRow("public", "users", "r", "tr1", 7, nil, `CREATE TRIGGER tr1 BEFORE INSERT ON public.users FOR EACH ROW EXECUTE FUNCTION f1()`, nil).
+			AddRow("public", "users", "r", "tr2", 24, "b,a", `CREATE TRIGGER tr2 AFTER DELETE OR UPDATE OF b, a ON public.users FOR EACH STATEMENT WHEN (pg_trigger_depth() < 1) EXECUTE FUNCTION f2('x')`, "comment").
+			AddRow("public", "other", "r", "tr3", 4, nil, `CREATE TRIGGER tr3 AFTER INSERT ON public.other FOR EACH STATEMENT EXECUTE FUNCTION f1()`, nil))
+	mk.noEnums()
+	s, err := drv.InspectSchema(context.Background(), "", &schema.InspectOptions{
+		Mode: schema.InspectTables | schema.InspectTriggers,
+	})
+	require.NoError(t, err)
+	users, ok := s.Table("users")
+	require.True(t, ok)
+	a, _ := users.Column("a")
+	b, _ := users.Column("b")
+	// Triggers of uninspected tables are ignored.
+	require.Len(t, users.Triggers, 2)
+	tr1, ok := users.Trigger("tr1")
+	require.True(t, ok)
+	require.Equal(t, users, tr1.Table)
+	require.Equal(t, schema.TriggerTimeBefore, tr1.ActionTime)
+	require.Equal(t, schema.TriggerForRow, tr1.For)
+	require.Equal(t, []schema.TriggerEvent{schema.TriggerEventInsert}, tr1.Events)
+	require.Equal(t, "EXECUTE FUNCTION f1()", tr1.Body)
+	require.Empty(t, tr1.When)
+	require.Empty(t, tr1.Attrs)
+	tr2, ok := users.Trigger("tr2")
+	require.True(t, ok)
+	require.Equal(t, schema.TriggerTimeAfter, tr2.ActionTime)
+	require.Equal(t, schema.TriggerForStmt, tr2.For)
+	require.Equal(t, []schema.TriggerEvent{schema.TriggerEventDelete, schema.TriggerEventUpdateOf(b, a)}, tr2.Events)
+	require.Equal(t, "pg_trigger_depth() < 1", tr2.When)
+	require.Equal(t, "EXECUTE FUNCTION f2('x')", tr2.Body)
+	require.Equal(t, []schema.Attr{&schema.Comment{Text: "comment"}}, tr2.Attrs)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestPlanChanges_Triggers(t *testing.T) {
+	var (
+		s  = schema.New("public")
+		c1 = schema.NewIntColumn("a", "int")
+		t1 = schema.NewTable("t1").AddColumns(c1)
+		v1 = schema.NewView("v1", "SELECT a FROM t1")
+		tr = schema.NewTrigger("tr1").
+			SetActionTime(schema.TriggerTimeBefore).
+			AddEvents(schema.TriggerEventInsert, schema.TriggerEventUpdateOf(c1)).
+			SetFor(schema.TriggerForRow).
+			SetWhen("NEW.a > 0").
+			SetBody("EXECUTE FUNCTION f()")
+		tv = schema.NewTrigger("tr2").
+			SetActionTime(schema.TriggerTimeInstead).
+			AddEvents(schema.TriggerEventInsert).
+			SetFor(schema.TriggerForRow).
+			SetBody("EXECUTE FUNCTION f()")
+		// Modified versions of tr2.
+		tv2 = schema.NewTrigger("tr2").
+			SetActionTime(schema.TriggerTimeInstead).
+			AddEvents(schema.TriggerEventInsert, schema.TriggerEventDelete).
+			SetFor(schema.TriggerForRow).
+			SetBody("EXECUTE FUNCTION f()")
+		tv3 = schema.NewTrigger("tr2").
+			SetActionTime(schema.TriggerTimeInstead).
+			AddEvents(schema.TriggerEventDelete).
+			SetFor(schema.TriggerForRow).
+			SetBody("EXECUTE FUNCTION f()")
+	)
+	s.AddTables(t1.AddTriggers(tr)).AddViews(v1.AddTriggers(tv))
+	tv2.View, tv3.View = v1, v1
+	tr.Attrs = []schema.Attr{&schema.Comment{Text: "c"}}
+	tests := []struct {
+		version string
+		changes []schema.Change
+		want    [][2]string
+	}{
+		{
+			changes: []schema.Change{
+				&schema.AddTrigger{T: tr},
+				&schema.AddTrigger{T: tv},
+			},
+			want: [][2]string{
+				{`CREATE TRIGGER "tr1" BEFORE INSERT OR UPDATE OF "a" ON "public"."t1" FOR EACH ROW WHEN (NEW.a > 0) EXECUTE FUNCTION f()`, `DROP TRIGGER "tr1" ON "public"."t1"`},
+				{`COMMENT ON TRIGGER "tr1" ON "public"."t1" IS 'c'`, `COMMENT ON TRIGGER "tr1" ON "public"."t1" IS ''`},
+				{`CREATE TRIGGER "tr2" INSTEAD OF INSERT ON "public"."v1" FOR EACH ROW EXECUTE FUNCTION f()`, `DROP TRIGGER "tr2" ON "public"."v1"`},
+			},
+		},
+		// Triggers are dropped before the tables they are defined on.
+		{
+			changes: []schema.Change{
+				&schema.DropTable{T: t1},
+				&schema.DropTrigger{T: tv, Extra: []schema.Clause{&schema.IfExists{}}},
+			},
+			want: [][2]string{
+				{`DROP TRIGGER IF EXISTS "tr2" ON "public"."v1"`, `CREATE TRIGGER "tr2" INSTEAD OF INSERT ON "public"."v1" FOR EACH ROW EXECUTE FUNCTION f()`},
+				{`DROP TABLE "public"."t1"`, `CREATE TABLE "public"."t1" ("a" integer NOT NULL)`},
+			},
+		},
+		{
+			version: "140000",
+			changes: []schema.Change{
+				&schema.ModifyTrigger{From: tv, To: tv2},
+			},
+			want: [][2]string{
+				{`CREATE OR REPLACE TRIGGER "tr2" INSTEAD OF INSERT OR DELETE ON "public"."v1" FOR EACH ROW EXECUTE FUNCTION f()`, `CREATE OR REPLACE TRIGGER "tr2" INSTEAD OF INSERT ON "public"."v1" FOR EACH ROW EXECUTE FUNCTION f()`},
+			},
+		},
+		// PostgreSQL versions below 14 do not support CREATE OR REPLACE TRIGGER.
+		{
+			changes: []schema.Change{
+				&schema.ModifyTrigger{From: tv, To: tv3},
+			},
+			want: [][2]string{
+				{`DROP TRIGGER "tr2" ON "public"."v1"`, `CREATE TRIGGER "tr2" INSTEAD OF INSERT ON "public"."v1" FOR EACH ROW EXECUTE FUNCTION f()`},
+				{`CREATE TRIGGER "tr2" INSTEAD OF DELETE ON "public"."v1" FOR EACH ROW EXECUTE FUNCTION f()`, `DROP TRIGGER "tr2" ON "public"."v1"`},
+			},
+		},
+		// Only the comment was changed.
+		{
+			changes: []schema.Change{
+				&schema.ModifyTrigger{
+					From: tr,
+					To: func() *schema.Trigger {
+						to := *tr
+						to.Attrs = []schema.Attr{&schema.Comment{Text: "d"}}
+						return &to
+					}(),
+				},
+			},
+			want: [][2]string{
+				{`COMMENT ON TRIGGER "tr1" ON "public"."t1" IS 'd'`, `COMMENT ON TRIGGER "tr1" ON "public"."t1" IS 'c'`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		db, mk, err := sqlmock.New()
+		require.NoError(t, err)
+		if tt.version == "" {
+			tt.version = "130000"
+		}
+		mock{mk}.version(tt.version)
+		drv, err := Open(db)
+		require.NoError(t, err)
+		plan, err := drv.PlanChanges(context.Background(), "plan", tt.changes)
+		require.NoError(t, err)
+		require.Len(t, plan.Changes, len(tt.want))
+		for i, c := range plan.Changes {
+			require.Equal(t, tt.want[i][0], c.Cmd)
+			if tt.want[i][1] == "" {
+				require.Nil(t, c.Reverse)
+			} else {
+				require.Equal(t, tt.want[i][1], c.Reverse)
+			}
+		}
+	}
+}
+
+func TestDiff_Triggers(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	var (
+		trigger = func(name string, e ...schema.TriggerEvent) *schema.Trigger {
+			return schema.NewTrigger(name).
+				SetActionTime(schema.TriggerTimeAfter).
+				AddEvents(e...).
+				SetFor(schema.TriggerForRow).
+				SetBody("EXECUTE FUNCTION f()")
+		}
+		from = schema.New("public").AddTables(
+			schema.NewTable("t1").AddTriggers(
+				trigger("dropped", schema.TriggerEventInsert),
+				trigger("modified", schema.TriggerEventInsert),
+				// Events order is ignored.
+				trigger("unchanged", schema.TriggerEventInsert, schema.TriggerEventDelete),
+			),
+			schema.NewTable("t2").AddTriggers(trigger("dropped", schema.TriggerEventInsert)),
+		)
+		to = schema.New("public").AddTables(
+			schema.NewTable("t1").AddTriggers(
+				trigger("modified", schema.TriggerEventDelete),
+				trigger("unchanged", schema.TriggerEventDelete, schema.TriggerEventInsert),
+				trigger("added", schema.TriggerEventInsert),
+			),
+			schema.NewTable("t3").AddTriggers(trigger("added", schema.TriggerEventInsert)),
+		)
+	)
+	changes, err := drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	// Triggers of dropped tables are dropped with them.
+	require.EqualValues(t, []schema.Change{
+		&schema.DropTable{T: from.Tables[1]},
+		&schema.AddTable{T: to.Tables[1]},
+		&schema.DropTrigger{T: from.Tables[0].Triggers[0]},
+		&schema.ModifyTrigger{From: from.Tables[0].Triggers[1], To: to.Tables[0].Triggers[0]},
+		&schema.AddTrigger{T: to.Tables[0].Triggers[2]},
+		&schema.AddTrigger{T: to.Tables[1].Triggers[0]},
+	}, changes)
+
+	// Expressions are compared without their wrapping parentheses.
+	from.Tables[0].Triggers[2].SetWhen("(NEW.a > 0)")
+	to.Tables[0].Triggers[1].SetWhen("NEW.a > 0")
+	changes, err = drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.Len(t, changes, 6)
+}
+
+func TestDriver_InspectFuncs(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("150000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= CURRENT_SCHEMA()"))).
+		WillReturnRows(sqltest.Rows(`
+ schema_name | comment 
+-------------+---------
+ public      | 
+`))
+	m.ExpectQuery(queryEnums).
+		WithArgs("public").
+		WillReturnRows(sqlmock.NewRows([]string{"schema_name", "enum_type", "enum_name", "enum_value"}).
+			AddRow("public", 1, "status", "on").
+			AddRow("public", 1, "status", "off"))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(funcsQuery, "p.prokind", "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqlmock.NewRows([]string{"schema_name", "func_name", "func_kind", "func_lang", "func_volatile", "func_result", "func_body", "func_args", "comment"}).
+			AddRow("public", "add", "f", "sql", "i", "integer", " SELECT a + b ", `[{"name":"a","type":"integer","mode":null,"default":null},{"name":"b","type":"integer","mode":null,"default":"1"}]`, "comment").
+			AddRow("public", "is_on", "f", "plpgsql", "v", "boolean", "BEGIN RETURN s = 'on'; END;", `[{"name":"s","type":"status","mode":"i","default":null}]`, nil).
+			AddRow("public", "pairs", "f", "sql", "s", "TABLE(a integer)", "SELECT 1", `[{"name":"n","type":"integer","mode":"i","default":null},{"name":"a","type":"integer","mode":"t","default":null}]`, nil).
+			AddRow("public", "reset", "p", "plpgsql", "v", nil, "BEGIN END;", `[{"name":null,"type":"text","mode":"b","default":null}]`, nil))
+	s, err := drv.InspectSchema(context.Background(), "", &schema.InspectOptions{
+		Mode: schema.InspectFuncs,
+	})
+	require.NoError(t, err)
+	require.Len(t, s.Objects, 5)
+	e := s.Objects[0].(*schema.EnumType)
+	add := s.Objects[1].(*schema.Func)
+	require.Equal(t, s, add.Schema)
+	require.Equal(t, "add", add.Name)
+	require.Equal(t, "sql", add.Lang)
+	require.Equal(t, "SELECT a + b", add.Body)
+	require.Equal(t, &schema.IntegerType{T: "integer"}, add.Ret)
+	require.Equal(t, []*schema.FuncArg{
+		{Name: "a", Type: &schema.IntegerType{T: "integer"}},
+		{Name: "b", Type: &schema.IntegerType{T: "integer"}, Default: &schema.RawExpr{X: "1"}},
+	}, add.Args)
+	require.Equal(t, []schema.Attr{&schema.Comment{Text: "comment"}, &FuncVolatility{V: FuncVolatilityImmutable}}, add.Attrs)
+	isOn := s.Objects[2].(*schema.Func)
+	// Enum types are linked to the inspected enums.
+	require.Equal(t, e, isOn.Args[0].Type)
+	require.Empty(t, isOn.Attrs)
+	pairs := s.Objects[3].(*schema.Func)
+	// TABLE columns are part of the return type.
+	require.Len(t, pairs.Args, 1)
+	require.Equal(t, &UserDefinedType{T: "TABLE(a integer)"}, pairs.Ret)
+	require.Equal(t, []schema.Attr{&FuncVolatility{V: FuncVolatilityStable}}, pairs.Attrs)
+	reset := s.Objects[4].(*schema.Proc)
+	require.Equal(t, "reset", reset.Name)
+	require.Equal(t, []*schema.FuncArg{{Type: &schema.StringType{T: "text"}, Mode: schema.FuncArgModeInOut}}, reset.Args)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestPlanChanges_Funcs(t *testing.T) {
+	var (
+		e   = &schema.EnumType{T: "status", Values: []string{"on", "off"}}
+		add = schema.NewFunc("add").
+			AddArgs(
+				schema.NewFuncArg("a", &schema.IntegerType{T: "int"}),
+				schema.NewFuncArg("b", &schema.IntegerType{T: "int"}).SetDefault(&schema.Literal{V: "1"}),
+			).
+			SetReturn(&schema.IntegerType{T: "int"}).
+			SetLang("SQL").
+			SetBody("SELECT a + b").
+			AddAttrs(&FuncVolatility{V: FuncVolatilityImmutable}).
+			SetComment("c")
+		isOn = schema.NewFunc("is_on").
+			AddArgs(schema.NewFuncArg("s", e)).
+			SetReturn(&schema.BoolType{T: "boolean"}).
+			SetLang("PLpgSQL").
+			SetBody("BEGIN\n  RETURN s = 'on' OR '$$' = '';\nEND;")
+		reset = schema.NewProc("reset").
+			AddArgs(schema.NewFuncArg("n", &schema.IntegerType{T: "int"})).
+			SetLang("SQL").
+			SetBody("DELETE FROM t WHERE id > n")
+		t1 = schema.NewTable("t1").AddColumns(
+			schema.NewIntColumn("a", "int").SetDefault(&schema.RawExpr{X: "add(1, 2)"}),
+		)
+	)
+	e.Schema = schema.New("public").AddObjects(e).AddFuncs(add, isOn).AddProcs(reset).AddTables(t1)
+	tests := []struct {
+		changes []schema.Change
+		want    [][2]string
+	}{
+		// Functions are created before the tables that use them.
+		{
+			changes: []schema.Change{
+				&schema.AddTable{T: t1},
+				&schema.AddObject{O: add},
+				&schema.AddObject{O: e},
+				&schema.AddObject{O: isOn},
+				&schema.AddObject{O: reset},
+			},
+			want: [][2]string{
+				{`CREATE FUNCTION "public"."add" ("a" integer, "b" integer DEFAULT 1) RETURNS integer LANGUAGE SQL IMMUTABLE AS $$SELECT a + b$$`, `DROP FUNCTION "public"."add" (integer, integer)`},
+				{`COMMENT ON FUNCTION "public"."add" (integer, integer) IS 'c'`, `COMMENT ON FUNCTION "public"."add" (integer, integer) IS ''`},
+				{`CREATE TYPE "public"."status" AS ENUM ('on', 'off')`, `DROP TYPE "public"."status"`},
+				{"CREATE FUNCTION \"public\".\"is_on\" (\"s\" \"public\".\"status\") RETURNS boolean LANGUAGE PLpgSQL AS $_0$\nBEGIN\n  RETURN s = 'on' OR '$$' = '';\nEND;\n$_0$", `DROP FUNCTION "public"."is_on" ("public"."status")`},
+				{`CREATE PROCEDURE "public"."reset" ("n" integer) LANGUAGE SQL AS $$DELETE FROM t WHERE id > n$$`, `DROP PROCEDURE "public"."reset" (integer)`},
+				{`CREATE TABLE "public"."t1" ("a" integer NOT NULL DEFAULT add(1, 2))`, `DROP TABLE "public"."t1"`},
+			},
+		},
+		// Functions are dropped after the tables that use them.
+		{
+			changes: []schema.Change{
+				&schema.DropObject{O: add},
+				&schema.DropTable{T: t1},
+			},
+			want: [][2]string{
+				{`DROP TABLE "public"."t1"`, `CREATE TABLE "public"."t1" ("a" integer NOT NULL DEFAULT add(1, 2))`},
+				{`DROP FUNCTION "public"."add" (integer, integer)`, `CREATE FUNCTION "public"."add" ("a" integer, "b" integer DEFAULT 1) RETURNS integer LANGUAGE SQL IMMUTABLE AS $$SELECT a + b$$`},
+			},
+		},
+		// The signature was not changed.
+		{
+			changes: []schema.Change{
+				&schema.ModifyObject{
+					From: add,
+					To: func() *schema.Func {
+						to := *add
+						to.Body = "SELECT a + b + 0"
+						to.Attrs = []schema.Attr{&schema.Comment{Text: "d"}}
+						return &to
+					}(),
+				},
+			},
+			want: [][2]string{
+				{`CREATE OR REPLACE FUNCTION "public"."add" ("a" integer, "b" integer DEFAULT 1) RETURNS integer LANGUAGE SQL AS $$SELECT a + b + 0$$`, `CREATE OR REPLACE FUNCTION "public"."add" ("a" integer, "b" integer DEFAULT 1) RETURNS integer LANGUAGE SQL IMMUTABLE AS $$SELECT a + b$$`},
+				{`COMMENT ON FUNCTION "public"."add" (integer, integer) IS 'd'`, `COMMENT ON FUNCTION "public"."add" (integer, integer) IS 'c'`},
+			},
+		},
+		// The return type was changed.
+		{
+			changes: []schema.Change{
+				&schema.ModifyObject{
+					From: add,
+					To: func() *schema.Func {
+						to := *add
+						to.Ret = &schema.IntegerType{T: "bigint"}
+						to.Attrs = nil
+						return &to
+					}(),
+				},
+			},
+			want: [][2]string{
+				{`DROP FUNCTION "public"."add" (integer, integer)`, `CREATE FUNCTION "public"."add" ("a" integer, "b" integer DEFAULT 1) RETURNS integer LANGUAGE SQL IMMUTABLE AS $$SELECT a + b$$`},
+				{`CREATE FUNCTION "public"."add" ("a" integer, "b" integer DEFAULT 1) RETURNS bigint LANGUAGE SQL AS $$SELECT a + b$$`, `DROP FUNCTION "public"."add" (integer, integer)`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		db, mk, err := sqlmock.New()
+		require.NoError(t, err)
+		mock{mk}.version("130000")
+		drv, err := Open(db)
+		require.NoError(t, err)
+		plan, err := drv.PlanChanges(context.Background(), "plan", tt.changes)
+		require.NoError(t, err)
+		require.Len(t, plan.Changes, len(tt.want))
+		for i, c := range plan.Changes {
+			require.Equal(t, tt.want[i][0], c.Cmd)
+			require.Equal(t, tt.want[i][1], c.Reverse)
+		}
+	}
+}
+
+func TestDiff_Funcs(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	var (
+		fn = func(name string, args ...*schema.FuncArg) *schema.Func {
+			return schema.NewFunc(name).
+				AddArgs(args...).
+				SetReturn(&schema.IntegerType{T: "integer"}).
+				SetLang("sql").
+				SetBody("SELECT 1")
+		}
+		intArg = func(name string) *schema.FuncArg {
+			return schema.NewFuncArg(name, &schema.IntegerType{T: "integer"})
+		}
+		from = schema.New("public").AddFuncs(
+			fn("dropped"),
+			fn("modified", intArg("a")),
+			// Overloaded functions are identified by their input arguments.
+			fn("overloaded", intArg("a")),
+			fn("unchanged", intArg("a")),
+		)
+		to = schema.New("public").AddFuncs(
+			fn("modified", intArg("a")).SetBody("SELECT 2"),
+			fn("overloaded", intArg("a"), intArg("b")),
+			// Types, languages and bodies are normalized.
+			fn("unchanged", schema.NewFuncArg("a", &schema.IntegerType{T: "int"})).SetLang("SQL").SetBody("\n  SELECT 1\n"),
+			fn("added"),
+		)
+	)
+	changes, err := drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.EqualValues(t, []schema.Change{
+		&schema.DropObject{O: from.Objects[0]},
+		&schema.DropObject{O: from.Objects[2]},
+		&schema.ModifyObject{From: from.Objects[1], To: to.Objects[0]},
+		&schema.AddObject{O: to.Objects[1]},
+		&schema.AddObject{O: to.Objects[3]},
+	}, changes)
+}
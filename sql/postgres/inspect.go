@@ -49,9 +49,44 @@ func (i *inspect) InspectRealm(ctx context.Context, opts *schema.InspectRealmOpt
 				return nil, err
 			}
 		}
+		if mode.Is(schema.InspectTriggers) {
+			if err := i.inspectTriggers(ctx, r, nil); err != nil {
+				return nil, err
+			}
+		}
+		if mode.Is(schema.InspectTables) && mode.Is(schema.InspectPolicies) {
+			if err := i.inspectPolicies(ctx, r); err != nil {
+				return nil, err
+			}
+		}
+		if mode.Is(schema.InspectExtensions) {
+			if err := i.inspectExtensions(ctx, r); err != nil {
+				return nil, err
+			}
+		}
 		if err := i.inspectEnums(ctx, r); err != nil {
 			return nil, err
 		}
+		if mode.Is(schema.InspectTypes) {
+			if err := i.inspectTypes(ctx, r); err != nil {
+				return nil, err
+			}
+		}
+		if mode.Is(schema.InspectFuncs) {
+			if err := i.inspectFuncs(ctx, r, nil); err != nil {
+				return nil, err
+			}
+		}
+		if mode.Is(schema.InspectSequences) {
+			if err := i.inspectSequences(ctx, r); err != nil {
+				return nil, err
+			}
+		}
+		if mode.Is(schema.InspectRoles) {
+			if err := i.inspectRoles(ctx, r); err != nil {
+				return nil, err
+			}
+		}
 	}
 	return sqlx.ExcludeRealm(r, opts.Exclude)
 }
@@ -85,9 +120,44 @@ func (i *inspect) InspectSchema(ctx context.Context, name string, opts *schema.I
 			return nil, err
 		}
 	}
+	if sqlx.ModeInspectSchema(opts).Is(schema.InspectTriggers) {
+		if err := i.inspectTriggers(ctx, r, opts); err != nil {
+			return nil, err
+		}
+	}
+	if sqlx.ModeInspectSchema(opts).Is(schema.InspectTables) && sqlx.ModeInspectSchema(opts).Is(schema.InspectPolicies) {
+		if err := i.inspectPolicies(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	if sqlx.ModeInspectSchema(opts).Is(schema.InspectExtensions) {
+		if err := i.inspectExtensions(ctx, r); err != nil {
+			return nil, err
+		}
+	}
 	if err := i.inspectEnums(ctx, r); err != nil {
 		return nil, err
 	}
+	if sqlx.ModeInspectSchema(opts).Is(schema.InspectTypes) {
+		if err := i.inspectTypes(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	if sqlx.ModeInspectSchema(opts).Is(schema.InspectFuncs) {
+		if err := i.inspectFuncs(ctx, r, opts); err != nil {
+			return nil, err
+		}
+	}
+	if sqlx.ModeInspectSchema(opts).Is(schema.InspectSequences) {
+		if err := i.inspectSequences(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	if sqlx.ModeInspectSchema(opts).Is(schema.InspectRoles) {
+		if err := i.inspectRoles(ctx, r); err != nil {
+			return nil, err
+		}
+	}
 	return sqlx.ExcludeSchema(r.Schemas[0], opts.Exclude)
 }
 
@@ -115,12 +185,15 @@ func (i *inspect) inspectTables(ctx context.Context, r *schema.Realm, opts *sche
 		if err := i.indexes(ctx, s); err != nil {
 			return err
 		}
-		if err := i.partitions(s); err != nil {
+		if err := i.partitions(ctx, s); err != nil {
 			return err
 		}
 		if err := i.fks(ctx, s); err != nil {
 			return err
 		}
+		if err := i.constraints(ctx, s); err != nil {
+			return err
+		}
 		if err := i.checks(ctx, s); err != nil {
 			return err
 		}
@@ -334,20 +407,490 @@ func (i *inspect) inspectEnums(ctx context.Context, r *schema.Realm) error {
 	return nil
 }
 
+// inspectExtensions queries and appends the extensions that are installed in the schemas of the realm.
+func (i *inspect) inspectExtensions(ctx context.Context, r *schema.Realm) error {
+	// CockroachDB does not support extensions.
+	if i.crdb || len(r.Schemas) == 0 {
+		return nil
+	}
+	args := make([]any, 0, len(r.Schemas))
+	for _, s := range r.Schemas {
+		args = append(args, s.Name)
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(extensionsQuery, nArgs(0, len(args))), args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying extensions: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			ns, name, version string
+			comment           sql.NullString
+		)
+		if err := rows.Scan(&ns, &name, &version, &comment); err != nil {
+			return fmt.Errorf("postgres: scanning extension information: %w", err)
+		}
+		s, ok := r.Schema(ns)
+		if !ok {
+			return fmt.Errorf("postgres: schema %q for extension %q was not found in inspection", ns, name)
+		}
+		e := &Extension{Name: name, Schema: s, Version: version}
+		if sqlx.ValidString(comment) {
+			e.Attrs = append(e.Attrs, &schema.Comment{Text: comment.String})
+		}
+		s.Objects = append(s.Objects, e)
+	}
+	return rows.Close()
+}
+
+// inspectTypes queries and appends the domains and the composite types of the given realm.
+// Columns (and types) that use them are updated to reference the inspected objects.
+func (i *inspect) inspectTypes(ctx context.Context, r *schema.Realm) error {
+	// CockroachDB does not support domains and composite types.
+	if i.crdb || len(r.Schemas) == 0 {
+		return nil
+	}
+	args := make([]any, 0, len(r.Schemas))
+	for _, s := range r.Schemas {
+		args = append(args, s.Name)
+	}
+	if err := i.domains(ctx, r, args); err != nil {
+		return err
+	}
+	if err := i.composites(ctx, r, args); err != nil {
+		return err
+	}
+	resolveTypes(r)
+	return nil
+}
+
+// domains queries and appends the domains of the given realm.
+func (i *inspect) domains(ctx context.Context, r *schema.Realm, args []any) error {
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(domainsQuery, nArgs(0, len(args))), args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying domains: %w", err)
+	}
+	defer rows.Close()
+	var last *Domain
+	for rows.Next() {
+		var (
+			ns, name, base                 string
+			notnull                        bool
+			defaults, check, expr, comment sql.NullString
+		)
+		if err := rows.Scan(&ns, &name, &base, &notnull, &defaults, &check, &expr, &comment); err != nil {
+			return fmt.Errorf("postgres: scanning domain information: %w", err)
+		}
+		s, ok := r.Schema(ns)
+		if !ok {
+			return fmt.Errorf("postgres: schema %q for domain %q was not found in inspection", ns, name)
+		}
+		// Domains with multiple checks are returned in multiple rows.
+		if last == nil || last.T != name || last.Schema != s {
+			t, err := ParseType(base)
+			if err != nil {
+				return fmt.Errorf("postgres: parse base type of domain %q: %w", name, err)
+			}
+			last = &Domain{T: name, Schema: s, Base: t, Null: !notnull}
+			if defaults.Valid {
+				c := &schema.Column{Type: &schema.ColumnType{Type: t}}
+				defaultExpr(c, defaults.String)
+				last.Default = c.Default
+			}
+			if sqlx.ValidString(comment) {
+				last.Attrs = append(last.Attrs, &schema.Comment{Text: comment.String})
+			}
+			s.Objects = append(s.Objects, last)
+		}
+		if sqlx.ValidString(check) {
+			last.Checks = append(last.Checks, &schema.Check{Name: check.String, Expr: expr.String})
+		}
+	}
+	return rows.Close()
+}
+
+// composites queries and appends the composite types of the given realm.
+func (i *inspect) composites(ctx context.Context, r *schema.Realm, args []any) error {
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(compositesQuery, nArgs(0, len(args))), args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying composite types: %w", err)
+	}
+	defer rows.Close()
+	var last *Composite
+	for rows.Next() {
+		var (
+			ns, name            string
+			field, typ, comment sql.NullString
+		)
+		if err := rows.Scan(&ns, &name, &field, &typ, &comment); err != nil {
+			return fmt.Errorf("postgres: scanning composite type information: %w", err)
+		}
+		s, ok := r.Schema(ns)
+		if !ok {
+			return fmt.Errorf("postgres: schema %q for composite type %q was not found in inspection", ns, name)
+		}
+		// Composite types are returned in a row per field.
+		if last == nil || last.T != name || last.Schema != s {
+			last = &Composite{T: name, Schema: s}
+			if sqlx.ValidString(comment) {
+				last.Attrs = append(last.Attrs, &schema.Comment{Text: comment.String})
+			}
+			s.Objects = append(s.Objects, last)
+		}
+		if !sqlx.ValidString(field) {
+			continue
+		}
+		t, err := ParseType(typ.String)
+		if err != nil {
+			return fmt.Errorf("postgres: parse type of field %q in composite type %q: %w", field.String, name, err)
+		}
+		last.Fields = append(last.Fields, &schema.Column{Name: field.String, Type: &schema.ColumnType{Raw: typ.String, Type: t}})
+	}
+	return rows.Close()
+}
+
+// resolveTypes replaces the user-defined types of the columns, domains and
+// composite types in the realm with the types they reference, if exist.
+func resolveTypes(r *schema.Realm) {
+	resolve := func(s *schema.Schema, cs []*schema.Column) {
+		for _, c := range cs {
+			if u, ok := c.Type.Type.(*UserDefinedType); ok {
+				if t, ok := userType(r, s, u.T); ok {
+					c.Type.Type = t
+				}
+			}
+		}
+	}
+	for _, s := range r.Schemas {
+		for _, t := range s.Tables {
+			resolve(s, t.Columns)
+		}
+		for _, v := range s.Views {
+			resolve(s, v.Columns)
+		}
+		for _, o := range s.Objects {
+			switch o := o.(type) {
+			case *Domain:
+				if u, ok := o.Base.(*UserDefinedType); ok {
+					if t, ok := userType(r, s, u.T); ok {
+						o.Base = t
+					}
+				}
+			case *Composite:
+				resolve(s, o.Fields)
+			}
+		}
+	}
+}
+
+// userType returns the enum, domain or composite type that is defined in the realm
+// by the given (optionally qualified) name. Unqualified names are looked up in the
+// given schema first.
+func userType(r *schema.Realm, s *schema.Schema, name string) (schema.Type, bool) {
+	var ns string
+	if i := strings.LastIndexByte(name, '.'); i > 0 {
+		ns, name = strings.Trim(name[:i], `"`), name[i+1:]
+	}
+	name = strings.Trim(name, `"`)
+	match := func(s *schema.Schema) (schema.Type, bool) {
+		o, ok := s.Object(func(o schema.Object) bool {
+			switch o := o.(type) {
+			case *schema.EnumType:
+				return strings.EqualFold(o.T, name)
+			case *Domain:
+				return strings.EqualFold(o.T, name)
+			case *Composite:
+				return strings.EqualFold(o.T, name)
+			}
+			return false
+		})
+		if !ok {
+			return nil, false
+		}
+		return o.(schema.Type), true
+	}
+	if ns != "" {
+		if s, ok := r.Schema(ns); ok {
+			return match(s)
+		}
+		return nil, false
+	}
+	if t, ok := match(s); ok {
+		return t, true
+	}
+	for _, s2 := range r.Schemas {
+		if s2 != s {
+			if t, ok := match(s2); ok {
+				return t, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// inspectPolicies queries and appends the row-level security configuration
+// and policies of the tables in the given realm.
+func (i *inspect) inspectPolicies(ctx context.Context, r *schema.Realm) error {
+	// CockroachDB does not support row-level security.
+	if i.crdb {
+		return nil
+	}
+	for _, s := range r.Schemas {
+		if len(s.Tables) == 0 {
+			continue
+		}
+		if err := i.policies(ctx, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// policies queries and appends the row-level security configuration and policies of the tables in the schema.
+func (i *inspect) policies(ctx context.Context, s *schema.Schema) error {
+	rows, err := i.querySchema(ctx, policiesQuery, s)
+	if err != nil {
+		return fmt.Errorf("postgres: querying schema %q policies: %w", s.Name, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			enabled, enforced, permissive      bool
+			table                              string
+			name, cmd, roles, using, withCheck sql.NullString
+		)
+		if err := rows.Scan(&table, &enabled, &enforced, &name, &permissive, &cmd, &roles, &using, &withCheck); err != nil {
+			return fmt.Errorf("postgres: scanning policy: %w", err)
+		}
+		t, ok := s.Table(table)
+		if !ok {
+			return fmt.Errorf("table %q was not found in schema", table)
+		}
+		if (enabled || enforced) && !sqlx.Has(t.Attrs, &RowSecurity{}) {
+			t.Attrs = append(t.Attrs, &RowSecurity{Enabled: enabled, Enforced: enforced})
+		}
+		if !sqlx.ValidString(name) {
+			continue
+		}
+		p := &Policy{Name: name.String, As: PolicyAsPermissive, For: policyCmd(cmd.String), Using: using.String, Check: withCheck.String}
+		if !permissive {
+			p.As = PolicyAsRestrictive
+		}
+		if roles.String != "" {
+			p.To = strings.Split(roles.String, ",")
+		}
+		t.Attrs = append(t.Attrs, p)
+	}
+	return rows.Err()
+}
+
+// policyCmd returns the command of the policy from its pg_policy.polcmd value.
+func policyCmd(c string) string {
+	switch c {
+	case "r":
+		return PolicyForSelect
+	case "a":
+		return PolicyForInsert
+	case "w":
+		return PolicyForUpdate
+	case "d":
+		return PolicyForDelete
+	default:
+		return PolicyForAll
+	}
+}
+
+// inspectSequences queries and appends the standalone sequences of the given realm. Identity
+// sequences, and sequences that are owned by serial columns, are described by their columns.
+func (i *inspect) inspectSequences(ctx context.Context, r *schema.Realm) error {
+	// CockroachDB does not expose the pg_sequences view.
+	if i.crdb || len(r.Schemas) == 0 {
+		return nil
+	}
+	args := make([]any, 0, len(r.Schemas))
+	for _, s := range r.Schemas {
+		args = append(args, s.Name)
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(sequencesQuery, nArgs(0, len(args))), args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying sequences: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			ns, name, typ                 string
+			start, inc, minV, maxV, cache int64
+			cycle                         bool
+			table, column, comment        sql.NullString
+		)
+		if err := rows.Scan(&ns, &name, &typ, &start, &inc, &minV, &maxV, &cache, &cycle, &table, &column, &comment); err != nil {
+			return fmt.Errorf("postgres: scanning sequence information: %w", err)
+		}
+		s, ok := r.Schema(ns)
+		if !ok {
+			return fmt.Errorf("postgres: schema %q for sequence %q was not found in inspection", ns, name)
+		}
+		t, err := ParseType(typ)
+		if err != nil {
+			return fmt.Errorf("postgres: parse type of sequence %q: %w", name, err)
+		}
+		seq := &Sequence{
+			Name:      name,
+			Schema:    s,
+			Type:      t,
+			Start:     start,
+			Increment: inc,
+			Min:       &minV,
+			Max:       &maxV,
+			Cache:     cache,
+			Cycle:     cycle,
+		}
+		if sqlx.ValidString(table) && sqlx.ValidString(column) {
+			if t, ok := s.Table(table.String); ok {
+				c, ok := t.Column(column.String)
+				if !ok {
+					return fmt.Errorf("postgres: owner column %q of sequence %q was not found in table %q", column.String, name, t.Name)
+				}
+				// Sequences of serial columns are managed by their columns.
+				if st, ok := c.Type.Type.(*SerialType); ok && st.sequence(t, c) == name {
+					continue
+				}
+				seq.Owner.Table, seq.Owner.Column = t, c
+			}
+		}
+		if sqlx.ValidString(comment) {
+			seq.Attrs = append(seq.Attrs, &schema.Comment{Text: comment.String})
+		}
+		s.Objects = append(s.Objects, seq)
+	}
+	return rows.Close()
+}
+
+// inspectRoles queries and appends the roles of the realm, and the privileges that were granted to them on
+// the inspected schemas and their objects. System roles, superusers and the current user are not inspected.
+func (i *inspect) inspectRoles(ctx context.Context, r *schema.Realm) error {
+	if err := i.roles(ctx, r); err != nil {
+		return err
+	}
+	if len(r.Roles) == 0 || len(r.Schemas) == 0 {
+		return nil
+	}
+	return i.grants(ctx, r)
+}
+
+// roles queries and appends the roles of the realm, and links them to the roles they are member of.
+func (i *inspect) roles(ctx context.Context, r *schema.Realm) error {
+	rows, err := i.QueryContext(ctx, rolesQuery)
+	if err != nil {
+		return fmt.Errorf("postgres: querying roles: %w", err)
+	}
+	defer rows.Close()
+	members := make(map[*schema.Role][]string)
+	for rows.Next() {
+		var (
+			name     string
+			login    bool
+			memberOf sql.NullString
+		)
+		if err := rows.Scan(&name, &login, &memberOf); err != nil {
+			return fmt.Errorf("postgres: scanning role information: %w", err)
+		}
+		ro := &schema.Role{Name: name, User: login}
+		r.AddRoles(ro)
+		if memberOf.String != "" {
+			members[ro] = strings.Split(memberOf.String, ",")
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	// Memberships in roles that were not inspected are ignored.
+	for _, ro := range r.Roles {
+		for _, name := range members[ro] {
+			if m, ok := r.Role(name); ok {
+				ro.AddMemberOf(m)
+			}
+		}
+	}
+	return nil
+}
+
+// grants queries and appends the privileges that were granted to the
+// realm roles on the inspected schemas, tables, views, sequences and columns.
+func (i *inspect) grants(ctx context.Context, r *schema.Realm) error {
+	args := make([]any, 0, len(r.Schemas))
+	for _, s := range r.Schemas {
+		args = append(args, s.Name)
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(grantsQuery, nArgs(0, len(args))), args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying grants: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			grantee, kind, ns, name, privilege string
+			colName                            sql.NullString
+			grantable                          bool
+		)
+		if err := rows.Scan(&grantee, &kind, &ns, &name, &colName, &privilege, &grantable); err != nil {
+			return fmt.Errorf("postgres: scanning grant information: %w", err)
+		}
+		// Privileges granted to PUBLIC, or to roles
+		// that were not inspected, are ignored.
+		ro, ok := r.Role(grantee)
+		if !ok {
+			continue
+		}
+		s, ok := r.Schema(ns)
+		if !ok {
+			continue
+		}
+		var (
+			on     schema.Object
+			column *schema.Column
+		)
+		switch kind {
+		case "schema":
+			on = s
+		case "sequence":
+			if seq, ok := s.Object(func(o schema.Object) bool {
+				seq, ok := o.(*Sequence)
+				return ok && seq.Name == name
+			}); ok {
+				on = seq
+			}
+		case "table", "column":
+			if t, ok := s.Table(name); ok {
+				on = t
+				if kind == "column" {
+					if column, ok = t.Column(colName.String); !ok {
+						return fmt.Errorf("postgres: column %q of grant was not found in table %q", colName.String, t.Name)
+					}
+				}
+			} else if v, ok := s.View(name); ok {
+				on = v
+				if kind == "column" {
+					// Column-level privileges of views are not supported.
+					on = nil
+				}
+			}
+		}
+		// Objects that were not inspected are ignored.
+		if on == nil {
+			continue
+		}
+		sqlx.AddPrivilege(ro, on, column, privilege, grantable)
+	}
+	return rows.Err()
+}
+
 // indexes queries and appends the indexes of the given table.
 func (i *inspect) indexes(ctx context.Context, s *schema.Schema) error {
-	var query string
-	switch {
-	case i.conn.crdb:
+	if i.conn.crdb {
 		return i.crdbIndexes(ctx, s)
-	case i.supportsIndexNullsDistinct():
-		query = indexesAbove15
-	case i.supportsIndexInclude():
-		query = indexesAbove11
-	default:
-		query = indexesBelow11
 	}
-	rows, err := i.querySchema(ctx, query, s)
+	rows, err := i.querySchema(ctx, i.indexesQuery(), s)
 	if err != nil {
 		return fmt.Errorf("postgres: querying schema %q indexes: %w", s.Name, err)
 	}
@@ -358,7 +901,20 @@ func (i *inspect) indexes(ctx context.Context, s *schema.Schema) error {
 	return rows.Err()
 }
 
-// addIndexes scans the rows and adds the indexes to the table.
+// indexesQuery returns the query for inspecting indexes, based on the database version.
+func (i *inspect) indexesQuery() string {
+	switch {
+	case i.supportsIndexNullsDistinct():
+		return indexesAbove15
+	case i.supportsIndexInclude():
+		return indexesAbove11
+	default:
+		return indexesBelow11
+	}
+}
+
+// addIndexes scans the rows and adds the indexes to their tables,
+// or to their materialized views.
 func (i *inspect) addIndexes(s *schema.Schema, rows *sql.Rows) error {
 	names := make(map[string]*schema.Index)
 	for rows.Next() {
@@ -374,8 +930,17 @@ func (i *inspect) addIndexes(s *schema.Schema, rows *sql.Rows) error {
 		); err != nil {
 			return fmt.Errorf("postgres: scanning indexes for schema %q: %w", s.Name, err)
 		}
-		t, ok := s.Table(table)
-		if !ok {
+		var (
+			ok       bool
+			t        *schema.Table
+			v        *schema.View
+			columnOf func(string) (*schema.Column, bool)
+		)
+		if t, ok = s.Table(table); ok {
+			columnOf = t.Column
+		} else if v, ok = s.View(table); ok && v.Materialized() {
+			columnOf = v.Column
+		} else {
 			return fmt.Errorf("table %q was not found in schema", table)
 		}
 		idx, ok := names[name]
@@ -384,6 +949,7 @@ func (i *inspect) addIndexes(s *schema.Schema, rows *sql.Rows) error {
 				Name:   name,
 				Unique: uniq,
 				Table:  t,
+				View:   v,
 				Attrs: []schema.Attr{
 					&IndexType{T: typ},
 				},
@@ -414,9 +980,12 @@ func (i *inspect) addIndexes(s *schema.Schema, rows *sql.Rows) error {
 				idx.AddAttrs(&IndexNullsDistinct{V: false})
 			}
 			names[name] = idx
-			if primary {
+			switch {
+			case v != nil:
+				v.Indexes = append(v.Indexes, idx)
+			case primary:
 				t.PrimaryKey = idx
-			} else {
+			default:
 				t.Indexes = append(t.Indexes, idx)
 			}
 		}
@@ -429,7 +998,7 @@ func (i *inspect) addIndexes(s *schema.Schema, rows *sql.Rows) error {
 		}
 		switch {
 		case included:
-			c, ok := t.Column(column.String)
+			c, ok := columnOf(column.String)
 			if !ok {
 				return fmt.Errorf("postgres: INCLUDE column %q was not found for index %q", column.String, idx.Name)
 			}
@@ -438,7 +1007,7 @@ func (i *inspect) addIndexes(s *schema.Schema, rows *sql.Rows) error {
 			include.Columns = append(include.Columns, c)
 			schema.ReplaceOrAppend(&idx.Attrs, &include)
 		case sqlx.ValidString(column):
-			part.C, ok = t.Column(column.String)
+			part.C, ok = columnOf(column.String)
 			if !ok {
 				return fmt.Errorf("postgres: column %q was not found for index %q", column.String, idx.Name)
 			}
@@ -473,7 +1042,8 @@ func mayAppendOps(part *schema.IndexPart, name string, params string, defaults b
 }
 
 // partitions builds the partition each table in the schema.
-func (i *inspect) partitions(s *schema.Schema) error {
+func (i *inspect) partitions(ctx context.Context, s *schema.Schema) error {
+	var partitioned bool
 	for _, t := range s.Tables {
 		var d Partition
 		if !sqlx.Has(t.Attrs, &d) {
@@ -518,8 +1088,135 @@ func (i *inspect) partitions(s *schema.Schema) error {
 			}
 		}
 		schema.ReplaceOrAppend(&t.Attrs, &d)
+		partitioned = true
 	}
-	return nil
+	if !partitioned || i.crdb {
+		return nil
+	}
+	return i.partitionChildren(ctx, s)
+}
+
+// partitionChildren queries and appends the child partitions of the partitioned tables in the schema.
+func (i *inspect) partitionChildren(ctx context.Context, s *schema.Schema) error {
+	rows, err := i.querySchema(ctx, partitionChildrenQuery, s)
+	if err != nil {
+		return fmt.Errorf("postgres: querying schema %q partitions: %w", s.Name, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var table, name, bound string
+		if err := rows.Scan(&table, &name, &bound); err != nil {
+			return fmt.Errorf("postgres: scanning partition: %w", err)
+		}
+		t, ok := s.Table(table)
+		if !ok {
+			return fmt.Errorf("table %q was not found in schema", table)
+		}
+		b, err := parsePartitionBound(bound)
+		if err != nil {
+			return fmt.Errorf("postgres: parsing bound of partition %q: %w", name, err)
+		}
+		t.AddAttrs(&PartitionChild{Name: name, Bound: *b})
+	}
+	return rows.Close()
+}
+
+// parsePartitionBound parses the partition bound as returned by pg_get_expr.
+// For example: "FOR VALUES FROM ('2024-01-01') TO ('2024-02-01')", or "DEFAULT".
+func parsePartitionBound(s string) (*PartitionBound, error) {
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "DEFAULT") {
+		return &PartitionBound{Default: true}, nil
+	}
+	var (
+		b      PartitionBound
+		values = func(prefix string) ([]string, error) {
+			if !strings.HasPrefix(strings.ToUpper(s), prefix) {
+				return nil, fmt.Errorf("expected %q in %q", prefix, s)
+			}
+			s = strings.TrimSpace(s[len(prefix):])
+			j := parenEnd(s)
+			if j == -1 {
+				return nil, fmt.Errorf("unbalanced parentheses in %q", s)
+			}
+			list := s[1:j]
+			s = strings.TrimSpace(s[j+1:])
+			var vs []string
+			for list != "" {
+				j := sqlx.ExprLastIndex(list)
+				if j == -1 {
+					return nil, fmt.Errorf("unexpected value list: %q", list)
+				}
+				vs = append(vs, strings.TrimSpace(list[:j+1]))
+				list = strings.TrimSpace(strings.TrimPrefix(list[j+1:], ","))
+			}
+			return vs, nil
+		}
+		err error
+	)
+	switch u := strings.ToUpper(s); {
+	case strings.HasPrefix(u, "FOR VALUES FROM"):
+		if b.From, err = values("FOR VALUES FROM"); err != nil {
+			return nil, err
+		}
+		if b.To, err = values("TO"); err != nil {
+			return nil, err
+		}
+	case strings.HasPrefix(u, "FOR VALUES IN"):
+		if b.In, err = values("FOR VALUES IN"); err != nil {
+			return nil, err
+		}
+	case strings.HasPrefix(u, "FOR VALUES WITH"):
+		vs, err := values("FOR VALUES WITH")
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range vs {
+			switch k, n, _ := strings.Cut(v, " "); strings.ToLower(k) {
+			case "modulus":
+				b.Modulus, err = strconv.ParseInt(strings.TrimSpace(n), 10, 64)
+			case "remainder":
+				b.Remainder, err = strconv.ParseInt(strings.TrimSpace(n), 10, 64)
+			default:
+				err = fmt.Errorf("unexpected hash bound option %q", v)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unexpected partition bound %q", s)
+	}
+	if s != "" {
+		return nil, fmt.Errorf("unexpected trailing partition bound %q", s)
+	}
+	return &b, nil
+}
+
+// parenEnd returns the index of the parenthesis that closes the one
+// that opens the given string, while skipping quoted literals.
+func parenEnd(s string) int {
+	if s == "" || s[0] != '(' {
+		return -1
+	}
+	var depth int
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			if depth--; depth == 0 {
+				return i
+			}
+		case '\'', '"':
+			j := strings.IndexByte(s[i+1:], s[i])
+			if j == -1 {
+				return -1
+			}
+			i += j + 1
+		}
+	}
+	return -1
 }
 
 // fks queries and appends the foreign keys of the given table.
@@ -535,6 +1232,107 @@ func (i *inspect) fks(ctx context.Context, s *schema.Schema) error {
 	return rows.Err()
 }
 
+// constraints queries and appends the DEFERRABLE, EXCLUDE and NOT VALID attributes
+// of the foreign keys and the constraint indexes of the schema tables. The query is
+// skipped if there are no constraints that can hold these attributes.
+func (i *inspect) constraints(ctx context.Context, s *schema.Schema) error {
+	if i.conn.crdb || !hasConstraints(s) {
+		return nil
+	}
+	rows, err := i.querySchema(ctx, constraintsQuery, s)
+	if err != nil {
+		return fmt.Errorf("postgres: querying schema %q constraints: %w", s.Name, err)
+	}
+	defer rows.Close()
+	if err := i.addConstraints(s, rows); err != nil {
+		return err
+	}
+	return rows.Err()
+}
+
+// addConstraints scans the rows and adds the constraint attributes to their
+// foreign keys or indexes.
+func (i *inspect) addConstraints(s *schema.Schema, rows *sql.Rows) error {
+	for rows.Next() {
+		var (
+			deferrable, deferred, validated bool
+			table, name, typ                string
+			ops                             sql.NullString
+		)
+		if err := rows.Scan(&table, &name, &typ, &deferrable, &deferred, &validated, &ops); err != nil {
+			return fmt.Errorf("postgres: scanning constraints: %w", err)
+		}
+		t, ok := s.Table(table)
+		if !ok {
+			return fmt.Errorf("table %q was not found in schema", table)
+		}
+		var attrs *[]schema.Attr
+		switch typ {
+		case "f":
+			fk, ok := t.ForeignKey(name)
+			if !ok {
+				return fmt.Errorf("postgres: foreign key %q was not found in table %q", name, table)
+			}
+			attrs = &fk.Attrs
+		default:
+			idx, ok := constraintIndex(t, name)
+			if !ok {
+				return fmt.Errorf("postgres: index of constraint %q was not found in table %q", name, table)
+			}
+			if sqlx.ValidString(ops) {
+				var names []string
+				if err := json.Unmarshal([]byte(ops.String), &names); err != nil {
+					return fmt.Errorf("postgres: unmarshaling exclusion operators: %w", err)
+				}
+				if len(names) != len(idx.Parts) {
+					return fmt.Errorf("postgres: mismatched number of operators (%d) and parts (%d) of constraint %q", len(names), len(idx.Parts), name)
+				}
+				for j, op := range names {
+					idx.Parts[j].AddAttrs(&IndexExcludeOp{V: op})
+				}
+			}
+			attrs = &idx.Attrs
+		}
+		if deferrable {
+			*attrs = append(*attrs, &Deferrable{Deferred: deferred})
+		}
+		if !validated {
+			*attrs = append(*attrs, &NotValid{})
+		}
+	}
+	return nil
+}
+
+// hasConstraints reports if the schema tables hold foreign keys,
+// or indexes that back UNIQUE or EXCLUDE constraints.
+func hasConstraints(s *schema.Schema) bool {
+	for _, t := range s.Tables {
+		if len(t.ForeignKeys) > 0 {
+			return true
+		}
+		for _, idx := range t.Indexes {
+			for _, a := range idx.Attrs {
+				if c, ok := a.(*Constraint); ok && (c.IsUnique() || c.IsExclusion()) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// constraintIndex returns the index that backs the given table constraint.
+func constraintIndex(t *schema.Table, name string) (*schema.Index, bool) {
+	for _, idx := range t.Indexes {
+		for _, a := range idx.Attrs {
+			if c, ok := a.(*Constraint); ok && c.N == name {
+				return idx, true
+			}
+		}
+	}
+	return nil, false
+}
+
 // checks queries and appends the check constraints of the given table.
 func (i *inspect) checks(ctx context.Context, s *schema.Schema) error {
 	rows, err := i.querySchema(ctx, checksQuery, s)
@@ -553,10 +1351,10 @@ func (i *inspect) addChecks(s *schema.Schema, rows *sql.Rows) error {
 	names := make(map[string]*schema.Check)
 	for rows.Next() {
 		var (
-			noInherit                            bool
+			noInherit, validated                 bool
 			table, name, column, clause, indexes string
 		)
-		if err := rows.Scan(&table, &name, &clause, &column, &indexes, &noInherit); err != nil {
+		if err := rows.Scan(&table, &name, &clause, &column, &indexes, &noInherit, &validated); err != nil {
 			return fmt.Errorf("postgres: scanning check: %w", err)
 		}
 		t, ok := s.Table(table)
@@ -572,6 +1370,9 @@ func (i *inspect) addChecks(s *schema.Schema, rows *sql.Rows) error {
 			if noInherit {
 				check.Attrs = append(check.Attrs, &NoInherit{})
 			}
+			if !validated {
+				check.Attrs = append(check.Attrs, &NotValid{})
+			}
 			names[name] = check
 			t.Attrs = append(t.Attrs, check)
 		}
@@ -812,15 +1613,92 @@ type (
 		T string // c, f, p, u, t, x.
 	}
 
-	// Sequence defines (the supported) sequence options.
+	// Deferrable describes a DEFERRABLE constraint. It is attached to foreign
+	// keys, and to indexes that back UNIQUE or EXCLUDE constraints.
+	// https://www.postgresql.org/docs/current/sql-set-constraints.html
+	Deferrable struct {
+		schema.Attr
+		// Deferred reports if the constraint is INITIALLY DEFERRED.
+		// By default, a deferrable constraint is INITIALLY IMMEDIATE.
+		Deferred bool
+	}
+
+	// IndexExcludeOp describes the operator of an EXCLUDE constraint part,
+	// for example, "&&" in: EXCLUDE USING gist (during WITH &&).
+	// An index whose parts hold this attribute is an exclusion constraint.
+	// https://www.postgresql.org/docs/current/sql-createtable.html#SQL-CREATETABLE-EXCLUDE
+	IndexExcludeOp struct {
+		schema.Attr
+		V string
+	}
+
+	// Sequence defines (the supported) sequence options. Standalone sequences,
+	// created with CREATE SEQUENCE, are stored as objects in their schema.
 	// https://postgresql.org/docs/current/sql-createsequence.html
 	Sequence struct {
-		Start, Increment int64
+		schema.Object
+		// Name and schema of standalone sequences.
+		// Both are empty for identity sequences.
+		Name   string
+		Schema *schema.Schema
+		// Type is the sequence data type. A nil type means bigint.
+		Type schema.Type
+		// Zero values mean the default of the sequence option.
+		Start, Increment, Cache int64
+		// Min and Max hold the MINVALUE and MAXVALUE of the
+		// sequence. A nil value means the default of its type.
+		Min, Max *int64
+		Cycle    bool
+		// Owner holds the column that owns the sequence (OWNED BY), if there is one.
+		Owner struct {
+			Table  *schema.Table
+			Column *schema.Column
+		}
+		Attrs []schema.Attr
 		// Last sequence value written to disk.
 		// https://postgresql.org/docs/current/view-pg-sequences.html.
 		Last int64
 	}
 
+	// Extension describes an extension that is installed in a schema.
+	// https://postgresql.org/docs/current/sql-createextension.html
+	Extension struct {
+		schema.Object
+		Name   string
+		Schema *schema.Schema
+		// Version of the extension. An empty version
+		// means the default version of the extension.
+		Version string
+		Attrs   []schema.Attr
+	}
+
+	// Domain represents a domain type: a base type with optional constraints.
+	// Domains are stored in the schema objects, and can be used as column types.
+	// https://postgresql.org/docs/current/sql-createdomain.html
+	Domain struct {
+		schema.Object
+		schema.Type
+		T       string
+		Schema  *schema.Schema
+		Base    schema.Type // Underlying (base) type.
+		Null    bool        // Domains are nullable by default.
+		Default schema.Expr
+		Checks  []*schema.Check
+		Attrs   []schema.Attr
+	}
+
+	// Composite represents a composite type: a list of named and typed fields.
+	// Composites are stored in the schema objects, and can be used as column types.
+	// https://postgresql.org/docs/current/rowtypes.html
+	Composite struct {
+		schema.Object
+		schema.Type
+		T      string
+		Schema *schema.Schema
+		Fields []*schema.Column
+		Attrs  []schema.Attr
+	}
+
 	// Identity defines an identity column.
 	Identity struct {
 		schema.Attr
@@ -863,6 +1741,28 @@ type (
 		PagesPerRange int64
 	}
 
+	// StorageParams describes the storage parameters of a materialized view that
+	// are set with the WITH clause. For example, fillfactor or autovacuum_enabled.
+	// https://postgresql.org/docs/current/sql-createtable.html#SQL-CREATETABLE-STORAGE-PARAMETERS
+	StorageParams struct {
+		schema.Attr
+		Params []struct{ N, V string }
+	}
+
+	// Tablespace describes the tablespace a materialized view is stored in.
+	// https://postgresql.org/docs/current/sql-creatematerializedview.html
+	Tablespace struct {
+		schema.Attr
+		N string
+	}
+
+	// WithNoData describes the WITH NO DATA option of materialized views. Such
+	// views are created unpopulated, and cannot be queried until refreshed.
+	// https://postgresql.org/docs/current/sql-creatematerializedview.html
+	WithNoData struct {
+		schema.Attr
+	}
+
 	// IndexInclude describes the INCLUDE clause allows specifying
 	// a list of column which added to the index as non-key columns.
 	// https://www.postgresql.org/docs/current/sql-createindex.html
@@ -893,12 +1793,36 @@ type (
 		schema.Clause
 	}
 
+	// FuncVolatility describes the volatility category of a function.
+	// https://postgresql.org/docs/current/xfunc-volatility.html
+	FuncVolatility struct {
+		schema.Attr
+		V string // IMMUTABLE, STABLE or VOLATILE.
+	}
+
 	// NoInherit attribute defines the NO INHERIT flag for CHECK constraint.
 	// https://postgresql.org/docs/current/catalog-pg-constraint.html
 	NoInherit struct {
 		schema.Attr
 	}
 
+	// NotValid attribute describes a foreign key or a CHECK constraint that was
+	// added as NOT VALID, and its existing rows were not validated (yet).
+	// https://www.postgresql.org/docs/current/sql-altertable.html#SQL-ALTERTABLE-NOTES
+	NotValid struct {
+		schema.Attr
+	}
+
+	// ValidateLater describes a clause that instructs the planner to add a foreign
+	// key or a CHECK constraint as NOT VALID, followed by a VALIDATE CONSTRAINT statement
+	// that validates the existing rows without blocking writes to the table.
+	ValidateLater struct {
+		schema.Clause
+		// Separate reports if the VALIDATE CONSTRAINT statement
+		// should be written to a separate migration file.
+		Separate bool
+	}
+
 	// CheckColumns attribute hold the column named used by the CHECK constraints.
 	// This attribute is added on inspection for internal usage and has no meaning
 	// on migration.
@@ -921,6 +1845,25 @@ type (
 		start, attrs, exprs string
 	}
 
+	// PartitionChild describes a partition (a child table) of a partitioned table. Child
+	// partitions inherit the columns of their parent, are created in its schema, and are
+	// defined as part of it.
+	PartitionChild struct {
+		schema.Attr
+		Name  string
+		Bound PartitionBound
+	}
+
+	// PartitionBound holds the bound of a child partition. The fields that are
+	// set depend on the strategy of the parent, or on Default being set.
+	PartitionBound struct {
+		Default   bool     // DEFAULT.
+		From, To  []string // FOR VALUES FROM (...) TO (...) of RANGE partitions.
+		In        []string // FOR VALUES IN (...) of LIST partitions.
+		Modulus   int64    // FOR VALUES WITH (MODULUS m, REMAINDER r) of HASH partitions.
+		Remainder int64
+	}
+
 	// An PartitionPart represents an index part that
 	// can be either an expression or a column.
 	PartitionPart struct {
@@ -929,6 +1872,26 @@ type (
 		Attrs []schema.Attr
 	}
 
+	// RowSecurity describes the row-level security configuration of a table.
+	// https://postgresql.org/docs/current/ddl-rowsecurity.html
+	RowSecurity struct {
+		schema.Attr
+		Enabled  bool // ENABLE ROW LEVEL SECURITY.
+		Enforced bool // FORCE ROW LEVEL SECURITY (applies to the table owner as well).
+	}
+
+	// Policy describes a row-level security policy of a table.
+	// https://postgresql.org/docs/current/sql-createpolicy.html
+	Policy struct {
+		schema.Attr
+		Name  string
+		As    string   // PERMISSIVE (default) or RESTRICTIVE.
+		For   string   // ALL (default), SELECT, INSERT, UPDATE or DELETE.
+		To    []string // Roles the policy applies to. Defaults to PUBLIC.
+		Using string   // USING expression.
+		Check string   // WITH CHECK expression.
+	}
+
 	// Cascade describes that a CASCADE clause should be added to the DROP [TABLE|SCHEMA]
 	// operation. Note, this clause is automatically added to DROP SCHEMA by the planner.
 	Cascade struct {
@@ -939,6 +1902,9 @@ type (
 // IsUnique reports if the type is unique constraint.
 func (c Constraint) IsUnique() bool { return strings.ToLower(c.T) == "u" }
 
+// IsExclusion reports if the type is an exclusion constraint.
+func (c Constraint) IsExclusion() bool { return strings.ToLower(c.T) == "x" }
+
 // IntegerType returns the underlying integer type this serial type represents.
 func (s *SerialType) IntegerType() *schema.IntegerType {
 	t := &schema.IntegerType{T: TypeInteger}
@@ -1179,6 +2145,26 @@ WHERE
 	AND t5.objid IS NULL
 ORDER BY
 	t1.table_schema, t1.table_name
+`
+	// Query to list the child partitions of the partitioned tables in the schema.
+	partitionChildrenQuery = `
+SELECT
+	t2.relname AS table_name,
+	t1.relname AS partition_name,
+	pg_catalog.pg_get_expr(t1.relpartbound, t1.oid) AS partition_bound
+FROM
+	pg_catalog.pg_inherits AS t3
+	JOIN pg_catalog.pg_class AS t1 ON t1.oid = t3.inhrelid
+	JOIN pg_catalog.pg_class AS t2 ON t2.oid = t3.inhparent
+	JOIN pg_catalog.pg_namespace AS t4 ON t4.oid = t2.relnamespace
+WHERE
+	t1.relispartition
+	AND t2.relkind = 'p'
+	AND t1.relnamespace = t2.relnamespace
+	AND t4.nspname = $1
+	AND t2.relname IN (%s)
+ORDER BY
+	t2.relname, t1.relname
 `
 	tablesQueryArgs = `
 SELECT
@@ -1258,6 +2244,208 @@ ORDER BY
     n.nspname, e.enumtypid, e.enumsortorder
 `
 	// Query to list foreign-keys.
+	// Query to list the extensions that are installed in the given schemas.
+	extensionsQuery = `
+SELECT
+	n.nspname AS schema_name,
+	e.extname AS extension_name,
+	e.extversion AS extension_version,
+	pg_catalog.obj_description(e.oid, 'pg_extension') AS comment
+FROM
+	pg_catalog.pg_extension AS e
+	JOIN pg_catalog.pg_namespace AS n ON n.oid = e.extnamespace
+WHERE
+	n.nspname IN (%s)
+ORDER BY
+	e.extname
+`
+	// Query to list the row-level security configuration and the policies of the
+	// given tables, a row per policy. Tables without policies are returned once.
+	policiesQuery = `
+SELECT
+	c.relname AS table_name,
+	c.relrowsecurity AS enabled,
+	c.relforcerowsecurity AS enforced,
+	p.polname AS policy_name,
+	COALESCE(p.polpermissive, true) AS permissive,
+	p.polcmd AS command,
+	(CASE WHEN p.polroles = '{0}' THEN NULL ELSE pg_catalog.array_to_string(ARRAY(SELECT r.rolname FROM pg_catalog.pg_roles AS r WHERE r.oid = ANY(p.polroles) ORDER BY r.rolname), ',') END) AS roles,
+	pg_catalog.pg_get_expr(p.polqual, p.polrelid) AS using_expr,
+	pg_catalog.pg_get_expr(p.polwithcheck, p.polrelid) AS check_expr
+FROM
+	pg_catalog.pg_class AS c
+	JOIN pg_catalog.pg_namespace AS n ON n.oid = c.relnamespace
+	LEFT JOIN pg_catalog.pg_policy AS p ON p.polrelid = c.oid
+WHERE
+	n.nspname = $1
+	AND c.relname IN (%s)
+	AND (c.relrowsecurity OR c.relforcerowsecurity OR p.polname IS NOT NULL)
+ORDER BY
+	c.relname, p.polname
+`
+	// Query to list the roles and the roles they are member of. System roles, superusers
+	// and the current user are excluded, as they are not managed by the schema.
+	rolesQuery = `
+SELECT
+	r.rolname AS role_name,
+	r.rolcanlogin AS login,
+	pg_catalog.array_to_string(ARRAY(SELECT b.rolname FROM pg_catalog.pg_auth_members AS m JOIN pg_catalog.pg_roles AS b ON b.oid = m.roleid WHERE m.member = r.oid ORDER BY b.rolname), ',') AS member_of
+FROM
+	pg_catalog.pg_roles AS r
+WHERE
+	r.rolname !~ '^pg_'
+	AND NOT r.rolsuper
+	AND r.rolname <> CURRENT_USER
+ORDER BY
+	r.rolname
+`
+	// Query to list the privileges that were granted on the given schemas, and their tables, views,
+	// sequences and columns, a row per privilege. Privileges of the object owners are excluded.
+	grantsQuery = `
+SELECT
+	g.grantee,
+	'table' AS object_type,
+	g.table_schema AS schema_name,
+	g.table_name AS object_name,
+	NULL AS column_name,
+	g.privilege_type,
+	g.is_grantable = 'YES' AS grantable
+FROM
+	information_schema.role_table_grants AS g
+	JOIN pg_catalog.pg_namespace AS n ON n.nspname = g.table_schema
+	JOIN pg_catalog.pg_class AS c ON c.relnamespace = n.oid AND c.relname = g.table_name
+WHERE
+	g.table_schema IN (%[1]s)
+	AND g.grantee <> pg_catalog.pg_get_userbyid(c.relowner)
+UNION ALL
+SELECT
+	pg_catalog.pg_get_userbyid(a.grantee),
+	'schema',
+	n.nspname,
+	n.nspname,
+	NULL,
+	a.privilege_type,
+	a.is_grantable
+FROM
+	pg_catalog.pg_namespace AS n,
+	pg_catalog.aclexplode(n.nspacl) AS a
+WHERE
+	n.nspname IN (%[1]s)
+	AND a.grantee NOT IN (0, n.nspowner)
+UNION ALL
+SELECT
+	pg_catalog.pg_get_userbyid(a.grantee),
+	'sequence',
+	n.nspname,
+	c.relname,
+	NULL,
+	a.privilege_type,
+	a.is_grantable
+FROM
+	pg_catalog.pg_class AS c
+	JOIN pg_catalog.pg_namespace AS n ON n.oid = c.relnamespace,
+	pg_catalog.aclexplode(c.relacl) AS a
+WHERE
+	c.relkind = 'S'
+	AND n.nspname IN (%[1]s)
+	AND a.grantee NOT IN (0, c.relowner)
+UNION ALL
+SELECT
+	pg_catalog.pg_get_userbyid(a.grantee),
+	'column',
+	n.nspname,
+	c.relname,
+	t.attname,
+	a.privilege_type,
+	a.is_grantable
+FROM
+	pg_catalog.pg_attribute AS t
+	JOIN pg_catalog.pg_class AS c ON c.oid = t.attrelid
+	JOIN pg_catalog.pg_namespace AS n ON n.oid = c.relnamespace,
+	pg_catalog.aclexplode(t.attacl) AS a
+WHERE
+	t.attnum > 0
+	AND NOT t.attisdropped
+	AND n.nspname IN (%[1]s)
+	AND a.grantee NOT IN (0, c.relowner)
+ORDER BY
+	1, 2, 3, 4, 5
+`
+	// Query to list the domains of the given schemas, a row per CHECK constraint.
+	// Domains that belong to extensions are excluded.
+	domainsQuery = `
+SELECT
+	n.nspname AS schema_name,
+	t.typname AS domain_name,
+	pg_catalog.format_type(t.typbasetype, t.typtypmod) AS base_type,
+	t.typnotnull AS not_null,
+	t.typdefault AS default_value,
+	c.conname AS check_name,
+	pg_catalog.pg_get_expr(c.conbin, 0) AS check_expr,
+	pg_catalog.obj_description(t.oid, 'pg_type') AS comment
+FROM
+	pg_catalog.pg_type AS t
+	JOIN pg_catalog.pg_namespace AS n ON n.oid = t.typnamespace
+	LEFT JOIN pg_catalog.pg_constraint AS c ON c.contypid = t.oid AND c.contype = 'c'
+WHERE
+	t.typtype = 'd'
+	AND n.nspname IN (%s)
+	AND NOT EXISTS (SELECT 1 FROM pg_catalog.pg_depend AS d WHERE d.classid = 'pg_catalog.pg_type'::regclass AND d.objid = t.oid AND d.deptype = 'e')
+ORDER BY
+	n.nspname, t.typname, c.conname
+`
+	// Query to list the composite types of the given schemas, a row per field.
+	// Composite types that belong to extensions are excluded.
+	compositesQuery = `
+SELECT
+	n.nspname AS schema_name,
+	t.typname AS type_name,
+	a.attname AS field_name,
+	pg_catalog.format_type(a.atttypid, a.atttypmod) AS field_type,
+	pg_catalog.obj_description(t.oid, 'pg_type') AS comment
+FROM
+	pg_catalog.pg_type AS t
+	JOIN pg_catalog.pg_namespace AS n ON n.oid = t.typnamespace
+	JOIN pg_catalog.pg_class AS c ON c.oid = t.typrelid AND c.relkind = 'c'
+	LEFT JOIN pg_catalog.pg_attribute AS a ON a.attrelid = c.oid AND a.attnum > 0 AND NOT a.attisdropped
+WHERE
+	t.typtype = 'c'
+	AND n.nspname IN (%s)
+	AND NOT EXISTS (SELECT 1 FROM pg_catalog.pg_depend AS d WHERE d.classid = 'pg_catalog.pg_type'::regclass AND d.objid = t.oid AND d.deptype = 'e')
+ORDER BY
+	n.nspname, t.typname, a.attnum
+`
+	// Query to list the standalone sequences of the given schemas. Identity sequences
+	// and sequences that belong to extensions are excluded.
+	sequencesQuery = `
+SELECT
+	s.schemaname,
+	s.sequencename,
+	s.data_type,
+	s.start_value,
+	s.increment_by,
+	s.min_value,
+	s.max_value,
+	s.cache_size,
+	s.cycle,
+	t.relname AS owner_table,
+	a.attname AS owner_column,
+	pg_catalog.obj_description(c.oid, 'pg_class') AS comment
+FROM
+	pg_catalog.pg_sequences AS s
+	JOIN pg_catalog.pg_namespace AS n ON n.nspname = s.schemaname
+	JOIN pg_catalog.pg_class AS c ON c.relnamespace = n.oid AND c.relname = s.sequencename
+	LEFT JOIN pg_catalog.pg_depend AS d ON d.classid = 'pg_class'::regclass AND d.objid = c.oid AND d.refclassid = 'pg_class'::regclass AND d.deptype IN ('a', 'i')
+	LEFT JOIN pg_catalog.pg_class AS t ON t.oid = d.refobjid
+	LEFT JOIN pg_catalog.pg_attribute AS a ON a.attrelid = d.refobjid AND a.attnum = d.refobjsubid
+	LEFT JOIN pg_catalog.pg_depend AS e ON e.objid = c.oid AND e.deptype = 'e'
+WHERE
+	s.schemaname IN (%s)
+	AND (d.deptype IS NULL OR d.deptype = 'a')
+	AND e.objid IS NULL
+ORDER BY
+	s.schemaname, s.sequencename
+`
 	fksQuery = `
 SELECT 
     fk.constraint_name,
@@ -1298,6 +2486,33 @@ SELECT
 	    fk.conrelid, fk.constraint_name, fk.ord
 `
 
+	// Query to list the deferrable, the exclusion and the not validated constraints of tables.
+	constraintsQuery = `
+SELECT
+	t.relname AS table_name,
+	con.conname AS constraint_name,
+	con.contype AS constraint_type,
+	con.condeferrable AS deferrable,
+	con.condeferred AS deferred,
+	con.convalidated AS validated,
+	(
+		SELECT json_agg(op.oprname ORDER BY o.ord)
+		FROM unnest(con.conexclop) WITH ORDINALITY AS o(oid, ord)
+		JOIN pg_operator op ON op.oid = o.oid
+	) AS operators
+FROM
+	pg_constraint con
+	JOIN pg_class t ON t.oid = con.conrelid
+	JOIN pg_namespace nsp ON nsp.oid = t.relnamespace
+WHERE
+	nsp.nspname = $1
+	AND t.relname IN (%s)
+	AND con.contype IN ('f', 'u', 'x')
+	AND (con.condeferrable OR NOT con.convalidated OR con.contype = 'x')
+ORDER BY
+	t.relname, con.conname
+`
+
 	// Query to list table check constraints.
 	checksQuery = `
 SELECT
@@ -1306,7 +2521,8 @@ SELECT
 	pg_get_expr(t1.conbin, t1.conrelid) as expression,
 	t2.attname as column_name,
 	t1.conkey as column_indexes,
-	t1.connoinherit as no_inherit
+	t1.connoinherit as no_inherit,
+	t1.convalidated as validated
 FROM
 	pg_constraint t1
 	JOIN pg_attribute t2
@@ -7,6 +7,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"math"
 	"testing"
 
 	"ariga.io/atlas/sql/internal/sqltest"
@@ -27,6 +28,7 @@ var (
 	queryColumns     = sqltest.Escape(fmt.Sprintf(columnsQuery, "$2"))
 	queryCRDBColumns = sqltest.Escape(fmt.Sprintf(crdbColumnsQuery, "$2"))
 	queryIndexes     = sqltest.Escape(fmt.Sprintf(indexesAbove15, "$2"))
+	queryConstraints = sqltest.Escape(fmt.Sprintf(constraintsQuery, "$2"))
 	queryCRDBIndexes = sqltest.Escape(fmt.Sprintf(crdbIndexesQuery, "$2"))
 )
 
@@ -191,8 +193,18 @@ users           | idx2            | btree       | id          | f        | f
 users           | idx2            | btree       | c1          | t        | f       | f      |                 |                       | c                         |      |             |            |           |                                       |     int4_ops      |        t        |                | f
 users           | idx2            | btree       | parent_id   | t        | f       | f      |                 |                       | d                         |      |             |            |           |                                       |     int4_ops      |        t        |                | f
 users           | tsx             | gist        | ts          | f        | f       | f      |                 |                       | ts                        |      |             |            |           |                                       |     tsvector_ops  |        f        | {siglen=1}     | f
+users           | no_overlap      | gist        | c1          | f        | f       | f      | {"no_overlap": "x"} |                   | c1                        | f    | f           | f          |           |                                       |   gist_int2_ops   |        t        |                | f
+users           | no_overlap      | gist        | parent_id   | f        | f       | f      | {"no_overlap": "x"} |                   | parent_id                 | f    | f           | f          |           |                                       |   gist_int8_ops   |        t        |                | f
 `))
 				m.noFKs()
+				m.ExpectQuery(queryConstraints).
+					WithArgs("public", "users").
+					WillReturnRows(sqltest.Rows(`
+ table_name | constraint_name | constraint_type | deferrable | deferred | validated |  operators
+------------+-----------------+-----------------+------------+----------+-----------+------------
+ users      | name            | u               | t          | t        | t         |
+ users      | no_overlap      | x               | f          | f        | t         | ["=", "<>"]
+`))
 				m.noChecks()
 				m.noEnums()
 			},
@@ -214,7 +226,9 @@ users           | tsx             | gist        | ts          | f        | f
 					{Name: "idx6", Unique: true, Table: t, Attrs: []schema.Attr{&IndexType{T: "brin"}, &IndexStorageParams{AutoSummarize: true, PagesPerRange: 2}}, Parts: []*schema.IndexPart{{SeqNo: 1, C: columns[1]}}},
 					{Name: "idx2", Unique: false, Table: t, Attrs: []schema.Attr{&IndexType{T: "btree"}, &IndexInclude{Columns: columns[1:3]}}, Parts: []*schema.IndexPart{{SeqNo: 1, X: &schema.RawExpr{X: `((c * 2))`}, Attrs: []schema.Attr{&IndexColumnProperty{NullsLast: true}}}, {SeqNo: 2, C: columns[1], Attrs: []schema.Attr{&IndexColumnProperty{NullsLast: true}}}, {SeqNo: 3, C: columns[0], Attrs: []schema.Attr{&IndexColumnProperty{NullsLast: true}}}}},
 					{Name: "tsx", Unique: false, Table: t, Attrs: []schema.Attr{&IndexType{T: "gist"}}, Parts: []*schema.IndexPart{{SeqNo: 1, C: columns[3], Attrs: []schema.Attr{&IndexOpClass{Name: "tsvector_ops", Params: []struct{ N, V string }{{N: "siglen", V: "1"}}}}}}},
+					{Name: "no_overlap", Unique: false, Table: t, Attrs: []schema.Attr{&IndexType{T: "gist"}, &Constraint{N: "no_overlap", T: "x"}}, Parts: []*schema.IndexPart{{SeqNo: 1, C: columns[1], Attrs: []schema.Attr{&IndexExcludeOp{V: "="}}}, {SeqNo: 2, C: columns[2], Attrs: []schema.Attr{&IndexExcludeOp{V: "<>"}}}}},
 				}
+				indexes[2].Attrs = append(indexes[2].Attrs, &Deferrable{Deferred: true})
 				pk := &schema.Index{
 					Name:   "t1_pkey",
 					Unique: true,
@@ -223,8 +237,9 @@ users           | tsx             | gist        | ts          | f        | f
 					Parts:  []*schema.IndexPart{{SeqNo: 1, C: columns[0], Desc: true}},
 				}
 				columns[0].Indexes = append(columns[0].Indexes, pk, indexes[3], indexes[6])
-				columns[1].Indexes = indexes[2:7]
-				columns[3].Indexes = indexes[7:]
+				columns[1].Indexes = append(indexes[2:7:7], indexes[8])
+				columns[2].Indexes = indexes[8:]
+				columns[3].Indexes = indexes[7:8]
 				require.EqualValues(columns, t.Columns)
 				require.EqualValues(indexes, t.Indexes)
 				require.EqualValues(pk, t.PrimaryKey)
@@ -254,6 +269,14 @@ multi_column    | users      | id          | public       | t1
 multi_column    | users      | oid         | public       | t1                    | gid                    | public                 | NO ACTION   | CASCADE
 multi_column    | users      | oid         | public       | t1                    | xid                    | public                 | NO ACTION   | CASCADE
 self_reference  | users      | uid         | public       | users                 | id                     | public                 | NO ACTION   | CASCADE
+`))
+				m.ExpectQuery(queryConstraints).
+					WithArgs("public", "users").
+					WillReturnRows(sqltest.Rows(`
+ table_name | constraint_name | constraint_type | deferrable | deferred | validated | operators
+------------+-----------------+-----------------+------------+----------+-----------+-----------
+ users      | multi_column    | f               | f          | f        | f         |
+ users      | self_reference  | f               | t          | f        | t         |
 `))
 				m.noChecks()
 				m.noEnums()
@@ -263,8 +286,8 @@ self_reference  | users      | uid         | public       | users
 				require.Equal("users", t.Name)
 				require.Equal("public", t.Schema.Name)
 				fks := []*schema.ForeignKey{
-					{Symbol: "multi_column", Table: t, OnUpdate: schema.NoAction, OnDelete: schema.Cascade, RefTable: &schema.Table{Name: "t1", Schema: t.Schema}, RefColumns: []*schema.Column{{Name: "gid"}, {Name: "xid"}}},
-					{Symbol: "self_reference", Table: t, OnUpdate: schema.NoAction, OnDelete: schema.Cascade, RefTable: t},
+					{Symbol: "multi_column", Table: t, OnUpdate: schema.NoAction, OnDelete: schema.Cascade, RefTable: &schema.Table{Name: "t1", Schema: t.Schema}, RefColumns: []*schema.Column{{Name: "gid"}, {Name: "xid"}}, Attrs: []schema.Attr{&NotValid{}}},
+					{Symbol: "self_reference", Table: t, OnUpdate: schema.NoAction, OnDelete: schema.Cascade, RefTable: t, Attrs: []schema.Attr{&Deferrable{}}},
 				}
 				columns := []*schema.Column{
 					{Name: "id", Type: &schema.ColumnType{Raw: "integer", Type: &schema.IntegerType{T: "integer"}}, ForeignKeys: fks[0:1]},
@@ -296,16 +319,16 @@ users      | c3         | integer   | int4      | NO          |                |
 				m.ExpectQuery(queryChecks).
 					WithArgs("public", "users").
 					WillReturnRows(sqltest.Rows(`
-table_name   | constraint_name    |       expression        | column_name | column_indexes | no_inherit
--------------+--------------------+-------------------------+-------------+----------------+----------------
-users        | boring             | (c1 > 1)                | c1          | {1}            | t
-users        | users_c2_check     | (c2 > 0)                | c2          | {2}            | f
-users        | users_c2_check1    | (c2 > 0)                | c2          | {2}            | f
-users        | users_check        | ((c2 + c1) > 2)         | c2          | {2,1}          | f
-users        | users_check        | ((c2 + c1) > 2)         | c1          | {2,1}          | f
-users        | users_check1       | (((c2 + c1) + c3) > 10) | c2          | {2,1,3}        | f
-users        | users_check1       | (((c2 + c1) + c3) > 10) | c1          | {2,1,3}        | f
-users        | users_check1       | (((c2 + c1) + c3) > 10) | c3          | {2,1,3}        | f
+table_name   | constraint_name    |       expression        | column_name | column_indexes | no_inherit | validated
+-------------+--------------------+-------------------------+-------------+----------------+------------+-----------
+users        | boring             | (c1 > 1)                | c1          | {1}            | t          | t
+users        | users_c2_check     | (c2 > 0)                | c2          | {2}            | f          | t
+users        | users_c2_check1    | (c2 > 0)                | c2          | {2}            | f          | f
+users        | users_check        | ((c2 + c1) > 2)         | c2          | {2,1}          | f          | t
+users        | users_check        | ((c2 + c1) > 2)         | c1          | {2,1}          | f          | t
+users        | users_check1       | (((c2 + c1) + c3) > 10) | c2          | {2,1,3}        | f          | t
+users        | users_check1       | (((c2 + c1) + c3) > 10) | c1          | {2,1,3}        | f          | t
+users        | users_check1       | (((c2 + c1) + c3) > 10) | c3          | {2,1,3}        | f          | t
 `))
 				m.noEnums()
 			},
@@ -321,7 +344,7 @@ users        | users_check1       | (((c2 + c1) + c3) > 10) | c3          | {2,1
 				require.EqualValues([]schema.Attr{
 					&schema.Check{Name: "boring", Expr: "(c1 > 1)", Attrs: []schema.Attr{&CheckColumns{Columns: []string{"c1"}}, &NoInherit{}}},
 					&schema.Check{Name: "users_c2_check", Expr: "(c2 > 0)", Attrs: []schema.Attr{&CheckColumns{Columns: []string{"c2"}}}},
-					&schema.Check{Name: "users_c2_check1", Expr: "(c2 > 0)", Attrs: []schema.Attr{&CheckColumns{Columns: []string{"c2"}}}},
+					&schema.Check{Name: "users_c2_check1", Expr: "(c2 > 0)", Attrs: []schema.Attr{&CheckColumns{Columns: []string{"c2"}}, &NotValid{}}},
 					&schema.Check{Name: "users_check", Expr: "((c2 + c1) > 2)", Attrs: []schema.Attr{&CheckColumns{Columns: []string{"c2", "c1"}}}},
 					&schema.Check{Name: "users_check1", Expr: "(((c2 + c1) + c3) > 10)", Attrs: []schema.Attr{&CheckColumns{Columns: []string{"c2", "c1", "c3"}}}},
 				}, t.Attrs)
@@ -390,6 +413,13 @@ logs3      | c5         | integer   | integer   | NO          |                |
 `))
 	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(indexesAbove15, "$2, $3, $4"))).
 		WillReturnRows(sqlmock.NewRows([]string{"table_name", "index_name", "column_name", "primary", "unique", "constraint_type", "predicate", "expression", "options", "indnullsnotdistinct"}))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(partitionChildrenQuery, "$2, $3, $4"))).
+		WithArgs("public", "logs1", "logs2", "logs3").
+		WillReturnRows(sqlmock.NewRows([]string{"table_name", "partition_name", "partition_bound"}).
+			AddRow("logs2", "logs2_1", "FOR VALUES FROM (MINVALUE) TO (100)").
+			AddRow("logs2", "logs2_default", "DEFAULT").
+			AddRow("logs3", "logs3_1", "FOR VALUES IN (1, 2)").
+			AddRow("logs3", "logs3_2", "FOR VALUES IN ('a,b', 'c)')"))
 	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(fksQuery, "$2, $3, $4"))).
 		WillReturnRows(sqlmock.NewRows([]string{"constraint_name", "table_name", "column_name", "referenced_table_name", "referenced_column_name", "referenced_table_schema", "update_rule", "delete_rule"}))
 	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(checksQuery, "$2, $3, $4"))).
@@ -406,16 +436,20 @@ logs3      | c5         | integer   | integer   | NO          |                |
 
 	t2, ok := s.Table("logs2")
 	require.True(t, ok)
-	require.Len(t, t2.Attrs, 1)
+	require.Len(t, t2.Attrs, 3)
 	key := t2.Attrs[0].(*Partition)
 	require.Equal(t, PartitionTypeRange, key.T)
 	require.Equal(t, []*PartitionPart{
 		{C: &schema.Column{Name: "c2", Type: &schema.ColumnType{Raw: "integer", Type: &schema.IntegerType{T: "integer"}}}},
 	}, key.Parts)
+	require.Equal(t, []*PartitionChild{
+		{Name: "logs2_1", Bound: PartitionBound{From: []string{"MINVALUE"}, To: []string{"100"}}},
+		{Name: "logs2_default", Bound: PartitionBound{Default: true}},
+	}, tablePartitions(t2))
 
 	t3, ok := s.Table("logs3")
 	require.True(t, ok)
-	require.Len(t, t3.Attrs, 1)
+	require.Len(t, t3.Attrs, 3)
 	key = t3.Attrs[0].(*Partition)
 	require.Equal(t, PartitionTypeList, key.T)
 	require.Equal(t, []*PartitionPart{
@@ -423,6 +457,35 @@ logs3      | c5         | integer   | integer   | NO          |                |
 		{X: &schema.RawExpr{X: "(a + b)"}},
 		{X: &schema.RawExpr{X: "(a + (b * 2))"}},
 	}, key.Parts)
+	require.Equal(t, []*PartitionChild{
+		{Name: "logs3_1", Bound: PartitionBound{In: []string{"1", "2"}}},
+		{Name: "logs3_2", Bound: PartitionBound{In: []string{"'a,b'", "'c)'"}}},
+	}, tablePartitions(t3))
+}
+
+func TestParsePartitionBound(t *testing.T) {
+	for _, tt := range []struct {
+		bound string
+		want  *PartitionBound
+		err   bool
+	}{
+		{bound: "DEFAULT", want: &PartitionBound{Default: true}},
+		{bound: "FOR VALUES FROM ('2024-01-01') TO ('2024-02-01')", want: &PartitionBound{From: []string{"'2024-01-01'"}, To: []string{"'2024-02-01'"}}},
+		{bound: "FOR VALUES FROM (1, MINVALUE) TO (10, MAXVALUE)", want: &PartitionBound{From: []string{"1", "MINVALUE"}, To: []string{"10", "MAXVALUE"}}},
+		{bound: "FOR VALUES IN (NULL)", want: &PartitionBound{In: []string{"NULL"}}},
+		{bound: "FOR VALUES WITH (modulus 4, remainder 3)", want: &PartitionBound{Modulus: 4, Remainder: 3}},
+		{bound: "FOR VALUES FROM (1)", err: true},
+		{bound: "FOR VALUES IN (1", err: true},
+		{bound: "FOR VALUES WITH (modulus x, remainder 3)", err: true},
+	} {
+		b, err := parsePartitionBound(tt.bound)
+		if tt.err {
+			require.Error(t, err, tt.bound)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, tt.want, b)
+	}
 }
 
 func TestDriver_InspectCRDBSchema(t *testing.T) {
@@ -671,6 +734,293 @@ func TestDriver_Realm(t *testing.T) {
 	}(), realm)
 }
 
+func TestDriver_InspectSequences(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("150000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= CURRENT_SCHEMA()"))).
+		WillReturnRows(sqltest.Rows(`
+ schema_name | comment 
+-------------+---------
+ public      | 
+`))
+	mk.tableExists("public", "users", true)
+	m.ExpectQuery(queryColumns).
+		WithArgs("public", "users").
+		WillReturnRows(sqltest.Rows(`
+table_name |column_name | data_type | formatted | is_nullable | column_default                     | character_maximum_length | numeric_precision | datetime_precision | numeric_scale | interval_type | character_set_name | collation_name | is_identity | identity_start | identity_increment |   identity_last  | identity_generation | generation_expression | comment | typtype | typelem | elemtyp | oid
+-----------+------------+-----------+-----------+-------------+------------------------------------+--------------------------+-------------------+--------------------+---------------+---------------+--------------------+----------------+-------------+----------------+--------------------+------------------+---------------------+-----------------------+---------+---------+---------+---------+-----
+users      | id         | integer   | integer   | NO          | nextval('users_id_seq'::regclass)  |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |  23
+users      | c          | bigint    | bigint    | NO          | nextval('counter'::regclass)       |                          |                64 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |  20
+`))
+	mk.noIndexes()
+	mk.noFKs()
+	mk.noChecks()
+	mk.noEnums()
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(sequencesQuery, "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqlmock.NewRows([]string{"schemaname", "sequencename", "data_type", "start_value", "increment_by", "min_value", "max_value", "cache_size", "cycle", "owner_table", "owner_column", "comment"}).
+			AddRow("public", "counter", "bigint", 1, 1, 1, math.MaxInt64, 1, false, "users", "c", nil).
+			AddRow("public", "desc", "integer", -1, -1, math.MinInt32, -1, 10, true, nil, nil, "comment").
+			AddRow("public", "users_id_seq", "integer", 1, 1, 1, math.MaxInt32, 1, false, "users", "id", nil))
+	s, err := drv.InspectSchema(context.Background(), "", &schema.InspectOptions{
+		Mode: schema.InspectTables | schema.InspectSequences,
+	})
+	require.NoError(t, err)
+	users, ok := s.Table("users")
+	require.True(t, ok)
+	c, ok := users.Column("c")
+	require.True(t, ok)
+	// Sequences of serial columns are not inspected as objects.
+	require.Len(t, s.Objects, 2)
+	counter := s.Objects[0].(*Sequence)
+	require.Equal(t, "counter", counter.Name)
+	require.Equal(t, s, counter.Schema)
+	require.Equal(t, &schema.IntegerType{T: TypeBigInt}, counter.Type)
+	require.Equal(t, users, counter.Owner.Table)
+	require.Equal(t, c, counter.Owner.Column)
+	require.Equal(t, seqOptions(&Sequence{}), seqOptions(counter))
+	desc := s.Objects[1].(*Sequence)
+	require.Nil(t, desc.Owner.Table)
+	require.Equal(t, int64(-1), desc.Increment)
+	require.Equal(t, int64(10), desc.Cache)
+	require.True(t, desc.Cycle)
+	require.Equal(t, seqOptions(&Sequence{Type: &schema.IntegerType{T: TypeInteger}, Increment: -1, Cache: 10, Cycle: true}), seqOptions(desc))
+	require.Equal(t, []schema.Attr{&schema.Comment{Text: "comment"}}, desc.Attrs)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestDriver_InspectExtensions(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("150000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= CURRENT_SCHEMA()"))).
+		WillReturnRows(sqltest.Rows(`
+ schema_name | comment 
+-------------+---------
+ public      | 
+`))
+	mk.tableExists("public", "places", true)
+	m.ExpectQuery(queryColumns).
+		WithArgs("public", "places").
+		WillReturnRows(sqltest.Rows(`
+table_name |column_name | data_type    | formatted            | is_nullable | column_default | character_maximum_length | numeric_precision | datetime_precision | numeric_scale | interval_type | character_set_name | collation_name | is_identity | identity_start | identity_increment |   identity_last  | identity_generation | generation_expression | comment | typtype | typelem | elemtyp | oid
+-----------+------------+--------------+----------------------+-------------+----------------+--------------------------+-------------------+--------------------+---------------+---------------+--------------------+----------------+-------------+----------------+--------------------+------------------+---------------------+-----------------------+---------+---------+---------+---------+-------
+places     | name       | USER-DEFINED | citext               | NO          |                |                          |                   |                    |               |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         | 16390
+places     | location   | USER-DEFINED | geometry(Point,4326) | NO          |                |                          |                   |                    |               |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         | 16391
+`))
+	mk.noIndexes()
+	mk.noFKs()
+	mk.noChecks()
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(extensionsQuery, "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqlmock.NewRows([]string{"schema_name", "extension_name", "extension_version", "comment"}).
+			AddRow("public", "citext", "1.6", "data type for case-insensitive character strings").
+			AddRow("public", "postgis", "3.3.2", nil))
+	mk.noEnums()
+	s, err := drv.InspectSchema(context.Background(), "", &schema.InspectOptions{
+		Mode: schema.InspectTables | schema.InspectExtensions,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []schema.Object{
+		&Extension{Name: "citext", Schema: s, Version: "1.6", Attrs: []schema.Attr{&schema.Comment{Text: "data type for case-insensitive character strings"}}},
+		&Extension{Name: "postgis", Schema: s, Version: "3.3.2"},
+	}, s.Objects)
+	places, ok := s.Table("places")
+	require.True(t, ok)
+	// Types that are provided by extensions.
+	require.Equal(t, &UserDefinedType{T: "citext"}, places.Columns[0].Type.Type)
+	require.Equal(t, &schema.SpatialType{T: "geometry(Point,4326)"}, places.Columns[1].Type.Type)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestDriver_InspectTypes(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("150000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= CURRENT_SCHEMA()"))).
+		WillReturnRows(sqltest.Rows(`
+ schema_name | comment 
+-------------+---------
+ public      | 
+`))
+	mk.tableExists("public", "users", true)
+	m.ExpectQuery(queryColumns).
+		WithArgs("public", "users").
+		WillReturnRows(sqltest.Rows(`
+table_name |column_name | data_type    | formatted      | is_nullable | column_default | character_maximum_length | numeric_precision | datetime_precision | numeric_scale | interval_type | character_set_name | collation_name | is_identity | identity_start | identity_increment |   identity_last  | identity_generation | generation_expression | comment | typtype | typelem | elemtyp | oid
+-----------+------------+--------------+----------------+-------------+----------------+--------------------------+-------------------+--------------------+---------------+---------------+--------------------+----------------+-------------+----------------+--------------------+------------------+---------------------+-----------------------+---------+---------+---------+---------+-------
+users      | zip        | text         | us_postal_code | NO          |                |                          |                   |                    |               |               |                    |                | NO          |                |                    |                  |                     |                       |         | d       |         |         | 16390
+users      | address    | USER-DEFINED | address        | YES         |                |                          |                   |                    |               |               |                    |                | NO          |                |                    |                  |                     |                       |         | c       |         |         | 16391
+`))
+	mk.noIndexes()
+	mk.noFKs()
+	mk.noChecks()
+	mk.noEnums()
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(domainsQuery, "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqlmock.NewRows([]string{"schema_name", "domain_name", "base_type", "not_null", "default_value", "check_name", "check_expr", "comment"}).
+			AddRow("public", "positive", "integer", true, "1", nil, nil, nil).
+			AddRow("public", "us_postal_code", "text", false, nil, "us_postal_code_check", `((VALUE ~ '^\d{5}$'::text))`, "US zip code").
+			AddRow("public", "us_postal_code", "text", false, nil, "us_postal_code_length", "((length(VALUE) = 5))", "US zip code"))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(compositesQuery, "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqlmock.NewRows([]string{"schema_name", "type_name", "field_name", "field_type", "comment"}).
+			AddRow("public", "address", "street", "text", nil).
+			AddRow("public", "address", "zip", "us_postal_code", nil).
+			AddRow("public", "empty", nil, nil, nil))
+	s, err := drv.InspectSchema(context.Background(), "", &schema.InspectOptions{
+		Mode: schema.InspectTables | schema.InspectTypes,
+	})
+	require.NoError(t, err)
+	require.Len(t, s.Objects, 4)
+	positive, zip, addr, empty := s.Objects[0].(*Domain), s.Objects[1].(*Domain), s.Objects[2].(*Composite), s.Objects[3].(*Composite)
+	require.Equal(t, &Domain{T: "positive", Schema: s, Base: &schema.IntegerType{T: "integer"}, Default: &schema.Literal{V: "1"}}, positive)
+	require.Equal(t, &Domain{
+		T:      "us_postal_code",
+		Schema: s,
+		Base:   &schema.StringType{T: "text"},
+		Null:   true,
+		Checks: []*schema.Check{
+			{Name: "us_postal_code_check", Expr: `((VALUE ~ '^\d{5}$'::text))`},
+			{Name: "us_postal_code_length", Expr: "((length(VALUE) = 5))"},
+		},
+		Attrs: []schema.Attr{&schema.Comment{Text: "US zip code"}},
+	}, zip)
+	require.Equal(t, "address", addr.T)
+	require.Len(t, addr.Fields, 2)
+	require.Equal(t, &schema.StringType{T: "text"}, addr.Fields[0].Type.Type)
+	// Fields that use domains are linked to them.
+	require.Equal(t, zip, addr.Fields[1].Type.Type)
+	require.Equal(t, &Composite{T: "empty", Schema: s}, empty)
+	users, ok := s.Table("users")
+	require.True(t, ok)
+	require.Equal(t, zip, users.Columns[0].Type.Type)
+	require.Equal(t, addr, users.Columns[1].Type.Type)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestDriver_InspectPolicies(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("150000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= CURRENT_SCHEMA()"))).
+		WillReturnRows(sqltest.Rows(`
+ schema_name | comment 
+-------------+---------
+ public      | 
+`))
+	mk.tableExists("public", "users", true)
+	m.ExpectQuery(queryColumns).
+		WithArgs("public", "users").
+		WillReturnRows(sqltest.Rows(`
+table_name |column_name | data_type    | formatted      | is_nullable | column_default | character_maximum_length | numeric_precision | datetime_precision | numeric_scale | interval_type | character_set_name | collation_name | is_identity | identity_start | identity_increment |   identity_last  | identity_generation | generation_expression | comment | typtype | typelem | elemtyp | oid
+-----------+------------+--------------+----------------+-------------+----------------+--------------------------+-------------------+--------------------+---------------+---------------+--------------------+----------------+-------------+----------------+--------------------+------------------+---------------------+-----------------------+---------+---------+---------+---------+-------
+users      | owner      | text         | text           | NO          |                |                          |                   |                    |               |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         | 25
+`))
+	mk.noIndexes()
+	mk.noFKs()
+	mk.noChecks()
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(policiesQuery, "$2"))).
+		WithArgs("public", "users").
+		WillReturnRows(sqlmock.NewRows([]string{"table_name", "enabled", "enforced", "policy_name", "permissive", "cmd", "roles", "using_expr", "check_expr"}).
+			AddRow("users", true, false, "owner_read", true, "r", nil, "(owner = CURRENT_USER)", nil).
+			AddRow("users", true, false, "owner_write", false, "w", "admin,editor", "(owner = CURRENT_USER)", "(owner = CURRENT_USER)"))
+	mk.noEnums()
+	s, err := drv.InspectSchema(context.Background(), "", &schema.InspectOptions{
+		Mode: schema.InspectTables | schema.InspectPolicies,
+	})
+	require.NoError(t, err)
+	users, ok := s.Table("users")
+	require.True(t, ok)
+	require.Equal(t, []schema.Attr{
+		&RowSecurity{Enabled: true},
+		&Policy{Name: "owner_read", As: PolicyAsPermissive, For: PolicyForSelect, Using: "(owner = CURRENT_USER)"},
+		&Policy{Name: "owner_write", As: PolicyAsRestrictive, For: PolicyForUpdate, To: []string{"admin", "editor"}, Using: "(owner = CURRENT_USER)", Check: "(owner = CURRENT_USER)"},
+	}, users.Attrs)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestDriver_InspectRoles(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("150000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= CURRENT_SCHEMA()"))).
+		WillReturnRows(sqltest.Rows(`
+ schema_name | comment 
+-------------+---------
+ public      | 
+`))
+	mk.tableExists("public", "users", true)
+	m.ExpectQuery(queryColumns).
+		WithArgs("public", "users").
+		WillReturnRows(sqltest.Rows(`
+table_name |column_name | data_type    | formatted      | is_nullable | column_default | character_maximum_length | numeric_precision | datetime_precision | numeric_scale | interval_type | character_set_name | collation_name | is_identity | identity_start | identity_increment |   identity_last  | identity_generation | generation_expression | comment | typtype | typelem | elemtyp | oid
+-----------+------------+--------------+----------------+-------------+----------------+--------------------------+-------------------+--------------------+---------------+---------------+--------------------+----------------+-------------+----------------+--------------------+------------------+---------------------+-----------------------+---------+---------+---------+---------+-------
+users      | id         | integer      | integer        | NO          |                |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         | 23
+users      | email      | text         | text           | NO          |                |                          |                   |                    |               |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         | 25
+`))
+	mk.noIndexes()
+	mk.noFKs()
+	mk.noChecks()
+	mk.noEnums()
+	m.ExpectQuery(sqltest.Escape(rolesQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"role_name", "login", "member_of"}).
+			AddRow("alice", true, "readers,unknown").
+			AddRow("readers", false, ""))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(grantsQuery, "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqlmock.NewRows([]string{"grantee", "object_type", "schema_name", "object_name", "column_name", "privilege_type", "grantable"}).
+			AddRow("PUBLIC", "table", "public", "users", nil, "SELECT", false).
+			AddRow("alice", "column", "public", "users", "email", "UPDATE", false).
+			AddRow("alice", "table", "public", "users", nil, "INSERT", true).
+			AddRow("readers", "schema", "public", "public", nil, "USAGE", false).
+			AddRow("readers", "table", "public", "users", nil, "SELECT", false).
+			AddRow("readers", "table", "public", "users", nil, "UPDATE", false).
+			AddRow("readers", "table", "public", "unknown", nil, "SELECT", false))
+	s, err := drv.InspectSchema(context.Background(), "", &schema.InspectOptions{
+		Mode: schema.InspectTables | schema.InspectRoles,
+	})
+	require.NoError(t, err)
+	users, ok := s.Table("users")
+	require.True(t, ok)
+	email, ok := users.Column("email")
+	require.True(t, ok)
+	r := s.Realm
+	require.Len(t, r.Roles, 2)
+	alice, readers := r.Roles[0], r.Roles[1]
+	require.Equal(t, "alice", alice.Name)
+	require.True(t, alice.User)
+	require.Equal(t, []*schema.Role{readers}, alice.MemberOf)
+	require.Equal(t, []*schema.Grant{
+		{To: alice, On: users, Columns: []*schema.Column{email}, Privileges: []string{"UPDATE"}},
+		{To: alice, On: users, Privileges: []string{"INSERT"}, GrantOption: true},
+	}, alice.Grants)
+	require.Equal(t, "readers", readers.Name)
+	require.False(t, readers.User)
+	require.Empty(t, readers.MemberOf)
+	require.Equal(t, []*schema.Grant{
+		{To: readers, On: s, Privileges: []string{"USAGE"}},
+		{To: readers, On: users, Privileges: []string{"SELECT", "UPDATE"}},
+	}, readers.Grants)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
 func TestInspectMode_InspectRealm(t *testing.T) {
 	db, m, err := sqlmock.New()
 	require.NoError(t, err)
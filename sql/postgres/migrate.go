@@ -71,6 +71,12 @@ func (s *state) plan(changes []schema.Change) error {
 			return err
 		}
 	}
+	// Roles are created, and privileges are revoked, before the other
+	// changes, and privileges are granted after the objects were created.
+	changes, before, after := sqlx.RoleChanges(changes)
+	if err := s.planRoles(before); err != nil {
+		return err
+	}
 	planned, err := s.topLevel(changes)
 	if err != nil {
 		return err
@@ -79,20 +85,59 @@ func (s *state) plan(changes []schema.Change) error {
 		return err
 	}
 	var (
-		views []schema.Change
-		dropT []*schema.DropTable
-		dropO []*schema.DropObject
+		views    []schema.Change
+		triggers []schema.Change
+		dropT    []*schema.DropTable
+		dropO    []*schema.DropObject
+		dropV    []schema.Change
+		altered  = alteredTables(planned)
+		recreate = make(map[*schema.DropView]*schema.ModifyView)
 	)
+	// Triggers are dropped first, as they may depend on the
+	// modified tables, views or objects (e.g. UPDATE OF columns).
+	for _, c := range planned {
+		switch c := c.(type) {
+		case *schema.DropTrigger:
+			if err := s.dropTrigger(c); err != nil {
+				return err
+			}
+		case *schema.DropView:
+			dropV = append(dropV, c)
+		case *schema.ModifyView:
+			if s.viewRecreated(c, altered) {
+				d := &schema.DropView{V: c.From}
+				dropV, recreate[d] = append(dropV, d), c
+			}
+			views = append(views, c)
+		case *schema.AddView, *schema.RenameView:
+			views = append(views, c)
+		}
+	}
+	// Views (and the views that are recreated) are dropped before the table changes,
+	// as columns cannot be dropped or altered while views depend on them.
+	if dropV, err = sqlx.PlanViewChanges(dropV); err != nil {
+		return err
+	}
+	for _, c := range dropV {
+		d := c.(*schema.DropView)
+		if m, ok := recreate[d]; ok {
+			s.dropModifiedView(m)
+		} else if err := s.dropView(d); err != nil {
+			return err
+		}
+	}
 	for _, c := range planned {
 		switch c := c.(type) {
+		case *schema.DropTrigger, *schema.AddView, *schema.DropView, *schema.ModifyView, *schema.RenameView:
+			// Planned above or below.
+		case *schema.AddTrigger, *schema.ModifyTrigger:
+			triggers = append(triggers, c)
 		case *schema.AddTable:
 			err = s.addTable(c)
 		case *schema.ModifyTable:
 			err = s.modifyTable(c)
 		case *schema.RenameTable:
 			s.renameTable(c)
-		case *schema.AddView, *schema.DropView, *schema.ModifyView, *schema.RenameView:
-			views = append(views, c)
 		case *schema.DropTable:
 			dropT = append(dropT, c)
 		case *schema.DropObject:
@@ -104,6 +149,14 @@ func (s *state) plan(changes []schema.Change) error {
 			return err
 		}
 	}
+	// Sequences are attached to their owner columns after
+	// the tables and columns were created or modified.
+	for _, c := range changes {
+		if err := s.seqOwner(c); err != nil {
+			return err
+		}
+	}
+	// Views are created (or modified) after the tables they depend on.
 	if views, err = sqlx.PlanViewChanges(views); err != nil {
 		return err
 	}
@@ -111,20 +164,66 @@ func (s *state) plan(changes []schema.Change) error {
 		switch c := c.(type) {
 		case *schema.AddView:
 			err = s.addView(c)
-		case *schema.DropView:
-			err = s.dropView(c)
 		case *schema.ModifyView:
-			err = s.modifyView(c)
+			err = s.modifyView(c, s.viewRecreated(c, altered))
 		case *schema.RenameView:
 			s.renameView(c)
 		}
+		if err != nil {
+			return err
+		}
+	}
+	// Triggers are created after the tables and views they are defined on.
+	for _, c := range triggers {
+		switch c := c.(type) {
+		case *schema.AddTrigger:
+			err = s.addTrigger(c)
+		case *schema.ModifyTrigger:
+			err = s.modifyTrigger(c)
+		}
+		if err != nil {
+			return err
+		}
 	}
 	for _, c := range dropT {
 		if err := s.dropTable(c); err != nil {
 			return err
 		}
 	}
+	// Composite types are dropped before the domains and the enums
+	// they may use, and domains are dropped before their base types.
+	for _, c := range dropO {
+		if t, ok := c.O.(*Composite); ok {
+			if err := s.dropComposite(c, t); err != nil {
+				return err
+			}
+		}
+	}
+	for _, c := range dropO {
+		if d, ok := c.O.(*Domain); ok {
+			if err := s.dropDomain(c, d); err != nil {
+				return err
+			}
+		}
+	}
 	for _, c := range dropO {
+		switch c.O.(type) {
+		// Extensions are dropped last, as other objects may depend on them.
+		case *Extension, *Domain, *Composite:
+			continue
+		}
+		if seq, ok := c.O.(*Sequence); ok {
+			if err := s.dropSeq(c, seq); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, ok := funcOf(c.O); ok {
+			if err := s.dropFunc(c); err != nil {
+				return err
+			}
+			continue
+		}
 		e, ok := c.O.(*schema.EnumType)
 		if !ok {
 			return fmt.Errorf("unsupported object %T", c.O)
@@ -137,14 +236,169 @@ func (s *state) plan(changes []schema.Change) error {
 			Comment: fmt.Sprintf("drop enum type %q", e.T),
 		})
 	}
+	for _, c := range dropO {
+		if e, ok := c.O.(*Extension); ok {
+			s.dropExt(c, e)
+		}
+	}
+	return s.planRoles(after)
+}
+
+// planRoles plans the role and privilege changes. Memberships of the created roles
+// are granted after all roles were created, as they may depend on each other.
+func (s *state) planRoles(changes []schema.Change) error {
+	for _, c := range changes {
+		if c, ok := c.(*schema.AddRole); ok {
+			s.append(&migrate.Change{
+				Cmd:     s.createRole(c.R),
+				Source:  c,
+				Comment: fmt.Sprintf("create %s %q", roleKind(c.R), c.R.Name),
+				Reverse: s.Build("DROP ROLE").Ident(c.R.Name).String(),
+			})
+		}
+	}
+	for _, c := range changes {
+		switch c := c.(type) {
+		case *schema.AddRole:
+			s.append(s.memberships(c, c.R, nil, c.R.MemberOf)...)
+		case *schema.ModifyRole:
+			if c.From.User != c.To.User {
+				b := s.Build("ALTER ROLE").Ident(c.To.Name).P("WITH")
+				r := b.Clone()
+				s.append(&migrate.Change{
+					Cmd:     b.P(roleLogin(c.To)).String(),
+					Source:  c,
+					Comment: fmt.Sprintf("modify role %q", c.To.Name),
+					Reverse: r.P(roleLogin(c.From)).String(),
+				})
+			}
+			add, drop := sqlx.MembershipsDiff(c.From.MemberOf, c.To.MemberOf)
+			s.append(s.memberships(c, c.To, drop, add)...)
+		case *schema.DropRole:
+			reverse := []string{s.createRole(c.R)}
+			for _, m := range s.memberships(c, c.R, nil, c.R.MemberOf) {
+				reverse = append(reverse, m.Cmd)
+			}
+			s.append(&migrate.Change{
+				Cmd:     s.Build("DROP ROLE").Ident(c.R.Name).String(),
+				Source:  c,
+				Comment: fmt.Sprintf("drop %s %q", roleKind(c.R), c.R.Name),
+				Reverse: reverse,
+			})
+		case *schema.AddGrant:
+			grant, revoke, err := s.grant(c.G)
+			if err != nil {
+				return err
+			}
+			s.append(&migrate.Change{
+				Cmd:     grant,
+				Source:  c,
+				Comment: fmt.Sprintf("grant privileges to %q", c.G.To.Name),
+				Reverse: revoke,
+			})
+		case *schema.DropGrant:
+			grant, revoke, err := s.grant(c.G)
+			if err != nil {
+				return err
+			}
+			s.append(&migrate.Change{
+				Cmd:     revoke,
+				Source:  c,
+				Comment: fmt.Sprintf("revoke privileges from %q", c.G.To.Name),
+				Reverse: grant,
+			})
+		}
+	}
 	return nil
 }
 
+// createRole returns the statement for creating the role.
+func (s *state) createRole(r *schema.Role) string {
+	b := s.Build("CREATE ROLE").Ident(r.Name)
+	if r.User {
+		b.P("WITH LOGIN")
+	}
+	return b.String()
+}
+
+// memberships returns the statements for adding the role to the "add" roles, and removing it from the "drop" roles.
+func (s *state) memberships(c schema.Change, r *schema.Role, drop, add []*schema.Role) []*migrate.Change {
+	changes := make([]*migrate.Change, 0, len(drop)+len(add))
+	for _, m := range drop {
+		changes = append(changes, &migrate.Change{
+			Cmd:     s.Build("REVOKE").Ident(m.Name).P("FROM").Ident(r.Name).String(),
+			Source:  c,
+			Comment: fmt.Sprintf("remove %q from role %q", r.Name, m.Name),
+			Reverse: s.Build("GRANT").Ident(m.Name).P("TO").Ident(r.Name).String(),
+		})
+	}
+	for _, m := range add {
+		changes = append(changes, &migrate.Change{
+			Cmd:     s.Build("GRANT").Ident(m.Name).P("TO").Ident(r.Name).String(),
+			Source:  c,
+			Comment: fmt.Sprintf("add %q to role %q", r.Name, m.Name),
+			Reverse: s.Build("REVOKE").Ident(m.Name).P("FROM").Ident(r.Name).String(),
+		})
+	}
+	return changes
+}
+
+// grant returns the GRANT and the REVOKE statements of the given grant.
+func (s *state) grant(g *schema.Grant) (grant, revoke string, err error) {
+	if g.To == nil {
+		return "", "", errors.New("missing role for grant")
+	}
+	var on string
+	switch o := g.On.(type) {
+	case *schema.Schema:
+		on = s.Build("SCHEMA").Ident(o.Name).String()
+	case *schema.Table:
+		on = s.Build("TABLE").Table(o).String()
+	case *schema.View:
+		on = s.Build("TABLE").View(o).String()
+	case *Sequence:
+		on = s.Build("SEQUENCE").P(s.seqIdent(o)).String()
+	default:
+		return "", "", fmt.Errorf("unsupported object %T for grant to %q", g.On, g.To.Name)
+	}
+	privs := s.Build().MapComma(g.Privileges, func(i int, b *sqlx.Builder) {
+		b.P(sqlx.PrivilegeName(g.Privileges[i]))
+		if len(g.Columns) > 0 {
+			b.Wrap(func(b *sqlx.Builder) {
+				b.MapComma(g.Columns, func(i int, b *sqlx.Builder) {
+					b.Ident(g.Columns[i].Name)
+				})
+			})
+		}
+	}).String()
+	b := s.Build("GRANT", privs, "ON", on, "TO").Ident(g.To.Name)
+	if g.GrantOption {
+		b.P("WITH GRANT OPTION")
+	}
+	return b.String(), s.Build("REVOKE", privs, "ON", on, "FROM").Ident(g.To.Name).String(), nil
+}
+
+// roleKind returns the kind of the role for describing its changes.
+func roleKind(r *schema.Role) string {
+	if r.User {
+		return "user"
+	}
+	return "role"
+}
+
+// roleLogin returns the LOGIN option of the role.
+func roleLogin(r *schema.Role) string {
+	if r.User {
+		return "LOGIN"
+	}
+	return "NOLOGIN"
+}
+
 // topLevel executes first the changes for creating or dropping schemas and
 // create objects that tables might depend on.
 func (s *state) topLevel(changes []schema.Change) ([]schema.Change, error) {
 	planned := make([]schema.Change, 0, len(changes))
-	for _, c := range changes {
+	for _, c := range extFirst(changes) {
 		switch c := c.(type) {
 		case *schema.AddSchema:
 			b := s.Build("CREATE SCHEMA")
@@ -198,19 +452,59 @@ func (s *state) topLevel(changes []schema.Change) ([]schema.Change, error) {
 				Comment: fmt.Sprintf("Drop schema named %q", c.S.Name),
 			})
 		case *schema.AddObject:
-			e, ok := c.O.(*schema.EnumType)
-			if !ok {
+			switch o := c.O.(type) {
+			case *schema.EnumType:
+				create, drop := s.createDropEnum(o)
+				s.append(&migrate.Change{
+					Source:  c,
+					Cmd:     create,
+					Reverse: drop,
+					Comment: fmt.Sprintf("create enum type %q", o.T),
+				})
+			// Functions are created before the tables, as
+			// column defaults or checks may call them.
+			case *schema.Func, *schema.Proc:
+				if err := s.addFunc(c); err != nil {
+					return nil, err
+				}
+			// Sequences are created before the tables, as column defaults
+			// may use them. Their owner is set after the tables are created.
+			case *Sequence:
+				if err := s.addSeq(c); err != nil {
+					return nil, err
+				}
+			case *Extension:
+				s.addExt(c, o)
+			// Domains and composite types are created before
+			// the tables, as columns may use them as types.
+			case *Domain:
+				if err := s.addDomain(c, o); err != nil {
+					return nil, err
+				}
+			case *Composite:
+				if err := s.addComposite(c, o); err != nil {
+					return nil, err
+				}
+			default:
 				return nil, fmt.Errorf("unsupported object %T", c.O)
 			}
-			create, drop := s.createDropEnum(e)
-			s.append(&migrate.Change{
-				Source:  c,
-				Cmd:     create,
-				Reverse: drop,
-				Comment: fmt.Sprintf("create enum type %q", e.T),
-			})
 		case *schema.ModifyObject:
-			if err := s.alterEnum(c); err != nil {
+			var err error
+			switch c.From.(type) {
+			case *schema.Func, *schema.Proc:
+				err = s.modifyFunc(c)
+			case *Sequence:
+				err = s.modifySeq(c)
+			case *Extension:
+				err = s.modifyExt(c)
+			case *Domain:
+				err = s.modifyDomain(c)
+			case *Composite:
+				err = s.modifyComposite(c)
+			default:
+				err = s.alterEnum(c)
+			}
+			if err != nil {
 				return nil, err
 			}
 		case *schema.RenameObject:
@@ -258,6 +552,16 @@ func (s *state) addTable(add *schema.AddTable) error {
 			b.Comma()
 			s.fks(b, add.T.ForeignKeys...)
 		}
+		// EXCLUDE and DEFERRABLE constraints cannot be created
+		// using CREATE INDEX, and are defined on the table.
+		for _, idx := range add.T.Indexes {
+			if asConstraint(idx) {
+				b.Comma().NL()
+				if err := s.constraint(b, idx); err != nil {
+					errs = append(errs, err.Error())
+				}
+			}
+		}
 		for _, attr := range add.T.Attrs {
 			if c, ok := attr.(*schema.Check); ok {
 				b.Comma().NL()
@@ -282,12 +586,24 @@ func (s *state) addTable(add *schema.AddTable) error {
 		Reverse: s.Build("DROP TABLE").Table(add.T).String(),
 	})
 	for _, idx := range add.T.Indexes {
+		if asConstraint(idx) {
+			continue
+		}
 		// Indexes do not need to be created concurrently on new tables.
 		if err := s.addIndexes(add.T, &schema.AddIndex{I: idx}); err != nil {
 			return err
 		}
 	}
 	s.addComments(add.T)
+	if r, ok := rowSecurity(add.T); ok {
+		s.append(s.rowSecurity(add.T, &RowSecurity{}, r)...)
+	}
+	for _, p := range tablePolicies(add.T) {
+		s.append(s.addPolicy(add.T, p))
+	}
+	for _, p := range tablePartitions(add.T) {
+		s.append(s.addPartition(add.T, p))
+	}
 	return nil
 }
 
@@ -333,31 +649,85 @@ func (s *state) dropTable(drop *schema.DropTable) error {
 
 // modifyTable builds the statements that bring the table into its modified state.
 func (s *state) modifyTable(modify *schema.ModifyTable) error {
+	if s.ExpandContract {
+		phased, others, err := sqlx.PhasedColumns(modify)
+		if err != nil {
+			return fmt.Errorf("modify table %q: %w", modify.T.Name, err)
+		}
+		for _, c := range phased {
+			if err := s.phasedColumn(c); err != nil {
+				return err
+			}
+		}
+		modify = &schema.ModifyTable{T: modify.T, Changes: others, Extra: modify.Extra}
+	}
 	var (
 		alter   []schema.Change
 		addI    []*schema.AddIndex
 		dropI   []*schema.DropIndex
+		dropP   []*migrate.Change
+		renameC []*migrate.Change
 		changes []*migrate.Change
 	)
 	for _, change := range skipAutoChanges(modify.Changes) {
 		switch change := change.(type) {
-		case *schema.AddAttr, *schema.ModifyAttr:
-			from, to, err := commentChange(change)
-			if err != nil {
-				return err
+		case *schema.AddAttr:
+			switch a := change.A.(type) {
+			case *RowSecurity:
+				changes = append(changes, s.rowSecurity(modify.T, &RowSecurity{}, a)...)
+			case *Policy:
+				changes = append(changes, s.addPolicy(modify.T, a))
+			case *PartitionChild:
+				changes = append(changes, s.addPartition(modify.T, a))
+			default:
+				from, to, err := commentChange(change)
+				if err != nil {
+					return err
+				}
+				changes = append(changes, s.tableComment(modify.T, to, from))
+			}
+		case *schema.ModifyAttr:
+			switch a := change.From.(type) {
+			case *RowSecurity:
+				changes = append(changes, s.rowSecurity(modify.T, a, change.To.(*RowSecurity))...)
+			case *Policy:
+				changes = append(changes, s.modifyPolicy(modify.T, a, change.To.(*Policy))...)
+			case *PartitionChild:
+				changes = append(changes, s.modifyPartition(modify.T, a, change.To.(*PartitionChild))...)
+			default:
+				from, to, err := commentChange(change)
+				if err != nil {
+					return err
+				}
+				changes = append(changes, s.tableComment(modify.T, to, from))
 			}
-			changes = append(changes, s.tableComment(modify.T, to, from))
 		case *schema.DropAttr:
-			return fmt.Errorf("unsupported change type: %T", change)
+			switch a := change.A.(type) {
+			case *RowSecurity:
+				changes = append(changes, s.rowSecurity(modify.T, a, &RowSecurity{})...)
+			case *Policy:
+				// Policies are dropped before the table is altered,
+				// as they may depend on columns that are dropped.
+				dropP = append(dropP, s.dropPolicy(modify.T, a))
+			case *PartitionChild:
+				changes = append(changes, s.dropPartition(modify.T, a))
+			default:
+				return fmt.Errorf("unsupported change type: %T", change)
+			}
 		case *schema.AddIndex:
 			if c := (schema.Comment{}); sqlx.Has(change.I.Attrs, &c) {
 				changes = append(changes, s.indexComment(modify.T, change.I, c.Text, ""))
 			}
-			addI = append(addI, change)
+			// EXCLUDE and DEFERRABLE constraints are added with ALTER TABLE.
+			if asConstraint(change.I) {
+				alter = append(alter, change)
+			} else {
+				addI = append(addI, change)
+			}
 		case *schema.DropIndex:
 			// Unlike DROP INDEX statements that are executed separately,
 			// DROP CONSTRAINT are added to the ALTER TABLE statement below.
-			if isUniqueConstraint(change.I) {
+			if isUniqueConstraint(change.I) || asConstraint(change.I) {
 				alter = append(alter, change)
 			} else {
 				dropI = append(dropI, change)
@@ -384,8 +754,16 @@ func (s *state) modifyTable(modify *schema.ModifyTable) error {
 				}
 			}
 			// Index modification requires rebuilding the index.
-			addI = append(addI, &schema.AddIndex{I: change.To})
-			dropI = append(dropI, &schema.DropIndex{I: change.From})
+			if asConstraint(change.To) {
+				alter = append(alter, &schema.AddIndex{I: change.To})
+			} else {
+				addI = append(addI, &schema.AddIndex{I: change.To})
+			}
+			if asConstraint(change.From) {
+				alter = append(alter, &schema.DropIndex{I: change.From})
+			} else {
+				dropI = append(dropI, &schema.DropIndex{I: change.From})
+			}
 		case *schema.RenameIndex:
 			changes = append(changes, &migrate.Change{
 				Source:  change,
@@ -394,6 +772,11 @@ func (s *state) modifyTable(modify *schema.ModifyTable) error {
 				Reverse: s.Build("ALTER INDEX").Ident(change.To.Name).P("RENAME TO").Ident(change.From.Name).String(),
 			})
 		case *schema.ModifyForeignKey:
+			// Validating a NOT VALID foreign key does not require recreating it.
+			if change.Change == schema.ChangeAttr && notValidChanged(change.From.Attrs, change.To.Attrs) && !deferrableChanged(change.From.Attrs, change.To.Attrs) {
+				changes = append(changes, s.validateConstraint(modify.T, change.To.Symbol, change))
+				continue
+			}
 			// Foreign-key modification is translated into 2 steps.
 			// Dropping the current foreign key and creating a new one.
 			alter = append(alter, &schema.DropForeignKey{
@@ -401,6 +784,13 @@ func (s *state) modifyTable(modify *schema.ModifyTable) error {
 			}, &schema.AddForeignKey{
 				F: change.To,
 			})
+		case *schema.ModifyCheck:
+			// Validating a NOT VALID check does not require recreating it.
+			if change.From.Expr == change.To.Expr && sqlx.Has(change.From.Attrs, &NoInherit{}) == sqlx.Has(change.To.Attrs, &NoInherit{}) && notValidChanged(change.From.Attrs, change.To.Attrs) {
+				changes = append(changes, s.validateConstraint(modify.T, change.To.Name, change))
+			} else {
+				alter = append(alter, change)
+			}
 		case *schema.AddColumn:
 			if c := (schema.Comment{}); sqlx.Has(change.C.Attrs, &c) {
 				changes = append(changes, s.columnComment(modify.T, change.C, c.Text, ""))
@@ -421,10 +811,11 @@ func (s *state) modifyTable(modify *schema.ModifyTable) error {
 			}
 			alter = append(alter, &schema.ModifyColumn{To: change.To, From: change.From, Change: k})
 		case *schema.RenameColumn:
-			// "RENAME COLUMN" cannot be combined with other alterations.
+			// "RENAME COLUMN" cannot be combined with other alterations, and
+			// is executed first, as the alterations refer to the new names.
 			b := s.Build("ALTER TABLE").Table(modify.T).P("RENAME COLUMN")
 			r := b.Clone()
-			changes = append(changes, &migrate.Change{
+			renameC = append(renameC, &migrate.Change{
 				Source:  change,
 				Comment: fmt.Sprintf("rename a column from %q to %q", change.From.Name, change.To.Name),
 				Cmd:     b.Ident(change.From.Name).P("TO").Ident(change.To.Name).String(),
@@ -434,6 +825,8 @@ func (s *state) modifyTable(modify *schema.ModifyTable) error {
 			alter = append(alter, change)
 		}
 	}
+	s.append(dropP...)
+	s.append(renameC...)
 	if err := s.dropIndexes(modify.T, dropI...); err != nil {
 		return err
 	}
@@ -449,6 +842,108 @@ func (s *state) modifyTable(modify *schema.ModifyTable) error {
 	return nil
 }
 
+// phasedColumn plans the given column change in expand and contract phases. The expand
+// phase adds the shadow column, creates a trigger that keeps it in sync with the replaced
+// column, and backfills it. The contract phase drops the trigger and the replaced column.
+func (s *state) phasedColumn(c *sqlx.PhasedColumn) error {
+	var (
+		t    = c.T
+		name = fmt.Sprintf("%s_%s_sync", t.Name, c.From.Name)
+		from = s.Build().Ident(c.From.Name).String()
+		to   = s.Build().Ident(c.Shadow.Name).String()
+		fn   = &schema.Func{
+			Name:   name,
+			Schema: t.Schema,
+			Ret:    &UserDefinedType{T: "trigger"},
+			Lang:   "plpgsql",
+			Body:   fmt.Sprintf(syncBody, from, to),
+		}
+		exec = "EXECUTE PROCEDURE"
+	)
+	// EXECUTE FUNCTION was added in PostgreSQL 11, along with procedures.
+	if s.supportsProcedures() {
+		exec = "EXECUTE FUNCTION"
+	}
+	tr := schema.NewTrigger(name).
+		SetActionTime(schema.TriggerTimeBefore).
+		AddEvents(schema.TriggerEventInsert, schema.TriggerEventUpdate).
+		SetFor(schema.TriggerForRow).
+		SetBody(s.Build(exec).Func(fn).String() + "()")
+	tr.Table = t
+	if err := s.alterTable(t, []schema.Change{&schema.AddColumn{C: c.Expanded()}}); err != nil {
+		return err
+	}
+	if err := s.addFunc(&schema.AddObject{O: fn}); err != nil {
+		return err
+	}
+	if err := s.addTrigger(&schema.AddTrigger{T: tr}); err != nil {
+		return err
+	}
+	// Values are cast explicitly in case of a type change,
+	// as not all types have an assignment cast between them.
+	fromX, toX := from, to
+	if c.Shadow != c.To {
+		fromT, err := s.formatType(c.From)
+		if err != nil {
+			return err
+		}
+		toT, err := s.formatType(c.Shadow)
+		if err != nil {
+			return err
+		}
+		fromX, toX = fmt.Sprintf("CAST(%s AS %s)", from, toT), fmt.Sprintf("CAST(%s AS %s)", to, fromT)
+	}
+	s.append(&migrate.Change{
+		Cmd:     s.Build("UPDATE").Table(t).P("SET", to, "=", fromX).String(),
+		Comment: fmt.Sprintf("backfill column %q of table %q", c.Shadow.Name, t.Name),
+		Reverse: s.Build("UPDATE").Table(t).P("SET", from, "=", toX).String(),
+	})
+	if !c.Shadow.Type.Null {
+		modify := &schema.ModifyColumn{From: c.Expanded(), To: c.Shadow, Change: schema.ChangeNull}
+		if err := s.alterTable(t, []schema.Change{modify}); err != nil {
+			return err
+		}
+	}
+	n := len(s.Changes)
+	if err := s.dropTrigger(&schema.DropTrigger{T: tr}); err != nil {
+		return err
+	}
+	if err := s.dropFunc(&schema.DropObject{O: fn}); err != nil {
+		return err
+	}
+	if err := s.alterTable(t, []schema.Change{&schema.DropColumn{C: c.From}}); err != nil {
+		return err
+	}
+	if c.Shadow.Name != c.To.Name {
+		b := s.Build("ALTER TABLE").Table(t).P("RENAME COLUMN")
+		r := b.Clone()
+		s.append(&migrate.Change{
+			Comment: fmt.Sprintf("rename a column from %q to %q", c.Shadow.Name, c.To.Name),
+			Cmd:     b.Ident(c.Shadow.Name).P("TO").Ident(c.To.Name).String(),
+			Reverse: r.Ident(c.To.Name).P("TO").Ident(c.Shadow.Name).String(),
+		})
+	}
+	for _, c := range s.Changes[n:] {
+		c.Contract = true
+	}
+	return nil
+}
+
+// syncBody is the body of the trigger function that keeps the replaced column (%[1]s)
+// and its shadow column (%[2]s) in sync, while both are in use by the application.
+const syncBody = `
+BEGIN
+  IF TG_OP = 'INSERT' THEN
+    IF NEW.%[2]s IS NULL THEN NEW.%[2]s := NEW.%[1]s; ELSE NEW.%[1]s := NEW.%[2]s; END IF;
+  ELSIF NEW.%[1]s IS DISTINCT FROM OLD.%[1]s THEN
+    NEW.%[2]s := NEW.%[1]s;
+  ELSIF NEW.%[2]s IS DISTINCT FROM OLD.%[2]s THEN
+    NEW.%[1]s := NEW.%[2]s;
+  END IF;
+  RETURN NEW;
+END
+`
+
 // alterTable modifies the given table by executing on it a list of changes in one SQL statement.
 func (s *state) alterTable(t *schema.Table, changes []schema.Change) error {
 	var (
@@ -485,12 +980,11 @@ func (s *state) alterTable(t *schema.Table, changes []schema.Change) error {
 				b.P("DROP COLUMN").Ident(change.C.Name)
 				reverse = append(reverse, &schema.AddColumn{C: change.C})
 			case *schema.AddIndex:
-				// Skip reversing this operation as it is the inverse of
-				// the operation above and should not be used besides this.
-				b.P("ADD CONSTRAINT").Ident(change.I.Name).P("UNIQUE")
-				if err := s.indexParts(b, change.I); err != nil {
+				b.P("ADD")
+				if err := s.constraint(b, change.I); err != nil {
 					return err
 				}
+				reverse = append(reverse, &schema.DropIndex{I: change.I})
 			case *schema.DropIndex:
 				b.P("DROP CONSTRAINT").Ident(change.I.Name)
 				reverse = append(reverse, &schema.AddIndex{I: change.I})
@@ -506,12 +1000,18 @@ func (s *state) alterTable(t *schema.Table, changes []schema.Change) error {
 			case *schema.AddForeignKey:
 				b.P("ADD")
 				s.fks(b, change.F)
+				if err := s.notValid(b, alter, t, change.F.Symbol, change.F.Attrs, change.Extra, change); err != nil {
+					return err
+				}
 				reverse = append(reverse, &schema.DropForeignKey{F: change.F})
 			case *schema.DropForeignKey:
 				b.P("DROP CONSTRAINT").Ident(change.F.Symbol)
 				reverse = append(reverse, &schema.AddForeignKey{F: change.F})
 			case *schema.AddCheck:
 				check(b.P("ADD"), change.C)
+				if err := s.notValid(b, alter, t, change.C.Name, change.C.Attrs, change.Extra, change); err != nil {
+					return err
+				}
 				// Reverse operation is supported if
 				// the constraint name is not generated.
 				if reversible = reversible && change.C.Name != ""; reversible {
@@ -571,6 +1071,37 @@ func (s *state) alterTable(t *schema.Table, changes []schema.Change) error {
 	return nil
 }
 
+// notValid writes the NOT VALID clause of a foreign key or a check constraint that is
+// added to an existing table, and validates it after it was added, if it was requested.
+func (s *state) notValid(b *sqlx.Builder, alter *changeGroup, t *schema.Table, name string, attrs []schema.Attr, extra []schema.Clause, c schema.Change) error {
+	v := &ValidateLater{}
+	switch hasV := sqlx.Has(extra, v); {
+	// The desired state of the constraint is NOT VALID.
+	case sqlx.Has(attrs, &NotValid{}):
+		b.P("NOT VALID")
+	case hasV && name == "":
+		return errors.New("validating unnamed constraints later is not supported")
+	case hasV:
+		b.P("NOT VALID")
+		validate := s.validateConstraint(t, name, c)
+		validate.Separate = v.Separate
+		alter.after = append(alter.after, validate)
+	}
+	return nil
+}
+
+// validateConstraint returns the change for validating a NOT VALID constraint.
+// Its reverse is a no-op, as the constraint is dropped by the reverse of the
+// change that added it, and therefore, it does not affect plan reversibility.
+func (s *state) validateConstraint(t *schema.Table, name string, c schema.Change) *migrate.Change {
+	return &migrate.Change{
+		Source:  c,
+		Cmd:     s.Build("ALTER TABLE").Table(t).P("VALIDATE CONSTRAINT").Ident(name).String(),
+		Comment: fmt.Sprintf("validate constraint %q on table: %q", name, t.Name),
+		Reverse: []string{},
+	}
+}
+
 // changeGroup describes an alter table migrate.Change where its main command
 // can be supported by additional statements before and after it is executed.
 type changeGroup struct {
@@ -761,52 +1292,218 @@ func (s *state) schemaComment(sc *schema.Schema, to, from string) *migrate.Chang
 	}
 }
 
-func (s *state) tableComment(t *schema.Table, to, from string) *migrate.Change {
-	b := s.Build("COMMENT ON TABLE").Table(t).P("IS")
-	return &migrate.Change{
-		Cmd:     b.Clone().P(quote(to)).String(),
-		Comment: fmt.Sprintf("set comment to table: %q", t.Name),
-		Reverse: b.Clone().P(quote(from)).String(),
+// rowSecurity returns the statements for changing the row-level security of the table.
+func (s *state) rowSecurity(t *schema.Table, from, to *RowSecurity) []*migrate.Change {
+	var changes []*migrate.Change
+	if from.Enabled != to.Enabled {
+		changes = append(changes, s.rowSecurityCmd(t, rowSecurityClause(to.Enabled, "ENABLE", "DISABLE"), rowSecurityClause(from.Enabled, "ENABLE", "DISABLE")))
 	}
+	if from.Enforced != to.Enforced {
+		changes = append(changes, s.rowSecurityCmd(t, rowSecurityClause(to.Enforced, "FORCE", "NO FORCE"), rowSecurityClause(from.Enforced, "FORCE", "NO FORCE")))
+	}
+	return changes
 }
 
-func (s *state) columnComment(t *schema.Table, c *schema.Column, to, from string) *migrate.Change {
-	b := s.Build("COMMENT ON COLUMN").Table(t)
-	b.WriteByte('.')
-	b.Ident(c.Name).P("IS")
+func (s *state) rowSecurityCmd(t *schema.Table, cmd, reverse string) *migrate.Change {
 	return &migrate.Change{
-		Cmd:     b.Clone().P(quote(to)).String(),
-		Comment: fmt.Sprintf("set comment to column: %q on table: %q", c.Name, t.Name),
-		Reverse: b.Clone().P(quote(from)).String(),
+		Cmd:     s.Build("ALTER TABLE").Table(t).P(cmd).String(),
+		Comment: fmt.Sprintf("%s of table: %q", strings.ToLower(cmd), t.Name),
+		Reverse: s.Build("ALTER TABLE").Table(t).P(reverse).String(),
 	}
 }
 
-func (s *state) indexComment(t *schema.Table, idx *schema.Index, to, from string) *migrate.Change {
-	b := s.Build("COMMENT ON INDEX").Ident(idx.Name).P("IS")
+// rowSecurityClause returns the ROW LEVEL SECURITY clause for the given state.
+func rowSecurityClause(v bool, on, off string) string {
+	if v {
+		return on + " ROW LEVEL SECURITY"
+	}
+	return off + " ROW LEVEL SECURITY"
+}
+
+// addPartition returns the statement for creating a child partition of the table.
+func (s *state) addPartition(t *schema.Table, p *PartitionChild) *migrate.Change {
 	return &migrate.Change{
-		Cmd:     b.Clone().P(quote(to)).String(),
-		Comment: fmt.Sprintf("set comment to index: %q on table: %q", idx.Name, t.Name),
-		Reverse: b.Clone().P(quote(from)).String(),
+		Cmd:     s.createPartition(t, p),
+		Comment: fmt.Sprintf("create partition %q of table: %q", p.Name, t.Name),
+		Reverse: s.Build("DROP TABLE").Table(partitionTable(t, p)).String(),
 	}
 }
 
-func (s *state) dropIndexes(t *schema.Table, drops ...*schema.DropIndex) error {
-	adds := make([]*schema.AddIndex, len(drops))
-	for i, d := range drops {
-		adds[i] = &schema.AddIndex{I: d.I, Extra: d.Extra}
+// dropPartition returns the statement for dropping a child partition of the table.
+func (s *state) dropPartition(t *schema.Table, p *PartitionChild) *migrate.Change {
+	return &migrate.Change{
+		Cmd:     s.Build("DROP TABLE").Table(partitionTable(t, p)).String(),
+		Comment: fmt.Sprintf("drop partition %q of table: %q", p.Name, t.Name),
+		Reverse: s.createPartition(t, p),
 	}
-	rs := &state{conn: s.conn, PlanOptions: s.PlanOptions}
-	if err := rs.addIndexes(t, adds...); err != nil {
-		return err
+}
+
+// modifyPartition returns the statements for changing the bound of a child partition.
+// The partition is detached from its parent and attached back with its new bound, and
+// therefore, its rows are preserved and validated against the new bound.
+func (s *state) modifyPartition(t *schema.Table, from, to *PartitionChild) []*migrate.Change {
+	detach := s.Build("ALTER TABLE").Table(t).P("DETACH PARTITION").Table(partitionTable(t, from)).String()
+	return []*migrate.Change{
+		{
+			Cmd:     detach,
+			Comment: fmt.Sprintf("detach partition %q from table: %q", from.Name, t.Name),
+			Reverse: s.attachPartition(t, from),
+		},
+		{
+			Cmd:     s.attachPartition(t, to),
+			Comment: fmt.Sprintf("attach partition %q to table: %q", to.Name, t.Name),
+			Reverse: detach,
+		},
 	}
-	for i, add := range adds {
-		s.append(&migrate.Change{
-			Cmd:     rs.Changes[i].Reverse.(string),
-			Comment: fmt.Sprintf("drop index %q from table: %q", add.I.Name, t.Name),
-			Reverse: rs.Changes[i].Cmd,
-		})
+}
+
+// createPartition returns the statement for creating the child partition.
+func (s *state) createPartition(t *schema.Table, p *PartitionChild) string {
+	return s.Build("CREATE TABLE").Table(partitionTable(t, p)).P("PARTITION OF").Table(t).P(formatPartitionBound(p.Bound)).String()
+}
+
+// attachPartition returns the statement for attaching the child partition to the table.
+func (s *state) attachPartition(t *schema.Table, p *PartitionChild) string {
+	return s.Build("ALTER TABLE").Table(t).P("ATTACH PARTITION").Table(partitionTable(t, p)).P(formatPartitionBound(p.Bound)).String()
+}
+
+// partitionTable returns a table that represents the child partition.
+// Child partitions are created in the schema of their parent.
+func partitionTable(t *schema.Table, p *PartitionChild) *schema.Table {
+	return schema.NewTable(p.Name).SetSchema(t.Schema)
+}
+
+// addPolicy returns the statement for creating a policy on the table.
+func (s *state) addPolicy(t *schema.Table, p *Policy) *migrate.Change {
+	return &migrate.Change{
+		Cmd:     s.createPolicy(t, p),
+		Comment: fmt.Sprintf("create policy %q on table: %q", p.Name, t.Name),
+		Reverse: s.Build("DROP POLICY").Ident(p.Name).P("ON").Table(t).String(),
 	}
-	return nil
+}
+
+// dropPolicy returns the statement for dropping a policy from the table.
+func (s *state) dropPolicy(t *schema.Table, p *Policy) *migrate.Change {
+	return &migrate.Change{
+		Cmd:     s.Build("DROP POLICY").Ident(p.Name).P("ON").Table(t).String(),
+		Comment: fmt.Sprintf("drop policy %q from table: %q", p.Name, t.Name),
+		Reverse: s.createPolicy(t, p),
+	}
+}
+
+// modifyPolicy returns the statements for modifying a policy of the table. The type
+// and the command of a policy cannot be altered, nor its expressions can be removed,
+// and therefore, in these cases the policy is dropped and created with its new definition.
+func (s *state) modifyPolicy(t *schema.Table, from, to *Policy) []*migrate.Change {
+	if policyAs(from) != policyAs(to) || policyFor(from) != policyFor(to) ||
+		from.Using != "" && to.Using == "" || from.Check != "" && to.Check == "" {
+		return []*migrate.Change{s.dropPolicy(t, from), s.addPolicy(t, to)}
+	}
+	return []*migrate.Change{{
+		Cmd:     s.alterPolicy(t, from, to),
+		Comment: fmt.Sprintf("modify policy %q on table: %q", to.Name, t.Name),
+		Reverse: s.alterPolicy(t, to, from),
+	}}
+}
+
+// createPolicy returns the statement for creating the policy.
+func (s *state) createPolicy(t *schema.Table, p *Policy) string {
+	b := s.Build("CREATE POLICY").Ident(p.Name).P("ON").Table(t)
+	if as := policyAs(p); as != PolicyAsPermissive {
+		b.P("AS", as)
+	}
+	if cmd := policyFor(p); cmd != PolicyForAll {
+		b.P("FOR", cmd)
+	}
+	// Policies apply to PUBLIC by default.
+	if to := policyTo(p); len(to) > 1 || to[0] != policyToPublic {
+		s.policyRoles(b, p)
+	}
+	if p.Using != "" {
+		b.P("USING", sqlx.MayWrap(p.Using))
+	}
+	if p.Check != "" {
+		b.P("WITH CHECK", sqlx.MayWrap(p.Check))
+	}
+	return b.String()
+}
+
+// alterPolicy returns the statement for altering the policy from its current state to the desired one.
+func (s *state) alterPolicy(t *schema.Table, from, to *Policy) string {
+	b := s.Build("ALTER POLICY").Ident(to.Name).P("ON").Table(t)
+	if strings.Join(policyTo(from), ",") != strings.Join(policyTo(to), ",") {
+		s.policyRoles(b, to)
+	}
+	if !policyExprEqual(from.Using, to.Using) && to.Using != "" {
+		b.P("USING", sqlx.MayWrap(to.Using))
+	}
+	if !policyExprEqual(from.Check, to.Check) && to.Check != "" {
+		b.P("WITH CHECK", sqlx.MayWrap(to.Check))
+	}
+	return b.String()
+}
+
+// policyRoles writes the TO clause of the policy.
+func (s *state) policyRoles(b *sqlx.Builder, p *Policy) {
+	if len(p.To) == 0 {
+		b.P("TO", policyToPublic)
+		return
+	}
+	b.P("TO").MapComma(p.To, func(i int, b *sqlx.Builder) {
+		if strings.EqualFold(p.To[i], policyToPublic) {
+			b.P(policyToPublic)
+		} else {
+			b.Ident(p.To[i])
+		}
+	})
+}
+
+func (s *state) tableComment(t *schema.Table, to, from string) *migrate.Change {
+	b := s.Build("COMMENT ON TABLE").Table(t).P("IS")
+	return &migrate.Change{
+		Cmd:     b.Clone().P(quote(to)).String(),
+		Comment: fmt.Sprintf("set comment to table: %q", t.Name),
+		Reverse: b.Clone().P(quote(from)).String(),
+	}
+}
+
+func (s *state) columnComment(t *schema.Table, c *schema.Column, to, from string) *migrate.Change {
+	b := s.Build("COMMENT ON COLUMN").Table(t)
+	b.WriteByte('.')
+	b.Ident(c.Name).P("IS")
+	return &migrate.Change{
+		Cmd:     b.Clone().P(quote(to)).String(),
+		Comment: fmt.Sprintf("set comment to column: %q on table: %q", c.Name, t.Name),
+		Reverse: b.Clone().P(quote(from)).String(),
+	}
+}
+
+func (s *state) indexComment(t *schema.Table, idx *schema.Index, to, from string) *migrate.Change {
+	b := s.Build("COMMENT ON INDEX").Ident(idx.Name).P("IS")
+	return &migrate.Change{
+		Cmd:     b.Clone().P(quote(to)).String(),
+		Comment: fmt.Sprintf("set comment to index: %q on table: %q", idx.Name, t.Name),
+		Reverse: b.Clone().P(quote(from)).String(),
+	}
+}
+
+func (s *state) dropIndexes(t *schema.Table, drops ...*schema.DropIndex) error {
+	adds := make([]*schema.AddIndex, len(drops))
+	for i, d := range drops {
+		adds[i] = &schema.AddIndex{I: d.I, Extra: d.Extra}
+	}
+	rs := &state{conn: s.conn, PlanOptions: s.PlanOptions}
+	if err := rs.addIndexes(t, adds...); err != nil {
+		return err
+	}
+	for i, add := range adds {
+		s.append(&migrate.Change{
+			Cmd:     rs.Changes[i].Reverse.(string),
+			Comment: fmt.Sprintf("drop index %q from table: %q", add.I.Name, t.Name),
+			Reverse: rs.Changes[i].Cmd,
+		})
+	}
+	return nil
 }
 
 func (s *state) alterEnum(modify *schema.ModifyObject) error {
@@ -836,6 +1533,9 @@ func (s *state) alterEnum(modify *schema.ModifyObject) error {
 func (s *state) addIndexes(t *schema.Table, adds ...*schema.AddIndex) error {
 	for _, add := range adds {
 		b, idx := s.Build("CREATE"), add.I
+		if sqlx.Has(idx.Attrs, &Deferrable{}) {
+			return fmt.Errorf("postgres: DEFERRABLE is supported only by UNIQUE and EXCLUDE constraints. index: %q", idx.Name)
+		}
 		if idx.Unique {
 			b.P("UNIQUE")
 		}
@@ -888,7 +1588,7 @@ func (s *state) column(b *sqlx.Builder, c *schema.Column) error {
 	s.columnDefault(b, c)
 	for _, attr := range c.Attrs {
 		switch a := attr.(type) {
-		case *schema.Comment:
+		case *schema.Comment, *schema.RenamedFrom:
 		case *schema.Collation:
 			b.P("COLLATE").Ident(a.V)
 		case *Identity, *schema.GeneratedExpr:
@@ -971,6 +1671,11 @@ func (s *state) partAttrs(b *sqlx.Builder, idx *schema.Index, p *schema.IndexPar
 	if p.Desc {
 		b.P("DESC")
 	}
+	defer func() {
+		if x := (IndexExcludeOp{}); sqlx.Has(p.Attrs, &x) {
+			b.P("WITH", x.V)
+		}
+	}()
 	for _, attr := range p.Attrs {
 		switch attr := attr.(type) {
 		case *IndexColumnProperty:
@@ -985,7 +1690,7 @@ func (s *state) partAttrs(b *sqlx.Builder, idx *schema.Index, p *schema.IndexPar
 				b.P("NULLS FIRST")
 			}
 		// Handled above.
-		case *IndexOpClass, *schema.Collation:
+		case *IndexOpClass, *schema.Collation, *IndexExcludeOp:
 		default:
 			return fmt.Errorf("postgres: unexpected index part attribute: %T", attr)
 		}
@@ -1027,11 +1732,17 @@ func (s *state) index(b *sqlx.Builder, idx *schema.Index) error {
 		})
 	}
 	if p := (IndexPredicate{}); sqlx.Has(idx.Attrs, &p) {
-		b.P("WHERE").P(p.P)
+		b.P("WHERE")
+		// The predicate of EXCLUDE constraints must be wrapped with parentheses.
+		if isExclusion(idx) {
+			b.P(sqlx.MayWrap(p.P))
+		} else {
+			b.P(p.P)
+		}
 	}
 	for _, attr := range idx.Attrs {
 		switch attr.(type) {
-		case *schema.Comment, *IndexType, *IndexInclude, *Constraint, *IndexPredicate, *IndexStorageParams, *IndexNullsDistinct:
+		case *schema.Comment, *schema.RenamedFrom, *IndexType, *IndexInclude, *Constraint, *IndexPredicate, *IndexStorageParams, *IndexNullsDistinct, *Deferrable:
 		default:
 			return fmt.Errorf("postgres: unexpected index attribute: %T", attr)
 		}
@@ -1063,9 +1774,42 @@ func (s *state) fks(b *sqlx.Builder, fks ...*schema.ForeignKey) {
 		if fk.OnDelete != "" {
 			b.P("ON DELETE", string(fk.OnDelete))
 		}
+		if d := (Deferrable{}); sqlx.Has(fk.Attrs, &d) {
+			deferrable(b, d)
+		}
 	})
 }
 
+// constraint writes the UNIQUE or EXCLUDE table constraint that is backed by the index.
+func (s *state) constraint(b *sqlx.Builder, idx *schema.Index) error {
+	if idx.Name != "" {
+		b.P("CONSTRAINT").Ident(idx.Name)
+	}
+	if isExclusion(idx) {
+		b.P("EXCLUDE")
+		if err := s.index(b, idx); err != nil {
+			return err
+		}
+	} else {
+		b.P("UNIQUE")
+		if err := s.indexParts(b, idx); err != nil {
+			return err
+		}
+	}
+	if d := (Deferrable{}); sqlx.Has(idx.Attrs, &d) {
+		deferrable(b, d)
+	}
+	return nil
+}
+
+// deferrable writes the DEFERRABLE mode of a constraint to the builder.
+func deferrable(b *sqlx.Builder, d Deferrable) {
+	b.P("DEFERRABLE")
+	if d.Deferred {
+		b.P("INITIALLY DEFERRED")
+	}
+}
+
 func (s *state) append(c ...*migrate.Change) {
 	s.Changes = append(s.Changes, c...)
 }
@@ -1149,6 +1893,22 @@ func check(b *sqlx.Builder, c *schema.Check) {
 	}
 }
 
+// isExclusion reports if the index is an EXCLUDE constraint.
+func isExclusion(i *schema.Index) bool {
+	for _, p := range i.Parts {
+		if sqlx.Has(p.Attrs, &IndexExcludeOp{}) {
+			return true
+		}
+	}
+	return false
+}
+
+// asConstraint reports if the index must be created as a table constraint,
+// because EXCLUDE and DEFERRABLE constraints cannot be created with CREATE INDEX.
+func asConstraint(i *schema.Index) bool {
+	return isExclusion(i) || i.Unique && sqlx.Has(i.Attrs, &Deferrable{})
+}
+
 // isUniqueConstraint reports if the index is a valid UNIQUE constraint.
 func isUniqueConstraint(i *schema.Index) bool {
 	hasC := func() bool {
@@ -1191,6 +1951,568 @@ func quote(s string) string {
 	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
 }
 
+// extFirst returns the changes, where extensions are created after the schemas they
+// are installed in, but before all other changes, as objects and tables may use them.
+func extFirst(changes []schema.Change) []schema.Change {
+	var schemas, exts, others []schema.Change
+	for _, c := range changes {
+		switch c := c.(type) {
+		case *schema.AddSchema:
+			schemas = append(schemas, c)
+		case *schema.AddObject:
+			if _, ok := c.O.(*Extension); ok {
+				exts = append(exts, c)
+			} else {
+				others = append(others, c)
+			}
+		default:
+			others = append(others, c)
+		}
+	}
+	if len(exts) == 0 {
+		return changes
+	}
+	return append(append(schemas, exts...), others...)
+}
+
+// addExt builds and executes the queries for creating an extension.
+func (s *state) addExt(add *schema.AddObject, e *Extension) {
+	s.append(&migrate.Change{
+		Cmd:     s.createExt(e, sqlx.Has(add.Extra, &schema.IfNotExists{})),
+		Source:  add,
+		Comment: fmt.Sprintf("create extension %q", e.Name),
+		Reverse: s.Build("DROP EXTENSION").Ident(e.Name).String(),
+	})
+	if c := (schema.Comment{}); sqlx.Has(e.Attrs, &c) && c.Text != "" {
+		s.append(s.extComment(e, c.Text, ""))
+	}
+}
+
+// createExt returns the statement for creating the extension.
+func (s *state) createExt(e *Extension, ifNotExists bool) string {
+	b := s.Build("CREATE EXTENSION")
+	if ifNotExists {
+		b.P("IF NOT EXISTS")
+	}
+	b.Ident(e.Name)
+	if p := s.schemaPrefix(e.Schema); p != "" {
+		b.P("WITH SCHEMA", strings.TrimSuffix(p, "."))
+	}
+	if e.Version != "" {
+		b.P("VERSION", quote(e.Version))
+	}
+	return b.String()
+}
+
+// dropExt builds and executes the query for dropping an extension.
+func (s *state) dropExt(drop *schema.DropObject, e *Extension) {
+	b := s.Build("DROP EXTENSION")
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.Ident(e.Name).String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop extension %q", e.Name),
+		Reverse: s.createExt(e, false),
+	})
+}
+
+// modifyExt builds and executes the queries for updating the version and the comment of an extension.
+func (s *state) modifyExt(modify *schema.ModifyObject) error {
+	from, ok1 := modify.From.(*Extension)
+	to, ok2 := modify.To.(*Extension)
+	if !ok1 || !ok2 {
+		return fmt.Errorf("altering objects (%T) to (%T) is not supported", modify.From, modify.To)
+	}
+	if to.Version != "" && from.Version != to.Version {
+		c := &migrate.Change{
+			Cmd:     s.Build("ALTER EXTENSION").Ident(to.Name).P("UPDATE TO", quote(to.Version)).String(),
+			Source:  modify,
+			Comment: fmt.Sprintf("update extension %q to version %q", to.Name, to.Version),
+		}
+		if from.Version != "" {
+			c.Reverse = s.Build("ALTER EXTENSION").Ident(from.Name).P("UPDATE TO", quote(from.Version)).String()
+		}
+		s.append(c)
+	}
+	if change := sqlx.CommentDiff(from.Attrs, to.Attrs); change != nil {
+		fromC, toC, err := commentChange(change)
+		if err != nil {
+			return err
+		}
+		s.append(s.extComment(to, toC, fromC))
+	}
+	return nil
+}
+
+// extComment returns the change for setting the comment of an extension.
+func (s *state) extComment(e *Extension, to, from string) *migrate.Change {
+	b := s.Build("COMMENT ON EXTENSION").Ident(e.Name).P("IS")
+	return &migrate.Change{
+		Cmd:     b.Clone().P(quote(to)).String(),
+		Comment: fmt.Sprintf("set comment to extension: %q", e.Name),
+		Reverse: b.Clone().P(quote(from)).String(),
+	}
+}
+
+// addSeq builds and executes the queries for creating a sequence.
+func (s *state) addSeq(add *schema.AddObject) error {
+	seq := add.O.(*Sequence)
+	create, err := s.createSeq(seq)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     create,
+		Source:  add,
+		Comment: fmt.Sprintf("create %q sequence", seq.Name),
+		Reverse: s.Build("DROP SEQUENCE").P(s.seqIdent(seq)).String(),
+	})
+	if c := (schema.Comment{}); sqlx.Has(seq.Attrs, &c) && c.Text != "" {
+		s.append(s.seqComment(seq, c.Text, ""))
+	}
+	return nil
+}
+
+// dropSeq builds and executes the query for dropping a sequence.
+func (s *state) dropSeq(drop *schema.DropObject, seq *Sequence) error {
+	// Sequences are dropped along with the tables that own them.
+	if seq.Owner.Table != nil {
+		for _, t := range s.droppedT {
+			if t.Name == seq.Owner.Table.Name && (t.Schema == nil || seq.Schema == nil || t.Schema.Name == seq.Schema.Name) {
+				return nil
+			}
+		}
+	}
+	create, err := s.createSeq(seq)
+	if err != nil {
+		return err
+	}
+	b := s.Build("DROP SEQUENCE")
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.P(s.seqIdent(seq)).String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop %q sequence", seq.Name),
+		Reverse: create,
+	})
+	return nil
+}
+
+// modifySeq builds and executes the queries for altering the options and the comment of
+// a sequence. Changes to the sequence owner are planned separately by the seqOwner method.
+func (s *state) modifySeq(modify *schema.ModifyObject) error {
+	from, ok1 := modify.From.(*Sequence)
+	to, ok2 := modify.To.(*Sequence)
+	if !ok1 || !ok2 {
+		return fmt.Errorf("altering objects (%T) to (%T) is not supported", modify.From, modify.To)
+	}
+	if o1, o2 := seqOptions(from), seqOptions(to); o1 != o2 {
+		s.append(&migrate.Change{
+			Cmd:     s.alterSeq(to, o1, o2),
+			Source:  modify,
+			Comment: fmt.Sprintf("modify %q sequence", to.Name),
+			Reverse: s.alterSeq(from, o2, o1),
+		})
+	}
+	if change := sqlx.CommentDiff(from.Attrs, to.Attrs); change != nil {
+		fromC, toC, err := commentChange(change)
+		if err != nil {
+			return err
+		}
+		s.append(s.seqComment(to, toC, fromC))
+	}
+	return nil
+}
+
+// seqOwner builds and executes the query for setting the owner column of
+// an added sequence, or for changing the owner of a modified sequence.
+func (s *state) seqOwner(c schema.Change) error {
+	var from, to *Sequence
+	switch c := c.(type) {
+	case *schema.AddObject:
+		seq, ok := c.O.(*Sequence)
+		if !ok || seqOwner(seq) == "" {
+			return nil
+		}
+		from, to = &Sequence{Name: seq.Name, Schema: seq.Schema}, seq
+	case *schema.ModifyObject:
+		seq1, ok1 := c.From.(*Sequence)
+		seq2, ok2 := c.To.(*Sequence)
+		if !ok1 || !ok2 || seqOwner(seq1) == seqOwner(seq2) {
+			return nil
+		}
+		from, to = seq1, seq2
+	default:
+		return nil
+	}
+	s.append(&migrate.Change{
+		Cmd:     s.Build("ALTER SEQUENCE").P(s.seqIdent(to), "OWNED BY", s.seqOwnedBy(to)).String(),
+		Source:  c,
+		Comment: fmt.Sprintf("set owner of %q sequence", to.Name),
+		Reverse: s.Build("ALTER SEQUENCE").P(s.seqIdent(to), "OWNED BY", s.seqOwnedBy(from)).String(),
+	})
+	return nil
+}
+
+// createSeq returns the statement for creating the sequence. Options are written
+// only if they are different from their defaults. The owner is set separately.
+func (s *state) createSeq(seq *Sequence) (string, error) {
+	if seq.Name == "" {
+		return "", errors.New("postgres: missing sequence name")
+	}
+	to := seqOptions(seq)
+	from := seqDefaults(to)
+	from.typ, from.inc = TypeBigInt, defaultSeqIncrement
+	b := s.Build("CREATE SEQUENCE").P(s.seqIdent(seq))
+	seqClauses(b, from, to)
+	return b.String(), nil
+}
+
+// alterSeq returns the statement for changing the sequence options from one state to the other.
+func (s *state) alterSeq(seq *Sequence, from, to seqOpts) string {
+	b := s.Build("ALTER SEQUENCE").P(s.seqIdent(seq))
+	seqClauses(b, from, to)
+	return b.String()
+}
+
+// seqClauses writes the clauses of the sequence options that were changed.
+func seqClauses(b *sqlx.Builder, from, to seqOpts) {
+	if from.typ != to.typ {
+		b.P("AS", to.typ)
+	}
+	if from.inc != to.inc {
+		b.P("INCREMENT BY", strconv.FormatInt(to.inc, 10))
+	}
+	if from.min != to.min {
+		b.P("MINVALUE", strconv.FormatInt(to.min, 10))
+	}
+	if from.max != to.max {
+		b.P("MAXVALUE", strconv.FormatInt(to.max, 10))
+	}
+	if from.start != to.start {
+		b.P("START WITH", strconv.FormatInt(to.start, 10))
+	}
+	if from.cache != to.cache {
+		b.P("CACHE", strconv.FormatInt(to.cache, 10))
+	}
+	switch {
+	case from.cycle == to.cycle:
+	case to.cycle:
+		b.P("CYCLE")
+	default:
+		b.P("NO CYCLE")
+	}
+}
+
+// seqComment returns the change for setting the comment of a sequence.
+func (s *state) seqComment(seq *Sequence, to, from string) *migrate.Change {
+	b := s.Build("COMMENT ON SEQUENCE").P(s.seqIdent(seq), "IS")
+	return &migrate.Change{
+		Cmd:     b.Clone().P(quote(to)).String(),
+		Comment: fmt.Sprintf("set comment to sequence: %q", seq.Name),
+		Reverse: b.Clone().P(quote(from)).String(),
+	}
+}
+
+// seqIdent returns the (optionally qualified) sequence name.
+func (s *state) seqIdent(seq *Sequence) string {
+	return s.schemaPrefix(seq.Schema) + strconv.Quote(seq.Name)
+}
+
+// seqOwnedBy returns the OWNED BY clause value of the sequence.
+func (s *state) seqOwnedBy(seq *Sequence) string {
+	if seqOwner(seq) == "" {
+		return "NONE"
+	}
+	t := seq.Owner.Table
+	return fmt.Sprintf("%s%q.%q", s.schemaPrefix(t.Schema), t.Name, seq.Owner.Column.Name)
+}
+
+// addDomain builds and executes the queries for creating a domain.
+func (s *state) addDomain(add *schema.AddObject, d *Domain) error {
+	create, err := s.createDomain(d)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     create,
+		Source:  add,
+		Comment: fmt.Sprintf("create domain type %q", d.T),
+		Reverse: s.Build("DROP DOMAIN").P(s.typeIdent(d.Schema, d.T)).String(),
+	})
+	if c := (schema.Comment{}); sqlx.Has(d.Attrs, &c) && c.Text != "" {
+		s.append(s.typeComment("DOMAIN", d.Schema, d.T, c.Text, ""))
+	}
+	return nil
+}
+
+// dropDomain builds and executes the query for dropping a domain.
+func (s *state) dropDomain(drop *schema.DropObject, d *Domain) error {
+	create, err := s.createDomain(d)
+	if err != nil {
+		return err
+	}
+	b := s.Build("DROP DOMAIN")
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.P(s.typeIdent(d.Schema, d.T)).String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop domain type %q", d.T),
+		Reverse: create,
+	})
+	return nil
+}
+
+// modifyDomain builds and executes the queries for altering a domain. Note,
+// PostgreSQL does not support changing the base type of existing domains.
+func (s *state) modifyDomain(modify *schema.ModifyObject) error {
+	from, ok1 := modify.From.(*Domain)
+	to, ok2 := modify.To.(*Domain)
+	if !ok1 || !ok2 {
+		return fmt.Errorf("altering objects (%T) to (%T) is not supported", modify.From, modify.To)
+	}
+	if t1, t2 := formatT(from.Base), formatT(to.Base); t1 != t2 {
+		return fmt.Errorf("changing the base type of domain %q from %q to %q is not supported", to.T, t1, t2)
+	}
+	name := s.typeIdent(to.Schema, to.T)
+	alter := func(cmd, reverse func(*sqlx.Builder), comment string) {
+		b1, b2 := s.Build("ALTER DOMAIN").P(name), s.Build("ALTER DOMAIN").P(name)
+		cmd(b1)
+		reverse(b2)
+		s.append(&migrate.Change{Cmd: b1.String(), Source: modify, Comment: comment, Reverse: b2.String()})
+	}
+	setDefault := func(d *Domain) func(*sqlx.Builder) {
+		return func(b *sqlx.Builder) {
+			if d.Default == nil {
+				b.P("DROP DEFAULT")
+				return
+			}
+			b.P("SET")
+			s.columnDefault(b, &schema.Column{Type: &schema.ColumnType{Type: d.Base}, Default: d.Default})
+		}
+	}
+	if domainDefaultChanged(from, to) {
+		alter(setDefault(to), setDefault(from), fmt.Sprintf("set default of domain type %q", to.T))
+	}
+	setNull := func(null bool) func(*sqlx.Builder) {
+		return func(b *sqlx.Builder) {
+			if null {
+				b.P("DROP NOT NULL")
+			} else {
+				b.P("SET NOT NULL")
+			}
+		}
+	}
+	if from.Null != to.Null {
+		alter(setNull(to.Null), setNull(from.Null), fmt.Sprintf("set nullability of domain type %q", to.T))
+	}
+	addCheck := func(c *schema.Check) func(*sqlx.Builder) {
+		return func(b *sqlx.Builder) {
+			b.P("ADD")
+			s.domainCheck(b, c)
+		}
+	}
+	dropCheck := func(c *schema.Check) func(*sqlx.Builder) {
+		return func(b *sqlx.Builder) {
+			b.P("DROP CONSTRAINT").Ident(c.Name)
+		}
+	}
+	drops, adds := domainChecksDiff(from, to)
+	for _, c := range drops {
+		alter(dropCheck(c), addCheck(c), fmt.Sprintf("drop constraint %q from domain type %q", c.Name, to.T))
+	}
+	for _, c := range adds {
+		alter(addCheck(c), dropCheck(c), fmt.Sprintf("add constraint %q to domain type %q", c.Name, to.T))
+	}
+	if change := sqlx.CommentDiff(from.Attrs, to.Attrs); change != nil {
+		fromC, toC, err := commentChange(change)
+		if err != nil {
+			return err
+		}
+		s.append(s.typeComment("DOMAIN", to.Schema, to.T, toC, fromC))
+	}
+	return nil
+}
+
+// createDomain returns the statement for creating the domain.
+func (s *state) createDomain(d *Domain) (string, error) {
+	if d.Base == nil {
+		return "", fmt.Errorf("missing base type for domain %q", d.T)
+	}
+	c := &schema.Column{Type: &schema.ColumnType{Type: d.Base}, Default: d.Default}
+	f, err := s.formatType(c)
+	if err != nil {
+		return "", err
+	}
+	b := s.Build("CREATE DOMAIN").P(s.typeIdent(d.Schema, d.T), "AS", f)
+	s.columnDefault(b, c)
+	if !d.Null {
+		b.P("NOT NULL")
+	}
+	for _, c := range d.Checks {
+		s.domainCheck(b, c)
+	}
+	return b.String(), nil
+}
+
+// domainCheck writes the CHECK constraint of a domain.
+func (s *state) domainCheck(b *sqlx.Builder, c *schema.Check) {
+	if c.Name != "" {
+		b.P("CONSTRAINT").Ident(c.Name)
+	}
+	b.P("CHECK", sqlx.MayWrap(c.Expr))
+}
+
+// addComposite builds and executes the queries for creating a composite type.
+func (s *state) addComposite(add *schema.AddObject, t *Composite) error {
+	create, err := s.createComposite(t)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     create,
+		Source:  add,
+		Comment: fmt.Sprintf("create composite type %q", t.T),
+		Reverse: s.Build("DROP TYPE").P(s.typeIdent(t.Schema, t.T)).String(),
+	})
+	if c := (schema.Comment{}); sqlx.Has(t.Attrs, &c) && c.Text != "" {
+		s.append(s.typeComment("TYPE", t.Schema, t.T, c.Text, ""))
+	}
+	return nil
+}
+
+// dropComposite builds and executes the query for dropping a composite type.
+func (s *state) dropComposite(drop *schema.DropObject, t *Composite) error {
+	create, err := s.createComposite(t)
+	if err != nil {
+		return err
+	}
+	b := s.Build("DROP TYPE")
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.P(s.typeIdent(t.Schema, t.T)).String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop composite type %q", t.T),
+		Reverse: create,
+	})
+	return nil
+}
+
+// modifyComposite builds and executes the queries for altering the fields and the comment of a
+// composite type. Fields are matched by their names, and the changes are applied in one statement.
+func (s *state) modifyComposite(modify *schema.ModifyObject) error {
+	from, ok1 := modify.From.(*Composite)
+	to, ok2 := modify.To.(*Composite)
+	if !ok1 || !ok2 {
+		return fmt.Errorf("altering objects (%T) to (%T) is not supported", modify.From, modify.To)
+	}
+	field := func(fs []*schema.Column, name string) (*schema.Column, bool) {
+		for _, f := range fs {
+			if f.Name == name {
+				return f, true
+			}
+		}
+		return nil, false
+	}
+	var cmd, reverse []string
+	for _, f1 := range from.Fields {
+		f2, ok := field(to.Fields, f1.Name)
+		if !ok {
+			t1, err := s.formatType(f1)
+			if err != nil {
+				return err
+			}
+			cmd = append(cmd, s.Build("DROP ATTRIBUTE").Ident(f1.Name).String())
+			reverse = append(reverse, s.Build("ADD ATTRIBUTE").Ident(f1.Name).P(t1).String())
+			continue
+		}
+		if formatT(f1.Type.Type) != formatT(f2.Type.Type) {
+			t1, err := s.formatType(f1)
+			if err != nil {
+				return err
+			}
+			t2, err := s.formatType(f2)
+			if err != nil {
+				return err
+			}
+			cmd = append(cmd, s.Build("ALTER ATTRIBUTE").Ident(f2.Name).P("TYPE", t2).String())
+			reverse = append(reverse, s.Build("ALTER ATTRIBUTE").Ident(f1.Name).P("TYPE", t1).String())
+		}
+	}
+	for _, f2 := range to.Fields {
+		if _, ok := field(from.Fields, f2.Name); !ok {
+			t2, err := s.formatType(f2)
+			if err != nil {
+				return err
+			}
+			cmd = append(cmd, s.Build("ADD ATTRIBUTE").Ident(f2.Name).P(t2).String())
+			reverse = append(reverse, s.Build("DROP ATTRIBUTE").Ident(f2.Name).String())
+		}
+	}
+	if len(cmd) > 0 {
+		name := s.typeIdent(to.Schema, to.T)
+		s.append(&migrate.Change{
+			Cmd:     s.Build("ALTER TYPE").P(name, strings.Join(cmd, ", ")).String(),
+			Source:  modify,
+			Comment: fmt.Sprintf("modify composite type %q", to.T),
+			Reverse: s.Build("ALTER TYPE").P(name, strings.Join(reverse, ", ")).String(),
+		})
+	}
+	if change := sqlx.CommentDiff(from.Attrs, to.Attrs); change != nil {
+		fromC, toC, err := commentChange(change)
+		if err != nil {
+			return err
+		}
+		s.append(s.typeComment("TYPE", to.Schema, to.T, toC, fromC))
+	}
+	return nil
+}
+
+// createComposite returns the statement for creating the composite type.
+func (s *state) createComposite(t *Composite) (string, error) {
+	var (
+		err error
+		b   = s.Build("CREATE TYPE").P(s.typeIdent(t.Schema, t.T), "AS")
+	)
+	b.Wrap(func(b *sqlx.Builder) {
+		err = b.MapCommaErr(t.Fields, func(i int, b *sqlx.Builder) error {
+			f, err := s.formatType(t.Fields[i])
+			if err != nil {
+				return err
+			}
+			b.Ident(t.Fields[i].Name).P(f)
+			return nil
+		})
+	})
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// typeComment returns the change for setting the comment of a domain or a composite type.
+func (s *state) typeComment(kind string, ns *schema.Schema, name, to, from string) *migrate.Change {
+	b := s.Build("COMMENT ON", kind).P(s.typeIdent(ns, name), "IS")
+	return &migrate.Change{
+		Cmd:     b.Clone().P(quote(to)).String(),
+		Comment: fmt.Sprintf("set comment to %s type: %q", strings.ToLower(kind), name),
+		Reverse: b.Clone().P(quote(from)).String(),
+	}
+}
+
+// typeIdent returns the (optionally qualified) name of a user-defined type.
+func (s *state) typeIdent(ns *schema.Schema, name string) string {
+	return s.schemaPrefix(ns) + strconv.Quote(name)
+}
+
 func (s *state) createDropEnum(e *schema.EnumType) (string, string) {
 	name := s.enumIdent(e)
 	return s.Build("CREATE TYPE").
@@ -1238,6 +2560,10 @@ func (s *state) formatType(c *schema.Column) (string, error) {
 	switch tt := c.Type.Type.(type) {
 	case *schema.EnumType:
 		return s.enumIdent(tt), nil
+	case *Domain:
+		return s.typeIdent(tt.Schema, tt.T), nil
+	case *Composite:
+		return s.typeIdent(tt.Schema, tt.T), nil
 	case *ArrayType:
 		if e, ok := tt.Type.(*schema.EnumType); ok {
 			return s.enumIdent(e) + "[]", nil
@@ -1208,14 +1208,14 @@ func TestPlanChanges(t *testing.T) {
 				Reversible:    true,
 				Transactional: true,
 				Changes: []*migrate.Change{
-					{
-						Cmd:     `ALTER TABLE "s1"."t1" ADD COLUMN "c" integer NOT NULL`,
-						Reverse: `ALTER TABLE "s1"."t1" DROP COLUMN "c"`,
-					},
 					{
 						Cmd:     `ALTER TABLE "s1"."t1" RENAME COLUMN "a" TO "b"`,
 						Reverse: `ALTER TABLE "s1"."t1" RENAME COLUMN "b" TO "a"`,
 					},
+					{
+						Cmd:     `ALTER TABLE "s1"."t1" ADD COLUMN "c" integer NOT NULL`,
+						Reverse: `ALTER TABLE "s1"."t1" DROP COLUMN "c"`,
+					},
 				},
 			},
 		},
@@ -1548,6 +1548,634 @@ func TestPlanChanges(t *testing.T) {
 	}
 }
 
+func TestPlanChanges_Sequences(t *testing.T) {
+	var (
+		minV    = int64(0)
+		users   = schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "bigint").SetDefault(&schema.RawExpr{X: "nextval('users_seq')"}))
+		counter = &Sequence{Name: "counter", Type: &schema.IntegerType{T: "int"}, Min: &minV, Cache: 10, Cycle: true}
+		seq     = &Sequence{Name: "users_seq", Attrs: []schema.Attr{&schema.Comment{Text: "c"}}}
+	)
+	seq.Owner.Table, seq.Owner.Column = users, users.Columns[0]
+	schema.New("public").AddTables(users).AddObjects(counter, seq)
+	counter.Schema, seq.Schema = users.Schema, users.Schema
+	tests := []struct {
+		changes []schema.Change
+		want    [][2]string
+	}{
+		// Sequences are created before the tables, and owned after.
+		{
+			changes: []schema.Change{
+				&schema.AddTable{T: users},
+				&schema.AddObject{O: seq},
+				&schema.AddObject{O: counter},
+			},
+			want: [][2]string{
+				{`CREATE SEQUENCE "public"."users_seq"`, `DROP SEQUENCE "public"."users_seq"`},
+				{`COMMENT ON SEQUENCE "public"."users_seq" IS 'c'`, `COMMENT ON SEQUENCE "public"."users_seq" IS ''`},
+				{`CREATE SEQUENCE "public"."counter" AS integer MINVALUE 0 CACHE 10 CYCLE`, `DROP SEQUENCE "public"."counter"`},
+				{`CREATE TABLE "public"."users" ("id" bigint NOT NULL DEFAULT nextval('users_seq'))`, `DROP TABLE "public"."users"`},
+				{`ALTER SEQUENCE "public"."users_seq" OWNED BY "public"."users"."id"`, `ALTER SEQUENCE "public"."users_seq" OWNED BY NONE`},
+			},
+		},
+		// Sequences owned by dropped tables are dropped along with them.
+		{
+			changes: []schema.Change{
+				&schema.DropObject{O: seq},
+				&schema.DropObject{O: counter},
+				&schema.DropTable{T: users},
+			},
+			want: [][2]string{
+				{`DROP TABLE "public"."users"`, `CREATE TABLE "public"."users" ("id" bigint NOT NULL DEFAULT nextval('users_seq'))`},
+				{`DROP SEQUENCE "public"."counter"`, `CREATE SEQUENCE "public"."counter" AS integer MINVALUE 0 CACHE 10 CYCLE`},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.ModifyObject{
+					From: counter,
+					To:   &Sequence{Name: "counter", Schema: counter.Schema, Increment: -1, Attrs: []schema.Attr{&schema.Comment{Text: "c"}}},
+				},
+			},
+			want: [][2]string{
+				{`ALTER SEQUENCE "public"."counter" AS bigint INCREMENT BY -1 MINVALUE -9223372036854775808 MAXVALUE -1 START WITH -1 CACHE 1 NO CYCLE`, `ALTER SEQUENCE "public"."counter" AS integer INCREMENT BY 1 MINVALUE 0 MAXVALUE 2147483647 START WITH 0 CACHE 10 CYCLE`},
+				{`COMMENT ON SEQUENCE "public"."counter" IS 'c'`, `COMMENT ON SEQUENCE "public"."counter" IS ''`},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.ModifyObject{
+					From: seq,
+					To:   &Sequence{Name: "users_seq", Schema: seq.Schema, Attrs: seq.Attrs},
+				},
+			},
+			want: [][2]string{
+				{`ALTER SEQUENCE "public"."users_seq" OWNED BY NONE`, `ALTER SEQUENCE "public"."users_seq" OWNED BY "public"."users"."id"`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		db, mk, err := sqlmock.New()
+		require.NoError(t, err)
+		mock{mk}.version("130000")
+		drv, err := Open(db)
+		require.NoError(t, err)
+		plan, err := drv.PlanChanges(context.Background(), "plan", tt.changes)
+		require.NoError(t, err)
+		require.Len(t, plan.Changes, len(tt.want))
+		for i, c := range plan.Changes {
+			require.Equal(t, tt.want[i][0], c.Cmd)
+			require.Equal(t, tt.want[i][1], c.Reverse)
+		}
+	}
+}
+
+func TestPlanChanges_Extensions(t *testing.T) {
+	var (
+		ext   = &Extension{Name: "uuid-ossp", Version: "1.1", Attrs: []schema.Attr{&schema.Comment{Text: "c"}}}
+		users = schema.NewTable("users").AddColumns(
+			schema.NewColumn("id").SetType(&schema.UUIDType{T: "uuid"}).SetDefault(&schema.RawExpr{X: "uuid_generate_v4()"}),
+		)
+		s = schema.New("app").AddObjects(ext).AddTables(users)
+	)
+	ext.Schema = s
+	tests := []struct {
+		changes []schema.Change
+		want    [][2]string
+	}{
+		// Extensions are created after their schemas, but before all other objects.
+		{
+			changes: []schema.Change{
+				&schema.AddTable{T: users},
+				&schema.AddSchema{S: s},
+				&schema.AddObject{O: ext},
+			},
+			want: [][2]string{
+				{`CREATE SCHEMA "app"`, `DROP SCHEMA "app" CASCADE`},
+				{`CREATE EXTENSION "uuid-ossp" WITH SCHEMA "app" VERSION '1.1'`, `DROP EXTENSION "uuid-ossp"`},
+				{`COMMENT ON EXTENSION "uuid-ossp" IS 'c'`, `COMMENT ON EXTENSION "uuid-ossp" IS ''`},
+				{`CREATE TABLE "app"."users" ("id" uuid NOT NULL DEFAULT uuid_generate_v4())`, `DROP TABLE "app"."users"`},
+			},
+		},
+		// Extensions are dropped last.
+		{
+			changes: []schema.Change{
+				&schema.DropObject{O: ext},
+				&schema.DropTable{T: users},
+			},
+			want: [][2]string{
+				{`DROP TABLE "app"."users"`, `CREATE TABLE "app"."users" ("id" uuid NOT NULL DEFAULT uuid_generate_v4())`},
+				{`DROP EXTENSION "uuid-ossp"`, `CREATE EXTENSION "uuid-ossp" WITH SCHEMA "app" VERSION '1.1'`},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.ModifyObject{From: ext, To: &Extension{Name: "uuid-ossp", Schema: s, Version: "1.2"}},
+			},
+			want: [][2]string{
+				{`ALTER EXTENSION "uuid-ossp" UPDATE TO '1.2'`, `ALTER EXTENSION "uuid-ossp" UPDATE TO '1.1'`},
+				{`COMMENT ON EXTENSION "uuid-ossp" IS ''`, `COMMENT ON EXTENSION "uuid-ossp" IS 'c'`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		db, mk, err := sqlmock.New()
+		require.NoError(t, err)
+		mock{mk}.version("130000")
+		drv, err := Open(db)
+		require.NoError(t, err)
+		plan, err := drv.PlanChanges(context.Background(), "plan", tt.changes)
+		require.NoError(t, err)
+		require.Len(t, plan.Changes, len(tt.want))
+		for i, c := range plan.Changes {
+			require.Equal(t, tt.want[i][0], c.Cmd)
+			require.Equal(t, tt.want[i][1], c.Reverse)
+		}
+	}
+}
+
+func TestPlanChanges_Types(t *testing.T) {
+	var (
+		s   = schema.New("public")
+		zip = &Domain{
+			T:       "us_postal_code",
+			Schema:  s,
+			Base:    &schema.StringType{T: "text"},
+			Default: &schema.Literal{V: "'00000'"},
+			Checks:  []*schema.Check{{Name: "zip_check", Expr: "VALUE ~ '^\\d{5}$'"}},
+			Attrs:   []schema.Attr{&schema.Comment{Text: "US zip code"}},
+		}
+		addr = &Composite{
+			T:      "address",
+			Schema: s,
+			Fields: []*schema.Column{
+				schema.NewColumn("street").SetType(&schema.StringType{T: "text"}),
+				schema.NewColumn("zip").SetType(zip),
+			},
+		}
+		users = schema.NewTable("users").
+			SetSchema(s).
+			AddColumns(
+				schema.NewColumn("zip").SetType(zip),
+				schema.NewNullColumn("address").SetType(addr),
+			)
+	)
+	tests := []struct {
+		changes []schema.Change
+		want    [][2]string
+		wantErr string
+	}{
+		// Types are created before the tables that use them.
+		{
+			changes: []schema.Change{
+				&schema.AddTable{T: users},
+				&schema.AddObject{O: zip},
+				&schema.AddObject{O: addr},
+			},
+			want: [][2]string{
+				{`CREATE DOMAIN "public"."us_postal_code" AS text DEFAULT '00000' NOT NULL CONSTRAINT "zip_check" CHECK (VALUE ~ '^\d{5}$')`, `DROP DOMAIN "public"."us_postal_code"`},
+				{`COMMENT ON DOMAIN "public"."us_postal_code" IS 'US zip code'`, `COMMENT ON DOMAIN "public"."us_postal_code" IS ''`},
+				{`CREATE TYPE "public"."address" AS ("street" text, "zip" "public"."us_postal_code")`, `DROP TYPE "public"."address"`},
+				{`CREATE TABLE "public"."users" ("zip" "public"."us_postal_code" NOT NULL, "address" "public"."address" NULL)`, `DROP TABLE "public"."users"`},
+			},
+		},
+		// Types are dropped after the tables that use them, and composites before domains.
+		{
+			changes: []schema.Change{
+				&schema.DropObject{O: zip},
+				&schema.DropObject{O: addr},
+				&schema.DropTable{T: users},
+			},
+			want: [][2]string{
+				{`DROP TABLE "public"."users"`, `CREATE TABLE "public"."users" ("zip" "public"."us_postal_code" NOT NULL, "address" "public"."address" NULL)`},
+				{`DROP TYPE "public"."address"`, `CREATE TYPE "public"."address" AS ("street" text, "zip" "public"."us_postal_code")`},
+				{`DROP DOMAIN "public"."us_postal_code"`, `CREATE DOMAIN "public"."us_postal_code" AS text DEFAULT '00000' NOT NULL CONSTRAINT "zip_check" CHECK (VALUE ~ '^\d{5}$')`},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.ModifyObject{
+					From: zip,
+					To: &Domain{
+						T:      "us_postal_code",
+						Schema: s,
+						Base:   &schema.StringType{T: "text"},
+						Null:   true,
+						Checks: []*schema.Check{{Name: "zip_check", Expr: "VALUE ~ '^\\d{5}(-\\d{4})?$'"}},
+					},
+				},
+			},
+			want: [][2]string{
+				{`ALTER DOMAIN "public"."us_postal_code" DROP DEFAULT`, `ALTER DOMAIN "public"."us_postal_code" SET DEFAULT '00000'`},
+				{`ALTER DOMAIN "public"."us_postal_code" DROP NOT NULL`, `ALTER DOMAIN "public"."us_postal_code" SET NOT NULL`},
+				{`ALTER DOMAIN "public"."us_postal_code" DROP CONSTRAINT "zip_check"`, `ALTER DOMAIN "public"."us_postal_code" ADD CONSTRAINT "zip_check" CHECK (VALUE ~ '^\d{5}$')`},
+				{`ALTER DOMAIN "public"."us_postal_code" ADD CONSTRAINT "zip_check" CHECK (VALUE ~ '^\d{5}(-\d{4})?$')`, `ALTER DOMAIN "public"."us_postal_code" DROP CONSTRAINT "zip_check"`},
+				{`COMMENT ON DOMAIN "public"."us_postal_code" IS ''`, `COMMENT ON DOMAIN "public"."us_postal_code" IS 'US zip code'`},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.ModifyObject{From: zip, To: &Domain{T: "us_postal_code", Schema: s, Base: &schema.IntegerType{T: "integer"}}},
+			},
+			wantErr: `changing the base type of domain "us_postal_code" from "text" to "integer" is not supported`,
+		},
+		{
+			changes: []schema.Change{
+				&schema.ModifyObject{
+					From: addr,
+					To: &Composite{
+						T:      "address",
+						Schema: s,
+						Fields: []*schema.Column{
+							schema.NewColumn("street").SetType(&schema.StringType{T: "varchar", Size: 255}),
+							schema.NewColumn("city").SetType(&schema.StringType{T: "text"}),
+						},
+					},
+				},
+			},
+			want: [][2]string{
+				{`ALTER TYPE "public"."address" ALTER ATTRIBUTE "street" TYPE character varying(255), DROP ATTRIBUTE "zip", ADD ATTRIBUTE "city" text`, `ALTER TYPE "public"."address" ALTER ATTRIBUTE "street" TYPE text, ADD ATTRIBUTE "zip" "public"."us_postal_code", DROP ATTRIBUTE "city"`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		db, mk, err := sqlmock.New()
+		require.NoError(t, err)
+		mock{mk}.version("130000")
+		drv, err := Open(db)
+		require.NoError(t, err)
+		plan, err := drv.PlanChanges(context.Background(), "plan", tt.changes)
+		if tt.wantErr != "" {
+			require.EqualError(t, err, tt.wantErr)
+			continue
+		}
+		require.NoError(t, err)
+		require.Len(t, plan.Changes, len(tt.want))
+		for i, c := range plan.Changes {
+			require.Equal(t, tt.want[i][0], c.Cmd)
+			require.Equal(t, tt.want[i][1], c.Reverse)
+		}
+	}
+}
+
+func TestPlanChanges_Policies(t *testing.T) {
+	var (
+		read = &Policy{Name: "owner_read", For: PolicyForSelect, Using: "owner = CURRENT_USER"}
+		edit = &Policy{Name: "owner_edit", As: PolicyAsRestrictive, To: []string{"admin", "public"}, Using: "owner = CURRENT_USER", Check: "(owner = CURRENT_USER)"}
+		rs   = &RowSecurity{Enabled: true}
+		user = schema.NewTable("users").
+			SetSchema(schema.New("public")).
+			AddColumns(schema.NewStringColumn("owner", "text")).
+			AddAttrs(rs, read)
+	)
+	tests := []struct {
+		changes []schema.Change
+		want    [][2]string
+	}{
+		// Row-level security and policies are created with their table.
+		{
+			changes: []schema.Change{&schema.AddTable{T: user}},
+			want: [][2]string{
+				{`CREATE TABLE "public"."users" ("owner" text NOT NULL)`, `DROP TABLE "public"."users"`},
+				{`ALTER TABLE "public"."users" ENABLE ROW LEVEL SECURITY`, `ALTER TABLE "public"."users" DISABLE ROW LEVEL SECURITY`},
+				{`CREATE POLICY "owner_read" ON "public"."users" FOR SELECT USING (owner = CURRENT_USER)`, `DROP POLICY "owner_read" ON "public"."users"`},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.ModifyTable{
+					T: user,
+					Changes: []schema.Change{
+						&schema.ModifyAttr{From: rs, To: &RowSecurity{Enabled: true, Enforced: true}},
+						&schema.AddAttr{A: edit},
+						&schema.DropAttr{A: read},
+					},
+				},
+			},
+			want: [][2]string{
+				{`DROP POLICY "owner_read" ON "public"."users"`, `CREATE POLICY "owner_read" ON "public"."users" FOR SELECT USING (owner = CURRENT_USER)`},
+				{`ALTER TABLE "public"."users" FORCE ROW LEVEL SECURITY`, `ALTER TABLE "public"."users" NO FORCE ROW LEVEL SECURITY`},
+				{`CREATE POLICY "owner_edit" ON "public"."users" AS RESTRICTIVE TO "admin", PUBLIC USING (owner = CURRENT_USER) WITH CHECK (owner = CURRENT_USER)`, `DROP POLICY "owner_edit" ON "public"."users"`},
+			},
+		},
+		// Policies are altered in place, unless their type or command were changed.
+		{
+			changes: []schema.Change{
+				&schema.ModifyTable{
+					T: user,
+					Changes: []schema.Change{
+						&schema.ModifyAttr{From: read, To: &Policy{Name: "owner_read", For: PolicyForSelect, To: []string{"reader"}, Using: "true"}},
+						&schema.ModifyAttr{From: edit, To: &Policy{Name: "owner_edit", Using: "true"}},
+						&schema.DropAttr{A: rs},
+					},
+				},
+			},
+			want: [][2]string{
+				{`ALTER POLICY "owner_read" ON "public"."users" TO "reader" USING (true)`, `ALTER POLICY "owner_read" ON "public"."users" TO PUBLIC USING (owner = CURRENT_USER)`},
+				{`DROP POLICY "owner_edit" ON "public"."users"`, `CREATE POLICY "owner_edit" ON "public"."users" AS RESTRICTIVE TO "admin", PUBLIC USING (owner = CURRENT_USER) WITH CHECK (owner = CURRENT_USER)`},
+				{`CREATE POLICY "owner_edit" ON "public"."users" USING (true)`, `DROP POLICY "owner_edit" ON "public"."users"`},
+				{`ALTER TABLE "public"."users" DISABLE ROW LEVEL SECURITY`, `ALTER TABLE "public"."users" ENABLE ROW LEVEL SECURITY`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		db, mk, err := sqlmock.New()
+		require.NoError(t, err)
+		mock{mk}.version("130000")
+		drv, err := Open(db)
+		require.NoError(t, err)
+		plan, err := drv.PlanChanges(context.Background(), "plan", tt.changes)
+		require.NoError(t, err)
+		require.Len(t, plan.Changes, len(tt.want))
+		for i, c := range plan.Changes {
+			require.Equal(t, tt.want[i][0], c.Cmd)
+			require.Equal(t, tt.want[i][1], c.Reverse)
+		}
+	}
+}
+
+func TestPlanChanges_Partitions(t *testing.T) {
+	var (
+		c    = schema.NewTimeColumn("created", "date")
+		jan  = &PartitionChild{Name: "logs_2024_01", Bound: PartitionBound{From: []string{"'2024-01-01'"}, To: []string{"'2024-02-01'"}}}
+		def  = &PartitionChild{Name: "logs_default", Bound: PartitionBound{Default: true}}
+		logs = schema.NewTable("logs").
+			SetSchema(schema.New("public")).
+			AddColumns(c).
+			AddAttrs(&Partition{T: PartitionTypeRange, Parts: []*PartitionPart{{C: c}}}, jan, def)
+	)
+	tests := []struct {
+		changes []schema.Change
+		want    [][2]string
+	}{
+		// Child partitions are created with their table.
+		{
+			changes: []schema.Change{&schema.AddTable{T: logs}},
+			want: [][2]string{
+				{`CREATE TABLE "public"."logs" ("created" date NOT NULL) PARTITION BY RANGE ("created")`, `DROP TABLE "public"."logs"`},
+				{`CREATE TABLE "public"."logs_2024_01" PARTITION OF "public"."logs" FOR VALUES FROM ('2024-01-01') TO ('2024-02-01')`, `DROP TABLE "public"."logs_2024_01"`},
+				{`CREATE TABLE "public"."logs_default" PARTITION OF "public"."logs" DEFAULT`, `DROP TABLE "public"."logs_default"`},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.ModifyTable{
+					T: logs,
+					Changes: []schema.Change{
+						&schema.AddAttr{A: &PartitionChild{Name: "logs_2024_02", Bound: PartitionBound{From: []string{"'2024-02-01'"}, To: []string{"'2024-03-01'"}}}},
+						&schema.ModifyAttr{From: jan, To: &PartitionChild{Name: "logs_2024_01", Bound: PartitionBound{From: []string{"'2023-12-01'"}, To: []string{"'2024-02-01'"}}}},
+						&schema.DropAttr{A: def},
+					},
+				},
+			},
+			want: [][2]string{
+				{`CREATE TABLE "public"."logs_2024_02" PARTITION OF "public"."logs" FOR VALUES FROM ('2024-02-01') TO ('2024-03-01')`, `DROP TABLE "public"."logs_2024_02"`},
+				{`ALTER TABLE "public"."logs" DETACH PARTITION "public"."logs_2024_01"`, `ALTER TABLE "public"."logs" ATTACH PARTITION "public"."logs_2024_01" FOR VALUES FROM ('2024-01-01') TO ('2024-02-01')`},
+				{`ALTER TABLE "public"."logs" ATTACH PARTITION "public"."logs_2024_01" FOR VALUES FROM ('2023-12-01') TO ('2024-02-01')`, `ALTER TABLE "public"."logs" DETACH PARTITION "public"."logs_2024_01"`},
+				{`DROP TABLE "public"."logs_default"`, `CREATE TABLE "public"."logs_default" PARTITION OF "public"."logs" DEFAULT`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		db, mk, err := sqlmock.New()
+		require.NoError(t, err)
+		mock{mk}.version("130000")
+		drv, err := Open(db)
+		require.NoError(t, err)
+		plan, err := drv.PlanChanges(context.Background(), "plan", tt.changes)
+		require.NoError(t, err)
+		require.Len(t, plan.Changes, len(tt.want))
+		for i, c := range plan.Changes {
+			require.Equal(t, tt.want[i][0], c.Cmd)
+			require.Equal(t, tt.want[i][1], c.Reverse)
+		}
+	}
+}
+
+func TestPlanChanges_ExpandContract(t *testing.T) {
+	var (
+		users = schema.NewTable("users").SetSchema(schema.New("public")).AddColumns(schema.NewIntColumn("id", "int"), schema.NewStringColumn("full_name", "text"))
+		name  = schema.NewStringColumn("name", "text")
+	)
+	plan, err := DefaultPlan.PlanChanges(context.Background(), "plan", []schema.Change{
+		&schema.ModifyTable{T: users, Changes: []schema.Change{&schema.RenameColumn{From: name, To: users.Columns[1]}}},
+	}, func(o *migrate.PlanOptions) { o.ExpandContract = true })
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 8)
+	var expand, contract []string
+	for _, c := range plan.Changes {
+		if c.Contract {
+			contract = append(contract, c.Cmd)
+		} else {
+			expand = append(expand, c.Cmd)
+		}
+	}
+	require.Equal(t, `ALTER TABLE "public"."users" ADD COLUMN "full_name" text NULL`, expand[0])
+	require.Contains(t, expand[1], `CREATE FUNCTION "public"."users_name_sync" () RETURNS trigger LANGUAGE plpgsql AS $$`)
+	require.Contains(t, expand[1], `ELSIF NEW."name" IS DISTINCT FROM OLD."name" THEN`)
+	require.Equal(t, `CREATE TRIGGER "users_name_sync" BEFORE INSERT OR UPDATE ON "public"."users" FOR EACH ROW EXECUTE PROCEDURE "public"."users_name_sync"()`, expand[2])
+	require.Equal(t, `UPDATE "public"."users" SET "full_name" = "name"`, expand[3])
+	require.Equal(t, `ALTER TABLE "public"."users" ALTER COLUMN "full_name" SET NOT NULL`, expand[4])
+	require.Equal(t, []string{
+		`DROP TRIGGER "users_name_sync" ON "public"."users"`,
+		`DROP FUNCTION "public"."users_name_sync" ()`,
+		`ALTER TABLE "public"."users" DROP COLUMN "name"`,
+	}, contract)
+
+	// Type changes are applied on a shadow column that replaces the column.
+	age := schema.NewIntColumn("age", "bigint")
+	users.AddColumns(age)
+	plan, err = DefaultPlan.PlanChanges(context.Background(), "plan", []schema.Change{
+		&schema.ModifyTable{T: users, Changes: []schema.Change{&schema.ModifyColumn{From: schema.NewIntColumn("age", "int"), To: age, Change: schema.ChangeType}}},
+	}, func(o *migrate.PlanOptions) { o.ExpandContract = true })
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 9)
+	require.Equal(t, `ALTER TABLE "public"."users" ADD COLUMN "age_new" bigint NULL`, plan.Changes[0].Cmd)
+	require.Equal(t, `UPDATE "public"."users" SET "age_new" = CAST("age" AS bigint)`, plan.Changes[3].Cmd)
+	require.Equal(t, `UPDATE "public"."users" SET "age" = CAST("age_new" AS integer)`, plan.Changes[3].Reverse)
+	require.True(t, plan.Changes[8].Contract)
+	require.Equal(t, `ALTER TABLE "public"."users" RENAME COLUMN "age_new" TO "age"`, plan.Changes[8].Cmd)
+
+	// Without the option, changes are planned as usual.
+	plan, err = DefaultPlan.PlanChanges(context.Background(), "plan", []schema.Change{
+		&schema.ModifyTable{T: users, Changes: []schema.Change{&schema.RenameColumn{From: name, To: users.Columns[1]}}},
+	})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	require.Equal(t, `ALTER TABLE "public"."users" RENAME COLUMN "name" TO "full_name"`, plan.Changes[0].Cmd)
+}
+
+func TestPlanChanges_Constraints(t *testing.T) {
+	var (
+		users    = schema.NewTable("users").SetSchema(schema.New("public")).AddColumns(schema.NewIntColumn("id", "int"))
+		id, room = schema.NewIntColumn("id", "int"), schema.NewIntColumn("room", "int")
+		during   = schema.NewColumn("during").SetType(&RangeType{T: "tsrange"})
+		uid      = schema.NewIntColumn("user_id", "int")
+		bookings = schema.NewTable("bookings").SetSchema(users.Schema).AddColumns(id, room, during, uid)
+		fk       = schema.NewForeignKey("user_fk").AddColumns(uid).SetRefTable(users).AddRefColumns(users.Columns[0])
+		excl     = &schema.Index{
+			Name:  "no_overlap",
+			Table: bookings,
+			Attrs: []schema.Attr{&IndexType{T: "GIST"}, &IndexPredicate{P: "id > 0"}},
+			Parts: []*schema.IndexPart{
+				{SeqNo: 1, C: room, Attrs: []schema.Attr{&IndexExcludeOp{V: "="}}},
+				{SeqNo: 2, C: during, Attrs: []schema.Attr{&IndexExcludeOp{V: "&&"}}},
+			},
+		}
+		uniq = schema.NewUniqueIndex("uniq_id").AddColumns(id).AddAttrs(&Deferrable{})
+	)
+	bookings.AddForeignKeys(fk.AddAttrs(&Deferrable{Deferred: true}))
+	bookings.AddIndexes(excl, uniq)
+	tests := []struct {
+		changes []schema.Change
+		want    [][2]string
+	}{
+		// EXCLUDE and DEFERRABLE constraints are defined inline.
+		{
+			changes: []schema.Change{&schema.AddTable{T: bookings}},
+			want: [][2]string{
+				{`CREATE TABLE "public"."bookings" ("id" integer NOT NULL, "room" integer NOT NULL, "during" tsrange NOT NULL, "user_id" integer NOT NULL, CONSTRAINT "user_fk" FOREIGN KEY ("user_id") REFERENCES "public"."users" ("id") DEFERRABLE INITIALLY DEFERRED, CONSTRAINT "no_overlap" EXCLUDE USING GIST ("room" WITH =, "during" WITH &&) WHERE (id > 0), CONSTRAINT "uniq_id" UNIQUE ("id") DEFERRABLE)`, `DROP TABLE "public"."bookings"`},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.ModifyTable{
+					T: bookings,
+					Changes: []schema.Change{
+						&schema.AddIndex{I: excl},
+						&schema.DropIndex{I: uniq},
+						&schema.ModifyForeignKey{
+							From:   schema.NewForeignKey("user_fk").AddColumns(uid).SetRefTable(users).AddRefColumns(users.Columns[0]),
+							To:     fk,
+							Change: schema.ChangeAttr,
+						},
+					},
+				},
+			},
+			want: [][2]string{
+				{
+					`ALTER TABLE "public"."bookings" DROP CONSTRAINT "uniq_id", DROP CONSTRAINT "user_fk", ADD CONSTRAINT "no_overlap" EXCLUDE USING GIST ("room" WITH =, "during" WITH &&) WHERE (id > 0), ADD CONSTRAINT "user_fk" FOREIGN KEY ("user_id") REFERENCES "public"."users" ("id") DEFERRABLE INITIALLY DEFERRED`,
+					`ALTER TABLE "public"."bookings" DROP CONSTRAINT "user_fk", DROP CONSTRAINT "no_overlap", ADD CONSTRAINT "user_fk" FOREIGN KEY ("user_id") REFERENCES "public"."users" ("id"), ADD CONSTRAINT "uniq_id" UNIQUE ("id") DEFERRABLE`,
+				},
+			},
+		},
+		// Making a unique index deferrable, replaces it with a constraint.
+		{
+			changes: []schema.Change{
+				&schema.ModifyTable{
+					T: bookings,
+					Changes: []schema.Change{
+						&schema.ModifyIndex{
+							From:   schema.NewUniqueIndex("uniq_id").AddColumns(id),
+							To:     uniq,
+							Change: schema.ChangeAttr,
+						},
+					},
+				},
+			},
+			want: [][2]string{
+				{`DROP INDEX "public"."uniq_id"`, `CREATE UNIQUE INDEX "uniq_id" ON "public"."bookings" ("id")`},
+				{`ALTER TABLE "public"."bookings" ADD CONSTRAINT "uniq_id" UNIQUE ("id") DEFERRABLE`, `ALTER TABLE "public"."bookings" DROP CONSTRAINT "uniq_id"`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		db, mk, err := sqlmock.New()
+		require.NoError(t, err)
+		mock{mk}.version("130000")
+		drv, err := Open(db)
+		require.NoError(t, err)
+		plan, err := drv.PlanChanges(context.Background(), "plan", tt.changes)
+		require.NoError(t, err)
+		require.Len(t, plan.Changes, len(tt.want))
+		for i, c := range plan.Changes {
+			require.Equal(t, tt.want[i][0], c.Cmd)
+			require.Equal(t, tt.want[i][1], c.Reverse)
+		}
+	}
+	// Only UNIQUE and EXCLUDE constraints can be deferrable.
+	db, mk, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{mk}.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	_, err = drv.PlanChanges(context.Background(), "plan", []schema.Change{
+		&schema.ModifyTable{
+			T: bookings,
+			Changes: []schema.Change{
+				&schema.AddIndex{I: schema.NewIndex("idx").AddColumns(id).AddAttrs(&Deferrable{})},
+			},
+		},
+	})
+	require.EqualError(t, err, `postgres: DEFERRABLE is supported only by UNIQUE and EXCLUDE constraints. index: "idx"`)
+}
+
+func TestPlanChanges_Roles(t *testing.T) {
+	var (
+		users   = schema.NewTable("users").SetSchema(schema.New("public")).AddColumns(schema.NewIntColumn("id", "int"), schema.NewStringColumn("email", "text"))
+		seq     = &Sequence{Name: "counter", Schema: users.Schema}
+		readers = schema.NewRole("readers")
+		alice   = schema.NewUser("alice").AddMemberOf(readers)
+	)
+	tests := []struct {
+		changes []schema.Change
+		want    [][2]any
+	}{
+		// Roles are created before the other changes, and privileges are granted after them.
+		{
+			changes: []schema.Change{
+				&schema.AddGrant{G: schema.NewGrant(users, "SELECT", "INSERT").SetGrantOption(true)},
+				&schema.AddTable{T: users},
+				&schema.AddRole{R: alice},
+				&schema.AddRole{R: readers},
+				&schema.AddGrant{G: schema.NewGrant(users.Schema, "USAGE")},
+			},
+			want: [][2]any{
+				{`CREATE ROLE "alice" WITH LOGIN`, `DROP ROLE "alice"`},
+				{`CREATE ROLE "readers"`, `DROP ROLE "readers"`},
+				{`GRANT "readers" TO "alice"`, `REVOKE "readers" FROM "alice"`},
+				{`CREATE TABLE "public"."users" ("id" integer NOT NULL, "email" text NOT NULL)`, `DROP TABLE "public"."users"`},
+				{`GRANT SELECT, INSERT ON TABLE "public"."users" TO "alice" WITH GRANT OPTION`, `REVOKE SELECT, INSERT ON TABLE "public"."users" FROM "alice"`},
+				{`GRANT USAGE ON SCHEMA "public" TO "readers"`, `REVOKE USAGE ON SCHEMA "public" FROM "readers"`},
+			},
+		},
+		// Privileges are revoked before roles are dropped.
+		{
+			changes: []schema.Change{
+				&schema.DropRole{R: alice},
+				&schema.DropGrant{G: schema.NewGrant(seq, "all")},
+				&schema.ModifyRole{From: readers, To: schema.NewUser("readers").AddMemberOf(alice)},
+				&schema.AddGrant{G: schema.NewGrant(users, "UPDATE", "SELECT").SetColumns(users.Columns[1])},
+			},
+			want: [][2]any{
+				{`ALTER ROLE "readers" WITH LOGIN`, `ALTER ROLE "readers" WITH NOLOGIN`},
+				{`GRANT "alice" TO "readers"`, `REVOKE "alice" FROM "readers"`},
+				{`REVOKE ALL PRIVILEGES ON SEQUENCE "public"."counter" FROM "readers"`, `GRANT ALL PRIVILEGES ON SEQUENCE "public"."counter" TO "readers"`},
+				{`GRANT UPDATE ("email"), SELECT ("email") ON TABLE "public"."users" TO "alice"`, `REVOKE UPDATE ("email"), SELECT ("email") ON TABLE "public"."users" FROM "alice"`},
+				{`DROP ROLE "alice"`, []string{`CREATE ROLE "alice" WITH LOGIN`, `GRANT "readers" TO "alice"`}},
+			},
+		},
+	}
+	// Grants are linked to their roles.
+	tests[0].changes[0].(*schema.AddGrant).G.To = alice
+	tests[0].changes[4].(*schema.AddGrant).G.To = readers
+	tests[1].changes[1].(*schema.DropGrant).G.To = readers
+	tests[1].changes[3].(*schema.AddGrant).G.To = alice
+	for _, tt := range tests {
+		db, mk, err := sqlmock.New()
+		require.NoError(t, err)
+		mock{mk}.version("130000")
+		drv, err := Open(db)
+		require.NoError(t, err)
+		plan, err := drv.PlanChanges(context.Background(), "plan", tt.changes)
+		require.NoError(t, err)
+		require.Len(t, plan.Changes, len(tt.want))
+		for i, c := range plan.Changes {
+			require.Equal(t, tt.want[i][0], c.Cmd)
+			require.Equal(t, tt.want[i][1], c.Reverse)
+		}
+	}
+}
+
 func TestDefaultPlan(t *testing.T) {
 	changes, err := DefaultPlan.PlanChanges(context.Background(), "plan", []schema.Change{
 		&schema.AddTable{T: schema.NewTable("t1").SetSchema(schema.New("s1")).AddColumns(schema.NewIntColumn("a", "int"))},
@@ -17,6 +17,7 @@ import (
 	"ariga.io/atlas/sql/sqlcheck"
 	"ariga.io/atlas/sql/sqlcheck/condrop"
 	"ariga.io/atlas/sql/sqlcheck/datadepend"
+	"ariga.io/atlas/sql/sqlcheck/design"
 	"ariga.io/atlas/sql/sqlcheck/destructive"
 	"ariga.io/atlas/sql/sqlcheck/incompatible"
 	"ariga.io/atlas/sql/sqlcheck/naming"
@@ -65,6 +66,19 @@ func init() {
 		}
 		return []sqlcheck.Analyzer{ds, dd, cd, bc, nm, ci}, nil
 	})
+	sqlcheck.RegisterState(postgres.DriverName, func(r *schemahcl.Resource) ([]sqlcheck.StateAnalyzer, error) {
+		de, err := design.New(r, design.Handler{
+			Reserved: isReserved,
+		})
+		if err != nil {
+			return nil, err
+		}
+		nm, err := naming.New(r)
+		if err != nil {
+			return nil, err
+		}
+		return []sqlcheck.StateAnalyzer{de, nm}, nil
+	})
 }
 
 type (
@@ -107,14 +121,14 @@ func (*ConcurrentIndex) Name() string {
 var (
 	// codeCreateNoCon is a PostgreSQL specific code for reporting
 	// indexes creation without the CONCURRENTLY option.
-	codeCreateNoCon = sqlcheck.Code("PG101")
+	codeCreateNoCon = sqlcheck.CodeWithText("PG101", "Missing the CONCURRENTLY in index creation")
 	// codeDropNoCon is a PostgreSQL specific code for reporting
 	// indexes deletion without the CONCURRENTLY option.
-	codeDropNoCon = sqlcheck.Code("PG102")
+	codeDropNoCon = sqlcheck.CodeWithText("PG102", "Missing the CONCURRENTLY in index deletion")
 	// codeNoTxNone is a PostgreSQL specific code for reporting indexes
 	// creation or deletion concurrently without the txmode directive set
 	// to none.
-	codeNoTxNone = sqlcheck.Code("PG103")
+	codeNoTxNone = sqlcheck.CodeWithText("PG103", "Missing atlas:txmode none directive in file header")
 )
 
 // Analyze implements sqlcheck.Analyzer.
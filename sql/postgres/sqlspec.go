@@ -5,6 +5,7 @@
 package postgres
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -23,10 +24,20 @@ import (
 
 type (
 	doc struct {
-		Tables  []*sqlspec.Table  `spec:"table"`
-		Views   []*sqlspec.View   `spec:"view"`
-		Enums   []*Enum           `spec:"enum"`
-		Schemas []*sqlspec.Schema `spec:"schema"`
+		Tables       []*sqlspec.Table   `spec:"table"`
+		Views        []*sqlspec.View    `spec:"view"`
+		Materialized []*sqlspec.View    `spec:"materialized"`
+		Triggers     []*sqlspec.Trigger `spec:"trigger"`
+		Extensions   []*extension       `spec:"extension"`
+		Enums        []*Enum            `spec:"enum"`
+		Domains      []*domain          `spec:"domain"`
+		Composites   []*composite       `spec:"composite"`
+		Funcs        []*sqlspec.Func    `spec:"function"`
+		Procs        []*sqlspec.Proc    `spec:"procedure"`
+		Sequences    []*sequence        `spec:"sequence"`
+		Schemas      []*sqlspec.Schema  `spec:"schema"`
+		Roles        []*sqlspec.Role    `spec:"role"`
+		Users        []*sqlspec.Role    `spec:"user"`
 	}
 	// Enum holds a specification for an enum, that can be referenced as a column type.
 	Enum struct {
@@ -35,10 +46,52 @@ type (
 		Values []string       `spec:"values"`
 		schemahcl.DefaultExtension
 	}
+	// extension holds a specification for an extension. The extension
+	// version and comment are stored as extra attributes.
+	extension struct {
+		Name   string         `spec:",name"`
+		Schema *schemahcl.Ref `spec:"schema"`
+		schemahcl.DefaultExtension
+	}
+	// domain holds a specification for a domain type. The nullability
+	// and the comment of the domain are stored as extra attributes.
+	domain struct {
+		Name    string           `spec:",name"`
+		Schema  *schemahcl.Ref   `spec:"schema"`
+		Type    *schemahcl.Type  `spec:"type"`
+		Default cty.Value        `spec:"default"`
+		Checks  []*sqlspec.Check `spec:"check"`
+		schemahcl.DefaultExtension
+	}
+	// composite holds a specification for a composite type.
+	composite struct {
+		Name   string            `spec:",name"`
+		Schema *schemahcl.Ref    `spec:"schema"`
+		Fields []*compositeField `spec:"field"`
+		schemahcl.DefaultExtension
+	}
+	// compositeField holds a specification for a field in a composite type.
+	compositeField struct {
+		Name string          `spec:",name"`
+		Type *schemahcl.Type `spec:"type"`
+		schemahcl.DefaultExtension
+	}
+	// sequence holds a specification for a standalone sequence. The sequence
+	// options (e.g. start or increment) are stored as extra attributes.
+	sequence struct {
+		Name   string          `spec:",name"`
+		Schema *schemahcl.Ref  `spec:"schema"`
+		Type   *schemahcl.Type `spec:"type"`
+		schemahcl.DefaultExtension
+	}
 )
 
 func init() {
 	schemahcl.Register("enum", &Enum{})
+	schemahcl.Register("sequence", &sequence{})
+	schemahcl.Register("extension", &extension{})
+	schemahcl.Register("domain", &domain{})
+	schemahcl.Register("composite", &composite{})
 }
 
 // evalSpec evaluates an Atlas DDL document into v using the input.
@@ -50,16 +103,31 @@ func evalSpec(p *hclparse.Parser, v any, input map[string]cty.Value) error {
 			return err
 		}
 		if err := specutil.Scan(v,
-			&specutil.ScanDoc{Schemas: d.Schemas, Tables: d.Tables, Views: d.Views},
-			&specutil.ScanFuncs{Table: convertTable, View: convertView},
+			&specutil.ScanDoc{Schemas: d.Schemas, Tables: d.Tables, Views: d.Views, Materialized: d.Materialized, Triggers: d.Triggers},
+			&specutil.ScanFuncs{Table: convertTable, View: convertView, Materialized: convertMaterialized, Trigger: convertTrigger, ForeignKey: convertFK},
 		); err != nil {
 			return fmt.Errorf("specutil: failed converting to *schema.Realm: %w", err)
 		}
+		if err := convertExtensions(d.Extensions, v); err != nil {
+			return err
+		}
 		if len(d.Enums) > 0 {
 			if err := convertEnums(d.Tables, d.Enums, v); err != nil {
 				return err
 			}
 		}
+		if err := convertTypes(d.Tables, d.Domains, d.Composites, v); err != nil {
+			return err
+		}
+		if err := convertFuncs(d.Funcs, d.Procs, v); err != nil {
+			return err
+		}
+		if err := convertSequences(d.Sequences, v); err != nil {
+			return err
+		}
+		if err := convertRoles(d.Roles, d.Users, v); err != nil {
+			return err
+		}
 	case *schema.Schema:
 		var d doc
 		if err := hclState.Eval(p, &d, input); err != nil {
@@ -70,14 +138,29 @@ func evalSpec(p *hclparse.Parser, v any, input map[string]cty.Value) error {
 		}
 		r := &schema.Realm{}
 		if err := specutil.Scan(r,
-			&specutil.ScanDoc{Schemas: d.Schemas, Tables: d.Tables, Views: d.Views},
-			&specutil.ScanFuncs{Table: convertTable, View: convertView},
+			&specutil.ScanDoc{Schemas: d.Schemas, Tables: d.Tables, Views: d.Views, Materialized: d.Materialized, Triggers: d.Triggers},
+			&specutil.ScanFuncs{Table: convertTable, View: convertView, Materialized: convertMaterialized, Trigger: convertTrigger, ForeignKey: convertFK},
 		); err != nil {
 			return err
 		}
+		if err := convertExtensions(d.Extensions, r); err != nil {
+			return err
+		}
 		if err := convertEnums(d.Tables, d.Enums, r); err != nil {
 			return err
 		}
+		if err := convertTypes(d.Tables, d.Domains, d.Composites, r); err != nil {
+			return err
+		}
+		if err := convertFuncs(d.Funcs, d.Procs, r); err != nil {
+			return err
+		}
+		if err := convertSequences(d.Sequences, r); err != nil {
+			return err
+		}
+		if err := convertRoles(d.Roles, d.Users, r); err != nil {
+			return err
+		}
 		*v = *r.Schemas[0]
 	case schema.Schema, schema.Realm:
 		return fmt.Errorf("postgres: Eval expects a pointer: received %[1]T, expected *%[1]T", v)
@@ -99,8 +182,16 @@ func MarshalSpec(v any, marshaler schemahcl.Marshaler) ([]byte, error) {
 		}
 		d.Tables = doc.Tables
 		d.Views = doc.Views
+		d.Materialized = doc.Materialized
+		d.Triggers = doc.Triggers
 		d.Schemas = doc.Schemas
 		d.Enums = doc.Enums
+		d.Domains = doc.Domains
+		d.Composites = doc.Composites
+		d.Funcs = doc.Funcs
+		d.Procs = doc.Procs
+		d.Sequences = doc.Sequences
+		d.Extensions = doc.Extensions
 	case *schema.Realm:
 		for _, s := range s.Schemas {
 			doc, err := schemaSpec(s)
@@ -109,18 +200,34 @@ func MarshalSpec(v any, marshaler schemahcl.Marshaler) ([]byte, error) {
 			}
 			d.Tables = append(d.Tables, doc.Tables...)
 			d.Views = append(d.Views, doc.Views...)
+			d.Materialized = append(d.Materialized, doc.Materialized...)
+			d.Triggers = append(d.Triggers, doc.Triggers...)
 			d.Schemas = append(d.Schemas, doc.Schemas...)
 			d.Enums = append(d.Enums, doc.Enums...)
+			d.Domains = append(d.Domains, doc.Domains...)
+			d.Composites = append(d.Composites, doc.Composites...)
+			d.Funcs = append(d.Funcs, doc.Funcs...)
+			d.Procs = append(d.Procs, doc.Procs...)
+			d.Sequences = append(d.Sequences, doc.Sequences...)
+			d.Extensions = append(d.Extensions, doc.Extensions...)
 		}
 		if err := specutil.QualifyTables(d.Tables); err != nil {
 			return nil, err
 		}
+		qualifySeqOwners(d.Sequences, d.Tables)
 		if err := specutil.QualifyViews(d.Views); err != nil {
 			return nil, err
 		}
+		if err := specutil.QualifyViews(d.Materialized); err != nil {
+			return nil, err
+		}
 		if err := specutil.QualifyReferences(d.Tables, s); err != nil {
 			return nil, err
 		}
+		var err error
+		if d.Roles, d.Users, err = specutil.FromRoles(s, sequenceRef); err != nil {
+			return nil, err
+		}
 	default:
 		return nil, fmt.Errorf("specutil: failed marshaling spec. %T is not supported", v)
 	}
@@ -131,9 +238,25 @@ var (
 	hclState = schemahcl.New(append(specOptions,
 		schemahcl.WithTypes("table.column.type", TypeRegistry.Specs()),
 		schemahcl.WithTypes("view.column.type", TypeRegistry.Specs()),
+		schemahcl.WithTypes("materialized.column.type", TypeRegistry.Specs()),
+		schemahcl.WithTypes("function.arg.type", TypeRegistry.Specs()),
+		schemahcl.WithTypes("function.return", TypeRegistry.Specs()),
+		schemahcl.WithTypes("procedure.arg.type", TypeRegistry.Specs()),
+		schemahcl.WithTypes("sequence.type", TypeRegistry.Specs()),
+		schemahcl.WithTypes("domain.type", TypeRegistry.Specs()),
+		schemahcl.WithTypes("composite.field.type", TypeRegistry.Specs()),
 		schemahcl.WithScopedEnums("view.check_option", schema.ViewCheckOptionLocal, schema.ViewCheckOptionCascaded),
+		schemahcl.WithScopedEnums("trigger.for", string(schema.TriggerForRow), string(schema.TriggerForStmt)),
+		schemahcl.WithScopedEnums("function.lang", funcLangSQL, funcLangPLpgSQL),
+		schemahcl.WithScopedEnums("function.volatility", FuncVolatilityImmutable, FuncVolatilityStable, FuncVolatilityVolatile),
+		schemahcl.WithScopedEnums("function.arg.mode", string(schema.FuncArgModeIn), string(schema.FuncArgModeOut), string(schema.FuncArgModeInOut), string(schema.FuncArgModeVariadic)),
+		schemahcl.WithScopedEnums("procedure.lang", funcLangSQL, funcLangPLpgSQL),
+		schemahcl.WithScopedEnums("procedure.arg.mode", string(schema.FuncArgModeIn), string(schema.FuncArgModeOut), string(schema.FuncArgModeInOut), string(schema.FuncArgModeVariadic)),
 		schemahcl.WithScopedEnums("table.index.type", IndexTypeBTree, IndexTypeBRIN, IndexTypeHash, IndexTypeGIN, IndexTypeGiST, "GiST", IndexTypeSPGiST, "SPGiST"),
+		schemahcl.WithScopedEnums("materialized.index.type", IndexTypeBTree, IndexTypeBRIN, IndexTypeHash, IndexTypeGIN, IndexTypeGiST, "GiST", IndexTypeSPGiST, "SPGiST"),
 		schemahcl.WithScopedEnums("table.partition.type", PartitionTypeRange, PartitionTypeList, PartitionTypeHash),
+		schemahcl.WithScopedEnums("table.policy.as", PolicyAsPermissive, PolicyAsRestrictive),
+		schemahcl.WithScopedEnums("table.policy.for", PolicyForAll, PolicyForSelect, PolicyForInsert, PolicyForUpdate, PolicyForDelete),
 		schemahcl.WithScopedEnums("table.column.identity.generated", GeneratedTypeAlways, GeneratedTypeByDefault),
 		schemahcl.WithScopedEnums("table.column.as.type", "STORED"),
 		schemahcl.WithScopedEnums("table.foreign_key.on_update", specutil.ReferenceVars...),
@@ -161,13 +284,16 @@ var (
 // ForeignKeySpecs into ForeignKeys, as the target tables do not necessarily exist in the schema
 // at this point. Instead, the linking is done by the convertSchema function.
 func convertTable(spec *sqlspec.Table, parent *schema.Schema) (*schema.Table, error) {
-	t, err := specutil.Table(spec, parent, convertColumn, convertPK, convertIndex, specutil.Check)
+	t, err := specutil.Table(spec, parent, convertColumn, convertPK, convertIndex, convertCheck)
 	if err != nil {
 		return nil, err
 	}
 	if err := convertPartition(spec.Extra, t); err != nil {
 		return nil, err
 	}
+	if err := convertRowSecurity(spec.Extra, t); err != nil {
+		return nil, err
+	}
 	return t, nil
 }
 
@@ -182,6 +308,78 @@ func convertView(spec *sqlspec.View, parent *schema.Schema) (*schema.View, error
 	return v, nil
 }
 
+// convertMaterialized converts a sqlspec.View to a schema.View that represents a materialized view.
+func convertMaterialized(spec *sqlspec.View, parent *schema.Schema) (*schema.View, error) {
+	v, err := convertView(spec, parent)
+	if err != nil {
+		return nil, err
+	}
+	v.AddAttrs(&schema.Materialized{})
+	if a, ok := spec.Attr("with_data"); ok {
+		b, err := a.Bool()
+		if err != nil {
+			return nil, fmt.Errorf("expect bool value for attribute materialized.%s.with_data: %w", spec.Name, err)
+		}
+		if !b {
+			v.AddAttrs(&WithNoData{})
+		}
+	}
+	if a, ok := spec.Attr("tablespace"); ok {
+		t, err := a.String()
+		if err != nil {
+			return nil, fmt.Errorf("expect string value for attribute materialized.%s.tablespace: %w", spec.Name, err)
+		}
+		v.AddAttrs(&Tablespace{N: t})
+	}
+	if r, ok := spec.Extra.Resource("storage_params"); ok {
+		p := &StorageParams{}
+		for _, a := range r.Attrs {
+			switch t := a.V.Type(); {
+			case t == cty.String:
+				p.Params = append(p.Params, struct{ N, V string }{N: a.K, V: a.V.AsString()})
+			case t == cty.Bool:
+				p.Params = append(p.Params, struct{ N, V string }{N: a.K, V: strconv.FormatBool(a.V.True())})
+			case t == cty.Number:
+				p.Params = append(p.Params, struct{ N, V string }{N: a.K, V: a.V.AsBigFloat().Text('f', -1)})
+			default:
+				return nil, fmt.Errorf("unexpected value type %s for materialized.%s.storage_params.%s", t.FriendlyName(), spec.Name, a.K)
+			}
+		}
+		v.AddAttrs(p)
+	}
+	// Indexes are defined on the view columns, and are converted
+	// using a table that mimics the materialized view.
+	t := schema.NewTable(v.Name).SetSchema(parent).AddColumns(v.Columns...)
+	for _, r := range spec.Extra.Children {
+		if r.Type != "index" {
+			continue
+		}
+		var is sqlspec.Index
+		if err := r.As(&is); err != nil {
+			return nil, fmt.Errorf("parsing materialized.%s.index: %w", spec.Name, err)
+		}
+		idx, err := convertIndex(&is, t)
+		if err != nil {
+			return nil, err
+		}
+		v.AddIndexes(idx)
+	}
+	return v, nil
+}
+
+// convertTrigger converts a sqlspec.Trigger to a schema.Trigger.
+func convertTrigger(spec *sqlspec.Trigger, r *schema.Realm) (*schema.Trigger, error) {
+	t, err := specutil.Trigger(spec, r)
+	if err != nil {
+		return nil, err
+	}
+	// FOR EACH STATEMENT is the default in PostgreSQL.
+	if t.For == "" {
+		t.SetFor(schema.TriggerForStmt)
+	}
+	return t, nil
+}
+
 // convertPartition converts and appends the partition block into the table attributes if exists.
 func convertPartition(spec schemahcl.Resource, table *schema.Table) error {
 	r, ok := spec.Resource("partition")
@@ -195,6 +393,15 @@ func convertPartition(spec schemahcl.Resource, table *schema.Table) error {
 			Expr   string         `spec:"expr"`
 			Column *schemahcl.Ref `spec:"column"`
 		} `spec:"by"`
+		Children []*struct {
+			Name      string   `spec:",name"`
+			Default   bool     `spec:"default"`
+			From      []string `spec:"from"`
+			To        []string `spec:"to"`
+			In        []string `spec:"in"`
+			Modulus   int64    `spec:"modulus"`
+			Remainder int64    `spec:"remainder"`
+		} `spec:"child"`
 	}
 	if err := r.As(&p); err != nil {
 		return fmt.Errorf("parsing %s.partition: %w", table.Name, err)
@@ -235,9 +442,38 @@ func convertPartition(spec schemahcl.Resource, table *schema.Table) error {
 		}
 	}
 	table.AddAttrs(key)
+	for _, c := range p.Children {
+		b := PartitionBound{Default: c.Default, From: c.From, To: c.To, In: c.In, Modulus: c.Modulus, Remainder: c.Remainder}
+		switch r, l, h := len(b.From) > 0 || len(b.To) > 0, len(b.In) > 0, b.Modulus > 0; {
+		case b.Default && (r || l || h), r && (l || h), l && h:
+			return fmt.Errorf("multiple bounds were defined for %s.partition.child.%s", table.Name, c.Name)
+		case b.Default:
+		case r && (len(b.From) == 0 || len(b.To) == 0):
+			return fmt.Errorf(`missing "from" or "to" bound for %s.partition.child.%s`, table.Name, c.Name)
+		case !r && !l && !h:
+			return fmt.Errorf("missing bound for %s.partition.child.%s", table.Name, c.Name)
+		}
+		table.AddAttrs(&PartitionChild{Name: c.Name, Bound: b})
+	}
 	return nil
 }
 
+// fromPartitionChild returns the resource spec for representing a child partition block.
+func fromPartitionChild(p *PartitionChild) *schemahcl.Resource {
+	child := &schemahcl.Resource{Type: "child", Name: p.Name}
+	switch b := p.Bound; {
+	case b.Default:
+		child.Attrs = append(child.Attrs, schemahcl.BoolAttr("default", true))
+	case len(b.From) > 0 || len(b.To) > 0:
+		child.Attrs = append(child.Attrs, schemahcl.StringsAttr("from", b.From...), schemahcl.StringsAttr("to", b.To...))
+	case len(b.In) > 0:
+		child.Attrs = append(child.Attrs, schemahcl.StringsAttr("in", b.In...))
+	default:
+		child.Attrs = append(child.Attrs, schemahcl.Int64Attr("modulus", b.Modulus), schemahcl.Int64Attr("remainder", b.Remainder))
+	}
+	return child
+}
+
 // fromPartition returns the resource spec for representing the partition block.
 func fromPartition(p Partition) *schemahcl.Resource {
 	key := &schemahcl.Resource{
@@ -273,6 +509,86 @@ func fromPartition(p Partition) *schemahcl.Resource {
 	return key
 }
 
+// convertRowSecurity converts and appends the row_security
+// and the policy blocks into the table attributes if exist.
+func convertRowSecurity(spec schemahcl.Resource, table *schema.Table) error {
+	if r, ok := spec.Resource("row_security"); ok {
+		var rs struct {
+			Enabled  bool `spec:"enabled"`
+			Enforced bool `spec:"enforced"`
+		}
+		if err := r.As(&rs); err != nil {
+			return fmt.Errorf("parsing %s.row_security: %w", table.Name, err)
+		}
+		if rs.Enabled || rs.Enforced {
+			table.AddAttrs(&RowSecurity{Enabled: rs.Enabled, Enforced: rs.Enforced})
+		}
+	}
+	for _, r := range spec.Children {
+		if r.Type != "policy" {
+			continue
+		}
+		var p struct {
+			As    string   `spec:"as"`
+			For   string   `spec:"for"`
+			To    []string `spec:"to"`
+			Using string   `spec:"using"`
+			Check string   `spec:"check"`
+		}
+		if err := r.As(&p); err != nil {
+			return fmt.Errorf("parsing %s.policy.%s: %w", table.Name, r.Name, err)
+		}
+		if _, ok := tablePolicy(table, r.Name); ok {
+			return fmt.Errorf("duplicate policy %q in table %q", r.Name, table.Name)
+		}
+		table.AddAttrs(&Policy{
+			Name:  r.Name,
+			As:    strings.ToUpper(p.As),
+			For:   strings.ToUpper(p.For),
+			To:    p.To,
+			Using: p.Using,
+			Check: p.Check,
+		})
+	}
+	return nil
+}
+
+// fromRowSecurity returns the resource specs for representing
+// the row_security and the policy blocks of the table.
+func fromRowSecurity(table *schema.Table) []*schemahcl.Resource {
+	var specs []*schemahcl.Resource
+	if r, ok := rowSecurity(table); ok {
+		spec := &schemahcl.Resource{Type: "row_security"}
+		if r.Enabled {
+			spec.Attrs = append(spec.Attrs, schemahcl.BoolAttr("enabled", true))
+		}
+		if r.Enforced {
+			spec.Attrs = append(spec.Attrs, schemahcl.BoolAttr("enforced", true))
+		}
+		specs = append(specs, spec)
+	}
+	for _, p := range tablePolicies(table) {
+		spec := &schemahcl.Resource{Type: "policy", Name: p.Name}
+		if as := policyAs(p); as != PolicyAsPermissive {
+			spec.Attrs = append(spec.Attrs, specutil.VarAttr("as", as))
+		}
+		if cmd := policyFor(p); cmd != PolicyForAll {
+			spec.Attrs = append(spec.Attrs, specutil.VarAttr("for", cmd))
+		}
+		if len(p.To) > 0 {
+			spec.Attrs = append(spec.Attrs, schemahcl.StringsAttr("to", p.To...))
+		}
+		if p.Using != "" {
+			spec.Attrs = append(spec.Attrs, schemahcl.StringAttr("using", p.Using))
+		}
+		if p.Check != "" {
+			spec.Attrs = append(spec.Attrs, schemahcl.StringAttr("check", p.Check))
+		}
+		specs = append(specs, spec)
+	}
+	return specs
+}
+
 // convertColumn converts a sqlspec.Column into a schema.Column.
 func convertColumn(spec *sqlspec.Column, _ *schema.Table) (*schema.Column, error) {
 	if err := fixDefaultQuotes(spec); err != nil {
@@ -370,12 +686,75 @@ func convertIndex(spec *sqlspec.Index, t *schema.Table) (*schema.Index, error) {
 		}
 		idx.Attrs = append(idx.Attrs, &IndexNullsDistinct{V: v})
 	}
+	if err := convertDeferrable(spec, &idx.Attrs); err != nil {
+		return nil, err
+	}
 	if err := convertIndexPK(spec, t, idx); err != nil {
 		return nil, err
 	}
 	return idx, nil
 }
 
+// convertFK converts the PostgreSQL specific attributes of a foreign key.
+func convertFK(spec *sqlspec.ForeignKey, fk *schema.ForeignKey) error {
+	if err := convertDeferrable(spec, &fk.Attrs); err != nil {
+		return err
+	}
+	return convertNotValid(spec, &fk.Attrs)
+}
+
+// convertCheck converts a sqlspec.Check into a schema.Check.
+func convertCheck(spec *sqlspec.Check) (*schema.Check, error) {
+	c, err := specutil.Check(spec)
+	if err != nil {
+		return nil, err
+	}
+	if err := convertNotValid(spec, &c.Attrs); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// convertNotValid converts the NOT VALID attribute of a constraint spec.
+func convertNotValid(spec specutil.Attrer, attrs *[]schema.Attr) error {
+	if attr, ok := spec.Attr("not_valid"); ok {
+		v, err := attr.Bool()
+		if err != nil {
+			return err
+		}
+		if v {
+			*attrs = append(*attrs, &NotValid{})
+		}
+	}
+	return nil
+}
+
+// convertDeferrable converts the DEFERRABLE attributes of a constraint spec.
+func convertDeferrable(spec specutil.Attrer, attrs *[]schema.Attr) error {
+	var d, deferred bool
+	if attr, ok := spec.Attr("deferrable"); ok {
+		v, err := attr.Bool()
+		if err != nil {
+			return err
+		}
+		d = v
+	}
+	if attr, ok := spec.Attr("initially_deferred"); ok {
+		v, err := attr.Bool()
+		if err != nil {
+			return err
+		}
+		deferred = v
+	}
+	switch {
+	case deferred && !d:
+		return errors.New("initially_deferred requires the constraint to be deferrable")
+	case d:
+		*attrs = append(*attrs, &Deferrable{Deferred: deferred})
+	}
+	return nil
+}
+
 // convertIndexPK converts the index parameters shared between primary and secondary indexes.
 func convertIndexPK(spec specutil.Attrer, t *schema.Table, idx *schema.Index) error {
 	if attr, ok := spec.Attr("page_per_range"); ok {
@@ -405,6 +784,13 @@ func convertIndexPK(spec specutil.Attrer, t *schema.Table, idx *schema.Index) er
 }
 
 func convertPart(spec *sqlspec.IndexPart, part *schema.IndexPart) error {
+	if attr, ok := spec.Attr("with"); ok {
+		op, err := attr.String()
+		if err != nil {
+			return err
+		}
+		part.Attrs = append(part.Attrs, &IndexExcludeOp{V: op})
+	}
 	switch opc, ok := spec.Attr("ops"); {
 	case !ok:
 	case opc.IsRawExpr():
@@ -477,7 +863,8 @@ func convertEnums(tables []*sqlspec.Table, enums []*Enum, r *schema.Realm) error
 		for _, c := range t.Columns {
 			var enum *schema.EnumType
 			switch {
-			case c.Type.IsRef:
+			// Other references (e.g. domains) are converted by convertTypes.
+			case c.Type.IsRef && strings.HasPrefix(c.Type.T, enumRef("").V):
 				n, err := enumName(c.Type)
 				if err != nil {
 					return err
@@ -544,23 +931,559 @@ func schemaSpec(s *schema.Schema) (*doc, error) {
 		return nil, err
 	}
 	d := &doc{
-		Tables:  spec.Tables,
-		Views:   spec.Views,
-		Schemas: []*sqlspec.Schema{spec.Schema},
-		Enums:   make([]*Enum, 0, len(s.Objects)),
+		Tables:       spec.Tables,
+		Views:        spec.Views,
+		Triggers:     spec.Triggers,
+		Materialized: spec.Materialized,
+		Schemas:      []*sqlspec.Schema{spec.Schema},
+		Enums:        make([]*Enum, 0, len(s.Objects)),
 	}
 	for _, o := range s.Objects {
-		if e, ok := o.(*schema.EnumType); ok {
+		switch o := o.(type) {
+		case *schema.EnumType:
 			d.Enums = append(d.Enums, &Enum{
-				Name:   e.T,
-				Values: e.Values,
+				Name:   o.T,
+				Values: o.Values,
 				Schema: specutil.SchemaRef(spec.Schema.Name),
 			})
+		case *schema.Func:
+			attrs := []*schemahcl.Attr{funcLangAttr(o.Lang)}
+			if v := (FuncVolatility{}); sqlx.Has(o.Attrs, &v) && v.V != "" && strings.ToUpper(v.V) != FuncVolatilityVolatile {
+				attrs = append(attrs, specutil.VarAttr("volatility", strings.ToUpper(v.V)))
+			}
+			f, err := specutil.FromFunc(o, funcTypeSpec, attrs...)
+			if err != nil {
+				return nil, err
+			}
+			f.Schema = specutil.SchemaRef(spec.Schema.Name)
+			d.Funcs = append(d.Funcs, f)
+		case *schema.Proc:
+			p, err := specutil.FromProc(o, funcTypeSpec, funcLangAttr(o.Lang))
+			if err != nil {
+				return nil, err
+			}
+			p.Schema = specutil.SchemaRef(spec.Schema.Name)
+			d.Procs = append(d.Procs, p)
+		case *Sequence:
+			seq, err := sequenceSpec(o)
+			if err != nil {
+				return nil, err
+			}
+			seq.Schema = specutil.SchemaRef(spec.Schema.Name)
+			d.Sequences = append(d.Sequences, seq)
+		case *Domain:
+			ds, err := domainSpec(o)
+			if err != nil {
+				return nil, err
+			}
+			ds.Schema = specutil.SchemaRef(spec.Schema.Name)
+			d.Domains = append(d.Domains, ds)
+		case *Composite:
+			cs, err := compositeSpec(o)
+			if err != nil {
+				return nil, err
+			}
+			cs.Schema = specutil.SchemaRef(spec.Schema.Name)
+			d.Composites = append(d.Composites, cs)
+		case *Extension:
+			e := &extension{Name: o.Name, Schema: specutil.SchemaRef(spec.Schema.Name)}
+			if o.Version != "" {
+				e.Extra.Attrs = append(e.Extra.Attrs, schemahcl.StringAttr("version", o.Version))
+			}
+			if c := (schema.Comment{}); sqlx.Has(o.Attrs, &c) {
+				e.Extra.Attrs = append(e.Extra.Attrs, schemahcl.StringAttr("comment", c.Text))
+			}
+			d.Extensions = append(d.Extensions, e)
 		}
 	}
 	return d, nil
 }
 
+// sequenceSpec converts from a standalone sequence to its spec. Options are
+// written only if they are different from the defaults of the sequence.
+func sequenceSpec(seq *Sequence) (*sequence, error) {
+	spec := &sequence{Name: seq.Name}
+	o := seqOptions(seq)
+	d := seqDefaults(o)
+	if o.typ != TypeBigInt {
+		t, err := funcTypeSpec(seq.Type)
+		if err != nil {
+			return nil, err
+		}
+		spec.Type = t
+	}
+	for _, a := range []struct {
+		k    string
+		v, d int64
+	}{
+		{k: "start", v: o.start, d: d.start},
+		{k: "increment", v: o.inc, d: defaultSeqIncrement},
+		{k: "min_value", v: o.min, d: d.min},
+		{k: "max_value", v: o.max, d: d.max},
+		{k: "cache", v: o.cache, d: defaultSeqCache},
+	} {
+		if a.v != a.d {
+			spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.Int64Attr(a.k, a.v))
+		}
+	}
+	if o.cycle {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.BoolAttr("cycle", true))
+	}
+	if seqOwner(seq) != "" {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.RefAttr("owned_by", schemahcl.BuildRef([]schemahcl.PathIndex{
+			{T: "table", V: []string{seq.Owner.Table.Name}},
+			{T: "column", V: []string{seq.Owner.Column.Name}},
+		})))
+	}
+	if c := (schema.Comment{}); sqlx.Has(seq.Attrs, &c) {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.StringAttr("comment", c.Text))
+	}
+	return spec, nil
+}
+
+// qualifySeqOwners qualifies the owner references of the sequences, in case
+// their tables were qualified. Note, owner tables reside in the sequence schema.
+func qualifySeqOwners(seqs []*sequence, tables []*sqlspec.Table) {
+	for _, seq := range seqs {
+		a, ok := seq.Attr("owned_by")
+		if !ok {
+			continue
+		}
+		ref, ok := a.V.EncapsulatedValue().(*schemahcl.Ref)
+		if !ok {
+			continue
+		}
+		ts, err1 := ref.ByType("table")
+		cs, err2 := ref.ByType("column")
+		ns, err3 := specutil.SchemaName(seq.Schema)
+		if err1 != nil || err2 != nil || err3 != nil || len(ts) != 1 || len(cs) != 1 {
+			continue
+		}
+		for _, t := range tables {
+			if s, err := specutil.SchemaName(t.Schema); err == nil && s == ns && t.Name == ts[0] && t.Qualifier != "" {
+				seq.Extra.SetAttr(schemahcl.RefAttr("owned_by", schemahcl.BuildRef([]schemahcl.PathIndex{
+					{T: "table", V: []string{t.Qualifier, t.Name}},
+					{T: "column", V: cs},
+				})))
+			}
+		}
+	}
+}
+
+// convertExtensions converts the extensions specs and adds them to their schemas.
+func convertExtensions(exts []*extension, r *schema.Realm) error {
+	for _, spec := range exts {
+		s, err := funcSchema(spec.Schema, r, "extension", spec.Name)
+		if err != nil {
+			return err
+		}
+		e := &Extension{Name: spec.Name, Schema: s}
+		if a, ok := spec.Attr("version"); ok {
+			if e.Version, err = a.String(); err != nil {
+				return fmt.Errorf("expect string value for attribute extension.%s.version: %w", spec.Name, err)
+			}
+		}
+		if a, ok := spec.Attr("comment"); ok {
+			c, err := a.String()
+			if err != nil {
+				return fmt.Errorf("expect string value for attribute extension.%s.comment: %w", spec.Name, err)
+			}
+			e.Attrs = append(e.Attrs, &schema.Comment{Text: c})
+		}
+		s.Objects = append(s.Objects, e)
+	}
+	return nil
+}
+
+// convertTypes converts the domains and the composite types specs and adds them to their schemas.
+// Types are converted after the enums, as domains and fields may use them. Then, table columns
+// that reference these types are updated.
+func convertTypes(tables []*sqlspec.Table, domains []*domain, composites []*composite, r *schema.Realm) error {
+	// Objects are added first, and their types are resolved
+	// after, as they can reference each other (in any order).
+	ds := make([]*Domain, len(domains))
+	for i, spec := range domains {
+		s, err := funcSchema(spec.Schema, r, "domain", spec.Name)
+		if err != nil {
+			return err
+		}
+		ds[i] = &Domain{T: spec.Name, Schema: s, Null: true}
+		s.Objects = append(s.Objects, ds[i])
+	}
+	cs := make([]*Composite, len(composites))
+	for i, spec := range composites {
+		s, err := funcSchema(spec.Schema, r, "composite", spec.Name)
+		if err != nil {
+			return err
+		}
+		cs[i] = &Composite{T: spec.Name, Schema: s}
+		s.Objects = append(s.Objects, cs[i])
+	}
+	for i, spec := range domains {
+		if err := convertDomain(spec, ds[i], r); err != nil {
+			return err
+		}
+	}
+	for i, spec := range composites {
+		if err := convertComposite(spec, cs[i], r); err != nil {
+			return err
+		}
+	}
+	for _, t := range tables {
+		for _, c := range t.Columns {
+			if !c.Type.IsRef || strings.HasPrefix(c.Type.T, enumRef("").V) {
+				continue
+			}
+			typ, err := refType(c.Type, r)
+			if err != nil {
+				return err
+			}
+			ns, err := specutil.SchemaName(t.Schema)
+			if err != nil {
+				return fmt.Errorf("extract schema name from table reference: %w", err)
+			}
+			s, ok := r.Schema(ns)
+			if !ok {
+				return fmt.Errorf("schema %q not found in realm for table %q", ns, t.Name)
+			}
+			tt, ok := s.Table(t.Name)
+			if !ok {
+				return fmt.Errorf("table %q not found in schema %q", t.Name, s.Name)
+			}
+			cc, ok := tt.Column(c.Name)
+			if !ok {
+				return fmt.Errorf("column %q not found in table %q", c.Name, t.Name)
+			}
+			cc.Type.Type = typ
+		}
+	}
+	// Columns that use these types by their names (e.g. sql("name")).
+	if len(domains) > 0 || len(composites) > 0 {
+		resolveTypes(r)
+	}
+	return nil
+}
+
+// convertDomain converts the domain spec into the given domain.
+func convertDomain(spec *domain, d *Domain, r *schema.Realm) (err error) {
+	if spec.Type == nil {
+		return fmt.Errorf("missing type for domain %q", spec.Name)
+	}
+	if d.Base, err = convertFuncType(spec.Type, r); err != nil {
+		return fmt.Errorf("convert type of domain %q: %w", spec.Name, err)
+	}
+	if !spec.Default.IsNull() {
+		if d.Default, err = specutil.DefaultExpr(spec.Default); err != nil {
+			return fmt.Errorf("convert default value of domain %q: %w", spec.Name, err)
+		}
+		// String defaults are quoted by the planner.
+		if l, ok := d.Default.(*schema.Literal); ok && sqlx.IsQuoted(l.V, '"') {
+			uq, err := strconv.Unquote(l.V)
+			if err != nil {
+				return err
+			}
+			l.V = "'" + uq + "'"
+		}
+	}
+	if a, ok := spec.Attr("null"); ok {
+		if d.Null, err = a.Bool(); err != nil {
+			return fmt.Errorf("expect bool value for attribute domain.%s.null: %w", spec.Name, err)
+		}
+	}
+	for _, c := range spec.Checks {
+		c, err := specutil.Check(c)
+		if err != nil {
+			return err
+		}
+		d.Checks = append(d.Checks, c)
+	}
+	return convertComment(spec, "domain", spec.Name, &d.Attrs)
+}
+
+// convertComposite converts the composite type spec into the given type.
+func convertComposite(spec *composite, c *Composite, r *schema.Realm) error {
+	for _, f := range spec.Fields {
+		if f.Type == nil {
+			return fmt.Errorf("missing type for field %q of composite type %q", f.Name, spec.Name)
+		}
+		t, err := convertFuncType(f.Type, r)
+		if err != nil {
+			return fmt.Errorf("convert type of field %q of composite type %q: %w", f.Name, spec.Name, err)
+		}
+		c.Fields = append(c.Fields, &schema.Column{Name: f.Name, Type: &schema.ColumnType{Type: t}})
+	}
+	return convertComment(spec, "composite", spec.Name, &c.Attrs)
+}
+
+// convertComment converts the comment attribute of the given object spec, if exists.
+func convertComment(spec specutil.Attrer, kind, name string, attrs *[]schema.Attr) error {
+	if a, ok := spec.Attr("comment"); ok {
+		c, err := a.String()
+		if err != nil {
+			return fmt.Errorf("expect string value for attribute %s.%s.comment: %w", kind, name, err)
+		}
+		*attrs = append(*attrs, &schema.Comment{Text: c})
+	}
+	return nil
+}
+
+// refType returns the enum, domain or composite type that is referenced by the given type.
+func refType(t *schemahcl.Type, r *schema.Realm) (schema.Type, error) {
+	kind, name, ok := strings.Cut(strings.TrimPrefix(t.T, "$"), ".")
+	if !ok {
+		return nil, fmt.Errorf("postgres: unexpected type reference %q", t.T)
+	}
+	for _, s := range r.Schemas {
+		if o, ok := s.Object(func(o schema.Object) bool {
+			switch o := o.(type) {
+			case *schema.EnumType:
+				return kind == "enum" && o.T == name
+			case *Domain:
+				return kind == "domain" && o.T == name
+			case *Composite:
+				return kind == "composite" && o.T == name
+			}
+			return false
+		}); ok {
+			return o.(schema.Type), nil
+		}
+	}
+	return nil, fmt.Errorf("%s %q was not found in realm", kind, name)
+}
+
+// domainSpec converts from a domain to its spec.
+func domainSpec(d *Domain) (*domain, error) {
+	t, err := funcTypeSpec(d.Base)
+	if err != nil {
+		return nil, err
+	}
+	spec := &domain{Name: d.T, Type: t}
+	if d.Default != nil {
+		if spec.Default, err = specutil.ExprValue(d.Default); err != nil {
+			return nil, err
+		}
+	}
+	if !d.Null {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.BoolAttr("null", false))
+	}
+	for _, c := range d.Checks {
+		spec.Checks = append(spec.Checks, specutil.FromCheck(c))
+	}
+	if c := (schema.Comment{}); sqlx.Has(d.Attrs, &c) {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.StringAttr("comment", c.Text))
+	}
+	return spec, nil
+}
+
+// compositeSpec converts from a composite type to its spec.
+func compositeSpec(c *Composite) (*composite, error) {
+	spec := &composite{Name: c.T}
+	for _, f := range c.Fields {
+		t, err := funcTypeSpec(f.Type.Type)
+		if err != nil {
+			return nil, err
+		}
+		spec.Fields = append(spec.Fields, &compositeField{Name: f.Name, Type: t})
+	}
+	if cm := (schema.Comment{}); sqlx.Has(c.Attrs, &cm) {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.StringAttr("comment", cm.Text))
+	}
+	return spec, nil
+}
+
+// convertSequences converts the sequences specs and adds them to their schemas.
+// Sequences are converted after the tables, as they may be owned by their columns.
+func convertSequences(seqs []*sequence, r *schema.Realm) error {
+	for _, spec := range seqs {
+		s, err := funcSchema(spec.Schema, r, "sequence", spec.Name)
+		if err != nil {
+			return err
+		}
+		seq := &Sequence{Name: spec.Name, Schema: s}
+		if spec.Type != nil {
+			if seq.Type, err = convertColumnType(&sqlspec.Column{Type: spec.Type}); err != nil {
+				return err
+			}
+		}
+		for k, v := range map[string]*int64{"start": &seq.Start, "increment": &seq.Increment, "cache": &seq.Cache} {
+			if a, ok := spec.Attr(k); ok {
+				if *v, err = a.Int64(); err != nil {
+					return fmt.Errorf("expect int value for attribute sequence.%s.%s: %w", spec.Name, k, err)
+				}
+			}
+		}
+		for k, v := range map[string]**int64{"min_value": &seq.Min, "max_value": &seq.Max} {
+			if a, ok := spec.Attr(k); ok {
+				i, err := a.Int64()
+				if err != nil {
+					return fmt.Errorf("expect int value for attribute sequence.%s.%s: %w", spec.Name, k, err)
+				}
+				*v = &i
+			}
+		}
+		if a, ok := spec.Attr("cycle"); ok {
+			if seq.Cycle, err = a.Bool(); err != nil {
+				return fmt.Errorf("expect bool value for attribute sequence.%s.cycle: %w", spec.Name, err)
+			}
+		}
+		if a, ok := spec.Attr("owned_by"); ok {
+			ref, ok := a.V.EncapsulatedValue().(*schemahcl.Ref)
+			if !ok {
+				return fmt.Errorf("expect reference for attribute sequence.%s.owned_by", spec.Name)
+			}
+			if seq.Owner.Table, seq.Owner.Column, err = specutil.ExternalColumn(ref, s); err != nil {
+				return fmt.Errorf("find owner of sequence %q: %w", spec.Name, err)
+			}
+		}
+		if a, ok := spec.Attr("comment"); ok {
+			c, err := a.String()
+			if err != nil {
+				return fmt.Errorf("expect string value for attribute sequence.%s.comment: %w", spec.Name, err)
+			}
+			seq.Attrs = append(seq.Attrs, &schema.Comment{Text: c})
+		}
+		s.Objects = append(s.Objects, seq)
+	}
+	return nil
+}
+
+// convertRoles converts the roles and users specs and adds them to the realm. Roles
+// are converted last, as their grants may reference any other object (e.g. sequences).
+func convertRoles(roles, users []*sqlspec.Role, r *schema.Realm) error {
+	return specutil.Roles(r, roles, users, func(ref *schemahcl.Ref) (schema.Object, error) {
+		vs, err := ref.ByType("sequence")
+		if err != nil {
+			return nil, err
+		}
+		if len(vs) != 1 {
+			return nil, fmt.Errorf("expected 1 sequence ref, got %d", len(vs))
+		}
+		var matches []*Sequence
+		for _, s := range r.Schemas {
+			for _, o := range s.Objects {
+				if seq, ok := o.(*Sequence); ok && seq.Name == vs[0] {
+					matches = append(matches, seq)
+				}
+			}
+		}
+		switch len(matches) {
+		case 1:
+			return matches[0], nil
+		case 0:
+			return nil, fmt.Errorf("referenced sequence %q was not found", vs[0])
+		default:
+			return nil, fmt.Errorf("multiple sequences found for %q", vs[0])
+		}
+	})
+}
+
+// sequenceRef returns the reference of a sequence that privileges were granted on.
+func sequenceRef(o schema.Object) (*schemahcl.Ref, error) {
+	seq, ok := o.(*Sequence)
+	if !ok {
+		return nil, fmt.Errorf("unsupported object %T for grant", o)
+	}
+	return schemahcl.BuildRef([]schemahcl.PathIndex{{T: "sequence", V: []string{seq.Name}}}), nil
+}
+
+// List of function languages that are defined as HCL variables.
+const (
+	funcLangSQL     = "SQL"
+	funcLangPLpgSQL = "PLpgSQL"
+)
+
+// funcLangAttr returns the language attribute of a function or a procedure.
+func funcLangAttr(lang string) *schemahcl.Attr {
+	for _, l := range []string{funcLangSQL, funcLangPLpgSQL} {
+		if strings.EqualFold(lang, l) {
+			return specutil.VarAttr("lang", l)
+		}
+	}
+	return schemahcl.StringAttr("lang", lang)
+}
+
+// funcTypeSpec converts the argument or return type of function into its spec.
+func funcTypeSpec(t schema.Type) (*schemahcl.Type, error) {
+	c, err := columnTypeSpec(t)
+	if err != nil {
+		return nil, err
+	}
+	return c.Type, nil
+}
+
+// convertFuncs converts the functions and procedures specs and adds them to their schemas.
+// Functions are converted after the enums, as their arguments or return type may use them.
+func convertFuncs(funcs []*sqlspec.Func, procs []*sqlspec.Proc, r *schema.Realm) error {
+	for _, spec := range funcs {
+		s, err := funcSchema(spec.Schema, r, "function", spec.Name)
+		if err != nil {
+			return err
+		}
+		f, err := specutil.Func(spec, func(t *schemahcl.Type) (schema.Type, error) {
+			return convertFuncType(t, r)
+		})
+		if err != nil {
+			return err
+		}
+		if f.Lang == "" {
+			return fmt.Errorf("missing 'lang' definition for function %q", spec.Name)
+		}
+		if a, ok := spec.Attr("volatility"); ok {
+			v, err := a.String()
+			if err != nil {
+				return fmt.Errorf("expect string value for attribute function.%s.volatility: %w", spec.Name, err)
+			}
+			if v = strings.ToUpper(v); v != FuncVolatilityVolatile {
+				f.AddAttrs(&FuncVolatility{V: v})
+			}
+		}
+		s.AddFuncs(f)
+	}
+	for _, spec := range procs {
+		s, err := funcSchema(spec.Schema, r, "procedure", spec.Name)
+		if err != nil {
+			return err
+		}
+		p, err := specutil.Proc(spec, func(t *schemahcl.Type) (schema.Type, error) {
+			return convertFuncType(t, r)
+		})
+		if err != nil {
+			return err
+		}
+		if p.Lang == "" {
+			return fmt.Errorf("missing 'lang' definition for procedure %q", spec.Name)
+		}
+		s.AddProcs(p)
+	}
+	return nil
+}
+
+// funcSchema returns the schema of a function or a procedure.
+func funcSchema(ref *schemahcl.Ref, r *schema.Realm, kind, name string) (*schema.Schema, error) {
+	ns, err := specutil.SchemaName(ref)
+	if err != nil {
+		return nil, fmt.Errorf("extract schema name from %s %q reference: %w", kind, name, err)
+	}
+	s, ok := r.Schema(ns)
+	if !ok {
+		return nil, fmt.Errorf("schema %q defined on %s %q was not found in realm", ns, kind, name)
+	}
+	return s, nil
+}
+
+// convertFuncType converts the argument or return type of a function. Type
+// references (e.g. enums) are resolved from the realm, and set-returning types
+// are kept as user-defined types.
+func convertFuncType(t *schemahcl.Type, r *schema.Realm) (schema.Type, error) {
+	if t.IsRef {
+		return refType(t, r)
+	}
+	if u := strings.ToUpper(t.T); strings.HasPrefix(u, "SETOF ") || strings.HasPrefix(u, "TABLE(") {
+		return &UserDefinedType{T: t.T}, nil
+	}
+	return convertColumnType(&sqlspec.Column{Type: t})
+}
+
 // tableSpec converts from a concrete Postgres sqlspec.Table to a schema.Table.
 func tableSpec(table *schema.Table) (*sqlspec.Table, error) {
 	spec, err := specutil.FromTable(
@@ -568,15 +1491,20 @@ func tableSpec(table *schema.Table) (*sqlspec.Table, error) {
 		tableColumnSpec,
 		pkSpec,
 		indexSpec,
-		specutil.FromForeignKey,
-		specutil.FromCheck,
+		fkSpec,
+		checkSpec,
 	)
 	if err != nil {
 		return nil, err
 	}
 	if p := (Partition{}); sqlx.Has(table.Attrs, &p) {
-		spec.Extra.Children = append(spec.Extra.Children, fromPartition(p))
+		key := fromPartition(p)
+		for _, c := range tablePartitions(table) {
+			key.Children = append(key.Children, fromPartitionChild(c))
+		}
+		spec.Extra.Children = append(spec.Extra.Children, key)
 	}
+	spec.Extra.Children = append(spec.Extra.Children, fromRowSecurity(table)...)
 	return spec, nil
 }
 
@@ -588,9 +1516,52 @@ func viewSpec(view *schema.View) (*sqlspec.View, error) {
 	if err != nil {
 		return nil, err
 	}
+	if view.Materialized() {
+		if err := materializedSpec(view, spec); err != nil {
+			return nil, err
+		}
+	}
 	return spec, nil
 }
 
+// materializedSpec appends the attributes and the indexes of the materialized view to its spec.
+func materializedSpec(view *schema.View, spec *sqlspec.View) error {
+	// The definition and its attributes are stored in the last child.
+	embed := spec.Extra.Children[len(spec.Extra.Children)-1]
+	if sqlx.Has(view.Attrs, &WithNoData{}) {
+		embed.Attrs = append(embed.Attrs, schemahcl.BoolAttr("with_data", false))
+	}
+	if t := (Tablespace{}); sqlx.Has(view.Attrs, &t) && t.N != "" {
+		embed.Attrs = append(embed.Attrs, schemahcl.StringAttr("tablespace", t.N))
+	}
+	if ps := storageParams(view); len(ps) > 0 {
+		r := &schemahcl.Resource{Type: "storage_params"}
+		for _, p := range ps {
+			if i, err := strconv.ParseInt(p.V, 10, 64); err == nil {
+				r.Attrs = append(r.Attrs, schemahcl.Int64Attr(p.N, i))
+			} else if b, err := strconv.ParseBool(p.V); err == nil {
+				r.Attrs = append(r.Attrs, schemahcl.BoolAttr(p.N, b))
+			} else {
+				r.Attrs = append(r.Attrs, schemahcl.StringAttr(p.N, p.V))
+			}
+		}
+		embed.Children = append(embed.Children, r)
+	}
+	for _, idx := range view.Indexes {
+		is, err := indexSpec(idx)
+		if err != nil {
+			return err
+		}
+		r := &schemahcl.Resource{}
+		if err := r.Scan(is); err != nil {
+			return err
+		}
+		r.Type = "index"
+		embed.Children = append(embed.Children, r)
+	}
+	return nil
+}
+
 func pkSpec(idx *schema.Index) (*sqlspec.PrimaryKey, error) {
 	spec, err := specutil.FromPrimaryKey(idx)
 	if err != nil {
@@ -616,9 +1587,41 @@ func indexSpec(idx *schema.Index) (*sqlspec.Index, error) {
 		spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.BoolAttr("nulls_distinct", i.V))
 	}
 	spec.Extra.Attrs = indexPKSpec(idx, spec.Extra.Attrs)
+	spec.Extra.Attrs = deferrableSpec(idx.Attrs, spec.Extra.Attrs)
+	return spec, nil
+}
+
+func fkSpec(fk *schema.ForeignKey) (*sqlspec.ForeignKey, error) {
+	spec, err := specutil.FromForeignKey(fk)
+	if err != nil {
+		return nil, err
+	}
+	spec.Extra.Attrs = deferrableSpec(fk.Attrs, spec.Extra.Attrs)
+	if sqlx.Has(fk.Attrs, &NotValid{}) {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.BoolAttr("not_valid", true))
+	}
 	return spec, nil
 }
 
+func checkSpec(c *schema.Check) *sqlspec.Check {
+	spec := specutil.FromCheck(c)
+	if sqlx.Has(c.Attrs, &NotValid{}) {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.BoolAttr("not_valid", true))
+	}
+	return spec
+}
+
+// deferrableSpec appends the DEFERRABLE attributes of a constraint to its spec.
+func deferrableSpec(attrs []schema.Attr, spec []*schemahcl.Attr) []*schemahcl.Attr {
+	if d := (Deferrable{}); sqlx.Has(attrs, &d) {
+		spec = append(spec, schemahcl.BoolAttr("deferrable", true))
+		if d.Deferred {
+			spec = append(spec, schemahcl.BoolAttr("initially_deferred", true))
+		}
+	}
+	return spec
+}
+
 func indexPKSpec(idx *schema.Index, attrs []*schemahcl.Attr) []*schemahcl.Attr {
 	if i := (IndexInclude{}); sqlx.Has(idx.Attrs, &i) && len(i.Columns) > 0 {
 		refs := make([]*schemahcl.Ref, 0, len(i.Columns))
@@ -634,6 +1637,9 @@ func indexPKSpec(idx *schema.Index, attrs []*schemahcl.Attr) []*schemahcl.Attr {
 }
 
 func partAttr(idx *schema.Index, part *schema.IndexPart, spec *sqlspec.IndexPart) error {
+	if x := (IndexExcludeOp{}); sqlx.Has(part.Attrs, &x) {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.StringAttr("with", x.V))
+	}
 	var op IndexOpClass
 	if !sqlx.Has(part.Attrs, &op) {
 		return nil
@@ -687,9 +1693,21 @@ func fromIdentity(i *Identity) *schemahcl.Resource {
 // columnTypeSpec converts from a concrete Postgres schema.Type into sqlspec.Column Type.
 func columnTypeSpec(t schema.Type) (*sqlspec.Column, error) {
 	// Handle postgres enum types. They cannot be put into the TypeRegistry since their name is dynamic.
-	if e, ok := t.(*schema.EnumType); ok {
+	switch t := t.(type) {
+	case *schema.EnumType:
+		return &sqlspec.Column{Type: &schemahcl.Type{
+			T:     enumRef(t.T).V,
+			IsRef: true,
+		}}, nil
+	// Domains and composite types are referenced by their names as well.
+	case *Domain:
+		return &sqlspec.Column{Type: &schemahcl.Type{
+			T:     "$domain." + t.T,
+			IsRef: true,
+		}}, nil
+	case *Composite:
 		return &sqlspec.Column{Type: &schemahcl.Type{
-			T:     enumRef(e.T).V,
+			T:     "$composite." + t.T,
 			IsRef: true,
 		}}, nil
 	}
@@ -770,7 +1788,10 @@ var TypeRegistry = schemahcl.NewRegistry(
 		schemahcl.NewTypeSpec(TypeTSTZMultiRange),
 		schemahcl.NewTypeSpec(TypeDateRange),
 		schemahcl.NewTypeSpec(TypeDateMultiRange),
-		schemahcl.NewTypeSpec("hstore"),
+		schemahcl.NewTypeSpec(TypeHStore),
+		schemahcl.NewTypeSpec(TypeCIText),
+		schemahcl.NewTypeSpec(TypeGeometry),
+		schemahcl.NewTypeSpec(TypeGeography),
 		schemahcl.NewTypeSpec("sql", schemahcl.WithAttributes(&schemahcl.TypeAttr{Name: "def", Required: true, Kind: reflect.String})),
 	),
 	// PostgreSQL internal and special types.
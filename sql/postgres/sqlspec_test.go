@@ -477,6 +477,352 @@ schema "other" {}
 	require.EqualValues(t, r, got)
 }
 
+func TestMarshalSpec_Materialized(t *testing.T) {
+	var (
+		t1 = schema.NewTable("t1").AddColumns(schema.NewIntColumn("a", "int"))
+		v1 = schema.NewView("v1", "SELECT a FROM t1").AddDeps(t1)
+		m1 = schema.NewMaterializedView("m1", "SELECT a FROM v1").
+			AddColumns(schema.NewIntColumn("a", "int")).
+			AddAttrs(
+				&WithNoData{},
+				&Tablespace{N: "fast"},
+				&StorageParams{Params: []struct{ N, V string }{{N: "fillfactor", V: "70"}, {N: "autovacuum_enabled", V: "false"}}},
+			).
+			SetComment("daily totals").
+			AddDeps(v1)
+		m2 = schema.NewMaterializedView("m2", "SELECT a FROM m1").
+			AddColumns(schema.NewIntColumn("a", "int"))
+		s = schema.New("public").AddTables(t1).AddViews(v1, m1, m2)
+	)
+	m1.AddIndexes(schema.NewUniqueIndex("m1_a").AddColumns(m1.Columns[0]))
+	m2.AddDeps(m1)
+	buf, err := MarshalHCL(s)
+	require.NoError(t, err)
+	f := `table "t1" {
+  schema = schema.public
+  column "a" {
+    null = false
+    type = int
+  }
+}
+view "v1" {
+  schema     = schema.public
+  as         = "SELECT a FROM t1"
+  depends_on = [table.t1]
+}
+materialized "m1" {
+  schema = schema.public
+  column "a" {
+    null = false
+    type = int
+  }
+  as         = "SELECT a FROM v1"
+  depends_on = [view.v1]
+  comment    = "daily totals"
+  with_data  = false
+  tablespace = "fast"
+  storage_params {
+    fillfactor         = 70
+    autovacuum_enabled = false
+  }
+  index "m1_a" {
+    unique  = true
+    columns = [column.a]
+  }
+}
+materialized "m2" {
+  schema = schema.public
+  column "a" {
+    null = false
+    type = int
+  }
+  as         = "SELECT a FROM m1"
+  depends_on = [materialized.m1]
+}
+schema "public" {
+}
+`
+	require.Equal(t, f, string(buf))
+
+	var got schema.Schema
+	require.NoError(t, EvalHCLBytes(buf, &got, nil))
+	require.Len(t, got.Views, 3)
+	require.False(t, got.Views[0].Materialized())
+	m, ok := got.View("m1")
+	require.True(t, ok)
+	require.True(t, m.Materialized())
+	require.True(t, sqlx.Has(m.Attrs, &WithNoData{}))
+	require.Equal(t, []schema.Object{got.Views[0]}, m.Deps)
+	var (
+		ts Tablespace
+		sp StorageParams
+	)
+	require.True(t, sqlx.Has(m.Attrs, &ts))
+	require.Equal(t, "fast", ts.N)
+	require.True(t, sqlx.Has(m.Attrs, &sp))
+	require.ElementsMatch(t, []struct{ N, V string }{{N: "fillfactor", V: "70"}, {N: "autovacuum_enabled", V: "false"}}, sp.Params)
+	require.Len(t, m.Indexes, 1)
+	require.True(t, m.Indexes[0].Unique)
+	require.Equal(t, m, m.Indexes[0].View)
+	require.Nil(t, m.Indexes[0].Table)
+	require.Equal(t, m.Columns[0], m.Indexes[0].Parts[0].C)
+	m, ok = got.View("m2")
+	require.True(t, ok)
+	require.Equal(t, []schema.Object{got.Views[1]}, m.Deps)
+}
+
+func TestMarshalTriggers(t *testing.T) {
+	var (
+		c1 = schema.NewIntColumn("a", "int")
+		t1 = schema.NewTable("t1").AddColumns(c1)
+		t2 = schema.NewTable("t2").AddColumns(schema.NewIntColumn("a", "int"))
+		v1 = schema.NewView("v1", "SELECT a FROM t1")
+		s  = schema.New("public").AddTables(t1, t2).AddViews(v1)
+	)
+	t1.AddTriggers(
+		schema.NewTrigger("tr1").
+			SetActionTime(schema.TriggerTimeBefore).
+			AddEvents(schema.TriggerEventInsert, schema.TriggerEventUpdateOf(c1)).
+			SetFor(schema.TriggerForRow).
+			SetWhen("NEW.a > 0").
+			SetBody("EXECUTE FUNCTION f()"),
+	)
+	// Trigger names are unique per table.
+	t2.AddTriggers(
+		schema.NewTrigger("tr1").
+			SetActionTime(schema.TriggerTimeAfter).
+			AddEvents(schema.TriggerEventDelete).
+			SetFor(schema.TriggerForStmt).
+			SetBody("EXECUTE FUNCTION f()"),
+	)
+	v1.AddTriggers(
+		schema.NewTrigger("tr2").
+			SetActionTime(schema.TriggerTimeInstead).
+			AddEvents(schema.TriggerEventInsert).
+			SetFor(schema.TriggerForRow).
+			SetBody("EXECUTE FUNCTION f()"),
+	)
+	v1.Triggers[0].Attrs = []schema.Attr{&schema.Comment{Text: "comment"}}
+	buf, err := MarshalHCL(s)
+	require.NoError(t, err)
+	f := `table "t1" {
+  schema = schema.public
+  column "a" {
+    null = false
+    type = int
+  }
+}
+table "t2" {
+  schema = schema.public
+  column "a" {
+    null = false
+    type = int
+  }
+}
+view "v1" {
+  schema = schema.public
+  as     = "SELECT a FROM t1"
+}
+trigger "tr1" {
+  on = table.t1
+  before {
+    insert    = true
+    update_of = [table.t1.column.a]
+  }
+  for  = ROW
+  when = "NEW.a > 0"
+  as   = "EXECUTE FUNCTION f()"
+}
+trigger "tr1" {
+  on = table.t2
+  after {
+    delete = true
+  }
+  for = STATEMENT
+  as  = "EXECUTE FUNCTION f()"
+}
+trigger "tr2" {
+  on = view.v1
+  instead_of {
+    insert = true
+  }
+  for     = ROW
+  as      = "EXECUTE FUNCTION f()"
+  comment = "comment"
+}
+schema "public" {
+}
+`
+	require.Equal(t, f, string(buf))
+	var got schema.Realm
+	require.NoError(t, EvalHCLBytes(buf, &got, nil))
+	require.EqualValues(t, schema.NewRealm(s), &got)
+}
+
+func TestUnmarshalTriggers(t *testing.T) {
+	f := `table "t1" {
+  schema = schema.public
+  column "a" {
+    type = int
+  }
+}
+trigger "tr1" {
+  on = table.t1
+  after {
+    insert = true
+    update = true
+  }
+  as = "EXECUTE FUNCTION f()"
+}
+schema "public" {}
+`
+	var got schema.Realm
+	require.NoError(t, EvalHCLBytes([]byte(f), &got, nil))
+	tr, ok := got.Schemas[0].Tables[0].Trigger("tr1")
+	require.True(t, ok)
+	require.Equal(t, schema.TriggerTimeAfter, tr.ActionTime)
+	require.Equal(t, []schema.TriggerEvent{schema.TriggerEventInsert, schema.TriggerEventUpdate}, tr.Events)
+	// FOR EACH STATEMENT is the default.
+	require.Equal(t, schema.TriggerForStmt, tr.For)
+
+	f = `table "t1" {
+  schema = schema.public
+  column "a" {
+    type = int
+  }
+}
+trigger "tr1" {
+  on = table.t1
+  after {
+    update    = true
+    update_of = [table.t1.column.a]
+  }
+  as = "EXECUTE FUNCTION f()"
+}
+schema "public" {}
+`
+	err := EvalHCLBytes([]byte(f), &schema.Realm{}, nil)
+	require.ErrorContains(t, err, `update and update_of cannot be both defined for trigger "tr1"`)
+}
+
+func TestMarshalFuncs(t *testing.T) {
+	var (
+		e = &schema.EnumType{T: "status", Values: []string{"on", "off"}}
+		s = schema.New("public").AddObjects(e)
+	)
+	s.AddFuncs(
+		schema.NewFunc("add").
+			AddArgs(
+				schema.NewFuncArg("a", &schema.IntegerType{T: "integer"}),
+				schema.NewFuncArg("b", &schema.IntegerType{T: "integer"}).SetDefault(&schema.Literal{V: "1"}),
+			).
+			SetReturn(&schema.IntegerType{T: "integer"}).
+			SetLang("sql").
+			SetBody("SELECT a + b").
+			AddAttrs(&FuncVolatility{V: FuncVolatilityImmutable}).
+			SetComment("add two numbers"),
+		schema.NewFunc("is_on").
+			AddArgs(
+				schema.NewFuncArg("s", e),
+				schema.NewFuncArg("ok", &schema.BoolType{T: "boolean"}).SetMode(schema.FuncArgModeOut),
+			).
+			SetReturn(&schema.BoolType{T: "boolean"}).
+			SetLang("plpgsql").
+			SetBody("BEGIN\n  ok := s = 'on';\nEND;"),
+		schema.NewFunc("touch").
+			SetReturn(&UserDefinedType{T: "trigger"}).
+			SetLang("plpgsql").
+			SetBody("BEGIN\n  NEW.updated_at := now();\n  RETURN NEW;\nEND;"),
+	)
+	s.AddProcs(
+		schema.NewProc("reset").
+			AddArgs(schema.NewFuncArg("n", &schema.IntegerType{T: "integer"})).
+			SetLang("sql").
+			SetBody("DELETE FROM t WHERE id > n"),
+	)
+	buf, err := MarshalHCL(s)
+	require.NoError(t, err)
+	f := `enum "status" {
+  schema = schema.public
+  values = ["on", "off"]
+}
+function "add" {
+  schema = schema.public
+  return = integer
+  arg "a" {
+    type = integer
+  }
+  arg "b" {
+    type    = integer
+    default = 1
+  }
+  lang       = SQL
+  volatility = IMMUTABLE
+  as         = "SELECT a + b"
+  comment    = "add two numbers"
+}
+function "is_on" {
+  schema = schema.public
+  return = boolean
+  arg "s" {
+    type = enum.status
+  }
+  arg "ok" {
+    type = boolean
+    mode = OUT
+  }
+  lang = PLpgSQL
+  as   = <<-SQL
+  BEGIN
+    ok := s = 'on';
+  END;
+  SQL
+}
+function "touch" {
+  schema = schema.public
+  return = sql("trigger")
+  lang   = PLpgSQL
+  as     = <<-SQL
+  BEGIN
+    NEW.updated_at := now();
+    RETURN NEW;
+  END;
+  SQL
+}
+procedure "reset" {
+  schema = schema.public
+  arg "n" {
+    type = integer
+  }
+  lang = SQL
+  as   = "DELETE FROM t WHERE id > n"
+}
+schema "public" {
+}
+`
+	require.Equal(t, f, string(buf))
+
+	var got schema.Realm
+	require.NoError(t, EvalHCLBytes(buf, &got, nil))
+	require.Len(t, got.Schemas, 1)
+	require.Len(t, got.Schemas[0].Objects, 5)
+	add := got.Schemas[0].Objects[1].(*schema.Func)
+	require.Equal(t, "add", add.Name)
+	require.Equal(t, "SQL", add.Lang)
+	require.Equal(t, "SELECT a + b", add.Body)
+	require.Equal(t, &schema.Literal{V: "1"}, add.Args[1].Default)
+	require.Equal(t, []schema.Attr{&schema.Comment{Text: "add two numbers"}, &FuncVolatility{V: FuncVolatilityImmutable}}, add.Attrs)
+	isOn := got.Schemas[0].Objects[2].(*schema.Func)
+	require.Equal(t, got.Schemas[0].Objects[0], isOn.Args[0].Type, "enum type should be resolved")
+	require.Equal(t, schema.FuncArgModeOut, isOn.Args[1].Mode)
+	require.Equal(t, "BEGIN\n  ok := s = 'on';\nEND;", isOn.Body)
+	touch := got.Schemas[0].Objects[3].(*schema.Func)
+	require.Equal(t, &UserDefinedType{T: "trigger"}, touch.Ret)
+	reset := got.Schemas[0].Objects[4].(*schema.Proc)
+	require.Equal(t, "reset", reset.Name)
+	require.Equal(t, got.Schemas[0], reset.Schema)
+}
+
 func TestUnmarshalSpec_IndexType(t *testing.T) {
 	f := `
 schema "s" {}
@@ -685,6 +1031,73 @@ table "logs" {
 		require.Equal(t, expected, s)
 	})
 
+	t.Run("Children", func(t *testing.T) {
+		var (
+			s = &schema.Schema{}
+			f = `
+schema "test" {}
+table "logs" {
+	schema = schema.test
+	column "created" {
+		type = date
+	}
+	partition {
+		type    = RANGE
+		columns = [column.created]
+		child "logs_2024_01" {
+			from = ["'2024-01-01'"]
+			to   = ["'2024-02-01'"]
+		}
+		child "logs_default" {
+			default = true
+		}
+	}
+}
+table "users" {
+	schema = schema.test
+	column "id" {
+		type = int
+	}
+	partition {
+		type    = HASH
+		columns = [column.id]
+		child "users_0" {
+			modulus   = 2
+			remainder = 0
+		}
+	}
+}
+table "events" {
+	schema = schema.test
+	column "kind" {
+		type = text
+	}
+	partition {
+		type    = LIST
+		columns = [column.kind]
+		child "events_a" {
+			in = ["'a'", "'b'"]
+		}
+	}
+}
+`
+		)
+		err := EvalHCLBytes([]byte(f), s, nil)
+		require.NoError(t, err)
+		logs, ok := s.Table("logs")
+		require.True(t, ok)
+		require.Equal(t, []*PartitionChild{
+			{Name: "logs_2024_01", Bound: PartitionBound{From: []string{"'2024-01-01'"}, To: []string{"'2024-02-01'"}}},
+			{Name: "logs_default", Bound: PartitionBound{Default: true}},
+		}, tablePartitions(logs))
+		users, ok := s.Table("users")
+		require.True(t, ok)
+		require.Equal(t, []*PartitionChild{{Name: "users_0", Bound: PartitionBound{Modulus: 2}}}, tablePartitions(users))
+		events, ok := s.Table("events")
+		require.True(t, ok)
+		require.Equal(t, []*PartitionChild{{Name: "events_a", Bound: PartitionBound{In: []string{"'a'", "'b'"}}}}, tablePartitions(events))
+	})
+
 	t.Run("Invalid", func(t *testing.T) {
 		err := EvalHCLBytes([]byte(`
 			schema "test" {}
@@ -698,6 +1111,23 @@ table "logs" {
 		`), &schema.Schema{}, nil)
 		require.Error(t, err, "missing partition type")
 
+		err = EvalHCLBytes([]byte(`
+			schema "test" {}
+			table "logs" {
+				schema = schema.test
+				column "name" { type = text }
+				partition {
+					type    = LIST
+					columns = [column.name]
+					child "logs_a" {
+						default = true
+						in      = ["'a'"]
+					}
+				}
+			}
+		`), &schema.Schema{}, nil)
+		require.EqualError(t, err, `specutil: cannot convert table "logs": multiple bounds were defined for logs.partition.child.logs_a`)
+
 		err = EvalHCLBytes([]byte(`
 			schema "test" {}
 			table "logs" {
@@ -773,6 +1203,38 @@ schema "test" {
 }
 schema "test" {
 }
+`, string(buf))
+	})
+
+	t.Run("Children", func(t *testing.T) {
+		c := schema.NewStringColumn("name", "text")
+		s := schema.New("test").
+			AddTables(schema.NewTable("logs").AddColumns(c).AddAttrs(
+				&Partition{T: PartitionTypeList, Parts: []*PartitionPart{{C: c}}},
+				&PartitionChild{Name: "logs_a", Bound: PartitionBound{In: []string{"'a'", "'b'"}}},
+				&PartitionChild{Name: "logs_default", Bound: PartitionBound{Default: true}},
+			))
+		buf, err := MarshalHCL(s)
+		require.NoError(t, err)
+		require.Equal(t, `table "logs" {
+  schema = schema.test
+  column "name" {
+    null = false
+    type = text
+  }
+  partition {
+    type    = LIST
+    columns = [column.name]
+    child "logs_a" {
+      in = ["'a'", "'b'"]
+    }
+    child "logs_default" {
+      default = true
+    }
+  }
+}
+schema "test" {
+}
 `, string(buf))
 	})
 }
@@ -1092,6 +1554,32 @@ table "t" {
 	})
 }
 
+func TestUnmarshalSpec_RenamedFrom(t *testing.T) {
+	var (
+		s schema.Schema
+		f = `
+schema "s" {}
+table "users" {
+	schema = schema.s
+	renamed_from = "accounts"
+	column "full_name" {
+		type = text
+		renamed_from = "name"
+	}
+	index "users_full_name" {
+		columns = [column.full_name]
+		renamed_from = "users_name"
+	}
+}
+`
+	)
+	require.NoError(t, EvalHCLBytes([]byte(f), &s, nil))
+	users := s.Tables[0]
+	require.Equal(t, []schema.Attr{&schema.RenamedFrom{Name: "accounts"}}, users.Attrs)
+	require.Equal(t, []schema.Attr{&schema.RenamedFrom{Name: "name"}}, users.Columns[0].Attrs)
+	require.Equal(t, []schema.Attr{&schema.RenamedFrom{Name: "users_name"}}, users.Indexes[0].Attrs)
+}
+
 func TestUnmarshalSpec_IndexInclude(t *testing.T) {
 	f := `
 schema "s" {}
@@ -1184,6 +1672,155 @@ schema "test" {
 	require.EqualValues(t, expected, string(buf))
 }
 
+func TestMarshalSpec_Constraints(t *testing.T) {
+	var (
+		users    = schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "int"))
+		room     = schema.NewIntColumn("room", "int")
+		during   = schema.NewColumn("during").SetType(&RangeType{T: "tsrange"})
+		uid      = schema.NewIntColumn("user_id", "int")
+		bookings = schema.NewTable("bookings").AddColumns(room, during, uid)
+	)
+	bookings.AddForeignKeys(
+		schema.NewForeignKey("user_fk").AddColumns(uid).SetRefTable(users).AddRefColumns(users.Columns[0]).AddAttrs(&Deferrable{Deferred: true}),
+	)
+	bookings.AddIndexes(
+		schema.NewUniqueIndex("uniq").AddColumns(uid).AddAttrs(&Deferrable{}),
+		schema.NewIndex("no_overlap").AddAttrs(&IndexType{T: IndexTypeGiST}).AddParts(
+			schema.NewColumnPart(room).AddAttrs(&IndexExcludeOp{V: "="}),
+			schema.NewColumnPart(during).AddAttrs(&IndexExcludeOp{V: "&&"}),
+		),
+	)
+	schema.New("public").AddTables(users, bookings)
+	buf, err := MarshalSpec(bookings.Schema, hclState)
+	require.NoError(t, err)
+	const expected = `table "users" {
+  schema = schema.public
+  column "id" {
+    null = false
+    type = int
+  }
+}
+table "bookings" {
+  schema = schema.public
+  column "room" {
+    null = false
+    type = int
+  }
+  column "during" {
+    null = false
+    type = tsrange
+  }
+  column "user_id" {
+    null = false
+    type = int
+  }
+  foreign_key "user_fk" {
+    columns            = [column.user_id]
+    ref_columns        = [table.users.column.id]
+    deferrable         = true
+    initially_deferred = true
+  }
+  index "uniq" {
+    unique     = true
+    columns    = [column.user_id]
+    deferrable = true
+  }
+  index "no_overlap" {
+    type = GIST
+    on {
+      column = column.room
+      with   = "="
+    }
+    on {
+      column = column.during
+      with   = "&&"
+    }
+  }
+}
+schema "public" {
+}
+`
+	require.EqualValues(t, expected, string(buf))
+
+	var got schema.Schema
+	require.NoError(t, EvalHCLBytes(buf, &got, nil))
+	tb, ok := got.Table("bookings")
+	require.True(t, ok)
+	require.Len(t, tb.ForeignKeys, 1)
+	require.Equal(t, []schema.Attr{&Deferrable{Deferred: true}}, tb.ForeignKeys[0].Attrs)
+	idx, ok := tb.Index("uniq")
+	require.True(t, ok)
+	require.Equal(t, []schema.Attr{&Deferrable{}}, idx.Attrs)
+	idx, ok = tb.Index("no_overlap")
+	require.True(t, ok)
+	require.Len(t, idx.Parts, 2)
+	require.Equal(t, []schema.Attr{&IndexExcludeOp{V: "="}}, idx.Parts[0].Attrs)
+	require.Equal(t, []schema.Attr{&IndexExcludeOp{V: "&&"}}, idx.Parts[1].Attrs)
+
+	err = EvalHCLBytes([]byte(`
+schema "public" {}
+table "t" {
+  schema = schema.public
+  column "c" {
+    type = int
+  }
+  index "c" {
+    unique             = true
+    columns            = [column.c]
+    initially_deferred = true
+  }
+}
+`), &got, nil)
+	require.ErrorContains(t, err, "initially_deferred requires the constraint to be deferrable")
+}
+
+func TestMarshalSpec_NotValid(t *testing.T) {
+	var (
+		users = schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "int"))
+		uid   = schema.NewIntColumn("user_id", "int")
+		posts = schema.NewTable("posts").AddColumns(uid)
+	)
+	posts.AddForeignKeys(schema.NewForeignKey("user_fk").AddColumns(uid).SetRefTable(users).AddRefColumns(users.Columns[0]).AddAttrs(&NotValid{}))
+	posts.AddChecks(&schema.Check{Name: "positive", Expr: "user_id > 0", Attrs: []schema.Attr{&NotValid{}}})
+	schema.New("public").AddTables(users, posts)
+	buf, err := MarshalSpec(posts.Schema, hclState)
+	require.NoError(t, err)
+	const expected = `table "users" {
+  schema = schema.public
+  column "id" {
+    null = false
+    type = int
+  }
+}
+table "posts" {
+  schema = schema.public
+  column "user_id" {
+    null = false
+    type = int
+  }
+  foreign_key "user_fk" {
+    columns     = [column.user_id]
+    ref_columns = [table.users.column.id]
+    not_valid   = true
+  }
+  check "positive" {
+    expr      = "user_id > 0"
+    not_valid = true
+  }
+}
+schema "public" {
+}
+`
+	require.EqualValues(t, expected, string(buf))
+
+	var got schema.Schema
+	require.NoError(t, EvalHCLBytes(buf, &got, nil))
+	tb, ok := got.Table("posts")
+	require.True(t, ok)
+	require.Equal(t, []schema.Attr{&NotValid{}}, tb.ForeignKeys[0].Attrs)
+	require.Equal(t, []schema.Attr{&NotValid{}}, tb.Attrs[0].(*schema.Check).Attrs)
+}
+
 func TestMarshalSpec_PrimaryKey(t *testing.T) {
 	s := schema.New("test").
 		AddTables(
@@ -1402,6 +2039,353 @@ schema "test" {
 	require.EqualValues(t, expected, string(buf))
 }
 
+func TestMarshalSpec_Sequence(t *testing.T) {
+	var (
+		minV  = int64(0)
+		users = schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "bigint"))
+		seq   = &Sequence{Name: "users_seq", Type: &schema.IntegerType{T: TypeInteger}, Min: &minV, Cache: 10, Cycle: true, Attrs: []schema.Attr{&schema.Comment{Text: "c"}}}
+	)
+	seq.Owner.Table, seq.Owner.Column = users, users.Columns[0]
+	s := schema.New("test").AddTables(users).AddObjects(seq, &Sequence{Name: "counter"})
+	buf, err := MarshalSpec(s, hclState)
+	require.NoError(t, err)
+	const expected = `table "users" {
+  schema = schema.test
+  column "id" {
+    null = false
+    type = bigint
+  }
+}
+sequence "users_seq" {
+  schema    = schema.test
+  type      = integer
+  min_value = 0
+  cache     = 10
+  cycle     = true
+  owned_by  = table.users.column.id
+  comment   = "c"
+}
+sequence "counter" {
+  schema = schema.test
+}
+schema "test" {
+}
+`
+	require.EqualValues(t, expected, string(buf))
+	var got schema.Schema
+	require.NoError(t, EvalHCLBytes(buf, &got, nil))
+	require.Len(t, got.Objects, 2)
+	seq1 := got.Objects[0].(*Sequence)
+	require.Equal(t, "users_seq", seq1.Name)
+	require.Equal(t, "test", seq1.Schema.Name)
+	require.Equal(t, seqOptions(seq), seqOptions(seq1))
+	require.Equal(t, got.Tables[0], seq1.Owner.Table)
+	require.Equal(t, got.Tables[0].Columns[0], seq1.Owner.Column)
+	require.Equal(t, seq.Attrs, seq1.Attrs)
+	require.Equal(t, seqOptions(&Sequence{}), seqOptions(got.Objects[1].(*Sequence)))
+}
+
+func TestMarshalSpec_Extension(t *testing.T) {
+	s := schema.New("public").
+		AddObjects(
+			&Extension{Name: "uuid-ossp", Version: "1.1"},
+			&Extension{Name: "citext", Attrs: []schema.Attr{&schema.Comment{Text: "c"}}},
+		).
+		AddTables(
+			schema.NewTable("users").AddColumns(schema.NewColumn("name").SetType(&UserDefinedType{T: "citext"})),
+		)
+	buf, err := MarshalSpec(s, hclState)
+	require.NoError(t, err)
+	const expected = `table "users" {
+  schema = schema.public
+  column "name" {
+    null = false
+    type = citext
+  }
+}
+extension "uuid-ossp" {
+  schema  = schema.public
+  version = "1.1"
+}
+extension "citext" {
+  schema  = schema.public
+  comment = "c"
+}
+schema "public" {
+}
+`
+	require.EqualValues(t, expected, string(buf))
+	var got schema.Schema
+	require.NoError(t, EvalHCLBytes(buf, &got, nil))
+	require.Len(t, got.Objects, 2)
+	e1, e2 := got.Objects[0].(*Extension), got.Objects[1].(*Extension)
+	require.Equal(t, "uuid-ossp", e1.Name)
+	require.Equal(t, "1.1", e1.Version)
+	require.Equal(t, "public", e1.Schema.Name)
+	require.Equal(t, "citext", e2.Name)
+	require.Empty(t, e2.Version)
+	require.Equal(t, []schema.Attr{&schema.Comment{Text: "c"}}, e2.Attrs)
+	require.Equal(t, &UserDefinedType{T: "citext"}, got.Tables[0].Columns[0].Type.Type)
+}
+
+func TestMarshalSpec_Policies(t *testing.T) {
+	s := schema.New("public").
+		AddTables(
+			schema.NewTable("users").
+				AddColumns(schema.NewStringColumn("owner", "text")).
+				AddAttrs(
+					&RowSecurity{Enabled: true, Enforced: true},
+					&Policy{Name: "owner_read", As: PolicyAsPermissive, For: PolicyForSelect, Using: "(owner = CURRENT_USER)"},
+					&Policy{Name: "owner_edit", As: PolicyAsRestrictive, For: PolicyForAll, To: []string{"admin", "editor"}, Check: "(owner = CURRENT_USER)"},
+				),
+		)
+	buf, err := MarshalSpec(s, hclState)
+	require.NoError(t, err)
+	const expected = `table "users" {
+  schema = schema.public
+  column "owner" {
+    null = false
+    type = text
+  }
+  row_security {
+    enabled  = true
+    enforced = true
+  }
+  policy "owner_read" {
+    for   = SELECT
+    using = "(owner = CURRENT_USER)"
+  }
+  policy "owner_edit" {
+    as    = RESTRICTIVE
+    to    = ["admin", "editor"]
+    check = "(owner = CURRENT_USER)"
+  }
+}
+schema "public" {
+}
+`
+	require.EqualValues(t, expected, string(buf))
+	var got schema.Schema
+	require.NoError(t, EvalHCLBytes(buf, &got, nil))
+	require.Equal(t, []schema.Attr{
+		&RowSecurity{Enabled: true, Enforced: true},
+		&Policy{Name: "owner_read", For: PolicyForSelect, Using: "(owner = CURRENT_USER)"},
+		&Policy{Name: "owner_edit", As: PolicyAsRestrictive, To: []string{"admin", "editor"}, Check: "(owner = CURRENT_USER)"},
+	}, got.Tables[0].Attrs)
+
+	err = EvalHCLBytes([]byte(`
+schema "public" {}
+table "users" {
+  schema = schema.public
+  column "owner" {
+    type = text
+  }
+  policy "p" {}
+  policy "p" {}
+}
+`), &schema.Schema{}, nil)
+	require.EqualError(t, err, `specutil: cannot convert table "users": duplicate policy "p" in table "users"`)
+}
+
+func TestMarshalSpec_Roles(t *testing.T) {
+	var (
+		users = schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "int"), schema.NewStringColumn("email", "text"))
+		s     = schema.New("public").AddTables(users)
+		seq   = &Sequence{Name: "counter", Schema: s, Start: 1, Increment: 1, Cache: 1}
+		r     = schema.NewRealm(s)
+	)
+	s.AddObjects(seq)
+	readers := schema.NewRole("readers").
+		AddGrants(
+			schema.NewGrant(s, "USAGE"),
+			schema.NewGrant(users, "SELECT"),
+		)
+	r.AddRoles(
+		readers,
+		schema.NewUser("alice").
+			AddMemberOf(readers).
+			AddGrants(
+				schema.NewGrant(users, "UPDATE").SetColumns(users.Columns[1]).SetGrantOption(true),
+				schema.NewGrant(seq, "USAGE"),
+			),
+	)
+	buf, err := MarshalSpec(r, hclState)
+	require.NoError(t, err)
+	const expected = `table "users" {
+  schema = schema.public
+  column "id" {
+    null = false
+    type = int
+  }
+  column "email" {
+    null = false
+    type = text
+  }
+}
+sequence "counter" {
+  schema = schema.public
+}
+schema "public" {
+}
+role "readers" {
+  grant {
+    privileges = ["USAGE"]
+    on         = schema.public
+  }
+  grant {
+    privileges = ["SELECT"]
+    on         = table.users
+  }
+}
+user "alice" {
+  member_of = [role.readers]
+  grant {
+    privileges   = ["UPDATE"]
+    on           = table.users
+    columns      = [table.users.column.email]
+    grant_option = true
+  }
+  grant {
+    privileges = ["USAGE"]
+    on         = sequence.counter
+  }
+}
+`
+	require.EqualValues(t, expected, string(buf))
+	var got schema.Realm
+	require.NoError(t, EvalHCLBytes(buf, &got, nil))
+	require.Len(t, got.Roles, 2)
+	gotS, gotT := got.Schemas[0], got.Schemas[0].Tables[0]
+	gotR, gotU := got.Roles[0], got.Roles[1]
+	require.Equal(t, "readers", gotR.Name)
+	require.False(t, gotR.User)
+	require.Equal(t, []*schema.Grant{
+		{To: gotR, On: gotS, Privileges: []string{"USAGE"}},
+		{To: gotR, On: gotT, Privileges: []string{"SELECT"}},
+	}, gotR.Grants)
+	require.Equal(t, "alice", gotU.Name)
+	require.True(t, gotU.User)
+	require.Equal(t, []*schema.Role{gotR}, gotU.MemberOf)
+	require.Equal(t, []*schema.Grant{
+		{To: gotU, On: gotT, Columns: gotT.Columns[1:], Privileges: []string{"UPDATE"}, GrantOption: true},
+		{To: gotU, On: gotS.Objects[0], Privileges: []string{"USAGE"}},
+	}, gotU.Grants)
+
+	err = EvalHCLBytes([]byte(`
+schema "public" {}
+user "alice" {
+  member_of = [role.readers]
+}
+role "readers" {}
+role "alice" {}
+`), &schema.Realm{}, nil)
+	require.EqualError(t, err, `specutil: duplicate role "alice"`)
+}
+
+func TestMarshalSpec_Types(t *testing.T) {
+	var (
+		s   = schema.New("public")
+		zip = &Domain{
+			T:       "us_postal_code",
+			Schema:  s,
+			Base:    &schema.StringType{T: "text"},
+			Default: &schema.Literal{V: "'00000'"},
+			Checks:  []*schema.Check{{Name: "zip_check", Expr: "length(VALUE) = 5"}},
+			Attrs:   []schema.Attr{&schema.Comment{Text: "US zip code"}},
+		}
+		addr = &Composite{
+			T:      "address",
+			Schema: s,
+			Fields: []*schema.Column{
+				schema.NewColumn("street").SetType(&schema.StringType{T: "text"}),
+				schema.NewColumn("zip").SetType(zip),
+			},
+		}
+	)
+	s.AddObjects(zip, addr).
+		AddTables(
+			schema.NewTable("users").AddColumns(
+				schema.NewColumn("zip").SetType(zip),
+				schema.NewNullColumn("address").SetType(addr),
+			),
+		)
+	buf, err := MarshalSpec(s, hclState)
+	require.NoError(t, err)
+	const expected = `table "users" {
+  schema = schema.public
+  column "zip" {
+    null = false
+    type = domain.us_postal_code
+  }
+  column "address" {
+    null = true
+    type = composite.address
+  }
+}
+domain "us_postal_code" {
+  schema  = schema.public
+  type    = text
+  default = "00000"
+  null    = false
+  comment = "US zip code"
+  check "zip_check" {
+    expr = "length(VALUE) = 5"
+  }
+}
+composite "address" {
+  schema = schema.public
+  field "street" {
+    type = text
+  }
+  field "zip" {
+    type = domain.us_postal_code
+  }
+}
+schema "public" {
+}
+`
+	require.EqualValues(t, expected, string(buf))
+	var got schema.Schema
+	require.NoError(t, EvalHCLBytes(buf, &got, nil))
+	require.Len(t, got.Objects, 2)
+	zip2, addr2 := got.Objects[0].(*Domain), got.Objects[1].(*Composite)
+	require.Equal(t, "us_postal_code", zip2.T)
+	require.Equal(t, &schema.StringType{T: "text"}, zip2.Base)
+	require.Equal(t, &schema.Literal{V: "00000"}, zip2.Default)
+	require.False(t, zip2.Null)
+	require.Equal(t, zip.Checks, zip2.Checks)
+	require.Equal(t, zip.Attrs, zip2.Attrs)
+	require.Equal(t, "address", addr2.T)
+	require.Len(t, addr2.Fields, 2)
+	require.Equal(t, zip2, addr2.Fields[1].Type.Type)
+	users := got.Tables[0]
+	require.Equal(t, zip2, users.Columns[0].Type.Type)
+	require.Equal(t, addr2, users.Columns[1].Type.Type)
+
+	// Domains are nullable by default, and columns can
+	// reference domains and composite types by their names.
+	require.NoError(t, EvalHCLBytes([]byte(`
+schema "public" {}
+domain "positive" {
+  schema = schema.public
+  type   = int
+  check {
+    expr = "VALUE > 0"
+  }
+}
+table "t" {
+  schema = schema.public
+  column "c" {
+    type = sql("positive")
+  }
+}
+`), &got, nil))
+	d := got.Objects[0].(*Domain)
+	require.True(t, d.Null)
+	require.Equal(t, []*schema.Check{{Expr: "VALUE > 0"}}, d.Checks)
+	require.Equal(t, d, got.Tables[0].Columns[0].Type.Type)
+}
+
 func TestMarshalSpec_TimePrecision(t *testing.T) {
 	s := schema.New("test").
 		AddTables(
@@ -1459,6 +2443,22 @@ func TestTypes(t *testing.T) {
 			typeExpr: `hstore`,
 			expected: &UserDefinedType{T: "hstore"},
 		},
+		{
+			typeExpr: `citext`,
+			expected: &UserDefinedType{T: "citext"},
+		},
+		{
+			typeExpr: `geometry`,
+			expected: &schema.SpatialType{T: "geometry"},
+		},
+		{
+			typeExpr: `geography`,
+			expected: &schema.SpatialType{T: "geography"},
+		},
+		{
+			typeExpr: `sql("geometry(point,4326)")`,
+			expected: &schema.SpatialType{T: "geometry(point,4326)"},
+		},
 		{
 			typeExpr: "bit_varying(10)",
 			expected: &BitType{T: TypeBitVar, Len: 10},
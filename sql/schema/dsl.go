@@ -85,6 +85,24 @@ func (s *Schema) AddObjects(objs ...Object) *Schema {
 	return s
 }
 
+// AddFuncs adds and links the given functions to the schema objects.
+func (s *Schema) AddFuncs(funcs ...*Func) *Schema {
+	for _, f := range funcs {
+		f.Schema = s
+		s.Objects = append(s.Objects, f)
+	}
+	return s
+}
+
+// AddProcs adds and links the given procedures to the schema objects.
+func (s *Schema) AddProcs(procs ...*Proc) *Schema {
+	for _, p := range procs {
+		p.Schema = s
+		s.Objects = append(s.Objects, p)
+	}
+	return s
+}
+
 // NewRealm creates a new Realm.
 func NewRealm(schemas ...*Schema) *Realm {
 	r := &Realm{Schemas: schemas}
@@ -103,6 +121,15 @@ func (r *Realm) AddSchemas(schemas ...*Schema) *Realm {
 	return r
 }
 
+// AddRoles adds and links the given roles to the realm.
+func (r *Realm) AddRoles(roles ...*Role) *Realm {
+	for _, ro := range roles {
+		ro.Realm = r
+	}
+	r.Roles = append(r.Roles, roles...)
+	return r
+}
+
 // SetCharset sets or appends the Charset attribute
 // to the realm with the given value.
 func (r *Realm) SetCharset(v string) *Realm {
@@ -226,11 +253,25 @@ func (t *Table) AddAttrs(attrs ...Attr) *Table {
 	return t
 }
 
+// AddTriggers adds and links the given triggers to the table.
+func (t *Table) AddTriggers(triggers ...*Trigger) *Table {
+	for _, tr := range triggers {
+		tr.Table, tr.View = t, nil
+	}
+	t.Triggers = append(t.Triggers, triggers...)
+	return t
+}
+
 // NewView creates a new View.
 func NewView(name, def string) *View {
 	return &View{Name: name, Def: def}
 }
 
+// NewMaterializedView creates a new materialized View.
+func NewMaterializedView(name, def string) *View {
+	return NewView(name, def).AddAttrs(&Materialized{})
+}
+
 // SetSchema sets the schema (named-database) of the view.
 func (v *View) SetSchema(s *Schema) *View {
 	v.Schema = s
@@ -268,6 +309,172 @@ func (v *View) SetCheckOption(opt string) *View {
 	return v
 }
 
+// AddTriggers adds and links the given triggers to the view.
+func (v *View) AddTriggers(triggers ...*Trigger) *View {
+	for _, t := range triggers {
+		t.View, t.Table = v, nil
+	}
+	v.Triggers = append(v.Triggers, triggers...)
+	return v
+}
+
+// AddIndexes appends the given indexes to the materialized view index list.
+func (v *View) AddIndexes(indexes ...*Index) *View {
+	for _, idx := range indexes {
+		idx.View, idx.Table = v, nil
+	}
+	v.Indexes = append(v.Indexes, indexes...)
+	return v
+}
+
+// NewTrigger creates a new Trigger.
+func NewTrigger(name string) *Trigger {
+	return &Trigger{Name: name}
+}
+
+// SetActionTime sets the action time of the trigger.
+func (t *Trigger) SetActionTime(at TriggerTime) *Trigger {
+	t.ActionTime = at
+	return t
+}
+
+// AddEvents adds the given events to the trigger.
+func (t *Trigger) AddEvents(events ...TriggerEvent) *Trigger {
+	t.Events = append(t.Events, events...)
+	return t
+}
+
+// SetFor sets the FOR EACH spec of the trigger.
+func (t *Trigger) SetFor(f TriggerFor) *Trigger {
+	t.For = f
+	return t
+}
+
+// SetWhen sets the WHEN condition of the trigger.
+func (t *Trigger) SetWhen(cond string) *Trigger {
+	t.When = cond
+	return t
+}
+
+// SetBody sets the body of the trigger.
+func (t *Trigger) SetBody(body string) *Trigger {
+	t.Body = body
+	return t
+}
+
+// AddDeps adds the given objects as dependencies to the trigger.
+func (t *Trigger) AddDeps(objs ...Object) *Trigger {
+	t.Deps = append(t.Deps, objs...)
+	return t
+}
+
+// NewFunc creates a new function.
+func NewFunc(name string) *Func {
+	return &Func{Name: name}
+}
+
+// AddArgs appends the given arguments to the function.
+func (f *Func) AddArgs(args ...*FuncArg) *Func {
+	f.Args = append(f.Args, args...)
+	return f
+}
+
+// SetReturn sets the return type of the function.
+func (f *Func) SetReturn(t Type) *Func {
+	f.Ret = t
+	return f
+}
+
+// SetLang sets the language of the function.
+func (f *Func) SetLang(lang string) *Func {
+	f.Lang = lang
+	return f
+}
+
+// SetBody sets the body of the function.
+func (f *Func) SetBody(body string) *Func {
+	f.Body = body
+	return f
+}
+
+// SetComment sets or appends the Comment attribute
+// to the function with the given value.
+func (f *Func) SetComment(c string) *Func {
+	ReplaceOrAppend(&f.Attrs, &Comment{Text: c})
+	return f
+}
+
+// AddAttrs adds and additional attributes to the function.
+func (f *Func) AddAttrs(attrs ...Attr) *Func {
+	f.Attrs = append(f.Attrs, attrs...)
+	return f
+}
+
+// AddDeps adds the given objects as dependencies to the function.
+func (f *Func) AddDeps(objs ...Object) *Func {
+	f.Deps = append(f.Deps, objs...)
+	return f
+}
+
+// NewProc creates a new procedure.
+func NewProc(name string) *Proc {
+	return &Proc{Name: name}
+}
+
+// AddArgs appends the given arguments to the procedure.
+func (p *Proc) AddArgs(args ...*FuncArg) *Proc {
+	p.Args = append(p.Args, args...)
+	return p
+}
+
+// SetLang sets the language of the procedure.
+func (p *Proc) SetLang(lang string) *Proc {
+	p.Lang = lang
+	return p
+}
+
+// SetBody sets the body of the procedure.
+func (p *Proc) SetBody(body string) *Proc {
+	p.Body = body
+	return p
+}
+
+// SetComment sets or appends the Comment attribute
+// to the procedure with the given value.
+func (p *Proc) SetComment(c string) *Proc {
+	ReplaceOrAppend(&p.Attrs, &Comment{Text: c})
+	return p
+}
+
+// AddAttrs adds and additional attributes to the procedure.
+func (p *Proc) AddAttrs(attrs ...Attr) *Proc {
+	p.Attrs = append(p.Attrs, attrs...)
+	return p
+}
+
+// AddDeps adds the given objects as dependencies to the procedure.
+func (p *Proc) AddDeps(objs ...Object) *Proc {
+	p.Deps = append(p.Deps, objs...)
+	return p
+}
+
+// NewFuncArg creates a new function argument with the given name and type.
+func NewFuncArg(name string, t Type) *FuncArg {
+	return &FuncArg{Name: name, Type: t}
+}
+
+// SetMode sets the mode of the argument.
+func (a *FuncArg) SetMode(m FuncArgMode) *FuncArg {
+	a.Mode = m
+	return a
+}
+
+// SetDefault sets the default value of the argument.
+func (a *FuncArg) SetDefault(x Expr) *FuncArg {
+	a.Default = x
+	return a
+}
+
 // NewColumn creates a new column with the given name.
 func NewColumn(name string) *Column {
 	return &Column{Name: name}
@@ -801,6 +1008,12 @@ func (f *ForeignKey) SetOnDelete(o ReferenceOption) *ForeignKey {
 	return f
 }
 
+// AddAttrs adds additional attributes to the foreign key.
+func (f *ForeignKey) AddAttrs(attrs ...Attr) *ForeignKey {
+	f.Attrs = append(f.Attrs, attrs...)
+	return f
+}
+
 // ReplaceOrAppend searches an attribute of the same type as v in
 // the list and replaces it. Otherwise, v is appended to the list.
 func ReplaceOrAppend(attrs *[]Attr, v Attr) {
@@ -836,3 +1049,51 @@ func del(attrs *[]Attr, v Attr) {
 		}
 	}
 }
+
+// NewRole creates a new Role.
+func NewRole(name string) *Role {
+	return &Role{Name: name}
+}
+
+// NewUser creates a new Role that can log in.
+func NewUser(name string) *Role {
+	return &Role{Name: name, User: true}
+}
+
+// AddMemberOf adds the given roles to the roles this role is a member of.
+func (r *Role) AddMemberOf(roles ...*Role) *Role {
+	r.MemberOf = append(r.MemberOf, roles...)
+	return r
+}
+
+// AddGrants adds and links the given grants to the role.
+func (r *Role) AddGrants(grants ...*Grant) *Role {
+	for _, g := range grants {
+		g.To = r
+	}
+	r.Grants = append(r.Grants, grants...)
+	return r
+}
+
+// AddAttrs adds additional attributes to the role.
+func (r *Role) AddAttrs(attrs ...Attr) *Role {
+	r.Attrs = append(r.Attrs, attrs...)
+	return r
+}
+
+// NewGrant creates a new Grant of the given privileges on the object.
+func NewGrant(on Object, privileges ...string) *Grant {
+	return &Grant{On: on, Privileges: privileges}
+}
+
+// SetColumns sets the columns of a column-level grant.
+func (g *Grant) SetColumns(columns ...*Column) *Grant {
+	g.Columns = columns
+	return g
+}
+
+// SetGrantOption sets the WITH GRANT OPTION of the grant.
+func (g *Grant) SetGrantOption(b bool) *Grant {
+	g.GrantOption = b
+	return g
+}
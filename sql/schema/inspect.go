@@ -46,10 +46,61 @@ const (
 
 	// InspectViews enables schema views inspection.
 	InspectViews
+
+	// InspectTriggers enables tables and views triggers inspection.
+	InspectTriggers
+
+	// InspectFuncs enables schema functions and procedures inspection.
+	InspectFuncs
+
+	// InspectSequences enables schema sequences inspection.
+	InspectSequences
+
+	// InspectExtensions enables inspection of the extensions
+	// that are installed in the schemas (e.g. in PostgreSQL).
+	InspectExtensions
+
+	// InspectTypes enables inspection of the user-defined
+	// types (e.g. domains or composite types in PostgreSQL).
+	InspectTypes
+
+	// InspectPolicies enables inspection of the row-level
+	// security policies of tables (e.g. in PostgreSQL).
+	InspectPolicies
+
+	// InspectEvents enables inspection of the scheduled
+	// events that are defined in the schemas (e.g. in MySQL).
+	InspectEvents
+
+	// InspectRoles enables inspection of the roles, users and their
+	// privileges. Unlike the other modes, it is not enabled by default
+	// nor by negated modes (e.g. ^InspectViews), and should be set explicitly
+	// (e.g. InspectSchemas|InspectTables|InspectRoles). Note that inspected
+	// roles are compared only if the DiffRoles option is passed to the differ.
+	InspectRoles
+
+	// inspectEnd marks the end of the defined modes.
+	inspectEnd
 )
 
+// inspectAll holds all modes that are defined above. Modes with bits
+// beyond it were built by negation (e.g. ^InspectViews).
+const inspectAll = inspectEnd - 1
+
 // Is reports whether the given mode is enabled.
-func (m InspectMode) Is(i InspectMode) bool { return m&i != 0 }
+//
+// Modes that were built by negation (e.g. ^InspectViews) select only from the
+// InspectSchemas, InspectTables and InspectViews modes, in order to keep their
+// meaning unchanged when new modes are added. The other modes, such as triggers,
+// functions or roles, should be set explicitly, for example:
+//
+//	InspectSchemas|InspectTables|InspectTriggers
+func (m InspectMode) Is(i InspectMode) bool {
+	if m&^inspectAll != 0 {
+		m &= InspectSchemas | InspectTables | InspectViews
+	}
+	return m&i != 0
+}
 
 type (
 	// InspectOptions describes options for Inspector.
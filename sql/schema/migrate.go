@@ -80,6 +80,7 @@ type (
 	ModifyTable struct {
 		T       *Table
 		Changes []Change
+		Extra   []Clause // Extra clauses and options.
 	}
 
 	// RenameTable describes a table rename change.
@@ -102,6 +103,7 @@ type (
 	// ModifyView describes a view modification change.
 	ModifyView struct {
 		From, To *View
+		Changes  []Change // Changes on the indexes of materialized views.
 	}
 
 	// RenameView describes a view rename change.
@@ -109,6 +111,23 @@ type (
 		From, To *View
 	}
 
+	// AddTrigger describes a trigger creation change.
+	AddTrigger struct {
+		T     *Trigger
+		Extra []Clause // Extra clauses and options.
+	}
+
+	// DropTrigger describes a trigger removal change.
+	DropTrigger struct {
+		T     *Trigger
+		Extra []Clause // Extra clauses.
+	}
+
+	// ModifyTrigger describes a trigger modification change.
+	ModifyTrigger struct {
+		From, To *Trigger
+	}
+
 	// AddObject describes a generic object creation change.
 	AddObject struct {
 		O     Object
@@ -133,6 +152,34 @@ type (
 		From, To Object
 	}
 
+	// AddRole describes a role (or user) creation change.
+	AddRole struct {
+		R     *Role
+		Extra []Clause // Extra clauses and options.
+	}
+
+	// DropRole describes a role (or user) removal change.
+	DropRole struct {
+		R     *Role
+		Extra []Clause // Extra clauses.
+	}
+
+	// ModifyRole describes a role modification change. For example,
+	// changing its membership in other roles, or its ability to log in.
+	ModifyRole struct {
+		From, To *Role
+	}
+
+	// AddGrant describes a change that grants privileges to a role (GRANT).
+	AddGrant struct {
+		G *Grant
+	}
+
+	// DropGrant describes a change that revokes privileges from a role (REVOKE).
+	DropGrant struct {
+		G *Grant
+	}
+
 	// AddColumn describes a column creation change.
 	AddColumn struct {
 		C *Column
@@ -195,7 +242,8 @@ type (
 
 	// AddForeignKey describes a foreign-key creation change.
 	AddForeignKey struct {
-		F *ForeignKey
+		F     *ForeignKey
+		Extra []Clause // Extra clauses and options.
 	}
 
 	// DropForeignKey describes a foreign-key removal change.
@@ -211,7 +259,8 @@ type (
 
 	// AddCheck describes a CHECK constraint creation change.
 	AddCheck struct {
-		C *Check
+		C     *Check
+		Extra []Clause // Extra clauses and options.
 	}
 
 	// DropCheck describes a CHECK constraint removal change.
@@ -337,6 +386,19 @@ type (
 		// SkipChanges defines a list of change types to skip.
 		SkipChanges []Change
 
+		// AskRename is an optional callback for resolving rename candidates
+		// that were detected by structural similarity. For example, a column
+		// that was dropped and another one with the same definition that was
+		// added to the same table. The callback is called with the candidate
+		// RenameTable, RenameColumn or RenameIndex change, and reports if the
+		// change should be planned as a rename instead of a drop and an add.
+		AskRename func(Change) (bool, error)
+
+		// Roles reports if roles, users and their privileges are compared.
+		// Roles are not inspected by default (see InspectRoles), and therefore,
+		// they are compared only if this option is set explicitly.
+		Roles bool
+
 		// Extra defines per-driver configuration. If not
 		// nil, should be set to schemahcl.Extension.
 		Extra any // avoid circular dependency with schemahcl.
@@ -365,6 +427,23 @@ func DiffSkipChanges(changes ...Change) DiffOption {
 	}
 }
 
+// DiffAskRename returns a DiffOption that sets the callback for
+// resolving rename candidates detected by the differ.
+func DiffAskRename(f func(Change) (bool, error)) DiffOption {
+	return func(o *DiffOptions) {
+		o.AskRename = f
+	}
+}
+
+// DiffRoles returns a DiffOption that enables comparing roles,
+// users and their privileges. It should be used only if roles were
+// inspected in the current state (see InspectRoles).
+func DiffRoles() DiffOption {
+	return func(o *DiffOptions) {
+		o.Roles = true
+	}
+}
+
 // Skipped reports whether the given change should be skipped.
 func (o *DiffOptions) Skipped(c Change) bool {
 	for _, s := range o.SkipChanges {
@@ -541,10 +620,18 @@ func (*AddView) change()          {}
 func (*DropView) change()         {}
 func (*ModifyView) change()       {}
 func (*RenameView) change()       {}
+func (*AddTrigger) change()       {}
+func (*DropTrigger) change()      {}
+func (*ModifyTrigger) change()    {}
 func (*AddObject) change()        {}
 func (*DropObject) change()       {}
 func (*ModifyObject) change()     {}
 func (*RenameObject) change()     {}
+func (*AddRole) change()          {}
+func (*DropRole) change()         {}
+func (*ModifyRole) change()       {}
+func (*AddGrant) change()         {}
+func (*DropGrant) change()        {}
 func (*AddIndex) change()         {}
 func (*DropIndex) change()        {}
 func (*ModifyIndex) change()      {}
@@ -9,6 +9,7 @@ type (
 	// and can be accessed and queried in the same connection (e.g. a physical database instance).
 	Realm struct {
 		Schemas []*Schema
+		Roles   []*Role // Roles and users.
 		Attrs   []Attr
 	}
 
@@ -37,17 +38,106 @@ type (
 		Indexes     []*Index
 		PrimaryKey  *Index
 		ForeignKeys []*ForeignKey
-		Attrs       []Attr // Attrs, constraints and options.
+		Attrs       []Attr     // Attrs, constraints and options.
+		Triggers    []*Trigger // Triggers on table.
 	}
 
 	// A View represents a view definition.
 	View struct {
-		Name    string
-		Def     string
-		Schema  *Schema
-		Columns []*Column
-		Attrs   []Attr   // Attrs and options.
-		Deps    []Object // Tables and views used in view definition.
+		Name     string
+		Def      string
+		Schema   *Schema
+		Columns  []*Column
+		Attrs    []Attr     // Attrs and options.
+		Deps     []Object   // Tables and views used in view definition.
+		Triggers []*Trigger // Triggers on view.
+		Indexes  []*Index   // Indexes on materialized view.
+	}
+
+	// A Trigger represents a trigger definition.
+	Trigger struct {
+		Name string
+		// Table or View the trigger belongs to.
+		Table      *Table
+		View       *View
+		ActionTime TriggerTime    // BEFORE, AFTER or INSTEAD OF.
+		Events     []TriggerEvent // INSERT, UPDATE, DELETE, etc.
+		For        TriggerFor     // FOR EACH ROW or FOR EACH STATEMENT.
+		When       string         // Optional WHEN condition.
+		Body       string         // Trigger body or the function it executes.
+		Attrs      []Attr         // Attrs and options.
+		Deps       []Object       // Functions and objects used by the trigger.
+	}
+
+	// TriggerTime represents the trigger action time.
+	TriggerTime string
+
+	// TriggerFor represents the trigger FOR EACH spec.
+	TriggerFor string
+
+	// TriggerEvent represents the trigger event.
+	TriggerEvent struct {
+		Name    string    // INSERT, UPDATE, DELETE, etc.
+		Columns []*Column // Columns of UPDATE OF events.
+	}
+
+	// A Func represents a function definition.
+	Func struct {
+		Name   string
+		Schema *Schema
+		Args   []*FuncArg
+		Ret    Type     // Return type.
+		Lang   string   // Language, e.g. SQL or PL/pgSQL.
+		Body   string   // Function body.
+		Attrs  []Attr   // Volatility, comment, etc.
+		Deps   []Object // Objects used by the function.
+	}
+
+	// A Proc represents a procedure definition.
+	Proc struct {
+		Name   string
+		Schema *Schema
+		Args   []*FuncArg
+		Lang   string   // Language, e.g. SQL or PL/pgSQL.
+		Body   string   // Procedure body.
+		Attrs  []Attr   // Comment, etc.
+		Deps   []Object // Objects used by the procedure.
+	}
+
+	// A FuncArg represents a single function or procedure argument.
+	FuncArg struct {
+		Name    string      // Optional name.
+		Type    Type        // Argument type.
+		Default Expr        // Optional default value.
+		Mode    FuncArgMode // IN, OUT, INOUT or VARIADIC.
+		Attrs   []Attr
+	}
+
+	// FuncArgMode represents the mode of a function argument.
+	FuncArgMode string
+
+	// A Role represents a database role, or a user (i.e. a role that can log in).
+	// Roles are defined at the realm level, and privileges on the database objects
+	// are granted to them.
+	Role struct {
+		Name     string
+		Realm    *Realm
+		User     bool     // The role is a user that can log in.
+		MemberOf []*Role  // Roles this role is a member of.
+		Grants   []*Grant // Privileges granted to the role.
+		Attrs    []Attr   // Attrs and options.
+	}
+
+	// A Grant describes a set of privileges that were granted to a role on a
+	// database object. The object can be a schema, a table (or a set of its
+	// columns), a view, or a driver-specific object, like a sequence.
+	Grant struct {
+		To          *Role
+		On          Object    // Schema, table, view or driver-specific object.
+		Columns     []*Column // Columns of column-level grants on tables.
+		Privileges  []string  // e.g. SELECT, INSERT or ALL PRIVILEGES.
+		GrantOption bool      // WITH GRANT OPTION.
+		Attrs       []Attr
 	}
 
 	// A Column represents a column definition.
@@ -74,6 +164,7 @@ type (
 		Name   string
 		Unique bool
 		Table  *Table
+		View   *View // Set for indexes on materialized views.
 		Attrs  []Attr
 		Parts  []*IndexPart
 	}
@@ -101,6 +192,7 @@ type (
 		RefColumns []*Column
 		OnUpdate   ReferenceOption
 		OnDelete   ReferenceOption
+		Attrs      []Attr
 	}
 )
 
@@ -114,6 +206,16 @@ func (r *Realm) Schema(name string) (*Schema, bool) {
 	return nil, false
 }
 
+// Role returns the first role that matched the given name.
+func (r *Realm) Role(name string) (*Role, bool) {
+	for _, ro := range r.Roles {
+		if ro.Name == name {
+			return ro, true
+		}
+	}
+	return nil, false
+}
+
 // Table returns the first table that matched the given name.
 func (s *Schema) Table(name string) (*Table, bool) {
 	for _, t := range s.Tables {
@@ -144,6 +246,16 @@ func (s *Schema) Object(f func(Object) bool) (Object, bool) {
 	return nil, false
 }
 
+// Trigger returns the first trigger that matched the given name.
+func (t *Table) Trigger(name string) (*Trigger, bool) {
+	for _, tr := range t.Triggers {
+		if tr.Name == name {
+			return tr, true
+		}
+	}
+	return nil, false
+}
+
 // Column returns the first column that matched the given name.
 func (t *Table) Column(name string) (*Column, bool) {
 	for _, c := range t.Columns {
@@ -184,6 +296,36 @@ func (v *View) Column(name string) (*Column, bool) {
 	return nil, false
 }
 
+// Index returns the first index that matched the given name.
+func (v *View) Index(name string) (*Index, bool) {
+	for _, idx := range v.Indexes {
+		if idx.Name == name {
+			return idx, true
+		}
+	}
+	return nil, false
+}
+
+// Materialized reports if the view is a materialized view.
+func (v *View) Materialized() bool {
+	for _, a := range v.Attrs {
+		if _, ok := a.(*Materialized); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Trigger returns the first trigger that matched the given name.
+func (v *View) Trigger(name string) (*Trigger, bool) {
+	for _, t := range v.Triggers {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
 // Column returns the first column that matches the given name.
 func (f *ForeignKey) Column(name string) (*Column, bool) {
 	for _, c := range f.Columns {
@@ -377,6 +519,19 @@ type (
 	ViewCheckOption struct {
 		V string // LOCAL, CASCADED, NONE, or driver specific.
 	}
+
+	// Materialized is an attribute that marks a view as a materialized
+	// view. Unlike regular views, the results of materialized views are
+	// stored when they are created (or refreshed), and can be indexed.
+	Materialized struct{}
+
+	// RenamedFrom is a hint attribute that can be attached to tables,
+	// columns and indexes of the desired state, and tells the differ
+	// that the element was renamed from the given name. The differ
+	// then emits a rename change instead of a drop and an add.
+	RenamedFrom struct {
+		Name string
+	}
 )
 
 // A list of known view check options.
@@ -386,9 +541,48 @@ const (
 	ViewCheckOptionCascaded = "CASCADED"
 )
 
+// List of supported trigger action times.
+const (
+	TriggerTimeBefore  TriggerTime = "BEFORE"
+	TriggerTimeAfter   TriggerTime = "AFTER"
+	TriggerTimeInstead TriggerTime = "INSTEAD OF"
+)
+
+// List of supported trigger FOR EACH specs.
+const (
+	TriggerForRow  TriggerFor = "ROW"
+	TriggerForStmt TriggerFor = "STATEMENT"
+)
+
+// List of common trigger events.
+var (
+	TriggerEventInsert   = TriggerEvent{Name: "INSERT"}
+	TriggerEventUpdate   = TriggerEvent{Name: "UPDATE"}
+	TriggerEventDelete   = TriggerEvent{Name: "DELETE"}
+	TriggerEventTruncate = TriggerEvent{Name: "TRUNCATE"}
+)
+
+// TriggerEventUpdateOf returns an UPDATE OF trigger event for the given columns.
+func TriggerEventUpdateOf(columns ...*Column) TriggerEvent {
+	return TriggerEvent{Name: TriggerEventUpdate.Name, Columns: columns}
+}
+
+// List of function argument modes.
+const (
+	FuncArgModeIn       FuncArgMode = "IN"
+	FuncArgModeOut      FuncArgMode = "OUT"
+	FuncArgModeInOut    FuncArgMode = "INOUT"
+	FuncArgModeVariadic FuncArgMode = "VARIADIC"
+)
+
 // objects.
+func (*Schema) obj()   {}
+func (*Role) obj()     {}
 func (*Table) obj()    {}
 func (*View) obj()     {}
+func (*Trigger) obj()  {}
+func (*Func) obj()     {}
+func (*Proc) obj()     {}
 func (*EnumType) obj() {}
 
 // expressions.
@@ -416,6 +610,8 @@ func (*Charset) attr()         {}
 func (*Collation) attr()       {}
 func (*GeneratedExpr) attr()   {}
 func (*ViewCheckOption) attr() {}
+func (*Materialized) attr()    {}
+func (*RenamedFrom) attr()     {}
 
 // UnderlyingExpr returns the underlying expression of x.
 func UnderlyingExpr(x Expr) Expr {
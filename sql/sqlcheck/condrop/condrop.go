@@ -33,7 +33,7 @@ func New(r *schemahcl.Resource) (*Analyzer, error) {
 
 // List of codes.
 var (
-	codeDropF = sqlcheck.Code("CD101")
+	codeDropF = sqlcheck.CodeWithText("CD101", "Foreign-key constraint was dropped")
 )
 
 // Name of the analyzer. Implements the sqlcheck.NamedAnalyzer interface.
@@ -70,10 +70,10 @@ func (a *Analyzer) Analyze(ctx context.Context, p *sqlcheck.Pass) error {
 
 // List of codes.
 var (
-	codeAddUniqueI  = sqlcheck.Code("MF101")
-	codeModUniqueI  = sqlcheck.Code("MF102")
-	codeAddNotNullC = sqlcheck.Code("MF103")
-	codeModNotNullC = sqlcheck.Code("MF104")
+	codeAddUniqueI  = sqlcheck.CodeWithText("MF101", "Add unique index to existing column")
+	codeModUniqueI  = sqlcheck.CodeWithText("MF102", "Modifying non-unique index to unique")
+	codeAddNotNullC = sqlcheck.CodeWithText("MF103", "Adding a non-nullable column to an existing table")
+	codeModNotNullC = sqlcheck.CodeWithText("MF104", "Modifying a nullable column to non-nullable")
 )
 
 // Diagnostics runs the common analysis on the file and returns its diagnostics.
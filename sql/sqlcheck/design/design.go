@@ -0,0 +1,155 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package design
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"ariga.io/atlas/schemahcl"
+	"ariga.io/atlas/sql/internal/sqlx"
+	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlcheck"
+)
+
+type (
+	// Analyzer checks the design of a schema state. For example,
+	// tables without a primary key or unindexed foreign keys.
+	Analyzer struct {
+		sqlcheck.Options
+		Handler
+	}
+
+	// Handler holds the underlying driver handlers.
+	Handler struct {
+		// Reserved is an optional handler that reports if the given
+		// identifier is a reserved word of the database.
+		Reserved func(string) bool
+	}
+)
+
+// New creates a new schema design Analyzer with the given options.
+func New(r *schemahcl.Resource, h Handler) (*Analyzer, error) {
+	az := &Analyzer{Handler: h}
+	if r, ok := r.Resource(az.Name()); ok {
+		if err := r.As(&az.Options); err != nil {
+			return nil, fmt.Errorf("sql/sqlcheck: parsing design check options: %w", err)
+		}
+	}
+	return az, nil
+}
+
+// List of codes.
+var (
+	codeNoPK     = sqlcheck.CodeWithText("DE101", "Table has no primary key")
+	codeNoFKIdx  = sqlcheck.CodeWithText("DE102", "Foreign-key constraint is not covered by an index")
+	codeNullUniq = sqlcheck.CodeWithText("DE103", "Unique index contains a nullable column")
+	codeReserved = sqlcheck.CodeWithText("DE104", "Identifier is a reserved word")
+)
+
+// Name of the analyzer. Implements the sqlcheck.NamedAnalyzer interface.
+func (*Analyzer) Name() string {
+	return "design"
+}
+
+// AnalyzeState implements sqlcheck.StateAnalyzer.
+func (a *Analyzer) AnalyzeState(_ context.Context, p *sqlcheck.StatePass) error {
+	var diags []sqlcheck.Diagnostic
+	for _, s := range p.Realm.Schemas {
+		diags = append(diags, a.reserved(fmt.Sprintf("Schema name %q", s.Name), s.Name)...)
+		for _, t := range s.Tables {
+			diags = append(diags, a.reserved(fmt.Sprintf("Table name %q", t.Name), t.Name)...)
+			for _, c := range t.Columns {
+				diags = append(diags, a.reserved(fmt.Sprintf("Column name %q of table %q", c.Name, t.Name), c.Name)...)
+			}
+			if t.PrimaryKey == nil {
+				diags = append(diags, sqlcheck.Diagnostic{
+					Code: codeNoPK,
+					Text: fmt.Sprintf("Table %q has no primary key", t.Name),
+				})
+			}
+			for _, idx := range t.Indexes {
+				if !idx.Unique {
+					continue
+				}
+				for _, part := range idx.Parts {
+					if part.C != nil && part.C.Type != nil && part.C.Type.Null {
+						diags = append(diags, sqlcheck.Diagnostic{
+							Code: codeNullUniq,
+							Text: fmt.Sprintf("Unique index %q of table %q contains the nullable column %q", idx.Name, t.Name, part.C.Name),
+						})
+					}
+				}
+			}
+			for _, fk := range t.ForeignKeys {
+				if !covered(t, fk) {
+					diags = append(diags, sqlcheck.Diagnostic{
+						Code: codeNoFKIdx,
+						Text: fmt.Sprintf("Foreign-key constraint %q of table %q is not covered by an index", fkName(fk), t.Name),
+					})
+				}
+			}
+		}
+	}
+	if len(diags) > 0 {
+		const reportText = "schema design issues detected"
+		p.Reporter.WriteReport(sqlcheck.Report{Text: reportText, Diagnostics: diags})
+		if sqlx.V(a.Error) {
+			return errors.New(reportText)
+		}
+	}
+	return nil
+}
+
+func (a *Analyzer) reserved(resource, name string) []sqlcheck.Diagnostic {
+	if a.Reserved == nil || !a.Reserved(name) {
+		return nil
+	}
+	return []sqlcheck.Diagnostic{
+		{
+			Code: codeReserved,
+			Text: fmt.Sprintf("%s is a reserved word", resource),
+		},
+	}
+}
+
+// covered reports if the columns of the foreign key are
+// the leftmost columns of one of the table indexes.
+func covered(t *schema.Table, fk *schema.ForeignKey) bool {
+	idxs := t.Indexes
+	if t.PrimaryKey != nil {
+		idxs = append([]*schema.Index{t.PrimaryKey}, idxs...)
+	}
+	for _, idx := range idxs {
+		if len(idx.Parts) < len(fk.Columns) {
+			continue
+		}
+		match := true
+		for i, c := range fk.Columns {
+			if p := idx.Parts[i]; p.C == nil || p.C.Name != c.Name {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+// fkName returns the name of the foreign key, or its columns if it has no name.
+func fkName(fk *schema.ForeignKey) string {
+	if fk.Symbol != "" {
+		return fk.Symbol
+	}
+	names := make([]string, len(fk.Columns))
+	for i, c := range fk.Columns {
+		names[i] = c.Name
+	}
+	return strings.Join(names, ", ")
+}
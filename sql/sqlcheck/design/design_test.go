@@ -0,0 +1,94 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package design_test
+
+import (
+	"context"
+	"testing"
+
+	"ariga.io/atlas/schemahcl"
+	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlcheck"
+	"ariga.io/atlas/sql/sqlcheck/design"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzer_AnalyzeState(t *testing.T) {
+	var (
+		users = schema.NewTable("users").
+			AddColumns(
+				schema.NewIntColumn("id", "int"),
+				schema.NewNullStringColumn("email", "varchar(255)"),
+			)
+		pets = schema.NewTable("pets").
+			AddColumns(
+				schema.NewIntColumn("id", "int"),
+				schema.NewIntColumn("owner_id", "int"),
+				schema.NewIntColumn("order", "int"),
+			)
+	)
+	users.SetPrimaryKey(schema.NewPrimaryKey(users.Columns[0])).
+		AddIndexes(schema.NewUniqueIndex("users_email").AddColumns(users.Columns[1]))
+	pets.AddForeignKeys(
+		schema.NewForeignKey("owner").
+			AddColumns(pets.Columns[1]).
+			SetRefTable(users).
+			AddRefColumns(users.Columns[0]),
+	)
+	var (
+		report *sqlcheck.Report
+		pass   = &sqlcheck.StatePass{
+			Realm: schema.NewRealm(schema.New("public").AddTables(users, pets)),
+			Reporter: sqlcheck.ReportWriterFunc(func(r sqlcheck.Report) {
+				report = &r
+			}),
+		}
+	)
+	az, err := design.New(nil, design.Handler{
+		Reserved: func(s string) bool { return s == "order" },
+	})
+	require.NoError(t, err)
+	err = az.AnalyzeState(context.Background(), pass)
+	require.NoError(t, err)
+	require.Equal(t, "schema design issues detected", report.Text)
+	require.Equal(t, []sqlcheck.Diagnostic{
+		{Code: "DE103", Text: `Unique index "users_email" of table "users" contains the nullable column "email"`},
+		{Code: "DE104", Text: `Column name "order" of table "pets" is a reserved word`},
+		{Code: "DE101", Text: `Table "pets" has no primary key`},
+		{Code: "DE102", Text: `Foreign-key constraint "owner" of table "pets" is not covered by an index`},
+	}, report.Diagnostics)
+
+	// A covering index and a primary key resolve the diagnostics.
+	report = nil
+	pets.SetPrimaryKey(schema.NewPrimaryKey(pets.Columns[0])).
+		AddIndexes(schema.NewIndex("pets_owner_id").AddColumns(pets.Columns[1], pets.Columns[0]))
+	users.Columns[1].Type.Null = false
+	az, err = design.New(nil, design.Handler{})
+	require.NoError(t, err)
+	err = az.AnalyzeState(context.Background(), pass)
+	require.NoError(t, err)
+	require.Nil(t, report)
+}
+
+func TestAnalyzer_Error(t *testing.T) {
+	var cfg struct {
+		schemahcl.DefaultExtension
+	}
+	// language=hcl
+	err := schemahcl.New().EvalBytes([]byte(`
+design {
+  error = true
+}
+`), &cfg, nil)
+	require.NoError(t, err)
+	az, err := design.New(cfg.Remain(), design.Handler{})
+	require.NoError(t, err)
+	err = az.AnalyzeState(context.Background(), &sqlcheck.StatePass{
+		Realm:    schema.NewRealm(schema.New("public").AddTables(schema.NewTable("t"))),
+		Reporter: sqlcheck.ReportWriterFunc(func(sqlcheck.Report) {}),
+	})
+	require.EqualError(t, err, "schema design issues detected")
+}
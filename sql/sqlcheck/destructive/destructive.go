@@ -35,9 +35,9 @@ func New(r *schemahcl.Resource) (*Analyzer, error) {
 
 // List of codes.
 var (
-	codeDropS = sqlcheck.Code("DS101")
-	codeDropT = sqlcheck.Code("DS102")
-	codeDropC = sqlcheck.Code("DS103")
+	codeDropS = sqlcheck.CodeWithText("DS101", "Schema was dropped")
+	codeDropT = sqlcheck.CodeWithText("DS102", "Table was dropped")
+	codeDropC = sqlcheck.CodeWithText("DS103", "Non-virtual column was dropped")
 )
 
 // Name of the analyzer. Implements the sqlcheck.NamedAnalyzer interface.
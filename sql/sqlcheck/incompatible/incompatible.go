@@ -34,8 +34,8 @@ func New(r *schemahcl.Resource) (*Analyzer, error) {
 
 // List of codes.
 var (
-	codeRenameT = sqlcheck.Code("BC101")
-	codeRenameC = sqlcheck.Code("BC102")
+	codeRenameT = sqlcheck.CodeWithText("BC101", "Renaming a table")
+	codeRenameC = sqlcheck.CodeWithText("BC102", "Renaming a column")
 )
 
 // Name of the analyzer. Implements the sqlcheck.NamedAnalyzer interface.
@@ -77,12 +77,12 @@ func readOptions(r *schemahcl.Resource, opts *Options) error {
 
 // List of codes.
 var (
-	codeNameS = sqlcheck.Code("NM101")
-	codeNameT = sqlcheck.Code("NM102")
-	codeNameC = sqlcheck.Code("NM103")
-	codeNameI = sqlcheck.Code("NM104")
-	codeNameF = sqlcheck.Code("NM105")
-	codeNameK = sqlcheck.Code("NM106")
+	codeNameS = sqlcheck.CodeWithText("NM101", "Schema name violates the naming convention")
+	codeNameT = sqlcheck.CodeWithText("NM102", "Table name violates the naming convention")
+	codeNameC = sqlcheck.CodeWithText("NM103", "Column name violates the naming convention")
+	codeNameI = sqlcheck.CodeWithText("NM104", "Index name violates the naming convention")
+	codeNameF = sqlcheck.CodeWithText("NM105", "Foreign-key constraint name violates the naming convention")
+	codeNameK = sqlcheck.CodeWithText("NM106", "Check constraint name violates the naming convention")
 )
 
 // Name of the analyzer. Implements the sqlcheck.NamedAnalyzer interface.
@@ -97,34 +97,69 @@ func (a *Analyzer) Analyze(_ context.Context, p *sqlcheck.Pass) error {
 		for _, c := range sc.Changes {
 			switch c := c.(type) {
 			case *schema.AddSchema:
-				diags = append(diags, a.match(sc, codeNameS, c.S.Name, "Schema", a.Schema)...)
+				diags = append(diags, a.match(sc.Stmt.Pos, codeNameS, c.S.Name, "Schema", a.Schema)...)
 			case *schema.AddTable:
-				diags = append(diags, a.match(sc, codeNameT, c.T.Name, "Table", a.Table)...)
+				diags = append(diags, a.match(sc.Stmt.Pos, codeNameT, c.T.Name, "Table", a.Table)...)
 			case *schema.RenameTable:
-				diags = append(diags, a.match(sc, codeNameT, c.To.Name, "Table", a.Table)...)
+				diags = append(diags, a.match(sc.Stmt.Pos, codeNameT, c.To.Name, "Table", a.Table)...)
 			case *schema.ModifyTable:
 				for i := range c.Changes {
 					switch mc := c.Changes[i].(type) {
 					case *schema.AddColumn:
-						diags = append(diags, a.match(sc, codeNameC, mc.C.Name, "Column", a.Column)...)
+						diags = append(diags, a.match(sc.Stmt.Pos, codeNameC, mc.C.Name, "Column", a.Column)...)
 					case *schema.RenameColumn:
-						diags = append(diags, a.match(sc, codeNameC, mc.To.Name, "Column", a.Column)...)
+						diags = append(diags, a.match(sc.Stmt.Pos, codeNameC, mc.To.Name, "Column", a.Column)...)
 					case *schema.AddIndex:
-						diags = append(diags, a.match(sc, codeNameI, mc.I.Name, "Index", a.Index)...)
+						diags = append(diags, a.match(sc.Stmt.Pos, codeNameI, mc.I.Name, "Index", a.Index)...)
 					case *schema.RenameIndex:
-						diags = append(diags, a.match(sc, codeNameI, mc.To.Name, "Index", a.Index)...)
+						diags = append(diags, a.match(sc.Stmt.Pos, codeNameI, mc.To.Name, "Index", a.Index)...)
 					case *schema.AddForeignKey:
-						diags = append(diags, a.match(sc, codeNameF, mc.F.Symbol, "Foreign-key constraint", a.ForeignKey)...)
+						diags = append(diags, a.match(sc.Stmt.Pos, codeNameF, mc.F.Symbol, "Foreign-key constraint", a.ForeignKey)...)
 					case *schema.AddCheck:
-						diags = append(diags, a.match(sc, codeNameK, mc.C.Name, "Check constraint", a.Check)...)
+						diags = append(diags, a.match(sc.Stmt.Pos, codeNameK, mc.C.Name, "Check constraint", a.Check)...)
 					}
 				}
 			}
 		}
 	}
+	return a.report(p.Reporter, diags)
+}
+
+// AnalyzeState implements sqlcheck.StateAnalyzer.
+func (a *Analyzer) AnalyzeState(_ context.Context, p *sqlcheck.StatePass) error {
+	var diags []sqlcheck.Diagnostic
+	for _, s := range p.Realm.Schemas {
+		diags = append(diags, a.match(0, codeNameS, s.Name, "Schema", a.Schema)...)
+		for _, t := range s.Tables {
+			diags = append(diags, a.match(0, codeNameT, t.Name, "Table", a.Table)...)
+			for _, c := range t.Columns {
+				diags = append(diags, a.match(0, codeNameC, c.Name, "Column", a.Column)...)
+			}
+			// Unnamed indexes and constraints are named by the database.
+			for _, idx := range t.Indexes {
+				if idx.Name != "" {
+					diags = append(diags, a.match(0, codeNameI, idx.Name, "Index", a.Index)...)
+				}
+			}
+			for _, fk := range t.ForeignKeys {
+				if fk.Symbol != "" {
+					diags = append(diags, a.match(0, codeNameF, fk.Symbol, "Foreign-key constraint", a.ForeignKey)...)
+				}
+			}
+			for _, attr := range t.Attrs {
+				if c, ok := attr.(*schema.Check); ok && c.Name != "" {
+					diags = append(diags, a.match(0, codeNameK, c.Name, "Check constraint", a.Check)...)
+				}
+			}
+		}
+	}
+	return a.report(p.Reporter, diags)
+}
+
+func (a *Analyzer) report(rw sqlcheck.ReportWriter, diags []sqlcheck.Diagnostic) error {
 	if len(diags) > 0 {
 		const reportText = "naming violations detected"
-		p.Reporter.WriteReport(sqlcheck.Report{Text: reportText, Diagnostics: diags})
+		rw.WriteReport(sqlcheck.Report{Text: reportText, Diagnostics: diags})
 		if sqlx.V(a.Error) {
 			return errors.New(reportText)
 		}
@@ -132,7 +167,7 @@ func (a *Analyzer) Analyze(_ context.Context, p *sqlcheck.Pass) error {
 	return nil
 }
 
-func (a *Analyzer) match(c *sqlcheck.Change, code, name, resource string, opts Options) []sqlcheck.Diagnostic {
+func (a *Analyzer) match(pos int, code, name, resource string, opts Options) []sqlcheck.Diagnostic {
 	re, msg := opts.re, opts.Message
 	if re == nil {
 		re, msg = a.All.re, a.All.Message
@@ -141,7 +176,7 @@ func (a *Analyzer) match(c *sqlcheck.Change, code, name, resource string, opts O
 		return nil
 	}
 	d := sqlcheck.Diagnostic{
-		Pos:  c.Stmt.Pos,
+		Pos:  pos,
 		Text: fmt.Sprintf("%s named %q violates the naming policy", resource, name),
 		Code: code,
 	}
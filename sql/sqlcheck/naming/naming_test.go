@@ -76,3 +76,43 @@ naming {
 	require.Equal(t, `Table named "Users" violates the naming policy: must be lowercase`, report.Diagnostics[0].Text)
 	require.Equal(t, `Index named "pet_name" violates the naming policy: must be lowercase and end with _idx`, report.Diagnostics[1].Text)
 }
+
+func TestAnalyzer_AnalyzeState(t *testing.T) {
+	var cfg struct {
+		schemahcl.DefaultExtension
+	}
+	// language=hcl
+	err := schemahcl.New().EvalBytes([]byte(`
+naming {
+  match   = "^[a-z_]+$"
+  message = "must be lowercase"
+  index {
+    match   = "^[a-z_]+_idx$"
+  }
+}
+`), &cfg, nil)
+	require.NoError(t, err)
+	az, err := naming.New(cfg.Remain())
+	require.NoError(t, err)
+	users := schema.NewTable("Users").
+		AddColumns(schema.NewIntColumn("id", "int"), schema.NewIntColumn("groupID", "int"))
+	users.AddIndexes(
+		schema.NewIndex("users_group_idx").AddColumns(users.Columns[1]),
+		schema.NewIndex("users_id").AddColumns(users.Columns[0]),
+	)
+	var report *sqlcheck.Report
+	err = az.AnalyzeState(context.Background(), &sqlcheck.StatePass{
+		Realm: schema.NewRealm(schema.New("public").AddTables(users)),
+		Reporter: sqlcheck.ReportWriterFunc(func(r sqlcheck.Report) {
+			report = &r
+		}),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, report)
+	require.Equal(t, "naming violations detected", report.Text)
+	require.Equal(t, []sqlcheck.Diagnostic{
+		{Code: "NM102", Text: `Table named "Users" violates the naming policy: must be lowercase`},
+		{Code: "NM103", Text: `Column named "groupID" violates the naming policy: must be lowercase`},
+		{Code: "NM104", Text: `Index named "users_id" violates the naming policy`},
+	}, report.Diagnostics)
+}
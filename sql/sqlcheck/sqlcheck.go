@@ -7,11 +7,13 @@
 // schemas. With this package developers may define an Analyzer that can be used
 // to diagnose the impact of SQL statements on the target database. For instance,
 // The `destructive` package exposes an Analyzer that detects destructive changes
-// to the database schema, such as the dropping of tables or columns.
+// to the database schema, such as the dropping of tables or columns. A StateAnalyzer
+// diagnoses the desired schema itself, such as tables without a primary key.
 package sqlcheck
 
 import (
 	"context"
+	"sort"
 	"sync"
 
 	"ariga.io/atlas/schemahcl"
@@ -35,12 +37,34 @@ type (
 		Name() string
 	}
 
+	// A StateAnalyzer describes an analyzer of a schema state. Unlike an Analyzer, it
+	// checks the desired state itself, and not the changes made to reach it.
+	StateAnalyzer interface {
+		// AnalyzeState executes the analysis function.
+		AnalyzeState(context.Context, *StatePass) error
+	}
+
 	// A Pass provides information to the Run function that
 	// applies a specific analyzer to an SQL file.
 	Pass struct {
 		// A migration file and the changes it describes.
 		File *File
 
+		// Dev is a driver-specific environment used to execute analysis work.
+		// It might be nil in case the changes are analyzed without a dev database
+		// (e.g., on 'schema apply'), and checks that require it should be skipped.
+		Dev *sqlclient.Client
+
+		// Report reports analysis reports.
+		Reporter ReportWriter
+	}
+
+	// A StatePass provides information to the AnalyzeState
+	// function that applies a specific analyzer to a schema state.
+	StatePass struct {
+		// Realm holds the schema state to analyze.
+		Realm *schema.Realm
+
 		// Dev is a driver-specific environment used to execute analysis work.
 		Dev *sqlclient.Client
 
@@ -86,6 +110,9 @@ type (
 		Pos  int    // Diagnostic position.
 		Text string // Diagnostic text.
 		Code string // Code describes the check. For example, DS101
+		// Severity of the diagnostic. For example, SeverityWarning.
+		// Empty, unless configured explicitly by the user.
+		Severity string `json:"Severity,omitempty"`
 	}
 
 	// ReportWriter represents a writer for analysis reports.
@@ -104,6 +131,13 @@ type (
 	}
 )
 
+// List of diagnostic severity levels.
+const (
+	SeverityError   = "error"   // Diagnostic fails the analysis.
+	SeverityWarning = "warning" // Diagnostic is reported, but does not fail the analysis.
+	SeverityInfo    = "info"    // Diagnostic is informational only.
+)
+
 // Analyzers implements Analyzer.
 type Analyzers []Analyzer
 
@@ -125,6 +159,14 @@ func (f AnalyzerFunc) Analyze(ctx context.Context, p *Pass) error {
 	return f(ctx, p)
 }
 
+// StateAnalyzerFunc allows using ordinary functions as state analyzers.
+type StateAnalyzerFunc func(ctx context.Context, p *StatePass) error
+
+// AnalyzeState calls f.
+func (f StateAnalyzerFunc) AnalyzeState(ctx context.Context, p *StatePass) error {
+	return f(ctx, p)
+}
+
 // ReportWriterFunc is a function that implements Reporter.
 type ReportWriterFunc func(Report)
 
@@ -249,18 +291,62 @@ func (f *File) tableSpan(t *schema.Table) *tableSpan {
 	return f.spans[t.Schema.Name].tables[t.Name]
 }
 
+// drivers specific state analyzers.
+var stateDrivers sync.Map
+
+// RegisterState allows drivers to register a constructor function for
+// creating state analyzers from the given HCL resource.
+func RegisterState(name string, f func(*schemahcl.Resource) ([]StateAnalyzer, error)) {
+	stateDrivers.Store(name, f)
+}
+
+// StateAnalyzerFor instantiates a new StateAnalyzer from the given HCL
+// resource based on the registered constructor function.
+func StateAnalyzerFor(name string, r *schemahcl.Resource) ([]StateAnalyzer, error) {
+	f, ok := stateDrivers.Load(name)
+	if ok {
+		return f.(func(*schemahcl.Resource) ([]StateAnalyzer, error))(r)
+	}
+	return nil, nil
+}
+
 // codes registry
 var codes sync.Map
 
 // Code stores the given code in the registry.
 // It protects from duplicate analyzers' codes.
 func Code(code string) string {
-	if _, loaded := codes.LoadOrStore(code, struct{}{}); loaded {
+	return CodeWithText(code, "")
+}
+
+// CodeWithText stores the given code and its short description
+// in the registry. It protects from duplicate analyzers' codes.
+func CodeWithText(code, text string) string {
+	if _, loaded := codes.LoadOrStore(code, text); loaded {
 		panic("sqlcheck: Code called twice for " + code)
 	}
 	return code
 }
 
+// CodeInfo describes a registered analyzer code.
+type CodeInfo struct {
+	Code string // Code of the diagnostic, e.g. DS101.
+	Text string // Short description of the code.
+}
+
+// Codes returns all registered codes sorted by their names.
+func Codes() []CodeInfo {
+	var cs []CodeInfo
+	codes.Range(func(k, v any) bool {
+		cs = append(cs, CodeInfo{Code: k.(string), Text: v.(string)})
+		return true
+	})
+	sort.Slice(cs, func(i, j int) bool {
+		return cs[i].Code < cs[j].Code
+	})
+	return cs
+}
+
 // drivers specific analyzers.
 var drivers sync.Map
 
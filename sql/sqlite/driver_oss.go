@@ -8,29 +8,304 @@ package sqlite
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
 
 	"ariga.io/atlas/schemahcl"
+	"ariga.io/atlas/sql/internal/sqlx"
+	"ariga.io/atlas/sql/migrate"
 	"ariga.io/atlas/sql/schema"
 )
 
 var specOptions []schemahcl.Option
 
-func (*inspect) inspectViews(context.Context, *schema.Realm, *schema.InspectOptions) error {
-	return nil // unimplemented.
+// inspectViews queries and appends the views of the given realm, including their columns.
+func (i *inspect) inspectViews(ctx context.Context, r *schema.Realm, _ *schema.InspectOptions) error {
+	for _, s := range r.Schemas {
+		if err := i.views(ctx, s); err != nil {
+			return err
+		}
+		for _, v := range s.Views {
+			if err := i.viewColumns(ctx, v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
-func (*state) addView(*schema.AddView) error {
-	return nil // unimplemented.
+// reViewDef extracts the view definition (the SELECT statement) from its 'CREATE VIEW' statement.
+var reViewDef = regexp.MustCompile(`(?is)^\s*CREATE\s+(?:TEMP\s+|TEMPORARY\s+)?VIEW\s+(?:IF\s+NOT\s+EXISTS\s+)?.+?\s+AS\s+(.+)$`)
+
+// views queries and appends the views of the given schema.
+func (i *inspect) views(ctx context.Context, s *schema.Schema) error {
+	rows, err := i.QueryContext(ctx, viewsQuery)
+	if err != nil {
+		return fmt.Errorf("sqlite: querying views: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name, stmt string
+		if err := rows.Scan(&name, &stmt); err != nil {
+			return fmt.Errorf("sqlite: scanning view information: %w", err)
+		}
+		matches := reViewDef.FindStringSubmatch(stmt)
+		if len(matches) != 2 {
+			return fmt.Errorf("sqlite: unexpected definition for view %q: %q", name, stmt)
+		}
+		s.AddViews(schema.NewView(name, matches[1]))
+	}
+	return rows.Close()
+}
+
+// viewColumns queries and appends the columns of the given view.
+func (i *inspect) viewColumns(ctx context.Context, v *schema.View) error {
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(columnsQuery, v.Name))
+	if err != nil {
+		return fmt.Errorf("sqlite: querying view %q columns: %w", v.Name, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			nullable, primary   bool
+			hidden              sql.NullInt64
+			name, typ, defaults sql.NullString
+		)
+		if err := rows.Scan(&name, &typ, &nullable, &defaults, &primary, &hidden); err != nil {
+			return fmt.Errorf("sqlite: scanning view %q column: %w", v.Name, err)
+		}
+		c := &schema.Column{
+			Name: name.String,
+			Type: &schema.ColumnType{
+				Raw:  typ.String,
+				Null: nullable,
+			},
+		}
+		if c.Type.Type, err = ParseType(typ.String); err != nil {
+			return fmt.Errorf("sqlite: %w", err)
+		}
+		v.AddColumns(c)
+	}
+	return rows.Close()
+}
+
+// addView builds and executes the query for creating a view.
+func (s *state) addView(add *schema.AddView) error {
+	b := s.Build("CREATE VIEW")
+	if sqlx.Has(add.Extra, &schema.IfNotExists{}) {
+		b.P("IF NOT EXISTS")
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.View(add.V).P("AS", sqlx.TrimViewExtra(add.V.Def)).String(),
+		Source:  add,
+		Comment: fmt.Sprintf("create %q view", add.V.Name),
+		Reverse: s.Build("DROP VIEW").View(add.V).String(),
+	})
+	return nil
+}
+
+// dropView builds and executes the query for dropping a view.
+func (s *state) dropView(drop *schema.DropView) error {
+	b := s.Build("DROP VIEW")
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.View(drop.V).String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop %q view", drop.V.Name),
+		Reverse: s.createView(drop.V),
+	})
+	return nil
+}
+
+// modifyView builds and executes the queries for modifying a view.
+// SQLite does not support ALTER VIEW, and therefore, the view is
+// dropped and created with its new definition.
+func (s *state) modifyView(modify *schema.ModifyView) error {
+	s.replaceView(modify, modify.From, modify.To)
+	return nil
+}
+
+// renameView builds and executes the queries for renaming a view.
+// SQLite does not support renaming views, and therefore, the view
+// is dropped and created with its new name.
+func (s *state) renameView(rename *schema.RenameView) error {
+	s.replaceView(rename, rename.From, rename.To)
+	return nil
+}
+
+// replaceView drops the "from" view and creates the "to" view.
+func (s *state) replaceView(c schema.Change, from, to *schema.View) {
+	s.append(&migrate.Change{
+		Cmd:     s.Build("DROP VIEW").View(from).String(),
+		Source:  c,
+		Comment: fmt.Sprintf("drop %q view", from.Name),
+		Reverse: s.createView(from),
+	})
+	s.append(&migrate.Change{
+		Cmd:     s.createView(to),
+		Source:  c,
+		Comment: fmt.Sprintf("create %q view", to.Name),
+		Reverse: s.Build("DROP VIEW").View(to).String(),
+	})
+}
+
+// createView returns the statement for creating the view.
+func (s *state) createView(v *schema.View) string {
+	return s.Build("CREATE VIEW").View(v).P("AS", sqlx.TrimViewExtra(v.Def)).String()
+}
+
+// inspectTriggers queries and appends the triggers of the tables and views in the given realm.
+func (i *inspect) inspectTriggers(ctx context.Context, r *schema.Realm, _ *schema.InspectOptions) error {
+	for _, s := range r.Schemas {
+		if len(s.Tables) == 0 && len(s.Views) == 0 {
+			continue
+		}
+		if err := i.triggers(ctx, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reTriggerDef parses the 'CREATE TRIGGER' statement of a trigger. Note that
+// the table name, and the columns of the UPDATE OF event, are not qualified.
+var reTriggerDef = regexp.MustCompile(`(?is)^\s*CREATE\s+(?:TEMP\s+|TEMPORARY\s+)?TRIGGER\s+(?:IF\s+NOT\s+EXISTS\s+)?.+?\s+(BEFORE|AFTER|INSTEAD\s+OF)?\s*(DELETE|INSERT|UPDATE)(?:\s+OF\s+(.+?))?\s+ON\s+.+?\s+(FOR\s+EACH\s+ROW\s+)?(?:WHEN\s+(.+?)\s+)?(BEGIN\s.+)$`)
+
+// triggers queries and appends the triggers of the given schema.
+func (i *inspect) triggers(ctx context.Context, s *schema.Schema) error {
+	rows, err := i.QueryContext(ctx, triggersQuery)
+	if err != nil {
+		return fmt.Errorf("sqlite: querying triggers: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name, tName, stmt string
+		if err := rows.Scan(&name, &tName, &stmt); err != nil {
+			return fmt.Errorf("sqlite: scanning trigger information: %w", err)
+		}
+		matches := reTriggerDef.FindStringSubmatch(stmt)
+		if len(matches) != 7 {
+			return fmt.Errorf("sqlite: unexpected definition for trigger %q: %q", name, stmt)
+		}
+		t := schema.NewTrigger(name).
+			SetActionTime(schema.TriggerTimeBefore).
+			SetFor(schema.TriggerForRow).
+			SetWhen(matches[5]).
+			SetBody(strings.TrimSpace(matches[6]))
+		if at := matches[1]; at != "" {
+			t.SetActionTime(schema.TriggerTime(strings.Join(strings.Fields(strings.ToUpper(at)), " ")))
+		}
+		e := schema.TriggerEvent{Name: strings.ToUpper(matches[2])}
+		// Triggers of tables or views that were not inspected are ignored.
+		if v, ok := s.View(tName); ok {
+			t.AddEvents(e)
+			v.AddTriggers(t)
+			continue
+		}
+		tt, ok := s.Table(tName)
+		if !ok {
+			continue
+		}
+		if matches[3] != "" {
+			for _, name := range strings.Split(matches[3], ",") {
+				name = unquoteIdent(strings.TrimSpace(name))
+				c, ok := tt.Column(name)
+				if !ok {
+					return fmt.Errorf("sqlite: column %q of trigger %q was not found in table %q", name, t.Name, tt.Name)
+				}
+				e.Columns = append(e.Columns, c)
+			}
+		}
+		t.AddEvents(e)
+		tt.AddTriggers(t)
+	}
+	return rows.Close()
+}
+
+// unquoteIdent removes the quotes of the given identifier, if exist.
+func unquoteIdent(s string) string {
+	if n := len(s) - 1; n > 0 && (s[0] == '"' && s[n] == '"' || s[0] == '`' && s[n] == '`' || s[0] == '[' && s[n] == ']') {
+		return s[1:n]
+	}
+	return s
 }
 
-func (*state) dropView(*schema.DropView) error {
-	return nil // unimplemented.
+// addTrigger builds and executes the query for creating a trigger.
+func (s *state) addTrigger(add *schema.AddTrigger) error {
+	create, err := s.createTrigger(add.T)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     create,
+		Source:  add,
+		Comment: fmt.Sprintf("create %q trigger", add.T.Name),
+		Reverse: s.Build("DROP TRIGGER").Trigger(add.T).String(),
+	})
+	return nil
 }
 
-func (*state) modifyView(*schema.ModifyView) error {
-	return nil // unimplemented.
+// dropTrigger builds and executes the query for dropping a trigger.
+func (s *state) dropTrigger(drop *schema.DropTrigger) error {
+	create, err := s.createTrigger(drop.T)
+	if err != nil {
+		return err
+	}
+	b := s.Build("DROP TRIGGER")
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.Trigger(drop.T).String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop %q trigger", drop.T.Name),
+		Reverse: create,
+	})
+	return nil
 }
 
-func (*state) renameView(*schema.RenameView) error {
-	return nil // unimplemented.
+// createTrigger returns the statement for creating the trigger.
+func (s *state) createTrigger(t *schema.Trigger) (string, error) {
+	var on string
+	switch {
+	case len(t.Events) != 1:
+		return "", fmt.Errorf("sqlite: trigger %q must have exactly one event, got %d", t.Name, len(t.Events))
+	case t.Body == "":
+		return "", fmt.Errorf("sqlite: missing body for trigger %q", t.Name)
+	case t.Table != nil:
+		on = t.Table.Name
+	case t.View != nil:
+		on = t.View.Name
+	default:
+		return "", fmt.Errorf("sqlite: missing table or view for trigger %q", t.Name)
+	}
+	b := s.Build("CREATE TRIGGER").Trigger(t)
+	if t.ActionTime != "" {
+		b.P(string(t.ActionTime))
+	}
+	b.P(t.Events[0].Name)
+	if cs := t.Events[0].Columns; len(cs) > 0 {
+		b.P("OF").MapComma(cs, func(i int, b *sqlx.Builder) {
+			b.Ident(cs[i].Name)
+		})
+	}
+	// The table name cannot be qualified in SQLite triggers.
+	b.P("ON").Ident(on)
+	if t.For != "" {
+		b.P("FOR EACH", string(t.For))
+	}
+	if t.When != "" {
+		b.P("WHEN", t.When)
+	}
+	return b.P(t.Body).String(), nil
 }
+
+// Query to list database views.
+const viewsQuery = "SELECT `name`, `sql` FROM sqlite_master WHERE `type` = 'view' AND `name` NOT LIKE 'sqlite_%' ORDER BY `name`"
+
+// Query to list database triggers.
+const triggersQuery = "SELECT `name`, `tbl_name`, `sql` FROM sqlite_master WHERE `type` = 'trigger' ORDER BY `name`"
@@ -0,0 +1,300 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+//go:build !ent
+
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"ariga.io/atlas/sql/internal/sqltest"
+	"ariga.io/atlas/sql/schema"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDriver_InspectViews(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.systemVars("3.36.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(databasesQueryArgs, "?"))).
+		WithArgs("main").
+		WillReturnRows(sqltest.Rows(`
+ name |   file    
+------+-----------
+ main |   
+`))
+	m.ExpectQuery(sqltest.Escape(viewsQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "sql"}).
+			AddRow("v1", "CREATE VIEW v1 AS SELECT id FROM t1").
+			AddRow("v2", "CREATE VIEW IF NOT EXISTS `v2` as\nSELECT id\nFROM v1"))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(columnsQuery, "v1"))).
+		WillReturnRows(sqltest.Rows(`
+ name |   type       | nullable | dflt_value  | primary  | hidden
+------+--------------+----------+ ------------+----------+----------
+ id   | integer      |  1       |             |  0       |  0
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(columnsQuery, "v2"))).
+		WillReturnRows(sqltest.Rows(`
+ name |   type       | nullable | dflt_value  | primary  | hidden
+------+--------------+----------+ ------------+----------+----------
+ id   | integer      |  1       |             |  0       |  0
+`))
+	s, err := drv.InspectSchema(context.Background(), "", &schema.InspectOptions{
+		Mode: schema.InspectViews,
+	})
+	require.NoError(t, err)
+	require.Len(t, s.Views, 2)
+	require.Equal(t, "v1", s.Views[0].Name)
+	require.Equal(t, "SELECT id FROM t1", s.Views[0].Def)
+	require.Equal(t, []*schema.Column{
+		{Name: "id", Type: &schema.ColumnType{Raw: "integer", Null: true, Type: &schema.IntegerType{T: "integer"}}},
+	}, s.Views[0].Columns)
+	require.Equal(t, "v2", s.Views[1].Name)
+	require.Equal(t, "SELECT id\nFROM v1", s.Views[1].Def)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestPlanChanges_Views(t *testing.T) {
+	var (
+		s  = schema.New("main")
+		t1 = schema.NewTable("t1").AddColumns(schema.NewIntColumn("id", "integer"))
+		// Inspected views do not hold their dependencies.
+		v1 = schema.NewView("v1", "SELECT id FROM t1")
+		v2 = schema.NewView("v2", "SELECT id FROM v1").AddDeps(v1)
+		v3 = schema.NewView("v3", "SELECT 1 AS t1_id")
+	)
+	s.AddTables(t1).AddViews(v1, v2, v3)
+	tests := []struct {
+		changes []schema.Change
+		want    []string
+	}{
+		{
+			changes: []schema.Change{
+				&schema.AddView{V: v2},
+				&schema.AddView{V: v1},
+				&schema.DropView{V: schema.NewView("v4", "SELECT 1")},
+			},
+			want: []string{
+				"DROP VIEW `v4`",
+				"CREATE VIEW `v1` AS SELECT id FROM t1",
+				"CREATE VIEW `v2` AS SELECT id FROM v1",
+			},
+		},
+		// SQLite does not support ALTER VIEW.
+		{
+			changes: []schema.Change{
+				&schema.ModifyView{From: v3, To: schema.NewView("v3", "SELECT 2 AS t1_id")},
+				&schema.RenameView{From: v1, To: schema.NewView("v5", v1.Def)},
+			},
+			want: []string{
+				"DROP VIEW `v3`",
+				"CREATE VIEW `v3` AS SELECT 2 AS t1_id",
+				"DROP VIEW `v1`",
+				"CREATE VIEW `v5` AS SELECT id FROM t1",
+			},
+		},
+		// Views that depend on copied tables are dropped
+		// before the table changes, and created after them.
+		{
+			changes: []schema.Change{
+				&schema.ModifyTable{
+					T: t1,
+					Changes: []schema.Change{
+						&schema.DropColumn{C: schema.NewIntColumn("c", "integer")},
+					},
+				},
+				&schema.ModifyView{From: v2, To: schema.NewView("v2", "SELECT id, 1 AS c FROM v1").AddDeps(v1)},
+			},
+			want: []string{
+				"PRAGMA foreign_keys = off",
+				"DROP VIEW `v2`",
+				"DROP VIEW `v1`",
+				"CREATE TABLE `new_t1` (`id` integer NOT NULL)",
+				"INSERT INTO `new_t1` (`id`) SELECT `id` FROM `t1`",
+				"DROP TABLE `t1`",
+				"ALTER TABLE `new_t1` RENAME TO `t1`",
+				"CREATE VIEW `v1` AS SELECT id FROM t1",
+				"CREATE VIEW `v2` AS SELECT id, 1 AS c FROM v1",
+				"PRAGMA foreign_keys = on",
+			},
+		},
+		// Views are not affected by ALTER TABLE commands.
+		{
+			changes: []schema.Change{
+				&schema.ModifyTable{
+					T: t1,
+					Changes: []schema.Change{
+						&schema.AddColumn{C: schema.NewNullIntColumn("c", "integer")},
+					},
+				},
+			},
+			want: []string{
+				"ALTER TABLE `t1` ADD COLUMN `c` integer NULL",
+			},
+		},
+	}
+	for _, tt := range tests {
+		db, m, err := sqlmock.New()
+		require.NoError(t, err)
+		mock{m}.systemVars("3.36.0")
+		drv, err := Open(db)
+		require.NoError(t, err)
+		plan, err := drv.PlanChanges(context.Background(), "plan", tt.changes)
+		require.NoError(t, err)
+		cmds := make([]string, len(plan.Changes))
+		for i, c := range plan.Changes {
+			cmds[i] = c.Cmd
+		}
+		require.Equal(t, tt.want, cmds)
+	}
+}
+
+func TestDriver_InspectTriggers(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.systemVars("3.36.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.tableExists("users", true, "CREATE TABLE users(a int, b int)")
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(columnsQuery, "users"))).
+		WillReturnRows(sqltest.Rows(`
+ name |   type       | nullable | dflt_value  | primary  | hidden
+------+--------------+----------+ ------------+----------+----------
+ a    | int          |  1       |             |  0       |  0
+ b    | int          |  1       |             |  0       |  0
+`))
+	mk.noIndexes("users")
+	mk.noFKs("users")
+	m.ExpectQuery(sqltest.Escape(triggersQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "tbl_name", "sql"}).
+			AddRow("tr0", "other", "CREATE TRIGGER tr0 INSERT ON other BEGIN SELECT 1; END").
+			AddRow("tr1", "users", "CREATE TRIGGER tr1 AFTER UPDATE OF b, \"a\" ON users FOR EACH ROW WHEN NEW.a > 0 BEGIN\n UPDATE users SET b = 1;\nEND").
+			AddRow("tr2", "users", "CREATE TRIGGER IF NOT EXISTS `tr2` INSERT ON `users` BEGIN SELECT 1; END"))
+	s, err := drv.InspectSchema(context.Background(), "", &schema.InspectOptions{
+		Tables: []string{"users"},
+		Mode:   schema.InspectTables | schema.InspectTriggers,
+	})
+	require.NoError(t, err)
+	users, ok := s.Table("users")
+	require.True(t, ok)
+	a, _ := users.Column("a")
+	b, _ := users.Column("b")
+	// Triggers of uninspected tables are ignored.
+	require.Len(t, users.Triggers, 2)
+	tr1, ok := users.Trigger("tr1")
+	require.True(t, ok)
+	require.Equal(t, users, tr1.Table)
+	require.Equal(t, schema.TriggerTimeAfter, tr1.ActionTime)
+	require.Equal(t, schema.TriggerForRow, tr1.For)
+	require.Equal(t, []schema.TriggerEvent{schema.TriggerEventUpdateOf(b, a)}, tr1.Events)
+	require.Equal(t, "NEW.a > 0", tr1.When)
+	require.Equal(t, "BEGIN\n UPDATE users SET b = 1;\nEND", tr1.Body)
+	tr2, ok := users.Trigger("tr2")
+	require.True(t, ok)
+	require.Equal(t, schema.TriggerTimeBefore, tr2.ActionTime)
+	require.Equal(t, schema.TriggerForRow, tr2.For)
+	require.Equal(t, []schema.TriggerEvent{schema.TriggerEventInsert}, tr2.Events)
+	require.Empty(t, tr2.When)
+	require.Equal(t, "BEGIN SELECT 1; END", tr2.Body)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestPlanChanges_Triggers(t *testing.T) {
+	var (
+		s  = schema.New("main")
+		c1 = schema.NewIntColumn("id", "integer")
+		t1 = schema.NewTable("t1").AddColumns(c1)
+		v1 = schema.NewView("v1", "SELECT id FROM t1")
+		tr = schema.NewTrigger("tr1").
+			SetActionTime(schema.TriggerTimeAfter).
+			AddEvents(schema.TriggerEventUpdateOf(c1)).
+			SetFor(schema.TriggerForRow).
+			SetWhen("NEW.id > 0").
+			SetBody("BEGIN SELECT 1; END")
+		tv = schema.NewTrigger("tr2").
+			SetActionTime(schema.TriggerTimeInstead).
+			AddEvents(schema.TriggerEventInsert).
+			SetBody("BEGIN SELECT 2; END")
+	)
+	s.AddTables(t1.AddTriggers(tr)).AddViews(v1.AddTriggers(tv))
+	tests := []struct {
+		changes []schema.Change
+		want    []string
+	}{
+		{
+			changes: []schema.Change{
+				&schema.AddTrigger{T: tv},
+				&schema.DropTrigger{T: tr},
+			},
+			want: []string{
+				"DROP TRIGGER `tr1`",
+				"CREATE TRIGGER `tr2` INSTEAD OF INSERT ON `v1` BEGIN SELECT 2; END",
+			},
+		},
+		// SQLite does not support altering triggers.
+		{
+			changes: []schema.Change{
+				&schema.ModifyTrigger{
+					From: tr,
+					To: func() *schema.Trigger {
+						to := *tr
+						to.When = ""
+						return &to
+					}(),
+				},
+			},
+			want: []string{
+				"DROP TRIGGER `tr1`",
+				"CREATE TRIGGER `tr1` AFTER UPDATE OF `id` ON `t1` FOR EACH ROW BEGIN SELECT 1; END",
+			},
+		},
+		// Triggers of copied tables, and of the views that were
+		// recreated with them, are created after the table changes.
+		{
+			changes: []schema.Change{
+				&schema.ModifyTable{
+					T: t1,
+					Changes: []schema.Change{
+						&schema.DropColumn{C: schema.NewIntColumn("c", "integer")},
+					},
+				},
+			},
+			want: []string{
+				"PRAGMA foreign_keys = off",
+				"DROP VIEW `v1`",
+				"CREATE TABLE `new_t1` (`id` integer NOT NULL)",
+				"INSERT INTO `new_t1` (`id`) SELECT `id` FROM `t1`",
+				"DROP TABLE `t1`",
+				"ALTER TABLE `new_t1` RENAME TO `t1`",
+				"CREATE VIEW `v1` AS SELECT id FROM t1",
+				"CREATE TRIGGER `tr1` AFTER UPDATE OF `id` ON `t1` FOR EACH ROW WHEN NEW.id > 0 BEGIN SELECT 1; END",
+				"CREATE TRIGGER `tr2` INSTEAD OF INSERT ON `v1` BEGIN SELECT 2; END",
+				"PRAGMA foreign_keys = on",
+			},
+		},
+	}
+	for _, tt := range tests {
+		db, m, err := sqlmock.New()
+		require.NoError(t, err)
+		mock{m}.systemVars("3.36.0")
+		drv, err := Open(db)
+		require.NoError(t, err)
+		plan, err := drv.PlanChanges(context.Background(), "plan", tt.changes)
+		require.NoError(t, err)
+		cmds := make([]string, len(plan.Changes))
+		for i, c := range plan.Changes {
+			cmds[i] = c.Cmd
+		}
+		require.Equal(t, tt.want, cmds)
+	}
+}
@@ -7,6 +7,7 @@ package sqlite
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"ariga.io/atlas/sql/internal/sqlx"
@@ -76,7 +77,33 @@ type state struct {
 // Exec executes the changes on the database. An error is returned
 // if one of the operations fail, or a change is not supported.
 func (s *state) plan(ctx context.Context, changes []schema.Change) (err error) {
+	var tables, views, triggers []schema.Change
 	for _, c := range changes {
+		switch c.(type) {
+		case *schema.AddView, *schema.DropView, *schema.ModifyView, *schema.RenameView:
+			views = append(views, c)
+		case *schema.AddTrigger, *schema.DropTrigger, *schema.ModifyTrigger:
+			triggers = append(triggers, c)
+		default:
+			tables = append(tables, c)
+		}
+	}
+	pre, post, err := planViews(tables, views)
+	if err != nil {
+		return err
+	}
+	dropTr, addTr := planTriggers(tables, post, triggers)
+	for _, c := range dropTr {
+		if err := s.dropTrigger(c); err != nil {
+			return err
+		}
+	}
+	for _, c := range pre {
+		if err := s.dropView(c.(*schema.DropView)); err != nil {
+			return err
+		}
+	}
+	for _, c := range tables {
 		switch c := c.(type) {
 		case *schema.AddTable:
 			err = s.addTable(ctx, c)
@@ -86,24 +113,215 @@ func (s *state) plan(ctx context.Context, changes []schema.Change) (err error) {
 			err = s.modifyTable(ctx, c)
 		case *schema.RenameTable:
 			s.renameTable(c)
+		default:
+			err = fmt.Errorf("unsupported change %T", c)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for _, c := range post {
+		switch c := c.(type) {
 		case *schema.AddView:
 			err = s.addView(c)
-		case *schema.DropView:
-			err = s.dropView(c)
 		case *schema.ModifyView:
 			err = s.modifyView(c)
 		case *schema.RenameView:
 			err = s.renameView(c)
-		default:
-			err = fmt.Errorf("unsupported change %T", c)
 		}
 		if err != nil {
 			return err
 		}
 	}
+	for _, c := range addTr {
+		if err := s.addTrigger(c); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// planViews splits the view changes into the ones that are planned before the table
+// changes (view drops), and the ones that are planned after them. SQLite validates the
+// views in the schema when a table is renamed, the last step of the table-copy strategy
+// used by modifyTable. Hence, views that depend (directly or transitively) on copied
+// tables are dropped before the table changes and created again after them.
+func planViews(tables, views []schema.Change) (pre, post []schema.Change, err error) {
+	var (
+		schemas []*schema.Schema
+		deps    = make(depNames)
+	)
+	for _, c := range tables {
+		if m, ok := c.(*schema.ModifyTable); ok && !alterable(m) {
+			deps.add(m.T.Name)
+			if m.T.Schema != nil {
+				schemas = append(schemas, m.T.Schema)
+			}
+		}
+	}
+	if len(deps) > 0 {
+		var candidates []*schema.View
+		for _, s := range schemas {
+			candidates = append(candidates, s.Views...)
+		}
+		for _, c := range views {
+			switch c := c.(type) {
+			case *schema.ModifyView:
+				candidates = append(candidates, c.From)
+			case *schema.RenameView:
+				candidates = append(candidates, c.From)
+			}
+		}
+		// Loop until no more dependent views are found.
+		for found := true; found; {
+			found = false
+			for _, v := range candidates {
+				if !deps.has(v.Name) && viewDependsOn(v, deps) {
+					deps.add(v.Name)
+					found = true
+				}
+			}
+		}
+		seen := make(map[string]bool)
+		expanded := make([]schema.Change, 0, len(views))
+		for _, c := range views {
+			switch c := c.(type) {
+			case *schema.AddView:
+				seen[c.V.Name] = true
+			case *schema.DropView:
+				seen[c.V.Name] = true
+			case *schema.ModifyView:
+				seen[c.From.Name], seen[c.To.Name] = true, true
+				if deps.has(c.From.Name) || deps.has(c.To.Name) {
+					expanded = append(expanded, &schema.DropView{V: c.From}, &schema.AddView{V: c.To})
+					continue
+				}
+			case *schema.RenameView:
+				seen[c.From.Name], seen[c.To.Name] = true, true
+				if deps.has(c.From.Name) || deps.has(c.To.Name) {
+					expanded = append(expanded, &schema.DropView{V: c.From}, &schema.AddView{V: c.To})
+					continue
+				}
+			}
+			expanded = append(expanded, c)
+		}
+		// Unchanged views that depend on copied tables.
+		for _, s := range schemas {
+			for _, v := range s.Views {
+				if deps.has(v.Name) && !seen[v.Name] {
+					seen[v.Name] = true
+					expanded = append(expanded, &schema.DropView{V: v}, &schema.AddView{V: v})
+				}
+			}
+		}
+		views = expanded
+	}
+	if views, err = sqlx.PlanViewChanges(views); err != nil {
+		return nil, nil, err
+	}
+	for _, c := range views {
+		if _, ok := c.(*schema.DropView); ok {
+			pre = append(pre, c)
+		} else {
+			post = append(post, c)
+		}
+	}
+	return pre, post, nil
+}
+
+// planTriggers splits the trigger changes into the ones that are planned before the
+// table and view changes (trigger drops), and the ones that are planned after them.
+// SQLite does not support altering triggers, and triggers are dropped along with
+// their tables and views. Hence, modified triggers are dropped and created again,
+// and the triggers of copied tables and recreated views are created again as well.
+func planTriggers(tables, views, triggers []schema.Change) (drops []*schema.DropTrigger, adds []*schema.AddTrigger) {
+	// Trigger names are unique in the schema.
+	added := make(map[string]bool)
+	add := func(t *schema.Trigger) {
+		if !added[t.Name] {
+			added[t.Name] = true
+			adds = append(adds, &schema.AddTrigger{T: t})
+		}
+	}
+	for _, c := range triggers {
+		switch c := c.(type) {
+		case *schema.DropTrigger:
+			drops = append(drops, c)
+		case *schema.ModifyTrigger:
+			drops = append(drops, &schema.DropTrigger{T: c.From})
+			add(c.To)
+		case *schema.AddTrigger:
+			add(c.T)
+		}
+	}
+	for _, c := range tables {
+		if m, ok := c.(*schema.ModifyTable); ok && !alterable(m) {
+			for _, t := range m.T.Triggers {
+				add(t)
+			}
+		}
+	}
+	for _, c := range views {
+		var v *schema.View
+		switch c := c.(type) {
+		case *schema.AddView:
+			v = c.V
+		case *schema.ModifyView:
+			v = c.To
+		case *schema.RenameView:
+			v = c.To
+		}
+		if v != nil {
+			for _, t := range v.Triggers {
+				add(t)
+			}
+		}
+	}
+	return drops, adds
+}
+
+// depNames holds the names of the tables and views that views may depend on,
+// along with the matchers used to find these names in view definitions.
+type depNames map[string]*regexp.Regexp
+
+// add adds the given name to the set and compiles its matcher.
+func (d depNames) add(name string) {
+	d[name] = regexp.MustCompile(`(?i)(^|[^\w$])` + regexp.QuoteMeta(name) + `([^\w$]|$)`)
+}
+
+// has reports if the given name exists in the set.
+func (d depNames) has(name string) bool {
+	_, ok := d[name]
+	return ok
+}
+
+// viewDependsOn reports if the view depends on one of the given tables or views.
+func viewDependsOn(v *schema.View, names depNames) bool {
+	if len(v.Deps) > 0 {
+		for _, d := range v.Deps {
+			switch d := d.(type) {
+			case *schema.Table:
+				if names.has(d.Name) {
+					return true
+				}
+			case *schema.View:
+				if names.has(d.Name) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	// SQLite does not track view dependencies. Hence, in case they were
+	// not defined, we search for the object names in the view definition.
+	for _, m := range names {
+		if m.MatchString(v.Def) {
+			return true
+		}
+	}
+	return false
+}
+
 // addTable builds and executes the query for creating a table in a schema.
 func (s *state) addTable(ctx context.Context, add *schema.AddTable) error {
 	var (
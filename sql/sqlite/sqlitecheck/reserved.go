@@ -0,0 +1,27 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sqlitecheck
+
+import "strings"
+
+// isReserved reports if the given identifier is a reserved word in SQLite.
+func isReserved(name string) bool {
+	return reserved[strings.ToUpper(name)]
+}
+
+// reserved holds the SQLite keywords that cannot be used as unquoted identifiers.
+// See: https://www.sqlite.org/lang_keywords.html
+var reserved = func(words ...string) map[string]bool {
+	m := make(map[string]bool, len(words))
+	for _, w := range words {
+		m[w] = true
+	}
+	return m
+}(strings.Fields(`
+ADD ALL ALTER AND AS AUTOINCREMENT BETWEEN CASE CHECK COLLATE COMMIT CONSTRAINT CREATE DEFAULT
+DEFERRABLE DELETE DISTINCT DROP ELSE ESCAPE EXCEPT EXISTS FOREIGN FROM GROUP HAVING IN INDEX INSERT
+INTERSECT INTO IS ISNULL JOIN LIMIT NOT NOTNULL NULL ON OR ORDER PRIMARY REFERENCES SELECT SET
+TABLE THEN TO TRANSACTION UNION UNIQUE UPDATE USING VALUES WHEN WHERE
+`)...)
@@ -15,6 +15,7 @@ import (
 	"ariga.io/atlas/sql/sqlcheck"
 	"ariga.io/atlas/sql/sqlcheck/condrop"
 	"ariga.io/atlas/sql/sqlcheck/datadepend"
+	"ariga.io/atlas/sql/sqlcheck/design"
 	"ariga.io/atlas/sql/sqlcheck/destructive"
 	"ariga.io/atlas/sql/sqlcheck/incompatible"
 	"ariga.io/atlas/sql/sqlcheck/naming"
@@ -22,7 +23,7 @@ import (
 )
 
 // codeModNotNullC is an SQLite specific code for reporting modifying nullable columns to non-nullable.
-var codeModNotNullC = sqlcheck.Code("LT101")
+var codeModNotNullC = sqlcheck.CodeWithText("LT101", "Modifying a nullable column to non-nullable without a DEFAULT value")
 
 func addNotNull(p *datadepend.ColumnPass) (diags []sqlcheck.Diagnostic, err error) {
 	tt, err := sqlite.FormatType(p.Column.Type.Type)
@@ -93,7 +94,13 @@ func init() {
 						changes = append(changes, p.File.Changes[i])
 						continue
 					}
-					diff, err := p.Dev.Driver.TableDiff(prevT, currT)
+					// Diffing tables does not require a database connection,
+					// and the default differ is used in case no dev database was given.
+					differ := sqlite.DefaultDiff
+					if p.Dev != nil {
+						differ = p.Dev.Driver
+					}
+					diff, err := differ.TableDiff(prevT, currT)
 					if err != nil {
 						return nil
 					}
@@ -123,6 +130,19 @@ func init() {
 			ds, dd, cd, bc, nm,
 		}, nil
 	})
+	sqlcheck.RegisterState(sqlite.DriverName, func(r *schemahcl.Resource) ([]sqlcheck.StateAnalyzer, error) {
+		de, err := design.New(r, design.Handler{
+			Reserved: isReserved,
+		})
+		if err != nil {
+			return nil, err
+		}
+		nm, err := naming.New(r)
+		if err != nil {
+			return nil, err
+		}
+		return []sqlcheck.StateAnalyzer{de, nm}, nil
+	})
 }
 
 // modifyUsingTemp indicates if the 3 changes represents a table modification using
@@ -142,6 +142,63 @@ func TestDetectModifyTable(t *testing.T) {
 	require.Equal(t, report.Diagnostics[0].Text, `Modifying nullable column "text" to non-nullable without default value might fail in case it contains NULL values`)
 }
 
+func TestDetectModifyTable_NoDev(t *testing.T) {
+	pass := &sqlcheck.Pass{
+		File: &sqlcheck.File{
+			File: testFile{name: "1.sql"},
+			Changes: []*sqlcheck.Change{
+				{
+					Stmt: &migrate.Stmt{Text: "CREATE TABLE `new_posts` (`text` text NOT NULL);"},
+					Changes: schema.Changes{
+						&schema.AddTable{
+							T: schema.NewTable("new_posts").
+								SetSchema(schema.New("main")).
+								AddColumns(schema.NewStringColumn("text", "text")),
+						},
+					},
+				},
+				{
+					Stmt: &migrate.Stmt{Text: "INSERT INTO `new_posts` (`text`) SELECT `text` FROM `posts`;"},
+				},
+				{
+					Stmt: &migrate.Stmt{Text: "DROP TABLE `posts`"},
+					Changes: schema.Changes{
+						&schema.DropTable{
+							T: schema.NewTable("posts").
+								SetSchema(schema.New("main")).
+								AddColumns(schema.NewNullStringColumn("text", "text")),
+						},
+					},
+				},
+				{
+					Stmt: &migrate.Stmt{Text: "ALTER TABLE `new_posts` RENAME TO `posts`;"},
+					Changes: schema.Changes{
+						&schema.DropTable{
+							T: schema.NewTable("new_posts").
+								SetSchema(schema.New("main")).
+								AddColumns(schema.NewStringColumn("text", "text")),
+						},
+						&schema.AddTable{
+							T: schema.NewTable("posts").
+								SetSchema(schema.New("main")).
+								AddColumns(schema.NewStringColumn("text", "text")),
+						},
+					},
+				},
+			},
+		},
+	}
+	azs, err := sqlcheck.AnalyzerFor(sqlite.DriverName, nil)
+	require.NoError(t, err)
+	// Tables are diffed without a dev database.
+	require.NoError(t, azs[0].Analyze(context.Background(), pass))
+	require.Len(t, pass.File.Changes, 1)
+	m, ok := pass.File.Changes[0].Changes[0].(*schema.ModifyTable)
+	require.True(t, ok)
+	require.Len(t, m.Changes, 1)
+	require.IsType(t, (*schema.ModifyColumn)(nil), m.Changes[0])
+}
+
 type testFile struct {
 	name string
 	migrate.File
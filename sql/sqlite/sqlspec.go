@@ -20,9 +20,10 @@ import (
 )
 
 type doc struct {
-	Tables  []*sqlspec.Table  `spec:"table"`
-	Views   []*sqlspec.View   `spec:"view"`
-	Schemas []*sqlspec.Schema `spec:"schema"`
+	Tables   []*sqlspec.Table   `spec:"table"`
+	Views    []*sqlspec.View    `spec:"view"`
+	Triggers []*sqlspec.Trigger `spec:"trigger"`
+	Schemas  []*sqlspec.Schema  `spec:"schema"`
 }
 
 // evalSpec evaluates an Atlas DDL document using an unmarshaler into v by using the input.
@@ -34,8 +35,8 @@ func evalSpec(p *hclparse.Parser, v any, input map[string]cty.Value) error {
 			return err
 		}
 		if err := specutil.Scan(v,
-			&specutil.ScanDoc{Schemas: d.Schemas, Tables: d.Tables, Views: d.Views},
-			&specutil.ScanFuncs{Table: convertTable, View: convertView},
+			&specutil.ScanDoc{Schemas: d.Schemas, Tables: d.Tables, Views: d.Views, Triggers: d.Triggers},
+			&specutil.ScanFuncs{Table: convertTable, View: convertView, Trigger: convertTrigger},
 		); err != nil {
 			return fmt.Errorf("specutil: failed converting to *schema.Realm: %w", err)
 		}
@@ -49,8 +50,8 @@ func evalSpec(p *hclparse.Parser, v any, input map[string]cty.Value) error {
 		}
 		r := &schema.Realm{}
 		if err := specutil.Scan(r,
-			&specutil.ScanDoc{Schemas: d.Schemas, Tables: d.Tables, Views: d.Views},
-			&specutil.ScanFuncs{Table: convertTable, View: convertView},
+			&specutil.ScanDoc{Schemas: d.Schemas, Tables: d.Tables, Views: d.Views, Triggers: d.Triggers},
+			&specutil.ScanFuncs{Table: convertTable, View: convertView, Trigger: convertTrigger},
 		); err != nil {
 			return err
 		}
@@ -108,6 +109,19 @@ func convertView(spec *sqlspec.View, parent *schema.Schema) (*schema.View, error
 	return v, nil
 }
 
+// convertTrigger converts a sqlspec.Trigger to a schema.Trigger.
+func convertTrigger(spec *sqlspec.Trigger, r *schema.Realm) (*schema.Trigger, error) {
+	t, err := specutil.Trigger(spec, r)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite supports only FOR EACH ROW triggers.
+	if t.For == "" {
+		t.SetFor(schema.TriggerForRow)
+	}
+	return t, nil
+}
+
 // convertIndex converts a sqlspec.Index into a schema.Index.
 func convertIndex(spec *sqlspec.Index, t *schema.Table) (*schema.Index, error) {
 	idx, err := specutil.Index(spec, t)
@@ -274,6 +288,7 @@ var (
 		schemahcl.WithScopedEnums("table.column.as.type", stored, virtual),
 		schemahcl.WithScopedEnums("table.foreign_key.on_update", specutil.ReferenceVars...),
 		schemahcl.WithScopedEnums("table.foreign_key.on_delete", specutil.ReferenceVars...),
+		schemahcl.WithScopedEnums("trigger.for", string(schema.TriggerForRow)),
 	)...)
 	// MarshalHCL marshals v into an Atlas HCL DDL document.
 	MarshalHCL = schemahcl.MarshalerFunc(func(v any) ([]byte, error) {
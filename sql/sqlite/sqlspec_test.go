@@ -240,6 +240,69 @@ schema "public" {
 	require.EqualValues(t, exp, &s)
 }
 
+func TestMarshalTriggers(t *testing.T) {
+	t1 := schema.NewTable("t1").AddColumns(schema.NewIntColumn("id", "int"))
+	s := schema.New("public").AddTables(t1)
+	schema.NewRealm(s)
+	t1.AddTriggers(
+		schema.NewTrigger("tr1").
+			SetActionTime(schema.TriggerTimeBefore).
+			AddEvents(schema.TriggerEventInsert).
+			SetFor(schema.TriggerForRow).
+			SetBody("BEGIN\n  SELECT 1;\nEND"),
+	)
+	buf, err := MarshalHCL(s)
+	require.NoError(t, err)
+	const expected = `table "t1" {
+  schema = schema.public
+  column "id" {
+    null = false
+    type = int
+  }
+}
+trigger "tr1" {
+  on = table.t1
+  before {
+    insert = true
+  }
+  for = ROW
+  as  = <<-SQL
+  BEGIN
+    SELECT 1;
+  END
+  SQL
+}
+schema "public" {
+}
+`
+	require.Equal(t, expected, string(buf))
+	var got schema.Schema
+	require.NoError(t, EvalHCLBytes(buf, &got, nil))
+	require.EqualValues(t, s, &got)
+
+	// FOR EACH ROW is the default.
+	f := `table "t1" {
+  schema = schema.public
+  column "id" {
+    null = false
+    type = int
+  }
+}
+trigger "tr1" {
+  on = table.t1
+  before {
+    insert = true
+  }
+  as = "BEGIN\n  SELECT 1;\nEND"
+}
+schema "public" {
+}
+`
+	got = schema.Schema{}
+	require.NoError(t, EvalHCLBytes([]byte(f), &got, nil))
+	require.EqualValues(t, s, &got)
+}
+
 func TestMarshalSpec_AutoIncrement(t *testing.T) {
 	s := &schema.Schema{
 		Name: "test",
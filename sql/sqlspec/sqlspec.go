@@ -41,6 +41,46 @@ type (
 		schemahcl.DefaultExtension
 	}
 
+	// Trigger holds a specification for an SQL trigger.
+	Trigger struct {
+		Name string         `spec:",name"`
+		On   *schemahcl.Ref `spec:"on"` // A table or a view.
+		// The trigger timing block and definition are appended as
+		// additional attributes and blocks by the spec creator.
+		schemahcl.DefaultExtension
+	}
+
+	// Func holds the specification for a function.
+	Func struct {
+		Name      string          `spec:",name"`
+		Qualifier string          `spec:",qualifier"`
+		Schema    *schemahcl.Ref  `spec:"schema"`
+		Args      []*FuncArg      `spec:"arg"`
+		Return    *schemahcl.Type `spec:"return"`
+		// The language, definition and other attributes are
+		// appended as additional attributes by the spec creator.
+		schemahcl.DefaultExtension
+	}
+
+	// Proc holds the specification for a procedure.
+	Proc struct {
+		Name      string         `spec:",name"`
+		Qualifier string         `spec:",qualifier"`
+		Schema    *schemahcl.Ref `spec:"schema"`
+		Args      []*FuncArg     `spec:"arg"`
+		// The language, definition and other attributes are
+		// appended as additional attributes by the spec creator.
+		schemahcl.DefaultExtension
+	}
+
+	// FuncArg holds the specification for a function or a procedure argument.
+	FuncArg struct {
+		Name    string          `spec:",name"`
+		Type    *schemahcl.Type `spec:"type"`
+		Default cty.Value       `spec:"default"`
+		schemahcl.DefaultExtension
+	}
+
 	// Column holds a specification for a column in an SQL table.
 	Column struct {
 		Name    string          `spec:",name"`
@@ -90,12 +130,35 @@ type (
 		schemahcl.DefaultExtension
 	}
 
+	// Role holds a specification for a database role or user.
+	Role struct {
+		Name     string           `spec:",name"`
+		MemberOf []*schemahcl.Ref `spec:"member_of"`
+		Grants   []*Grant         `spec:"grant"`
+		schemahcl.DefaultExtension
+	}
+
+	// Grant holds a specification for privileges that were granted to a role
+	// on a schema, a table (or its columns), a view or a driver-specific object.
+	Grant struct {
+		Privileges  []string         `spec:"privileges"`
+		On          *schemahcl.Ref   `spec:"on"`
+		Columns     []*schemahcl.Ref `spec:"columns"`
+		GrantOption bool             `spec:"grant_option,omitempty"`
+		schemahcl.DefaultExtension
+	}
+
 	// Type represents a database agnostic column type.
 	Type string
 )
 
 func init() {
 	schemahcl.Register("view", &View{})
+	schemahcl.Register("trigger", &Trigger{})
+	schemahcl.Register("function", &Func{})
+	schemahcl.Register("procedure", &Proc{})
 	schemahcl.Register("table", &Table{})
 	schemahcl.Register("schema", &Schema{})
+	schemahcl.Register("role", &Role{})
+	schemahcl.Register("user", &Role{})
 }